@@ -17,6 +17,7 @@
 package ecdsa
 
 import (
+	"bytes"
 	"crypto/rand"
 	"crypto/subtle"
 	"testing"
@@ -60,5 +61,34 @@ func TestSerialization(t *testing.T) {
 		},
 	))
 
+	properties.Property("[BLS12-377] ECDSA serialization: SetBytesDER(BytesDER()) should stay the same", prop.ForAll(
+		func() bool {
+			privKey, _ := GenerateKey(rand.Reader)
+			msg := []byte("testing ECDSA")
+			buf, err := privKey.Sign(msg, nil)
+			if err != nil {
+				return false
+			}
+			var sig, end Signature
+			if _, err = sig.SetBytes(buf); err != nil {
+				return false
+			}
+			der, err := sig.BytesDER()
+			if err != nil {
+				return false
+			}
+			n, err := end.SetBytesDER(der)
+			if err != nil || n != len(der) {
+				return false
+			}
+			// trailing bytes are rejected
+			if _, err = end.SetBytesDER(append(der, 0)); err == nil {
+				return false
+			}
+
+			return bytes.Equal(end.Bytes(), buf)
+		},
+	))
+
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
@@ -37,6 +37,11 @@ import (
 //   - encoding.BinaryMarshaler
 //   - encoding.BinaryUnmarshaler
 //   - sort.Interface
+//
+// On amd64, for 4-word fields, Add, Sub, ScalarMul, Mul, Butterfly and the
+// products of InnerProduct run assembly kernels (the multiplications need
+// ADX, otherwise they fall back to Go). The other operations, fields and
+// architectures use the generic Go implementation.
 type Vector []Element
 
 // MarshalBinary implements encoding.BinaryMarshaler
@@ -199,6 +204,208 @@ func (vector Vector) Swap(i, j int) {
 	vector[i], vector[j] = vector[j], vector[i]
 }
 
+// VectorOption configures the execution of the vector operations.
+type VectorOption func(*vectorConfig)
+
+type vectorConfig struct {
+	nbTasks int
+}
+
+// WithNbTasks sets the maximum number of goroutines used by a vector operation.
+// By default, vector operations run on the calling goroutine. nbTasks <= 0 uses all the CPUs.
+func WithNbTasks(nbTasks int) VectorOption {
+	return func(cfg *vectorConfig) {
+		if nbTasks <= 0 {
+			nbTasks = runtime.NumCPU()
+		}
+		cfg.nbTasks = nbTasks
+	}
+}
+
+// vectorExecute runs work over [0, n) according to the options
+func vectorExecute(n int, work func(start, end int), opts []VectorOption) {
+	cfg := vectorConfig{nbTasks: 1}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if n == 0 {
+		return
+	}
+	execute(n, work, cfg.nbTasks)
+}
+
+// Add adds two vectors element-wise and stores the result in self.
+// It panics if the vectors don't have the same length.
+func (vector *Vector) Add(a, b Vector, opts ...VectorOption) {
+	if len(a) != len(b) || len(a) != len(*vector) {
+		panic("vector.Add: vectors don't have the same length")
+	}
+	vectorExecute(len(a), func(start, end int) {
+		addVec((*vector)[start:end], a[start:end], b[start:end])
+	}, opts)
+}
+
+// Sub subtracts two vectors element-wise and stores the result in self.
+// It panics if the vectors don't have the same length.
+func (vector *Vector) Sub(a, b Vector, opts ...VectorOption) {
+	if len(a) != len(b) || len(a) != len(*vector) {
+		panic("vector.Sub: vectors don't have the same length")
+	}
+	vectorExecute(len(a), func(start, end int) {
+		subVec((*vector)[start:end], a[start:end], b[start:end])
+	}, opts)
+}
+
+// ScalarMul multiplies a vector by a scalar element-wise and stores the result in self.
+// It panics if the vectors don't have the same length.
+func (vector *Vector) ScalarMul(a Vector, b *Element, opts ...VectorOption) {
+	if len(a) != len(*vector) {
+		panic("vector.ScalarMul: vectors don't have the same length")
+	}
+	// b may alias an element of vector
+	scalar := *b
+	vectorExecute(len(a), func(start, end int) {
+		scalarMulVec((*vector)[start:end], a[start:end], &scalar)
+	}, opts)
+}
+
+// Mul multiplies two vectors element-wise (Hadamard product) and stores the result in self.
+// It panics if the vectors don't have the same length.
+func (vector *Vector) Mul(a, b Vector, opts ...VectorOption) {
+	if len(a) != len(b) || len(a) != len(*vector) {
+		panic("vector.Mul: vectors don't have the same length")
+	}
+	vectorExecute(len(a), func(start, end int) {
+		mulVec((*vector)[start:end], a[start:end], b[start:end])
+	}, opts)
+}
+
+// Sum computes the sum of all elements in the vector.
+func (vector *Vector) Sum(opts ...VectorOption) (res Element) {
+	var lock sync.Mutex
+	vectorExecute(len(*vector), func(start, end int) {
+		var partial Element
+		for i := start; i < end; i++ {
+			partial.Add(&partial, &(*vector)[i])
+		}
+		lock.Lock()
+		res.Add(&res, &partial)
+		lock.Unlock()
+	}, opts)
+	return
+}
+
+// innerProductChunk is the number of products InnerProduct buffers on the stack
+const innerProductChunk = 256
+
+// InnerProduct computes the inner product of two vectors.
+// It panics if the vectors don't have the same length.
+func (vector *Vector) InnerProduct(other Vector, opts ...VectorOption) (res Element) {
+	if len(*vector) != len(other) {
+		panic("vector.InnerProduct: vectors don't have the same length")
+	}
+	var lock sync.Mutex
+	vectorExecute(len(other), func(start, end int) {
+		// the products are computed by chunks with the vector kernel, then summed
+		var partial Element
+		var buf [innerProductChunk]Element
+		for i := start; i < end; i += innerProductChunk {
+			chunk := buf[:]
+			if end-i < innerProductChunk {
+				chunk = buf[:end-i]
+			}
+			mulVec(chunk, (*vector)[i:i+len(chunk)], other[i:i+len(chunk)])
+			for j := range chunk {
+				partial.Add(&partial, &chunk[j])
+			}
+		}
+		lock.Lock()
+		res.Add(&res, &partial)
+		lock.Unlock()
+	}, opts)
+	return
+}
+
+// BatchInvert sets self[i] = 1 / a[i] using the Montgomery batch inversion trick
+// (one field inversion per task). Zero elements are mapped to zero.
+// Unlike the package level BatchInvert, it doesn't allocate the result; self and a must not overlap.
+// It panics if the vectors don't have the same length.
+func (vector *Vector) BatchInvert(a Vector, opts ...VectorOption) {
+	if len(a) != len(*vector) {
+		panic("vector.BatchInvert: vectors don't have the same length")
+	}
+	vectorExecute(len(a), func(start, end int) {
+		res := (*vector)[start:end]
+		a := a[start:end]
+
+		// res[i] holds the product of the non-zero a[j] for j < i
+		accumulator := One()
+		for i := 0; i < len(a); i++ {
+			if a[i].IsZero() {
+				res[i].SetZero()
+				continue
+			}
+			res[i] = accumulator
+			accumulator.Mul(&accumulator, &a[i])
+		}
+
+		accumulator.Inverse(&accumulator)
+
+		for i := len(a) - 1; i >= 0; i-- {
+			if a[i].IsZero() {
+				continue
+			}
+			res[i].Mul(&res[i], &accumulator)
+			accumulator.Mul(&accumulator, &a[i])
+		}
+	}, opts)
+}
+
+// Butterfly sets
+//
+//	self[i] = self[i] + other[i] (mod q)
+//	other[i] = self[i] - other[i] (mod q)
+//
+// It panics if the vectors don't have the same length.
+func (vector *Vector) Butterfly(other Vector, opts ...VectorOption) {
+	if len(*vector) != len(other) {
+		panic("vector.Butterfly: vectors don't have the same length")
+	}
+	vectorExecute(len(other), func(start, end int) {
+		butterflyVec((*vector)[start:end], other[start:end])
+	}, opts)
+}
+
+func addVecGeneric(res, a, b Vector) {
+	for i := 0; i < len(a); i++ {
+		res[i].Add(&a[i], &b[i])
+	}
+}
+
+func subVecGeneric(res, a, b Vector) {
+	for i := 0; i < len(a); i++ {
+		res[i].Sub(&a[i], &b[i])
+	}
+}
+
+func scalarMulVecGeneric(res, a Vector, b *Element) {
+	for i := 0; i < len(a); i++ {
+		res[i].Mul(&a[i], b)
+	}
+}
+
+func mulVecGeneric(res, a, b Vector) {
+	for i := 0; i < len(a); i++ {
+		res[i].Mul(&a[i], &b[i])
+	}
+}
+
+func butterflyVecGeneric(a, b Vector) {
+	for i := 0; i < len(a); i++ {
+		Butterfly(&a[i], &b[i])
+	}
+}
+
 // TODO @gbotrel make a public package out of that.
 // execute executes the work function in parallel.
 // this is copy paste from internal/parallel/parallel.go
@@ -81,7 +81,8 @@ func NewDecoder(r io.Reader, options ...func(*Decoder)) *Decoder {
 }
 
 // Decode reads the binary encoding of v from the stream
-// type must be *uint64, *fr.Element, *fp.Element, *G1Affine, *G2Affine, *[]G1Affine or *[]G2Affine
+// type must be *uint64, *fr.Element, *fp.Element, *G1Affine, *G2Affine, *[]G1Affine, *[]G2Affine,
+// *GT, *[]GT, *GTElement or *[]GTElement
 func (dec *Decoder) Decode(v interface{}) (err error) {
 	rv := reflect.ValueOf(v)
 	if v == nil || rv.Kind() != reflect.Ptr || rv.IsNil() || !rv.Elem().CanSet() {
@@ -225,6 +226,49 @@ func (dec *Decoder) Decode(v interface{}) (err error) {
 		}
 		_, err = t.setBytes(buf[:nbBytes], dec.subGroupCheck)
 		return
+	case *GT:
+		var bufGT [SizeOfGT]byte
+		read, err = io.ReadFull(dec.r, bufGT[:])
+		dec.n += int64(read)
+		if err != nil {
+			return
+		}
+		err = t.SetBytes(bufGT[:])
+		return
+	case *[]GT:
+		if sliceLen, err = dec.readUint32(); err != nil {
+			return
+		}
+		if len(*t) != int(sliceLen) {
+			*t = make([]GT, sliceLen)
+		}
+		var bufGT [SizeOfGT]byte
+		for i := range *t {
+			read, err = io.ReadFull(dec.r, bufGT[:])
+			dec.n += int64(read)
+			if err != nil {
+				return
+			}
+			if err = (*t)[i].SetBytes(bufGT[:]); err != nil {
+				return
+			}
+		}
+		return
+	case *GTElement:
+		return dec.readGTElement(t)
+	case *[]GTElement:
+		if sliceLen, err = dec.readUint32(); err != nil {
+			return
+		}
+		if len(*t) != int(sliceLen) {
+			*t = make([]GTElement, sliceLen)
+		}
+		for i := range *t {
+			if err = dec.readGTElement(&(*t)[i]); err != nil {
+				return
+			}
+		}
+		return
 	case *[]G1Affine:
 		sliceLen, err = dec.readUint32()
 		if err != nil {
@@ -368,6 +412,27 @@ func (dec *Decoder) Decode(v interface{}) (err error) {
 	}
 }
 
+// readGTElement reads a compressed GTElement, or an uncompressed one if the metadata says so
+func (dec *Decoder) readGTElement(e *GTElement) error {
+	var buf [SizeOfGT]byte
+	read, err := io.ReadFull(dec.r, buf[:SizeOfGTElementCompressed])
+	dec.n += int64(read)
+	if err != nil {
+		return err
+	}
+	nbBytes := SizeOfGTElementCompressed
+	if buf[0]&mGTCompressed == 0 {
+		nbBytes = SizeOfGT
+		read, err = io.ReadFull(dec.r, buf[SizeOfGTElementCompressed:])
+		dec.n += int64(read)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = e.setBytes(buf[:nbBytes], dec.subGroupCheck)
+	return err
+}
+
 // BytesRead return total bytes read from reader
 func (dec *Decoder) BytesRead() int64 {
 	return dec.n
@@ -426,7 +491,8 @@ func NewEncoder(w io.Writer, options ...func(*Encoder)) *Encoder {
 }
 
 // Encode writes the binary encoding of v to the stream
-// type must be uint64, *fr.Element, *fp.Element, *G1Affine, *G2Affine, []G1Affine or []G2Affine
+// type must be uint64, *fr.Element, *fp.Element, *G1Affine, *G2Affine, []G1Affine, []G2Affine,
+// *GT, []GT, *GTElement or []GTElement
 func (enc *Encoder) Encode(v interface{}) (err error) {
 	if enc.raw {
 		return enc.encodeRaw(v)
@@ -510,6 +576,16 @@ func (enc *Encoder) encode(v interface{}) (err error) {
 		written, err = enc.w.Write(buf[:])
 		enc.n += int64(written)
 		return
+	case *GT:
+		buf := t.Bytes()
+		written, err = enc.w.Write(buf[:])
+		enc.n += int64(written)
+		return
+	case *GTElement:
+		buf := t.Bytes()
+		written, err = enc.w.Write(buf[:])
+		enc.n += int64(written)
+		return
 	case fr.Vector:
 		written64, err = t.WriteTo(enc.w)
 		enc.n += written64
@@ -566,6 +642,44 @@ func (enc *Encoder) encode(v interface{}) (err error) {
 
 		var buf [SizeOfG2AffineCompressed]byte
 
+		for i := 0; i < len(t); i++ {
+			buf = t[i].Bytes()
+			written, err = enc.w.Write(buf[:])
+			enc.n += int64(written)
+			if err != nil {
+				return
+			}
+		}
+		return nil
+	case []GT:
+		// write slice length
+		err = binary.Write(enc.w, binary.BigEndian, uint32(len(t)))
+		if err != nil {
+			return
+		}
+		enc.n += 4
+
+		var buf [SizeOfGT]byte
+
+		for i := 0; i < len(t); i++ {
+			buf = t[i].Bytes()
+			written, err = enc.w.Write(buf[:])
+			enc.n += int64(written)
+			if err != nil {
+				return
+			}
+		}
+		return nil
+	case []GTElement:
+		// write slice length
+		err = binary.Write(enc.w, binary.BigEndian, uint32(len(t)))
+		if err != nil {
+			return
+		}
+		enc.n += 4
+
+		var buf [SizeOfGTElementCompressed]byte
+
 		for i := 0; i < len(t); i++ {
 			buf = t[i].Bytes()
 			written, err = enc.w.Write(buf[:])
@@ -628,6 +742,16 @@ func (enc *Encoder) encodeRaw(v interface{}) (err error) {
 		written, err = enc.w.Write(buf[:])
 		enc.n += int64(written)
 		return
+	case *GT:
+		buf := t.Bytes()
+		written, err = enc.w.Write(buf[:])
+		enc.n += int64(written)
+		return
+	case *GTElement:
+		buf := t.RawBytes()
+		written, err = enc.w.Write(buf[:])
+		enc.n += int64(written)
+		return
 	case fr.Vector:
 		written64, err = t.WriteTo(enc.w)
 		enc.n += written64
@@ -684,6 +808,44 @@ func (enc *Encoder) encodeRaw(v interface{}) (err error) {
 
 		var buf [SizeOfG2AffineUncompressed]byte
 
+		for i := 0; i < len(t); i++ {
+			buf = t[i].RawBytes()
+			written, err = enc.w.Write(buf[:])
+			enc.n += int64(written)
+			if err != nil {
+				return
+			}
+		}
+		return nil
+	case []GT:
+		// write slice length
+		err = binary.Write(enc.w, binary.BigEndian, uint32(len(t)))
+		if err != nil {
+			return
+		}
+		enc.n += 4
+
+		var buf [SizeOfGT]byte
+
+		for i := 0; i < len(t); i++ {
+			buf = t[i].Bytes()
+			written, err = enc.w.Write(buf[:])
+			enc.n += int64(written)
+			if err != nil {
+				return
+			}
+		}
+		return nil
+	case []GTElement:
+		// write slice length
+		err = binary.Write(enc.w, binary.BigEndian, uint32(len(t)))
+		if err != nil {
+			return
+		}
+		enc.n += 4
+
+		var buf [SizeOfGT]byte
+
 		for i := 0; i < len(t); i++ {
 			buf = t[i].RawBytes()
 			written, err = enc.w.Write(buf[:])
@@ -0,0 +1,100 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package eddsa
+
+import (
+	"crypto/cipher"
+	"errors"
+	"io"
+
+	"github.com/consensys/gnark-crypto/field/hash"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// eciesDST is the domain separation tag of the key derivation of Encrypt
+const eciesDST = "GNARK-CRYPTO-ECIES-V01-BLS12-377-TWISTEDEDWARDS-XMD:SHA-256-CHACHA20POLY1305"
+
+// Overhead is the difference between the sizes of a ciphertext produced by
+// Encrypt and of the corresponding plaintext.
+const Overhead = sizePublicKey + chacha20poly1305.Overhead
+
+var errShortCiphertext = errors.New("ciphertext too short")
+
+// Encrypt encrypts plaintext to pub with ECIES and authenticates additionalData,
+// which is not included in the ciphertext.
+//
+// The ciphertext is R ∥ c ∥ tag where
+//   - R = [r]B is a fresh ephemeral public key, encoded as PublicKey.Bytes
+//   - c ∥ tag is the ChaCha20-Poly1305 encryption of plaintext under the key and
+//     the nonce derived with expand_message_xmd (RFC 9380, SHA-256) from the
+//     shared secret ECDH(r, pub), R and pub.
+//
+// It is len(plaintext) + Overhead bytes long.
+func Encrypt(rand io.Reader, pub *PublicKey, plaintext, additionalData []byte) ([]byte, error) {
+	ephemeral, err := GenerateKey(rand)
+	if err != nil {
+		return nil, err
+	}
+	aead, nonce, err := eciesCipher(ephemeral, pub, &ephemeral.PublicKey, pub)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]byte, sizePublicKey, len(plaintext)+Overhead)
+	copy(res, ephemeral.PublicKey.Bytes())
+	return aead.Seal(res, nonce, plaintext, additionalData), nil
+}
+
+// Decrypt decrypts a ciphertext produced by Encrypt for privKey.PublicKey
+// and checks its authenticity together with the one of additionalData.
+func (privKey *PrivateKey) Decrypt(ciphertext, additionalData []byte) ([]byte, error) {
+	if len(ciphertext) < Overhead {
+		return nil, errShortCiphertext
+	}
+	var ephemeral PublicKey
+	if _, err := ephemeral.SetBytes(ciphertext[:sizePublicKey]); err != nil {
+		return nil, err
+	}
+	aead, nonce, err := eciesCipher(privKey, &ephemeral, &ephemeral, &privKey.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Open(nil, nonce, ciphertext[sizePublicKey:], additionalData)
+}
+
+// eciesCipher returns the AEAD and the nonce derived from ECDH(privKey, pub),
+// the ephemeral public key and the public key of the recipient.
+func eciesCipher(privKey *PrivateKey, pub, ephemeral, recipient *PublicKey) (cipher.AEAD, []byte, error) {
+	shared, err := privKey.ECDH(pub)
+	if err != nil {
+		return nil, nil, err
+	}
+	msg := make([]byte, 0, len(shared)+2*sizePublicKey)
+	msg = append(msg, shared...)
+	msg = append(msg, ephemeral.Bytes()...)
+	msg = append(msg, recipient.Bytes()...)
+
+	okm, err := hash.ExpandMsgXmd(msg, []byte(eciesDST), chacha20poly1305.KeySize+chacha20poly1305.NonceSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	aead, err := chacha20poly1305.New(okm[:chacha20poly1305.KeySize])
+	if err != nil {
+		return nil, nil, err
+	}
+	return aead, okm[chacha20poly1305.KeySize:], nil
+}
@@ -264,6 +264,67 @@ func TestEddsaSHA256(t *testing.T) {
 
 }
 
+func TestBatchVerify(t *testing.T) {
+
+	hFunc := hash.MIMC_BLS12_377.New()
+
+	const n = 20
+	pubs := make([]PublicKey, n)
+	msgs := make([][]byte, n)
+	sigs := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		privKey, err := GenerateKey(crand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pubs[i] = privKey.PublicKey
+		var frMsg fr.Element
+		frMsg.SetRandom()
+		msgs[i] = frMsg.Marshal()
+		if sigs[i], err = privKey.Sign(msgs[i], hFunc); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	valid, invalid, err := BatchVerify(pubs, msgs, sigs, hFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !valid || len(invalid) != 0 {
+		t.Fatal("valid batch rejected")
+	}
+
+	// wrong message, wrong public key and undecodable signature
+	msgs[3], msgs[4] = msgs[4], msgs[3]
+	pubs[17] = pubs[16]
+	sigs[11] = sigs[11][1:]
+	valid, invalid, err = BatchVerify(pubs, msgs, sigs, hFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []int{3, 4, 11, 17}
+	if valid || len(invalid) != len(expected) {
+		t.Fatalf("expected invalid signatures %v, got %v", expected, invalid)
+	}
+	for i := range expected {
+		if invalid[i] != expected[i] {
+			t.Fatalf("expected invalid signatures %v, got %v", expected, invalid)
+		}
+	}
+
+	// the result of the batch verification matches Verify
+	for i := range pubs {
+		ok, _ := pubs[i].Verify(sigs[i], msgs[i], hFunc)
+		if ok == (i == 3 || i == 4 || i == 11 || i == 17) {
+			t.Fatalf("signature %d: Verify and BatchVerify disagree", i)
+		}
+	}
+
+	if _, _, err := BatchVerify(pubs, msgs[1:], sigs, hFunc); err == nil {
+		t.Fatal("mismatched lengths should fail")
+	}
+}
+
 // benchmarks
 
 func BenchmarkVerify(b *testing.B) {
@@ -289,3 +350,26 @@ func BenchmarkVerify(b *testing.B) {
 		pubKey.Verify(signature, msgBin[:], hFunc)
 	}
 }
+
+func BenchmarkBatchVerify(b *testing.B) {
+
+	hFunc := hash.MIMC_BLS12_377.New()
+
+	const n = 1024
+	pubs := make([]PublicKey, n)
+	msgs := make([][]byte, n)
+	sigs := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		privKey, _ := GenerateKey(crand.Reader)
+		pubs[i] = privKey.PublicKey
+		var frMsg fr.Element
+		frMsg.SetRandom()
+		msgs[i] = frMsg.Marshal()
+		sigs[i], _ = privKey.Sign(msgs[i], hFunc)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		BatchVerify(pubs, msgs, sigs, hFunc)
+	}
+}
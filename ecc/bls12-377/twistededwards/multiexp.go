@@ -0,0 +1,229 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package twistededwards
+
+import (
+	"errors"
+	"math/big"
+	"math/bits"
+	"runtime"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// MultiExp computes the multi-scalar multiplication ∑ scalars[i]⋅points[i]
+// and sets p to the result.
+//
+// It implements the bucket method of section 4 of https://eprint.iacr.org/2012/549.pdf
+// with signed digits (negating a point on a twisted Edwards curve is free).
+//
+// The scalars are reduced modulo the order of the prime subgroup, and the points
+// are expected to be in that subgroup.
+//
+// This call return an error if len(scalars) != len(points) or if provided config is invalid.
+func (p *PointExtended) MultiExp(points []PointAffine, scalars []big.Int, config ecc.MultiExpConfig) (*PointExtended, error) {
+	nbPoints := len(points)
+	if nbPoints != len(scalars) {
+		return nil, errors.New("len(points) != len(scalars)")
+	}
+
+	// if nbTasks is not set, use all available CPUs
+	if config.NbTasks <= 0 {
+		config.NbTasks = runtime.NumCPU()
+	} else if config.NbTasks > 1024 {
+		return nil, errors.New("invalid config: config.NbTasks > 1024")
+	}
+
+	initOnce.Do(initCurveParams)
+	msmPoints := points
+	k := make([][fr.Limbs]uint64, nbPoints)
+	parallel.Execute(nbPoints, func(start, end int) {
+		var s big.Int
+		var e fr.Element
+		for i := start; i < end; i++ {
+			s.Mod(&scalars[i], &curveParams.Order)
+			k[i] = e.SetBigInt(&s).Bits()
+		}
+	}, config.NbTasks)
+
+	return p.multiExp(msmPoints, k, config), nil
+}
+
+// multiExp runs the bucket method on the (non-negative) scalars given as little endian limbs.
+func (p *PointExtended) multiExp(points []PointAffine, scalars [][fr.Limbs]uint64, config ecc.MultiExpConfig) *PointExtended {
+	p.setInfinity()
+
+	// the cost of the algorithm depends on the bit length of the largest scalar
+	nbBits := 0
+	for i := range scalars {
+		for j := fr.Limbs - 1; j >= 0; j-- {
+			if scalars[i][j] != 0 {
+				if b := 64*j + bits.Len64(scalars[i][j]); b > nbBits {
+					nbBits = b
+				}
+				break
+			}
+		}
+	}
+	if nbBits == 0 {
+		return p
+	}
+
+	c := bestC(len(points), nbBits)
+	// with signed digits, the last window must absorb the carry of the previous one
+	nbChunks := (nbBits + 1 + c - 1) / c
+
+	// step 1
+	// we compute, for each scalar over c-bit wide windows, nbChunks digits
+	// if the digit is larger than 2^{c-1}, then, we borrow 2^c from the next window and subtract
+	// 2^{c} to the current digit, making it negative.
+	// digits[chunk*len(points)+i] is the digit of the i-th scalar in window chunk.
+	digits := make([]int32, nbChunks*len(points))
+	parallel.Execute(len(points), func(start, end int) {
+		max := int64(1) << (c - 1)
+		for i := start; i < end; i++ {
+			var carry int64
+			for chunk := 0; chunk < nbChunks; chunk++ {
+				d := int64(window(&scalars[i], chunk*c, c)) + carry
+				carry = 0
+				if d > max {
+					d -= 1 << c
+					carry = 1
+				}
+				digits[chunk*len(points)+i] = int32(d)
+			}
+		}
+	}, config.NbTasks)
+
+	// step 2
+	// each window is processed independently, 2^{c-1} buckets are used (see step 1)
+	chunks := make([]PointExtended, nbChunks)
+	sem := make(chan struct{}, config.NbTasks)
+	done := make(chan struct{}, nbChunks)
+	for chunk := 0; chunk < nbChunks; chunk++ {
+		sem <- struct{}{}
+		go func(chunk int) {
+			processChunk(&chunks[chunk], c, points, digits[chunk*len(points):(chunk+1)*len(points)])
+			<-sem
+			done <- struct{}{}
+		}(chunk)
+	}
+	for chunk := 0; chunk < nbChunks; chunk++ {
+		<-done
+	}
+
+	// step 3
+	// reduce the weighted sums of the windows into the result
+	p.Set(&chunks[nbChunks-1])
+	for chunk := nbChunks - 2; chunk >= 0; chunk-- {
+		for j := 0; j < c; j++ {
+			p.Double(p)
+		}
+		p.Add(p, &chunks[chunk])
+	}
+
+	return p
+}
+
+// processChunk sets res to ∑ digits[i]⋅points[i], the digits being at most 2^{c-1} in absolute value.
+func processChunk(res *PointExtended, c int, points []PointAffine, digits []int32) {
+	buckets := make([]PointExtended, 1<<(c-1))
+	for i := range buckets {
+		buckets[i].setInfinity()
+	}
+
+	var q PointExtended
+	for i, d := range digits {
+		if d == 0 {
+			continue
+		}
+		q.FromAffine(&points[i])
+		if d > 0 {
+			buckets[d-1].Add(&buckets[d-1], &q)
+		} else {
+			q.Neg(&q)
+			buckets[-d-1].Add(&buckets[-d-1], &q)
+		}
+	}
+
+	// reduce buckets into total
+	// total =  bucket[0] + 2*bucket[1] + 3*bucket[2] ... + n*bucket[n-1]
+	var runningSum PointExtended
+	runningSum.setInfinity()
+	res.setInfinity()
+	for k := len(buckets) - 1; k >= 0; k-- {
+		runningSum.Add(&runningSum, &buckets[k])
+		res.Add(res, &runningSum)
+	}
+}
+
+// bestC returns the window size minimizing the approximate cost (in group operations)
+// bits/c * (nbPoints + 2^{c-1}) of the bucket method
+func bestC(nbPoints, nbBits int) int {
+	C := 2
+	min := -1
+	for c := 2; c <= 16; c++ {
+		cost := (nbBits + c) / c * (nbPoints + (1 << (c - 1)))
+		if min == -1 || cost < min {
+			min = cost
+			C = c
+		}
+	}
+	return C
+}
+
+// window returns the c bits of k starting at bit position start
+func window(k *[fr.Limbs]uint64, start, c int) uint64 {
+	w := start / 64
+	if w >= fr.Limbs {
+		return 0
+	}
+	shift := uint(start % 64)
+	mask := uint64(1)<<c - 1
+	res := k[w] >> shift
+	if shift+uint(c) > 64 && w+1 < fr.Limbs {
+		res |= k[w+1] << (64 - shift)
+	}
+	return res & mask
+}
+
+// BatchFromExtended converts a slice of points in extended coordinates to affine coordinates,
+// using a single field inversion (Montgomery batch inversion trick).
+func BatchFromExtended(points []PointExtended) []PointAffine {
+	result := make([]PointAffine, len(points))
+	if len(points) == 0 {
+		return result
+	}
+
+	// on a complete twisted Edwards curve Z is never 0
+	zInv := make([]fr.Element, len(points))
+	for i := 0; i < len(points); i++ {
+		zInv[i] = points[i].Z
+	}
+	zInv = fr.BatchInvert(zInv)
+
+	parallel.Execute(len(points), func(start, end int) {
+		for i := start; i < end; i++ {
+			result[i].X.Mul(&points[i].X, &zInv[i])
+			result[i].Y.Mul(&points[i].Y, &zInv[i])
+		}
+	})
+
+	return result
+}
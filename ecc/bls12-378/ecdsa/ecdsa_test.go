@@ -17,6 +17,7 @@
 package ecdsa
 
 import (
+	"bytes"
 	"crypto/rand"
 	"crypto/sha256"
 	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
@@ -62,6 +63,28 @@ func TestECDSA(t *testing.T) {
 		},
 	))
 
+	properties.Property("[BLS12-378] test the deterministic (RFC 6979) low-s signing and verification", prop.ForAll(
+		func() bool {
+
+			privKey, _ := GenerateKey(rand.Reader)
+			publicKey := privKey.PublicKey
+
+			msg := []byte("testing ECDSA")
+			sig1, _ := privKey.SignWithOptions(msg, sha256.New(), WithRFC6979(sha256.New), WithLowS())
+			sig2, _ := privKey.SignWithOptions(msg, sha256.New(), WithRFC6979(sha256.New), WithLowS())
+			if !bytes.Equal(sig1, sig2) {
+				return false
+			}
+			var sig Signature
+			if _, err := sig.SetBytes(sig1); err != nil || !sig.IsLowS() {
+				return false
+			}
+			flag, _ := publicKey.Verify(sig1, msg, sha256.New())
+
+			return flag
+		},
+	))
+
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
@@ -0,0 +1,104 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecdsa
+
+import (
+	"crypto/hmac"
+	"hash"
+	"math/big"
+)
+
+// rfc6979 is the HMAC_DRBG deriving the ECDSA nonces from the private key
+// and the hash of the message.
+//
+// https://datatracker.ietf.org/doc/html/rfc6979#section-3.2
+type rfc6979 struct {
+	newHash func() hash.Hash
+	k, v    []byte
+	started bool
+}
+
+// newRFC6979 instantiates the generator with the private key x and the hash h1
+// of the message (steps a. to g.).
+func newRFC6979(newHash func() hash.Hash, x *big.Int, h1 []byte) *rfc6979 {
+	hLen := newHash().Size()
+	d := &rfc6979{
+		newHash: newHash,
+		k:       make([]byte, hLen),
+		v:       make([]byte, hLen),
+	}
+	for i := range d.v {
+		d.v[i] = 0x01
+	}
+
+	xBytes := int2octets(x)
+	hBytes := int2octets(new(big.Int).Mod(bits2int(h1), order))
+
+	d.k = d.mac(d.v, []byte{0x00}, xBytes, hBytes)
+	d.v = d.mac(d.v)
+	d.k = d.mac(d.v, []byte{0x01}, xBytes, hBytes)
+	d.v = d.mac(d.v)
+
+	return d
+}
+
+// next returns the next candidate nonce k in [1, order) (step h.). Subsequent
+// calls are only needed if k yields r = 0 or s = 0.
+func (d *rfc6979) next() *big.Int {
+	qLen := order.BitLen()
+	for {
+		if d.started {
+			d.k = d.mac(d.v, []byte{0x00})
+			d.v = d.mac(d.v)
+		}
+		d.started = true
+
+		t := make([]byte, 0, (qLen+7)/8+len(d.v))
+		for len(t)*8 < qLen {
+			d.v = d.mac(d.v)
+			t = append(t, d.v...)
+		}
+		k := bits2int(t)
+		if k.Sign() > 0 && k.Cmp(order) < 0 {
+			return k
+		}
+	}
+}
+
+// mac returns HMAC_K(data[0] ∥ data[1] ∥ ...) with the current key K.
+func (d *rfc6979) mac(data ...[]byte) []byte {
+	h := hmac.New(d.newHash, d.k)
+	for _, b := range data {
+		h.Write(b)
+	}
+	return h.Sum(nil)
+}
+
+// bits2int interprets the left-most qlen bits of b as a big endian integer,
+// qlen being the bit length of the order.
+func bits2int(b []byte) *big.Int {
+	res := new(big.Int).SetBytes(b)
+	if excess := len(b)*8 - order.BitLen(); excess > 0 {
+		res.Rsh(res, uint(excess))
+	}
+	return res
+}
+
+// int2octets returns the big endian encoding of x on rlen = ⌈qlen/8⌉ bytes.
+func int2octets(x *big.Int) []byte {
+	return x.FillBytes(make([]byte, (order.BitLen()+7)/8))
+}
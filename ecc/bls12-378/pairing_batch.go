@@ -0,0 +1,228 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12378
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+)
+
+var (
+	ErrBatchInvalidInputs = errors.New("invalid inputs sizes")
+	ErrBatchEmpty         = errors.New("no pairing equation to verify")
+)
+
+// PairingBatchVerifier accumulates pairing equations of the form
+//
+//	∏ᵢ e(Pᵢ, Qᵢ) == 1
+//
+// and checks all of them at once. Each equation is multiplied by a random scalar
+// (applied on the G1 side), the G1 arguments sharing the same G2 argument are merged
+// with a multi-exponentiation, and a single multi-Miller loop and final exponentiation
+// is performed. G2 arguments whose lines were registered with SetLines use the
+// fixed-argument Miller loop.
+//
+// The verifier doesn't check that the inputs are in the correct subgroup. See IsInSubGroup.
+type PairingBatchVerifier struct {
+	equations [][]term
+	g2        []G2Affine
+	index     map[[SizeOfG2AffineUncompressed]byte]int
+	lines     map[int]*[2][len(LoopCounter) - 1]LineEvaluationAff
+	hash      hash.Hash
+}
+
+// term is a G1 point paired with the G2 argument g2[q] of the verifier
+type term struct {
+	p G1Affine
+	q int
+}
+
+// PairingBatchVerifierOption can be given to NewPairingBatchVerifier
+type PairingBatchVerifierOption func(*PairingBatchVerifier)
+
+// WithTranscriptHash derives the random scalars from a hash of all the accumulated
+// equations instead of a CSPRNG, making the verification deterministic. h can be
+// primed with the transcript of the protocol; it is written to by Verify.
+func WithTranscriptHash(h hash.Hash) PairingBatchVerifierOption {
+	return func(v *PairingBatchVerifier) {
+		v.hash = h
+	}
+}
+
+// NewPairingBatchVerifier returns an empty PairingBatchVerifier.
+// By default, the random scalars are drawn from crypto/rand.
+func NewPairingBatchVerifier(options ...PairingBatchVerifierOption) *PairingBatchVerifier {
+	v := &PairingBatchVerifier{
+		index: make(map[[SizeOfG2AffineUncompressed]byte]int),
+		lines: make(map[int]*[2][len(LoopCounter) - 1]LineEvaluationAff),
+	}
+	for _, opt := range options {
+		opt(v)
+	}
+	return v
+}
+
+// SetLines registers precomputed lines (see PrecomputeLines) for the G2 point Q.
+// Equations involving Q are then evaluated with the fixed-argument Miller loop.
+func (v *PairingBatchVerifier) SetLines(Q *G2Affine, lines *[2][len(LoopCounter) - 1]LineEvaluationAff) {
+	v.lines[v.g2Index(Q)] = lines
+}
+
+// Add adds the equation ∏ᵢ e(P[i], Q[i]) == 1 to the batch.
+func (v *PairingBatchVerifier) Add(P []G1Affine, Q []G2Affine) error {
+	if len(P) == 0 || len(P) != len(Q) {
+		return ErrBatchInvalidInputs
+	}
+	eq := make([]term, len(P))
+	for i := range P {
+		eq[i].p = P[i]
+		eq[i].q = v.g2Index(&Q[i])
+	}
+	v.equations = append(v.equations, eq)
+	return nil
+}
+
+// Len returns the number of accumulated equations.
+func (v *PairingBatchVerifier) Len() int {
+	return len(v.equations)
+}
+
+// Reset removes all the accumulated equations and registered lines.
+func (v *PairingBatchVerifier) Reset() {
+	v.equations = v.equations[:0]
+	v.g2 = v.g2[:0]
+	v.index = make(map[[SizeOfG2AffineUncompressed]byte]int)
+	v.lines = make(map[int]*[2][len(LoopCounter) - 1]LineEvaluationAff)
+}
+
+func (v *PairingBatchVerifier) g2Index(Q *G2Affine) int {
+	key := Q.RawBytes()
+	if i, ok := v.index[key]; ok {
+		return i
+	}
+	v.index[key] = len(v.g2)
+	v.g2 = append(v.g2, *Q)
+	return len(v.g2) - 1
+}
+
+// Verify returns true if all the accumulated equations hold (up to a negligible
+// soundness error), false otherwise.
+func (v *PairingBatchVerifier) Verify() (bool, error) {
+	if len(v.equations) == 0 {
+		return false, ErrBatchEmpty
+	}
+
+	r, err := v.randomScalars()
+	if err != nil {
+		return false, err
+	}
+
+	// for each G2 argument Q, compute ∑ⱼ rⱼ ∑ᵢ Pⱼᵢ over the terms (Pⱼᵢ, Q) of equation j
+	points := make([][]G1Affine, len(v.g2))
+	scalars := make([][]fr.Element, len(v.g2))
+	for j, eq := range v.equations {
+		for _, t := range eq {
+			points[t.q] = append(points[t.q], t.p)
+			scalars[t.q] = append(scalars[t.q], r[j])
+		}
+	}
+	folded := make([]G1Jac, len(v.g2))
+	for q := range v.g2 {
+		if _, err := folded[q].MultiExp(points[q], scalars[q], ecc.MultiExpConfig{}); err != nil {
+			return false, err
+		}
+	}
+	foldedAff := BatchJacobianToAffineG1(folded)
+
+	var P, PFixed []G1Affine
+	var Q []G2Affine
+	var lines [][2][len(LoopCounter) - 1]LineEvaluationAff
+	for q := range v.g2 {
+		if foldedAff[q].IsInfinity() {
+			continue
+		}
+		if l, ok := v.lines[q]; ok {
+			PFixed = append(PFixed, foldedAff[q])
+			lines = append(lines, *l)
+		} else {
+			P = append(P, foldedAff[q])
+			Q = append(Q, v.g2[q])
+		}
+	}
+
+	var f GT
+	f.SetOne()
+	if len(P) != 0 {
+		ml, err := MillerLoop(P, Q)
+		if err != nil {
+			return false, err
+		}
+		f.Mul(&f, &ml)
+	}
+	if len(PFixed) != 0 {
+		ml, err := MillerLoopFixedQ(PFixed, lines)
+		if err != nil {
+			return false, err
+		}
+		f.Mul(&f, &ml)
+	}
+	f = FinalExponentiation(&f)
+
+	return f.IsOne(), nil
+}
+
+// randomScalars returns one scalar per equation, the first one being 1.
+func (v *PairingBatchVerifier) randomScalars() ([]fr.Element, error) {
+	r := make([]fr.Element, len(v.equations))
+	r[0].SetOne()
+	if len(r) == 1 {
+		return r, nil
+	}
+
+	if v.hash == nil {
+		for j := 1; j < len(r); j++ {
+			if _, err := r[j].SetRandom(); err != nil {
+				return nil, err
+			}
+		}
+		return r, nil
+	}
+
+	// bind the scalars to all the inputs
+	var buf [8]byte
+	for _, eq := range v.equations {
+		binary.BigEndian.PutUint64(buf[:], uint64(len(eq)))
+		v.hash.Write(buf[:])
+		for _, t := range eq {
+			p := t.p.RawBytes()
+			q := v.g2[t.q].RawBytes()
+			v.hash.Write(p[:])
+			v.hash.Write(q[:])
+		}
+	}
+	seed := v.hash.Sum(nil)
+	h, err := fr.Hash(seed, []byte("PAIRING-BATCH-VERIFIER"), len(r)-1)
+	if err != nil {
+		return nil, err
+	}
+	copy(r[1:], h)
+	return r, nil
+}
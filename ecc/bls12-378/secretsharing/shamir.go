@@ -0,0 +1,193 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package secretsharing
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr/polynomial"
+)
+
+var (
+	ErrInvalidThreshold = errors.New("threshold must be between 1 and the number of shares")
+	ErrNotEnoughShares  = errors.New("not enough shares")
+	ErrZeroIndex        = errors.New("share index is zero")
+	ErrDuplicateIndex   = errors.New("share indices are not distinct")
+)
+
+// Share of a secret f(0), the evaluation of the sharing polynomial f at Index.
+type Share struct {
+	Index fr.Element // x ≠ 0
+	Value fr.Element // f(x)
+}
+
+// Indices returns the indices 1, 2, ..., n.
+func Indices(n int) []fr.Element {
+	res := make([]fr.Element, n)
+	for i := range res {
+		res[i].SetUint64(uint64(i + 1))
+	}
+	return res
+}
+
+// NewPolynomial returns a random sharing polynomial f of degree threshold-1 with f(0) = secret.
+func NewPolynomial(secret *fr.Element, threshold int) (polynomial.Polynomial, error) {
+	if threshold < 1 {
+		return nil, ErrInvalidThreshold
+	}
+	f := make(polynomial.Polynomial, threshold)
+	f[0].Set(secret)
+	for i := 1; i < threshold; i++ {
+		if _, err := f[i].SetRandom(); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// EvalShares returns the shares f(x) for x in indices, which must be distinct and non zero.
+func EvalShares(f polynomial.Polynomial, indices []fr.Element) ([]Share, error) {
+	if len(f) > len(indices) {
+		return nil, ErrInvalidThreshold
+	}
+	if err := checkIndices(indices); err != nil {
+		return nil, err
+	}
+	shares := make([]Share, len(indices))
+	for i := range indices {
+		shares[i].Index.Set(&indices[i])
+		shares[i].Value = f.Eval(&indices[i])
+	}
+	return shares, nil
+}
+
+// Split splits secret into n shares, at the indices 1, ..., n, such that any
+// threshold of them reconstruct it.
+func Split(secret *fr.Element, threshold, n int) ([]Share, error) {
+	if threshold > n {
+		return nil, ErrInvalidThreshold
+	}
+	f, err := NewPolynomial(secret, threshold)
+	if err != nil {
+		return nil, err
+	}
+	return EvalShares(f, Indices(n))
+}
+
+// Reconstruct returns the secret f(0) from threshold shares or more.
+// With more shares than the threshold, the result is only correct if all the
+// shares are, which the verifiable schemes allow to check beforehand.
+func Reconstruct(shares []Share, threshold int) (fr.Element, error) {
+	if threshold < 1 {
+		return fr.Element{}, ErrInvalidThreshold
+	}
+	if len(shares) < threshold {
+		return fr.Element{}, ErrNotEnoughShares
+	}
+	var zero fr.Element
+	return InterpolateAt(shares[:threshold], &zero)
+}
+
+// InterpolateAt returns f(x), where f is the polynomial of degree len(shares)-1
+// going through the shares.
+func InterpolateAt(shares []Share, x *fr.Element) (fr.Element, error) {
+	indices := make([]fr.Element, len(shares))
+	for i := range shares {
+		indices[i].Set(&shares[i].Index)
+	}
+	lambdas, err := LagrangeCoefficients(indices, x)
+	if err != nil {
+		return fr.Element{}, err
+	}
+	var res, tmp fr.Element
+	for i := range shares {
+		tmp.Mul(&lambdas[i], &shares[i].Value)
+		res.Add(&res, &tmp)
+	}
+	return res, nil
+}
+
+// LagrangeCoefficients returns the Lagrange coefficients of the indices at x,
+//
+//	λᵢ(x) = ∏_{j≠i} (x-xⱼ)/(xᵢ-xⱼ)
+//
+// such that f(x) = ∑ λᵢ(x)⋅f(xᵢ) for any polynomial f of degree < len(indices).
+// The indices must be distinct and non zero. The numerators are computed with
+// prefix and suffix products and the denominators are inverted at once.
+func LagrangeCoefficients(indices []fr.Element, x *fr.Element) ([]fr.Element, error) {
+	if err := checkIndices(indices); err != nil {
+		return nil, err
+	}
+	n := len(indices)
+	if n == 0 {
+		return nil, ErrNotEnoughShares
+	}
+
+	// diffs[i] = x - xᵢ
+	diffs := make([]fr.Element, n)
+	for i := range indices {
+		diffs[i].Sub(x, &indices[i])
+	}
+
+	// numerators[i] = ∏_{j<i} (x-xⱼ) ⋅ ∏_{j>i} (x-xⱼ)
+	numerators := make([]fr.Element, n)
+	numerators[0].SetOne()
+	for i := 1; i < n; i++ {
+		numerators[i].Mul(&numerators[i-1], &diffs[i-1])
+	}
+	var suffix fr.Element
+	suffix.SetOne()
+	for i := n - 1; i >= 0; i-- {
+		numerators[i].Mul(&numerators[i], &suffix)
+		suffix.Mul(&suffix, &diffs[i])
+	}
+
+	// denominators[i] = ∏_{j≠i} (xᵢ-xⱼ)
+	denominators := make([]fr.Element, n)
+	var tmp fr.Element
+	for i := range indices {
+		denominators[i].SetOne()
+		for j := range indices {
+			if j != i {
+				tmp.Sub(&indices[i], &indices[j])
+				denominators[i].Mul(&denominators[i], &tmp)
+			}
+		}
+	}
+	denominators = fr.BatchInvert(denominators)
+
+	for i := range numerators {
+		numerators[i].Mul(&numerators[i], &denominators[i])
+	}
+	return numerators, nil
+}
+
+// checkIndices checks that the indices are distinct and non zero
+func checkIndices(indices []fr.Element) error {
+	seen := make(map[fr.Element]struct{}, len(indices))
+	for i := range indices {
+		if indices[i].IsZero() {
+			return ErrZeroIndex
+		}
+		if _, ok := seen[indices[i]]; ok {
+			return ErrDuplicateIndex
+		}
+		seen[indices[i]] = struct{}{}
+	}
+	return nil
+}
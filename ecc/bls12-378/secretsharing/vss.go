@@ -0,0 +1,203 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package secretsharing
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr/polynomial"
+)
+
+var (
+	ErrInvalidShare      = errors.New("share does not match the commitment")
+	ErrCommitmentSize    = errors.New("commitment size does not match the threshold")
+	ErrPolynomialsDegree = errors.New("polynomials must have the same degree")
+)
+
+// FeldmanCommitment commitment Cₖ = [aₖ]G to the coefficients of a sharing
+// polynomial f = ∑ aₖXᵏ, where G is the generator of G1. C₀ = [s]G is the
+// public key of the secret s.
+type FeldmanCommitment []bls12378.G1Affine
+
+// CommitFeldman returns the Feldman commitment to f.
+func CommitFeldman(f polynomial.Polynomial) FeldmanCommitment {
+	g := g1Generator()
+	res := make(FeldmanCommitment, len(f))
+	for i := range f {
+		res[i].ScalarMultiplication(&g, f[i].BigInt(new(big.Int)))
+	}
+	return res
+}
+
+// SplitFeldman splits secret as Split, and returns the Feldman commitment to
+// the sharing polynomial with the shares.
+func SplitFeldman(secret *fr.Element, threshold, n int) ([]Share, FeldmanCommitment, error) {
+	if threshold > n {
+		return nil, nil, ErrInvalidThreshold
+	}
+	f, err := NewPolynomial(secret, threshold)
+	if err != nil {
+		return nil, nil, err
+	}
+	shares, err := EvalShares(f, Indices(n))
+	if err != nil {
+		return nil, nil, err
+	}
+	return shares, CommitFeldman(f), nil
+}
+
+// Eval returns [f(x)]G = ∑ [xᵏ]Cₖ, the commitment to the share at x.
+func (c FeldmanCommitment) Eval(x *fr.Element) bls12378.G1Affine {
+	var res bls12378.G1Affine
+	res.MultiExp(c, powers(x, len(c)), ecc.MultiExpConfig{})
+	return res
+}
+
+// Verify checks that [share.Value]G = ∑ [xᵏ]Cₖ where x is the index of the share,
+// with a single multi scalar multiplication.
+func (c FeldmanCommitment) Verify(share *Share) error {
+	if share.Index.IsZero() {
+		return ErrZeroIndex
+	}
+	points := make([]bls12378.G1Affine, len(c)+1)
+	copy(points, c)
+	points[len(c)] = g1Generator()
+
+	scalars := powers(&share.Index, len(c)+1)
+	scalars[len(c)].Neg(&share.Value)
+
+	return checkZero(points, scalars)
+}
+
+// PedersenParams generators of the Pedersen commitments, the discrete logarithm
+// of H in base G must be unknown.
+type PedersenParams struct {
+	G, H bls12378.G1Affine
+}
+
+// NewPedersenParams returns the generator G of G1 and H = HashToG1("H", seed).
+func NewPedersenParams(seed []byte) (PedersenParams, error) {
+	h, err := bls12378.HashToG1([]byte("H"), seed)
+	if err != nil {
+		return PedersenParams{}, err
+	}
+	return PedersenParams{G: g1Generator(), H: h}, nil
+}
+
+// PedersenCommitment commitment Cₖ = [aₖ]G + [bₖ]H to the coefficients of a
+// sharing polynomial f = ∑ aₖXᵏ, hidden by a random polynomial g = ∑ bₖXᵏ.
+// Unlike a Feldman commitment, it reveals nothing about the secret.
+type PedersenCommitment []bls12378.G1Affine
+
+// Commit returns the Pedersen commitment to f, hidden by g.
+func (pp *PedersenParams) Commit(f, g polynomial.Polynomial) (PedersenCommitment, error) {
+	if len(f) != len(g) {
+		return nil, ErrPolynomialsDegree
+	}
+	res := make(PedersenCommitment, len(f))
+	for i := range f {
+		if _, err := res[i].MultiExp([]bls12378.G1Affine{pp.G, pp.H}, []fr.Element{f[i], g[i]}, ecc.MultiExpConfig{}); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// SplitPedersen splits secret as Split, and returns the Pedersen commitment to
+// the sharing polynomial with the shares and the blinding shares g(x).
+func (pp *PedersenParams) SplitPedersen(secret *fr.Element, threshold, n int) (shares, blindings []Share, c PedersenCommitment, err error) {
+	if threshold > n {
+		return nil, nil, nil, ErrInvalidThreshold
+	}
+	f, err := NewPolynomial(secret, threshold)
+	if err != nil {
+		return
+	}
+	var r fr.Element
+	if _, err = r.SetRandom(); err != nil {
+		return
+	}
+	g, err := NewPolynomial(&r, threshold)
+	if err != nil {
+		return
+	}
+	if shares, err = EvalShares(f, Indices(n)); err != nil {
+		return
+	}
+	if blindings, err = EvalShares(g, Indices(n)); err != nil {
+		return
+	}
+	c, err = pp.Commit(f, g)
+	return
+}
+
+// Verify checks that [share.Value]G + [blinding.Value]H = ∑ [xᵏ]Cₖ where x is
+// the index of the share and of the blinding share, with a single multi scalar
+// multiplication.
+func (c PedersenCommitment) Verify(pp *PedersenParams, share, blinding *Share) error {
+	if share.Index.IsZero() {
+		return ErrZeroIndex
+	}
+	if !share.Index.Equal(&blinding.Index) {
+		return ErrInvalidShare
+	}
+	points := make([]bls12378.G1Affine, len(c)+2)
+	copy(points, c)
+	points[len(c)] = pp.G
+	points[len(c)+1] = pp.H
+
+	scalars := powers(&share.Index, len(c)+2)
+	scalars[len(c)].Neg(&share.Value)
+	scalars[len(c)+1].Neg(&blinding.Value)
+
+	return checkZero(points, scalars)
+}
+
+// checkZero returns ErrInvalidShare if ∑ [scalars[i]]points[i] ≠ 0
+func checkZero(points []bls12378.G1Affine, scalars []fr.Element) error {
+	var res bls12378.G1Jac
+	if _, err := res.MultiExp(points, scalars, ecc.MultiExpConfig{}); err != nil {
+		return err
+	}
+	if !res.Z.IsZero() {
+		return ErrInvalidShare
+	}
+	return nil
+}
+
+// powers returns 1, x, ..., xⁿ⁻¹
+func powers(x *fr.Element, n int) []fr.Element {
+	res := make([]fr.Element, n)
+	if n == 0 {
+		return res
+	}
+	res[0].SetOne()
+	for i := 1; i < n; i++ {
+		res[i].Mul(&res[i-1], x)
+	}
+	return res
+}
+
+// g1Generator returns the generator of G1
+func g1Generator() bls12378.G1Affine {
+	_, _, g, _ := bls12378.Generators()
+	return g
+}
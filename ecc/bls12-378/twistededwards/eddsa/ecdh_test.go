@@ -0,0 +1,129 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package eddsa
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestECDH(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("[BLS12-378] ECDH: both parties should get the same shared secret", prop.ForAll(
+		func() bool {
+			alice, _ := GenerateKey(rand.Reader)
+			bob, _ := GenerateKey(rand.Reader)
+			s1, err := alice.ECDH(&bob.PublicKey)
+			if err != nil || len(s1) != sizePublicKey {
+				return false
+			}
+			s2, err := bob.ECDH(&alice.PublicKey)
+			if err != nil {
+				return false
+			}
+			return bytes.Equal(s1, s2)
+		},
+	))
+
+	properties.Property("[BLS12-378] ECDH: points not on the curve and small order points should be rejected", prop.ForAll(
+		func() bool {
+			alice, _ := GenerateKey(rand.Reader)
+			var pub PublicKey
+			pub.A.X.SetOne()
+			pub.A.Y.SetOne()
+			if _, err := alice.ECDH(&pub); err != errInvalidPublicKey {
+				return false
+			}
+			// (0, -1) is of order 2
+			pub.A.X.SetZero()
+			pub.A.Y.SetOne().Neg(&pub.A.Y)
+			_, err := alice.ECDH(&pub)
+			return err == errIdentity
+		},
+	))
+
+	properties.Property("[BLS12-378] ECIES: Decrypt(Encrypt(m)) should be m", prop.ForAll(
+		func(plaintext, additionalData []byte) bool {
+			privKey, _ := GenerateKey(rand.Reader)
+			ciphertext, err := Encrypt(rand.Reader, &privKey.PublicKey, plaintext, additionalData)
+			if err != nil || len(ciphertext) != len(plaintext)+Overhead {
+				return false
+			}
+			res, err := privKey.Decrypt(ciphertext, additionalData)
+			return err == nil && bytes.Equal(res, plaintext)
+		},
+		gopter.Gen(genBytes),
+		gopter.Gen(genBytes),
+	))
+
+	properties.Property("[BLS12-378] ECIES: a tampered ciphertext, other additional data or another key should be rejected", prop.ForAll(
+		func(plaintext []byte) bool {
+			privKey, _ := GenerateKey(rand.Reader)
+			other, _ := GenerateKey(rand.Reader)
+			ciphertext, _ := Encrypt(rand.Reader, &privKey.PublicKey, plaintext, []byte("ad"))
+			if _, err := other.Decrypt(ciphertext, []byte("ad")); err == nil {
+				return false
+			}
+			if _, err := privKey.Decrypt(ciphertext, []byte("other ad")); err == nil {
+				return false
+			}
+			if _, err := privKey.Decrypt(ciphertext[:Overhead-1], []byte("ad")); err == nil {
+				return false
+			}
+			ciphertext[len(ciphertext)-1] ^= 1
+			_, err := privKey.Decrypt(ciphertext, []byte("ad"))
+			return err != nil
+		},
+		gopter.Gen(genBytes),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func genBytes(genParams *gopter.GenParameters) *gopter.GenResult {
+	buf := make([]byte, genParams.Rng.Intn(100))
+	genParams.Rng.Read(buf)
+	return gopter.NewGenResult(buf, gopter.NoShrinker)
+}
+
+// ------------------------------------------------------------
+// benches
+
+func BenchmarkECDH(b *testing.B) {
+	alice, _ := GenerateKey(rand.Reader)
+	bob, _ := GenerateKey(rand.Reader)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		alice.ECDH(&bob.PublicKey)
+	}
+}
+
+func BenchmarkEncrypt(b *testing.B) {
+	privKey, _ := GenerateKey(rand.Reader)
+	plaintext := make([]byte, 1024)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Encrypt(rand.Reader, &privKey.PublicKey, plaintext, nil)
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package frost
+
+import (
+	"encoding/binary"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/twistededwards"
+)
+
+// TrustedDealerKeyGen generates a random secret key and shares it among the participants
+// with the given identifiers, so that any threshold of them can sign. The dealer learns
+// the secret key and must be trusted to erase it.
+func TrustedDealerKeyGen(rand io.Reader, threshold int, ids []uint32) ([]KeyShare, *PublicKeyShares, error) {
+	if err := checkParticipants(threshold, ids); err != nil {
+		return nil, nil, err
+	}
+	coefficients, err := randomPolynomial(rand, threshold)
+	if err != nil {
+		return nil, nil, err
+	}
+	pub := &PublicKeyShares{
+		Threshold:  threshold,
+		Commitment: commitPolynomial(coefficients),
+	}
+	groupPublicKey := pub.GroupPublicKey()
+	shares := make([]KeyShare, len(ids))
+	for i := range ids {
+		shares[i].ID = ids[i]
+		shares[i].secret = evaluatePolynomial(coefficients, ids[i])
+		shares[i].PublicKey = pub.VerificationShare(ids[i])
+		shares[i].GroupPublicKey = groupPublicKey
+	}
+	return shares, pub, nil
+}
+
+// DKGParticipant is a participant of the distributed key generation of the FROST paper,
+// a Pedersen DKG where each participant proves the knowledge of its secret.
+//
+// Each participant
+//   - broadcasts the DKGRound1Message returned by NewDKGParticipant;
+//   - sends SecretShare(j) to each participant j, through a private channel;
+//   - calls Finalize with the messages and shares it received.
+type DKGParticipant struct {
+	id           uint32
+	threshold    int
+	ids          []uint32
+	coefficients []big.Int // secret polynomial of the participant
+	context      []byte    // context string Φ of the session
+}
+
+// DKGRound1Message is the message broadcast by a participant in the first round.
+type DKGRound1Message struct {
+	From       uint32
+	Commitment []twistededwards.PointAffine // commitments aₖ⋅Base to the coefficients of the polynomial of the participant
+	ProofR     twistededwards.PointAffine   // proof of knowledge of a₀
+	ProofZ     big.Int
+}
+
+// NewDKGParticipant samples the secret polynomial of the participant id and returns the
+// message to broadcast to the other participants.
+//
+// context is the context string Φ of the session, agreed on by all the participants. It
+// is bound to the proofs of knowledge, so that a proof can't be replayed in another
+// session: it should be unique to the session (e.g. a random nonce and the identifiers
+// of the participants).
+func NewDKGParticipant(rand io.Reader, id uint32, threshold int, ids []uint32, context []byte) (*DKGParticipant, *DKGRound1Message, error) {
+	if err := checkParticipants(threshold, ids); err != nil {
+		return nil, nil, err
+	}
+	if !contains(ids, id) {
+		return nil, nil, ErrUnknownSigner
+	}
+	coefficients, err := randomPolynomial(rand, threshold)
+	if err != nil {
+		return nil, nil, err
+	}
+	p := &DKGParticipant{
+		id:           id,
+		threshold:    threshold,
+		ids:          append([]uint32{}, ids...),
+		coefficients: coefficients,
+		context:      append([]byte{}, context...),
+	}
+	msg := &DKGRound1Message{
+		From:       id,
+		Commitment: commitPolynomial(coefficients),
+	}
+
+	// Schnorr proof of knowledge of a₀: R = k⋅Base, z = k + a₀⋅c
+	k, err := randomScalar(rand)
+	if err != nil {
+		return nil, nil, err
+	}
+	curve := twistededwards.GetEdwardsCurve()
+	msg.ProofR.ScalarMultiplication(&curve.Base, &k)
+	c := dkgChallenge(context, id, &msg.Commitment[0], &msg.ProofR)
+	msg.ProofZ.Mul(&c, &coefficients[0]).Add(&msg.ProofZ, &k).Mod(&msg.ProofZ, &curve.Order)
+
+	return p, msg, nil
+}
+
+// SecretShare returns the share f(to) of the secret of the participant, to send
+// privately to the participant to.
+func (p *DKGParticipant) SecretShare(to uint32) (big.Int, error) {
+	if !contains(p.ids, to) {
+		return big.Int{}, ErrUnknownSigner
+	}
+	return evaluatePolynomial(p.coefficients, to), nil
+}
+
+// Finalize checks the messages broadcast by all the participants and the secret shares
+// sent by all the participants (indexed by sender, including p), and returns the key
+// share of p and the public key shares of the group.
+func (p *DKGParticipant) Finalize(messages []DKGRound1Message, shares map[uint32]big.Int) (*KeyShare, *PublicKeyShares, error) {
+	if len(messages) != len(p.ids) || len(shares) != len(p.ids) {
+		return nil, nil, ErrNotEnoughSigners
+	}
+	curve := twistededwards.GetEdwardsCurve()
+	pub := &PublicKeyShares{
+		Threshold:  p.threshold,
+		Commitment: make([]twistededwards.PointAffine, p.threshold),
+	}
+	for k := range pub.Commitment {
+		pub.Commitment[k].Y.SetOne()
+	}
+	seen := make(map[uint32]bool, len(messages))
+	res := &KeyShare{ID: p.id}
+	for i := range messages {
+		msg := &messages[i]
+		if !contains(p.ids, msg.From) || seen[msg.From] {
+			return nil, nil, ErrUnknownSigner
+		}
+		seen[msg.From] = true
+		if err := msg.verify(p.threshold, p.context); err != nil {
+			return nil, nil, err
+		}
+
+		// f(id)⋅Base ?= ∑ idᵏ⋅Cₖ
+		share, ok := shares[msg.From]
+		if !ok {
+			return nil, nil, ErrNotEnoughSigners
+		}
+		var lhs twistededwards.PointAffine
+		lhs.ScalarMultiplication(&curve.Base, &share)
+		rhs := evaluateCommitment(msg.Commitment, p.id)
+		if share.Sign() < 0 || share.Cmp(&curve.Order) >= 0 || !lhs.Equal(&rhs) {
+			return nil, nil, &InvalidContributionError{ID: msg.From, Contribution: "secret share"}
+		}
+		res.secret.Add(&res.secret, &share)
+		for k := range pub.Commitment {
+			pub.Commitment[k].Add(&pub.Commitment[k], &msg.Commitment[k])
+		}
+	}
+	res.secret.Mod(&res.secret, &curve.Order)
+	res.PublicKey.ScalarMultiplication(&curve.Base, &res.secret)
+	res.GroupPublicKey = pub.GroupPublicKey()
+	return res, pub, nil
+}
+
+// verify checks the size of the commitment and the proof of knowledge of the message in
+// the session with the given context
+//
+// z⋅Base ?= R + c⋅C₀
+func (msg *DKGRound1Message) verify(threshold int, context []byte) error {
+	if len(msg.Commitment) != threshold {
+		return &InvalidContributionError{ID: msg.From, Contribution: "commitment"}
+	}
+	for k := range msg.Commitment {
+		if !isInPrimeSubgroup(&msg.Commitment[k]) {
+			return &InvalidContributionError{ID: msg.From, Contribution: "commitment"}
+		}
+	}
+	curve := twistededwards.GetEdwardsCurve()
+	if !isInPrimeSubgroup(&msg.ProofR) || msg.ProofZ.Sign() < 0 || msg.ProofZ.Cmp(&curve.Order) >= 0 {
+		return &InvalidContributionError{ID: msg.From, Contribution: "proof of knowledge"}
+	}
+	c := dkgChallenge(context, msg.From, &msg.Commitment[0], &msg.ProofR)
+	var lhs, rhs twistededwards.PointAffine
+	lhs.ScalarMultiplication(&curve.Base, &msg.ProofZ)
+	rhs.ScalarMultiplication(&msg.Commitment[0], &c).Add(&rhs, &msg.ProofR)
+	if !lhs.Equal(&rhs) {
+		return &InvalidContributionError{ID: msg.From, Contribution: "proof of knowledge"}
+	}
+	return nil
+}
+
+// dkgChallenge returns H(id, len(Φ), Φ, C₀, R) mod the order of the curve
+func dkgChallenge(context []byte, id uint32, c0, r *twistededwards.PointAffine) big.Int {
+	c0b, rb := c0.Bytes(), r.Bytes()
+	var contextLen [8]byte
+	binary.BigEndian.PutUint64(contextLen[:], uint64(len(context)))
+	h := hashBytes("dkg", identifierBytes(id), contextLen[:], context, c0b[:], rb[:])
+	var res big.Int
+	res.SetBytes(h[:]).Mod(&res, curveOrder())
+	return res
+}
+
+func checkParticipants(threshold int, ids []uint32) error {
+	if threshold < 1 || threshold > len(ids) {
+		return ErrInvalidThreshold
+	}
+	seen := make(map[uint32]bool, len(ids))
+	for _, id := range ids {
+		if id == 0 || seen[id] {
+			return ErrInvalidIdentifier
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+func contains(ids []uint32, id uint32) bool {
+	for i := range ids {
+		if ids[i] == id {
+			return true
+		}
+	}
+	return false
+}
+
+// randomPolynomial returns threshold random coefficients
+func randomPolynomial(rand io.Reader, threshold int) ([]big.Int, error) {
+	coefficients := make([]big.Int, threshold)
+	for k := range coefficients {
+		var err error
+		if coefficients[k], err = randomScalar(rand); err != nil {
+			return nil, err
+		}
+	}
+	return coefficients, nil
+}
+
+// commitPolynomial returns the commitments aₖ⋅Base to the coefficients
+func commitPolynomial(coefficients []big.Int) []twistededwards.PointAffine {
+	base := twistededwards.GetEdwardsCurve().Base
+	res := make([]twistededwards.PointAffine, len(coefficients))
+	for k := range coefficients {
+		res[k].ScalarMultiplication(&base, &coefficients[k])
+	}
+	return res
+}
+
+// evaluatePolynomial returns ∑ aₖxᵏ mod the order of the curve
+func evaluatePolynomial(coefficients []big.Int, x uint32) big.Int {
+	order := curveOrder()
+	bx := new(big.Int).SetUint64(uint64(x))
+	var res big.Int
+	for k := len(coefficients) - 1; k >= 0; k-- {
+		res.Mul(&res, bx).Add(&res, &coefficients[k]).Mod(&res, order)
+	}
+	return res
+}
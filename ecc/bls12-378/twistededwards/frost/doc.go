@@ -0,0 +1,37 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package frost provides FROST threshold signatures on bls12-378's twisted edwards curve.
+//
+// A group of participants shares the secret key of an EdDSA public key, so that any
+// t of them can sign together while t-1 of them learn nothing about the key. The key is
+// shared by a trusted dealer (TrustedDealerKeyGen) or with a distributed key generation
+// (NewDKGParticipant), without a dealer.
+//
+// Signing takes two rounds:
+//   - each signer publishes the commitment of fresh nonces (Commit);
+//   - once the coordinator has chosen the signers and sent them their commitments, each
+//     signer computes a signature share (Sign).
+//
+// The coordinator aggregates the shares (Aggregate) into a signature verified by
+// eddsa.PublicKey.Verify. A participant sending an invalid value is identified by an
+// InvalidContributionError.
+//
+// # See also
+//
+// https://eprint.iacr.org/2020/852
+// https://www.rfc-editor.org/rfc/rfc9591
+package frost
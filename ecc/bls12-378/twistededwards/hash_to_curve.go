@@ -0,0 +1,176 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package twistededwards
+
+import (
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+)
+
+// The Elligator 2 map is defined on the Montgomery curve K⋅t² = s³ + J⋅s² + s
+// birationally equivalent to the twisted Edwards curve, with
+//
+//	J = 2(a+d)/(a-d), K = 4/(a-d)
+//
+// RFC 9380, Section 6.7.1 and Appendix D.1
+var (
+	ell2Once sync.Once
+	ell2Z    fr.Element // non square in fr
+	ell2C1   fr.Element // J/K = (a+d)/2
+	ell2C2   fr.Element // 1/K² = (a-d)²/16
+	ell2K    fr.Element // K = 4/(a-d)
+)
+
+func initEll2() {
+	initOnce.Do(initCurveParams)
+
+	ell2Z.SetString("5")
+
+	var aMinusD fr.Element
+	aMinusD.Sub(&curveParams.A, &curveParams.D)
+
+	ell2C1.Add(&curveParams.A, &curveParams.D)
+	ell2C1.Halve()
+
+	ell2C2.SetUint64(16).Inverse(&ell2C2)
+	ell2C2.Mul(&ell2C2, &aMinusD).Mul(&ell2C2, &aMinusD)
+
+	ell2K.Inverse(&aMinusD).Double(&ell2K).Double(&ell2K)
+}
+
+// sgn0 returns the parity of the canonical representative of z
+//
+// RFC 9380, Section 4.1
+func sgn0(z *fr.Element) uint64 {
+	nonMont := z.Bits()
+	return nonMont[0] % 2
+}
+
+// mapToMontgomery is the Elligator 2 map of u to a point (x, y) of the curve
+// y² = x³ + J/K⋅x² + 1/K²⋅x, that is (s, t) = (K⋅x, K⋅y) on the Montgomery curve.
+//
+// RFC 9380, Section 6.7.1.1
+func mapToMontgomery(u *fr.Element) (x, y fr.Element) {
+	ell2Once.Do(initEll2)
+
+	var tv1, x1, x2, gx1, gx2, y2, minusOne fr.Element
+	minusOne.SetOne().Neg(&minusOne)
+
+	tv1.Square(u).Mul(&tv1, &ell2Z)
+	// 1 + Z⋅u² = 0 is sent to x1 = -J/K (tv1 = 0)
+	if tv1.Equal(&minusOne) {
+		tv1.SetZero()
+	}
+	x1.SetOne().Add(&x1, &tv1).Inverse(&x1)
+	x1.Mul(&x1, &ell2C1).Neg(&x1) // x1 = -(J/K) / (1 + Z⋅u²)
+
+	gx1.Add(&x1, &ell2C1).Mul(&gx1, &x1).Add(&gx1, &ell2C2).Mul(&gx1, &x1) // gx1 = x1³ + (J/K)⋅x1² + x1/K²
+
+	x2.Add(&x1, &ell2C1).Neg(&x2) // x2 = -x1 - J/K
+	gx2.Mul(&tv1, &gx1)           // gx2 = Z⋅u²⋅gx1
+
+	e2 := gx1.Legendre() != -1
+	if e2 {
+		x.Set(&x1)
+		y2.Set(&gx1)
+	} else {
+		x.Set(&x2)
+		y2.Set(&gx2)
+	}
+	y.Sqrt(&y2)
+
+	// fix the sign of y
+	e3 := sgn0(&y) == 1
+	if e2 != e3 {
+		y.Neg(&y)
+	}
+	return
+}
+
+// MapToCurve maps the field element u to a point of the twisted Edwards curve,
+// not necessarily in the prime order subgroup. It is the Elligator 2 map followed
+// by the rational map from the Montgomery curve
+//
+//	v = s/t, w = (s-1)/(s+1)
+//
+// where the exceptional cases t = 0 and s = -1 are sent to the identity.
+//
+// RFC 9380, Sections 6.7.1 and 6.8.2, Appendix D.1
+func MapToCurve(u *fr.Element) PointAffine {
+	x, y := mapToMontgomery(u)
+
+	// s/t = x/y and (s-1)/(s+1) = (K⋅x-1)/(K⋅x+1), computed with a single inversion
+	var s, sMinusOne, sPlusOne, den fr.Element
+	s.Mul(&x, &ell2K)
+	sMinusOne.SetOne()
+	sMinusOne.Sub(&s, &sMinusOne)
+	sPlusOne.SetOne()
+	sPlusOne.Add(&s, &sPlusOne)
+
+	var res PointAffine
+	den.Mul(&y, &sPlusOne)
+	if den.IsZero() {
+		res.setInfinity()
+		return res
+	}
+	den.Inverse(&den)
+	res.X.Mul(&x, &sPlusOne).Mul(&res.X, &den)
+	res.Y.Mul(&sMinusOne, &y).Mul(&res.Y, &den)
+	return res
+}
+
+// clearCofactor multiplies p by the cofactor 8 of the curve.
+// It uses doublings.
+func clearCofactor(p *PointAffine) {
+	p.Double(p).Double(p).Double(p)
+}
+
+// EncodeToCurve hashes a message to a point of the prime order subgroup using
+// the Elligator 2 map. It is faster than HashToCurve, but the result is not
+// uniformly distributed: it is unsuitable as a random oracle.
+// dst stands for "domain separation tag", a string unique to the construction using the hash function
+//
+// RFC 9380, Section 3 (encode_to_curve), with expand_message_xmd over SHA-256
+func EncodeToCurve(msg, dst []byte) (PointAffine, error) {
+	u, err := fr.Hash(msg, dst, 1)
+	if err != nil {
+		return PointAffine{}, err
+	}
+	res := MapToCurve(&u[0])
+	clearCofactor(&res)
+	return res, nil
+}
+
+// HashToCurve hashes a message to a point of the prime order subgroup using
+// the Elligator 2 map. It is usable as a random oracle.
+// dst stands for "domain separation tag", a string unique to the construction using the hash function
+//
+// RFC 9380, Section 3 (hash_to_curve), with expand_message_xmd over SHA-256
+func HashToCurve(msg, dst []byte) (PointAffine, error) {
+	u, err := fr.Hash(msg, dst, 2)
+	if err != nil {
+		return PointAffine{}, err
+	}
+	Q0 := MapToCurve(&u[0])
+	Q1 := MapToCurve(&u[1])
+
+	var res PointAffine
+	res.Add(&Q0, &Q1)
+	clearCofactor(&res)
+	return res, nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package twistededwards
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestHashToCurve(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genU := GenFr()
+
+	properties.Property("[BLS12-378] Elligator 2 should map to the Montgomery curve", prop.ForAll(
+		func(u fr.Element) bool {
+			x, y := mapToMontgomery(&u)
+			// y² = x³ + J/K⋅x² + 1/K²⋅x
+			var lhs, rhs fr.Element
+			lhs.Square(&y)
+			rhs.Add(&x, &ell2C1).Mul(&rhs, &x).Add(&rhs, &ell2C2).Mul(&rhs, &x)
+			return lhs.Equal(&rhs)
+		},
+		genU,
+	))
+
+	properties.Property("[BLS12-378] MapToCurve should output a point on the curve", prop.ForAll(
+		func(u fr.Element) bool {
+			p := MapToCurve(&u)
+			return p.IsOnCurve()
+		},
+		genU,
+	))
+
+	properties.Property("[BLS12-378] EncodeToCurve and HashToCurve should output points of the prime order subgroup", prop.ForAll(
+		func(u fr.Element) bool {
+			msg := u.Marshal()
+			p1, err := EncodeToCurve(msg, []byte("dst"))
+			if err != nil || !p1.IsOnCurve() || !isInSubGroup(&p1) {
+				return false
+			}
+			p2, err := HashToCurve(msg, []byte("dst"))
+			if err != nil || !p2.IsOnCurve() || !isInSubGroup(&p2) {
+				return false
+			}
+			return !p1.Equal(&p2)
+		},
+		genU,
+	))
+
+	properties.Property("[BLS12-378] HashToCurve should be deterministic and depend on the domain separation tag", prop.ForAll(
+		func(u fr.Element) bool {
+			msg := u.Marshal()
+			p1, _ := HashToCurve(msg, []byte("dst"))
+			p2, _ := HashToCurve(msg, []byte("dst"))
+			p3, _ := HashToCurve(msg, []byte("other dst"))
+			return p1.Equal(&p2) && !p1.Equal(&p3)
+		},
+		genU,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// exceptional case of the Elligator 2 map, tv1 = 0
+	var u fr.Element
+	p := MapToCurve(&u)
+	if !p.IsOnCurve() {
+		t.Fatal("MapToCurve(0) is not on the curve")
+	}
+}
+
+// isInSubGroup checks that [order]p = 0 with a double-and-add, which unlike the
+// scalar multiplication of the package is valid outside the prime order subgroup.
+func isInSubGroup(p *PointAffine) bool {
+	order := GetEdwardsCurve().Order
+	var res PointProj
+	res.setInfinity()
+	for i := order.BitLen() - 1; i >= 0; i-- {
+		res.Double(&res)
+		if order.Bit(i) == 1 {
+			res.MixedAdd(&res, p)
+		}
+	}
+	return res.IsZero()
+}
+
+// GenFr generates random field elements
+func GenFr() gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		var elmt fr.Element
+		if _, err := elmt.SetRandom(); err != nil {
+			panic(err)
+		}
+		return gopter.NewGenResult(elmt, gopter.NoShrinker)
+	}
+}
+
+// ------------------------------------------------------------
+// benches
+
+func BenchmarkHashToCurve(b *testing.B) {
+	msg := []byte("benchmark")
+	dst := []byte("dst")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		HashToCurve(msg, dst)
+	}
+}
@@ -0,0 +1,279 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package banderwagon implements the prime order group banderwagon, the quotient of
+// the bandersnatch subgroup 2E by its 2-torsion point (0, -1).
+//
+// Each element is represented by a bandersnatch point P, with P and P + (0, -1) = (-x, -y)
+// being the same element. The encoding of an element is the x coordinate of its
+// representative with a lexicographically largest y; decoding checks that it belongs to 2E.
+//
+// # See also
+//
+// https://hackmd.io/@6iQDuIePQjyYBqDChYw_jg/BJBNcv9fq
+package banderwagon
+
+import (
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+	fp "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// EncodedSize is the size of the encoding of an element, in bytes.
+const EncodedSize = fp.Bytes
+
+var (
+	errInvalidEncoding = errors.New("invalid banderwagon encoding")
+	errNotInSubgroup   = errors.New("banderwagon encoding is not in the subgroup")
+)
+
+// Element is an element of banderwagon
+type Element struct {
+	p bandersnatch.PointExtended
+}
+
+// Set sets e to a and returns e
+func (e *Element) Set(a *Element) *Element {
+	e.p.Set(&a.p)
+	return e
+}
+
+// SetIdentity sets e to the identity element and returns e
+func (e *Element) SetIdentity() *Element {
+	e.p.X.SetZero()
+	e.p.Y.SetOne()
+	e.p.Z.SetOne()
+	e.p.T.SetZero()
+	return e
+}
+
+// SetGenerator sets e to the class of the bandersnatch base point and returns e
+func (e *Element) SetGenerator() *Element {
+	params := bandersnatch.GetEdwardsCurve()
+	e.p.FromAffine(&params.Base)
+	return e
+}
+
+// Equal returns true if e and a are the same element, that is if x₁⋅y₂ = x₂⋅y₁
+func (e *Element) Equal(a *Element) bool {
+	var x1y2, x2y1 fp.Element
+	x1y2.Mul(&e.p.X, &a.p.Y)
+	x2y1.Mul(&a.p.X, &e.p.Y)
+	return x1y2.Equal(&x2y1)
+}
+
+// IsIdentity returns true if e is the identity element
+func (e *Element) IsIdentity() bool {
+	return e.p.X.IsZero()
+}
+
+// Add sets e = a + b and returns e
+func (e *Element) Add(a, b *Element) *Element {
+	e.p.Add(&a.p, &b.p)
+	return e
+}
+
+// Sub sets e = a - b and returns e
+func (e *Element) Sub(a, b *Element) *Element {
+	var nb bandersnatch.PointExtended
+	nb.Neg(&b.p)
+	e.p.Add(&a.p, &nb)
+	return e
+}
+
+// Double sets e = 2a and returns e
+func (e *Element) Double(a *Element) *Element {
+	e.p.Double(&a.p)
+	return e
+}
+
+// Neg sets e = -a and returns e
+func (e *Element) Neg(a *Element) *Element {
+	e.p.Neg(&a.p)
+	return e
+}
+
+// ScalarMultiplication sets e = [s]a and returns e
+func (e *Element) ScalarMultiplication(a *Element, s *big.Int) *Element {
+	e.p.ScalarMultiplication(&a.p, s)
+	return e
+}
+
+// MultiExp sets e = ∑ scalars[i]⋅elements[i] and returns e, see bandersnatch.PointExtended.MultiExp.
+//
+// This call return an error if len(scalars) != len(elements) or if provided config is invalid.
+func (e *Element) MultiExp(elements []Element, scalars []fr.Element, config ecc.MultiExpConfig) (*Element, error) {
+	points := make([]bandersnatch.PointExtended, len(elements))
+	for i := range elements {
+		points[i] = elements[i].p
+	}
+	s := make([]big.Int, len(scalars))
+	for i := range scalars {
+		scalars[i].BigInt(&s[i])
+	}
+	var res bandersnatch.PointExtended
+	if _, err := res.MultiExp(bandersnatch.BatchFromExtended(points), s, config); err != nil {
+		return nil, err
+	}
+	e.p = res
+	return e, nil
+}
+
+// Bytes returns the canonical encoding of e: the big endian x coordinate of its
+// representative with a lexicographically largest y coordinate.
+func (e *Element) Bytes() [EncodedSize]byte {
+	var zInv fp.Element
+	zInv.Inverse(&e.p.Z)
+	return encode(&e.p, &zInv)
+}
+
+// encode returns the encoding of p, zInv being the inverse of its Z coordinate
+func encode(p *bandersnatch.PointExtended, zInv *fp.Element) [EncodedSize]byte {
+	var x, y fp.Element
+	x.Mul(&p.X, zInv)
+	y.Mul(&p.Y, zInv)
+	if !y.LexicographicallyLargest() {
+		x.Neg(&x)
+	}
+	return x.Bytes()
+}
+
+// SetBytes decodes buf into e. It returns the number of bytes read, and an error if buf
+// is too short, is not the canonical encoding of a bandersnatch point or if this point
+// is not in the subgroup 2E.
+func (e *Element) SetBytes(buf []byte) (int, error) {
+	if len(buf) < EncodedSize {
+		return 0, io.ErrShortBuffer
+	}
+	var x fp.Element
+	if err := x.SetBytesCanonical(buf[:EncodedSize]); err != nil {
+		return 0, errInvalidEncoding
+	}
+	if err := e.setX(&x); err != nil {
+		return 0, err
+	}
+	return EncodedSize, nil
+}
+
+// setX sets e to the point of abscissa x with a lexicographically largest y, checking
+// that it belongs to 2E.
+func (e *Element) setX(x *fp.Element) error {
+	params := bandersnatch.GetEdwardsCurve()
+
+	// (x, y) is in 2E iff 1 - a⋅x² is a square
+	var one, xx, num, den, yy, y fp.Element
+	one.SetOne()
+	xx.Square(x)
+	num.Mul(&xx, &params.A)
+	num.Sub(&one, &num)
+	if num.Legendre() != 1 {
+		return errNotInSubgroup
+	}
+
+	// y² = (1 - a⋅x²) / (1 - d⋅x²)
+	den.Mul(&xx, &params.D)
+	den.Sub(&one, &den)
+	if den.IsZero() {
+		return errInvalidEncoding
+	}
+	den.Inverse(&den)
+	yy.Mul(&num, &den)
+	if y.Sqrt(&yy) == nil {
+		return errInvalidEncoding
+	}
+	if !y.LexicographicallyLargest() {
+		y.Neg(&y)
+	}
+
+	e.p.X.Set(x)
+	e.p.Y.Set(&y)
+	e.p.Z.SetOne()
+	e.p.T.Mul(x, &y)
+	return nil
+}
+
+// BatchToBytes returns the encodings of elements, using a single field inversion.
+func BatchToBytes(elements []Element) [][EncodedSize]byte {
+	res := make([][EncodedSize]byte, len(elements))
+	zInv := make([]fp.Element, len(elements))
+	for i := range elements {
+		zInv[i] = elements[i].p.Z
+	}
+	zInv = fp.BatchInvert(zInv)
+
+	parallel.Execute(len(elements), func(start, end int) {
+		for i := start; i < end; i++ {
+			res[i] = encode(&elements[i].p, &zInv[i])
+		}
+	})
+	return res
+}
+
+// BatchSetBytes decodes the encodings in buf, in parallel. It returns an error if one of
+// them is invalid, see SetBytes.
+func BatchSetBytes(buf [][EncodedSize]byte) ([]Element, error) {
+	res := make([]Element, len(buf))
+	errs := make([]error, len(buf))
+	parallel.Execute(len(buf), func(start, end int) {
+		for i := start; i < end; i++ {
+			_, errs[i] = res[i].SetBytes(buf[i][:])
+		}
+	})
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// MapToScalarField sets res to x/y mod r, where r is the order of banderwagon, and
+// returns res. The map is well defined since (x, y) and (-x, -y) have the same image.
+func (e *Element) MapToScalarField(res *fr.Element) *fr.Element {
+	var yInv fp.Element
+	yInv.Inverse(&e.p.Y)
+	return mapToScalarField(res, &e.p.X, &yInv)
+}
+
+func mapToScalarField(res *fr.Element, x, yInv *fp.Element) *fr.Element {
+	var xy fp.Element
+	xy.Mul(x, yInv)
+	b := xy.Bytes()
+	return res.SetBytes(b[:])
+}
+
+// BatchMapToScalarField sets res[i] to the image of elements[i] by MapToScalarField,
+// using a single field inversion. It panics if len(res) != len(elements).
+func BatchMapToScalarField(res []fr.Element, elements []Element) {
+	if len(res) != len(elements) {
+		panic("banderwagon: len(res) != len(elements)")
+	}
+	yInv := make([]fp.Element, len(elements))
+	for i := range elements {
+		yInv[i] = elements[i].p.Y
+	}
+	yInv = fp.BatchInvert(yInv)
+
+	parallel.Execute(len(elements), func(start, end int) {
+		for i := start; i < end; i++ {
+			mapToScalarField(&res[i], &elements[i].p.X, &yInv[i])
+		}
+	})
+}
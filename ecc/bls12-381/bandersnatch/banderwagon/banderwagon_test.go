@@ -0,0 +1,255 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package banderwagon
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+	fp "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func TestGeneratorDoublings(t *testing.T) {
+	// encodings of [2ⁱ]G, from the go-ipa reference implementation
+	expected := []string{
+		"4a2c7486fd924882bf02c6908de395122843e3e05264d7991e18e7985dad51e9",
+		"43aa74ef706605705989e8fd38df46873b7eae5921fbed115ac9d937399ce4d5",
+		"5e5f550494159f38aa54d2ed7f11a7e93e4968617990445cc93ac8e59808c126",
+		"0e7e3748db7c5c999a7bcd93d71d671f1f40090423792266f94cb27ca43fce5c",
+		"14ddaa48820cb6523b9ae5fe9fe257cbbd1f3d598a28e670a40da5d1159d864a",
+		"6989d1c82b2d05c74b62fb0fbdf8843adae62ff720d370e209a7b84e14548a7d",
+		"26b8df6fa414bf348a3dc780ea53b70303ce49f3369212dec6fbe4b349b832bf",
+		"37e46072db18f038f2cc7d3d5b5d1374c0eb86ca46f869d6a95fc2fb092c0d35",
+		"2c1ce64f26e1c772282a6633fac7ca73067ae820637ce348bb2c8477d228dc7d",
+		"297ab0f5a8336a7a4e2657ad7a33a66e360fb6e50812d4be3326fab73d6cee07",
+		"5b285811efa7a965bd6ef5632151ebf399115fcc8f5b9b8083415ce533cc39ce",
+		"1f939fa2fd457b3effb82b25d3fe8ab965f54015f108f8c09d67e696294ab626",
+		"3088dcb4d3f4bacd706487648b239e0be3072ed2059d981fe04ce6525af6f1b8",
+		"35fbc386a16d0227ff8673bc3760ad6b11009f749bb82d4facaea67f58fc60ed",
+		"00f29b4f3255e318438f0a31e058e4c081085426adb0479f14c64985d0b956e0",
+		"3fa4384b2fa0ecc3c0582223602921daaa893a97b64bdf94dcaa504e8b7b9e5f",
+	}
+	var e Element
+	e.SetGenerator()
+	for i, exp := range expected {
+		b := e.Bytes()
+		if hex.EncodeToString(b[:]) != exp {
+			t.Fatalf("[2^%d]G: got %x", i, b)
+		}
+		var decoded Element
+		if _, err := decoded.SetBytes(b[:]); err != nil {
+			t.Fatalf("[2^%d]G: %v", i, err)
+		}
+		if !decoded.Equal(&e) {
+			t.Fatalf("[2^%d]G: decoding mismatch", i)
+		}
+		e.Double(&e)
+	}
+}
+
+func TestVerkleBasis(t *testing.T) {
+	basis := VerkleBasis()
+	if len(basis) != VerkleBasisSize {
+		t.Fatal("wrong basis size")
+	}
+	encodings := BatchToBytes(basis)
+	if hex.EncodeToString(encodings[0][:]) != "01587ad1336675eb912550ec2a28eb8923b824b490dd2ba82e48f14590a298a0" {
+		t.Fatalf("first element: got %x", encodings[0])
+	}
+	h := sha256.New()
+	for i := range encodings {
+		h.Write(encodings[i][:])
+	}
+	if hex.EncodeToString(h.Sum(nil)) != "1fcaea10bf24f750200e06fa473c76ff0468007291fa548e2d99f09ba9256fdb" {
+		t.Fatal("basis digest mismatch")
+	}
+}
+
+func TestInvalidEncodings(t *testing.T) {
+	// non-canonical field encoding: p
+	b := fp.Modulus().FillBytes(make([]byte, EncodedSize))
+	if _, err := new(Element).SetBytes(b); err == nil {
+		t.Fatal("non-canonical encoding should be rejected")
+	}
+	if _, err := new(Element).SetBytes(b[1:]); err == nil {
+		t.Fatal("short buffer should be rejected")
+	}
+
+	// points on the curve outside of 2E, and abscissas of no point
+	params := bandersnatch.GetEdwardsCurve()
+	var one, x, xx, num, den, yy fp.Element
+	one.SetOne()
+	var outside, notOnCurve int
+	for i := uint64(1); outside < 5 || notOnCurve < 5; i++ {
+		x.SetUint64(i)
+		xx.Square(&x)
+		num.Mul(&xx, &params.A).Sub(&one, &num)
+		den.Mul(&xx, &params.D).Sub(&one, &den)
+		yy.Div(&num, &den)
+		b := x.Bytes()
+		_, err := new(Element).SetBytes(b[:])
+		switch {
+		case num.Legendre() == 1:
+			continue
+		case yy.Legendre() == 1:
+			outside++
+		default:
+			notOnCurve++
+		}
+		if err == nil {
+			t.Fatalf("x = %d should be rejected", i)
+		}
+	}
+}
+
+func TestTorsion(t *testing.T) {
+	// (0, -1) has order 2: adding it doesn't change the element
+	var torsion Element
+	torsion.SetIdentity()
+	torsion.p.Y.Neg(&torsion.p.Y)
+
+	var id Element
+	id.SetIdentity()
+	if !torsion.Equal(&id) || torsion.Bytes() != id.Bytes() {
+		t.Fatal("(0, -1) should be the identity")
+	}
+
+	for i := 0; i < 10; i++ {
+		e := randomElement()
+		var f Element
+		f.Add(&e, &torsion)
+		if !f.Equal(&e) || e.Bytes() != f.Bytes() {
+			t.Fatal("encoding depends on the representative")
+		}
+		var se, sf fr.Element
+		if !e.MapToScalarField(&se).Equal(f.MapToScalarField(&sf)) {
+			t.Fatal("MapToScalarField depends on the representative")
+		}
+
+		// [s]e and [s]f are the same element
+		s, _ := rand.Int(rand.Reader, fr.Modulus())
+		e.ScalarMultiplication(&e, s)
+		f.ScalarMultiplication(&f, s)
+		if !f.Equal(&e) {
+			t.Fatal("scalar multiplication depends on the representative")
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		e := randomElement()
+		b := e.Bytes()
+		var f Element
+		if _, err := f.SetBytes(b[:]); err != nil {
+			t.Fatal(err)
+		}
+		if !f.Equal(&e) {
+			t.Fatal("round trip failed")
+		}
+
+		// e - e = 0
+		f.Sub(&f, &e)
+		if !f.IsIdentity() {
+			t.Fatal("e - e != 0")
+		}
+	}
+}
+
+func TestBatch(t *testing.T) {
+	const n = 50
+	elements := make([]Element, n)
+	for i := range elements {
+		elements[i] = randomElement()
+	}
+	elements[3].SetIdentity()
+
+	encodings := BatchToBytes(elements)
+	decoded, err := BatchSetBytes(encodings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scalars := make([]fr.Element, n)
+	BatchMapToScalarField(scalars, elements)
+
+	for i := range elements {
+		if encodings[i] != elements[i].Bytes() {
+			t.Fatal("BatchToBytes mismatch")
+		}
+		if !decoded[i].Equal(&elements[i]) {
+			t.Fatal("BatchSetBytes mismatch")
+		}
+		var s fr.Element
+		if !elements[i].MapToScalarField(&s).Equal(&scalars[i]) {
+			t.Fatal("BatchMapToScalarField mismatch")
+		}
+	}
+
+	encodings[7][0] = 0xff
+	if _, err := BatchSetBytes(encodings); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestMultiExp(t *testing.T) {
+	const n = 50
+	elements := make([]Element, n)
+	scalars := make([]fr.Element, n)
+	var expected, tmp Element
+	expected.SetIdentity()
+	for i := 0; i < n; i++ {
+		elements[i] = randomElement()
+		scalars[i].SetRandom()
+		tmp.ScalarMultiplication(&elements[i], scalars[i].BigInt(new(big.Int)))
+		expected.Add(&expected, &tmp)
+	}
+	var res Element
+	if _, err := res.MultiExp(elements, scalars, ecc.MultiExpConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	if !res.Equal(&expected) {
+		t.Fatal("MultiExp mismatch")
+	}
+}
+
+func randomElement() Element {
+	var e Element
+	e.SetGenerator()
+	s, _ := rand.Int(rand.Reader, fr.Modulus())
+	e.ScalarMultiplication(&e, s)
+	return e
+}
+
+func BenchmarkBytes(b *testing.B) {
+	e := randomElement()
+	for i := 0; i < b.N; i++ {
+		e.Bytes()
+	}
+}
+
+func BenchmarkSetBytes(b *testing.B) {
+	e := randomElement()
+	buf := e.Bytes()
+	var f Element
+	for i := 0; i < b.N; i++ {
+		f.SetBytes(buf[:])
+	}
+}
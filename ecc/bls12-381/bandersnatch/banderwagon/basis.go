@@ -0,0 +1,70 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package banderwagon
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+
+	fp "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+const (
+	// VerkleSeed is the seed of the basis used by Verkle trees.
+	VerkleSeed = "eth_verkle_oct_2021"
+	// VerkleBasisSize is the number of elements of the Verkle basis.
+	VerkleBasisSize = 256
+)
+
+var (
+	verkleBasisOnce sync.Once
+	verkleBasis     []Element
+)
+
+// DeriveBasis returns n elements with unknown discrete logarithms relations, derived
+// from seed: the i-th candidate abscissa is sha256(seed ∥ i) (i as a big endian uint64)
+// reduced mod p, and candidates which are not valid encodings are skipped.
+func DeriveBasis(seed string, n int) []Element {
+	res := make([]Element, 0, n)
+	var counter [8]byte
+	for i := uint64(0); len(res) < n; i++ {
+		h := sha256.New()
+		h.Write([]byte(seed))
+		binary.BigEndian.PutUint64(counter[:], i)
+		h.Write(counter[:])
+
+		var x fp.Element
+		x.SetBytes(h.Sum(nil))
+		b := x.Bytes()
+
+		var e Element
+		if _, err := e.SetBytes(b[:]); err != nil {
+			continue
+		}
+		res = append(res, e)
+	}
+	return res
+}
+
+// VerkleBasis returns the 256 elements of the Verkle basis, derived from VerkleSeed.
+func VerkleBasis() []Element {
+	verkleBasisOnce.Do(func() {
+		verkleBasis = DeriveBasis(VerkleSeed, VerkleBasisSize)
+	})
+	res := make([]Element, len(verkleBasis))
+	copy(res, verkleBasis)
+	return res
+}
@@ -0,0 +1,29 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package ipa provides a transparent polynomial commitment scheme on banderwagon, based on
+// the inner product argument of Bulletproofs, as used in Halo and Verkle trees.
+//
+// Polynomials are given in Lagrange form, by their evaluations on the domain {0, 1, …, n-1}: the
+// commitment to p is ∑ p(i)⋅Gᵢ, for a basis (Gᵢ) derived from a seed. An opening proof at any point
+// has 2⋅log₂(n) group elements; several openings, at different points, can be batched in one proof.
+//
+// # See also
+//
+//   - https://eprint.iacr.org/2019/1021 (Halo)
+//   - https://dankradfeist.de/ethereum/2021/07/27/inner-product-arguments.html
+//   - https://dankradfeist.de/ethereum/2021/06/18/pcs-multiproofs.html
+package ipa
@@ -0,0 +1,339 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ipa
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/banderwagon"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+)
+
+const srsSize = 16
+
+var testSRS *SRS
+
+func init() {
+	var err error
+	testSRS, err = NewSRS(srsSize, "gnark-crypto ipa test")
+	if err != nil {
+		panic(err)
+	}
+}
+
+func randomPolynomial(size int) []fr.Element {
+	p := make([]fr.Element, size)
+	for i := range p {
+		p[i].SetRandom()
+	}
+	return p
+}
+
+func TestNewSRS(t *testing.T) {
+	for _, size := range []uint64{0, 1, 3, 12} {
+		if _, err := NewSRS(size, "seed"); err != ErrInvalidSRSSize {
+			t.Fatalf("size %d: expected ErrInvalidSRSSize", size)
+		}
+	}
+
+	// deterministic and seed dependent
+	srs, err := NewSRS(srsSize, "gnark-crypto ipa test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range srs.Basis {
+		if !srs.Basis[i].Equal(&testSRS.Basis[i]) {
+			t.Fatal("NewSRS is not deterministic")
+		}
+	}
+	if srs, err = NewSRS(srsSize, "another seed"); err != nil {
+		t.Fatal(err)
+	}
+	if srs.Basis[0].Equal(&testSRS.Basis[0]) {
+		t.Fatal("NewSRS doesn't depend on the seed")
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	// p = 3X³ + 2X + 5, given by its evaluations on the domain
+	var three, two, five fr.Element
+	three.SetUint64(3)
+	two.SetUint64(2)
+	five.SetUint64(5)
+	eval := func(x *fr.Element) fr.Element {
+		var res, tmp fr.Element
+		res.Square(x).Mul(&res, x).Mul(&res, &three)
+		tmp.Mul(x, &two)
+		res.Add(&res, &tmp).Add(&res, &five)
+		return res
+	}
+	p := make([]fr.Element, srsSize)
+	for i := range p {
+		var x fr.Element
+		x.SetUint64(uint64(i))
+		p[i] = eval(&x)
+	}
+
+	var points [3]fr.Element
+	points[0].SetRandom()
+	points[1].SetUint64(5)
+	points[2].SetUint64(srsSize)
+	for i := range points {
+		y, err := Evaluate(p, points[i], testSRS)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := eval(&points[i]); !y.Equal(&expected) {
+			t.Fatal("wrong evaluation")
+		}
+	}
+}
+
+func TestCommit(t *testing.T) {
+	// Commit is linear
+	p, q := randomPolynomial(srsSize), randomPolynomial(srsSize/2)
+	sum := make([]fr.Element, srsSize)
+	copy(sum, p)
+	for i := range q {
+		sum[i].Add(&sum[i], &q[i])
+	}
+	cp, err := Commit(p, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cq, err := Commit(q, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cs, err := Commit(sum, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp.Add(&cp, &cq)
+	if !cp.Equal(&cs) {
+		t.Fatal("commitment is not linear")
+	}
+
+	if _, err := Commit(nil, testSRS); err != ErrInvalidPolynomialSize {
+		t.Fatal("expected ErrInvalidPolynomialSize")
+	}
+	if _, err := Commit(randomPolynomial(srsSize+1), testSRS); err != ErrInvalidPolynomialSize {
+		t.Fatal("expected ErrInvalidPolynomialSize")
+	}
+}
+
+func TestOpen(t *testing.T) {
+	hf := sha256.New()
+
+	p := randomPolynomial(srsSize - 3)
+	digest, err := Commit(p, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var inside, outside fr.Element
+	inside.SetUint64(3)
+	outside.SetRandom()
+	for _, point := range []fr.Element{inside, outside} {
+		proof, err := Open(p, &digest, point, hf, testSRS, []byte("data"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected, _ := Evaluate(p, point, testSRS)
+		if !proof.ClaimedValue.Equal(&expected) {
+			t.Fatal("wrong claimed value")
+		}
+		if err := Verify(&digest, &proof, point, hf, testSRS, []byte("data")); err != nil {
+			t.Fatal(err)
+		}
+
+		// wrong data transcript
+		if err := Verify(&digest, &proof, point, hf, testSRS, []byte("other data")); err == nil {
+			t.Fatal("verifying with another transcript should fail")
+		}
+
+		// wrong point
+		var otherPoint fr.Element
+		otherPoint.SetRandom()
+		if err := Verify(&digest, &proof, otherPoint, hf, testSRS, []byte("data")); err == nil {
+			t.Fatal("verifying at another point should fail")
+		}
+
+		// wrong claimed value
+		wrongProof := proof
+		wrongProof.ClaimedValue.SetRandom()
+		if err := Verify(&digest, &wrongProof, point, hf, testSRS, []byte("data")); err == nil {
+			t.Fatal("verifying a wrong claimed value should fail")
+		}
+
+		// wrong digest
+		var wrongDigest Digest
+		wrongDigest.Add(&digest, &testSRS.Q)
+		if err := Verify(&wrongDigest, &proof, point, hf, testSRS, []byte("data")); err == nil {
+			t.Fatal("verifying against another digest should fail")
+		}
+
+		// tampered cross terms
+		wrongProof = proof
+		wrongProof.L = append([]banderwagon.Element{}, proof.L...)
+		wrongProof.L[0].Add(&wrongProof.L[0], &testSRS.Q)
+		if err := Verify(&digest, &wrongProof, point, hf, testSRS, []byte("data")); err == nil {
+			t.Fatal("verifying a tampered proof should fail")
+		}
+
+		// wrong number of rounds
+		wrongProof = proof
+		wrongProof.R = proof.R[1:]
+		if err := Verify(&digest, &wrongProof, point, hf, testSRS, []byte("data")); err != ErrInvalidProofSize {
+			t.Fatal("expected ErrInvalidProofSize")
+		}
+	}
+}
+
+func TestBatchOpen(t *testing.T) {
+	hf := sha256.New()
+
+	const nbPolynomials = 5
+	polynomials := make([][]fr.Element, nbPolynomials)
+	digests := make([]Digest, nbPolynomials)
+	points := make([]fr.Element, nbPolynomials)
+	for i := range polynomials {
+		polynomials[i] = randomPolynomial(srsSize - i)
+		var err error
+		if digests[i], err = Commit(polynomials[i], testSRS); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// points in and outside the domain, with a repetition
+	points[0].SetUint64(0)
+	points[1].SetUint64(srsSize - 1)
+	points[2].SetRandom()
+	points[3].SetRandom()
+	points[4].Set(&points[2])
+
+	proof, err := BatchOpen(polynomials, digests, points, hf, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range points {
+		expected, _ := Evaluate(polynomials[i], points[i], testSRS)
+		if !proof.ClaimedValues[i].Equal(&expected) {
+			t.Fatal("wrong claimed value")
+		}
+	}
+	if err := BatchVerify(digests, points, &proof, hf, testSRS); err != nil {
+		t.Fatal(err)
+	}
+
+	// wrong claimed value
+	wrongProof := proof
+	wrongProof.ClaimedValues = append([]fr.Element{}, proof.ClaimedValues...)
+	wrongProof.ClaimedValues[1].SetRandom()
+	if err := BatchVerify(digests, points, &wrongProof, hf, testSRS); err == nil {
+		t.Fatal("verifying a wrong claimed value should fail")
+	}
+
+	// wrong point
+	wrongPoints := append([]fr.Element{}, points...)
+	wrongPoints[0].SetUint64(1)
+	if err := BatchVerify(digests, wrongPoints, &proof, hf, testSRS); err == nil {
+		t.Fatal("verifying at another point should fail")
+	}
+
+	// swapped digests
+	wrongDigests := append([]Digest{}, digests...)
+	wrongDigests[0], wrongDigests[1] = wrongDigests[1], wrongDigests[0]
+	if err := BatchVerify(wrongDigests, points, &proof, hf, testSRS); err == nil {
+		t.Fatal("verifying against swapped digests should fail")
+	}
+
+	if _, err := BatchOpen(polynomials, digests[1:], points, hf, testSRS); err != ErrInvalidNbDigests {
+		t.Fatal("expected ErrInvalidNbDigests")
+	}
+	if _, err := BatchOpen(polynomials, digests, points[1:], hf, testSRS); err != ErrInvalidNbPoints {
+		t.Fatal("expected ErrInvalidNbPoints")
+	}
+	if err := BatchVerify(nil, nil, &proof, hf, testSRS); err != ErrZeroNbDigests {
+		t.Fatal("expected ErrZeroNbDigests")
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	hf := sha256.New()
+
+	polynomials := [][]fr.Element{randomPolynomial(srsSize), randomPolynomial(srsSize)}
+	digests := make([]Digest, len(polynomials))
+	points := make([]fr.Element, len(polynomials))
+	for i := range polynomials {
+		digests[i], _ = Commit(polynomials[i], testSRS)
+		points[i].SetRandom()
+	}
+	proof, err := BatchOpen(polynomials, digests, points, hf, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	written, err := proof.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded BatchOpeningProof
+	read, err := decoded.ReadFrom(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read != written || int(written) != buf.Len() {
+		t.Fatal("number of bytes read and written differ")
+	}
+	if err := BatchVerify(digests, points, &decoded, hf, testSRS); err != nil {
+		t.Fatal(err)
+	}
+
+	// truncated encodings are rejected
+	for _, size := range []int{0, 10, buf.Len() - 1} {
+		if _, err := new(BatchOpeningProof).ReadFrom(bytes.NewReader(buf.Bytes()[:size])); err == nil {
+			t.Fatalf("decoding %d bytes should fail", size)
+		}
+	}
+}
+
+func BenchmarkOpen(b *testing.B) {
+	srs, err := NewSRS(256, "gnark-crypto ipa benchmark")
+	if err != nil {
+		b.Fatal(err)
+	}
+	p := randomPolynomial(256)
+	digest, _ := Commit(p, srs)
+	var point fr.Element
+	point.SetRandom()
+	hf := sha256.New()
+
+	b.Run("open", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Open(p, &digest, point, hf, srs)
+		}
+	})
+	proof, _ := Open(p, &digest, point, hf, srs)
+	b.Run("verify", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Verify(&digest, &proof, point, hf, srs)
+		}
+	})
+}
@@ -0,0 +1,160 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package banderwagon
+
+import (
+	"errors"
+	"runtime"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// PrecomputedMSM computes multi-scalar multiplications with a fixed set of elements,
+// such as the Verkle basis, from precomputed tables.
+//
+// Scalars are split in signed digits of c bits; for each element P and window j the table
+// stores [d⋅2ᶜʲ]P for d in [1, 2ᶜ⁻¹], so that a multi-scalar multiplication is a sum of
+// table entries, without doublings. The tables hold len(points)⋅⌈(fr.Bits+1)/c⌉⋅2ᶜ⁻¹
+// affine points (64 bytes each): 8MB for 256 points and c = 4, 67MB for c = 8.
+type PrecomputedMSM struct {
+	c         int
+	nbWindows int
+	nbPoints  int
+	tableSize int
+	tables    []bandersnatch.PointAffine
+}
+
+// NewPrecomputedMSM computes the tables for points, with windows of c bits.
+// It returns an error if c is not in [2, 16].
+func NewPrecomputedMSM(points []Element, c int) (*PrecomputedMSM, error) {
+	if c < 2 || c > 16 {
+		return nil, errors.New("banderwagon: window size must be in [2, 16]")
+	}
+	m := &PrecomputedMSM{
+		c:         c,
+		nbWindows: (fr.Bits + c) / c, // signed digits may carry one extra bit
+		nbPoints:  len(points),
+		tableSize: 1 << (c - 1),
+	}
+	perPoint := m.nbWindows * m.tableSize
+	m.tables = make([]bandersnatch.PointAffine, len(points)*perPoint)
+
+	parallel.Execute(len(points), func(start, end int) {
+		multiples := make([]bandersnatch.PointExtended, perPoint)
+		for i := start; i < end; i++ {
+			var base bandersnatch.PointExtended
+			base.Set(&points[i].p)
+			for j := 0; j < m.nbWindows; j++ {
+				t := multiples[j*m.tableSize : (j+1)*m.tableSize]
+				t[0].Set(&base)
+				for d := 1; d < m.tableSize; d++ {
+					t[d].Add(&t[d-1], &base)
+				}
+				// 2ᶜ⋅base = 2⋅(2ᶜ⁻¹⋅base)
+				base.Double(&t[m.tableSize-1])
+			}
+			copy(m.tables[i*perPoint:(i+1)*perPoint], bandersnatch.BatchFromExtended(multiples))
+		}
+	})
+	return m, nil
+}
+
+// MultiExp returns ∑ scalars[i]⋅points[i], points being the ones given to NewPrecomputedMSM.
+// It returns an error if there are more scalars than points; missing scalars are zero.
+func (m *PrecomputedMSM) MultiExp(scalars []fr.Element) (Element, error) {
+	var res Element
+	res.SetIdentity()
+	if len(scalars) > m.nbPoints {
+		return res, errors.New("banderwagon: more scalars than precomputed points")
+	}
+
+	nbTasks := runtime.NumCPU()
+	if nbTasks > len(scalars) {
+		nbTasks = len(scalars)
+	}
+	if nbTasks == 0 {
+		return res, nil
+	}
+	partials := make([]bandersnatch.PointExtended, nbTasks)
+	perTask := (len(scalars) + nbTasks - 1) / nbTasks
+	parallel.Execute(nbTasks, func(start, end int) {
+		for task := start; task < end; task++ {
+			acc := &partials[task]
+			acc.X.SetZero()
+			acc.Y.SetOne()
+			acc.Z.SetOne()
+			acc.T.SetZero()
+			from, to := task*perTask, (task+1)*perTask
+			if to > len(scalars) {
+				to = len(scalars)
+			}
+			for i := from; i < to; i++ {
+				m.accumulate(acc, i, &scalars[i])
+			}
+		}
+	}, nbTasks)
+
+	for i := range partials {
+		res.p.Add(&res.p, &partials[i])
+	}
+	return res, nil
+}
+
+// accumulate adds scalar⋅points[i] to acc
+func (m *PrecomputedMSM) accumulate(acc *bandersnatch.PointExtended, i int, scalar *fr.Element) {
+	if scalar.IsZero() {
+		return
+	}
+	k := scalar.Bits()
+	table := m.tables[i*m.nbWindows*m.tableSize:]
+	mask := uint64(1)<<m.c - 1
+	carry := uint64(0)
+	var neg bandersnatch.PointAffine
+	for j := 0; j < m.nbWindows; j++ {
+		digit := window(&k, j*m.c, m.c, mask) + carry
+		carry = 0
+		if digit > uint64(m.tableSize) {
+			// digit - 2ᶜ ≤ 0, and the next window gets the carry
+			digit = (mask + 1) - digit
+			carry = 1
+			if digit == 0 {
+				continue
+			}
+			e := &table[j*m.tableSize+int(digit)-1]
+			neg.X.Neg(&e.X)
+			neg.Y.Set(&e.Y)
+			acc.MixedAdd(acc, &neg)
+			continue
+		}
+		if digit != 0 {
+			acc.MixedAdd(acc, &table[j*m.tableSize+int(digit)-1])
+		}
+	}
+}
+
+// window returns the c bits of k starting at bit start
+func window(k *[fr.Limbs]uint64, start, c int, mask uint64) uint64 {
+	limb, shift := start/64, uint(start%64)
+	if limb >= fr.Limbs {
+		return 0
+	}
+	w := k[limb] >> shift
+	if shift+uint(c) > 64 && limb+1 < fr.Limbs {
+		w |= k[limb+1] << (64 - shift)
+	}
+	return w & mask
+}
@@ -0,0 +1,83 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package banderwagon
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+)
+
+func TestPrecomputedMSM(t *testing.T) {
+	const n = 20
+	points := make([]Element, n)
+	for i := range points {
+		points[i] = randomElement()
+	}
+
+	scalars := make([]fr.Element, n)
+	for i := range scalars {
+		scalars[i].SetRandom()
+	}
+	// edge cases of the signed digits decomposition
+	scalars[0].SetZero()
+	scalars[1].SetOne()
+	scalars[2].SetOne().Neg(&scalars[2])
+	scalars[3].SetUint64(^uint64(0))
+	scalars[4].SetBigInt(new(big.Int).Lsh(big.NewInt(1), fr.Bits-1))
+
+	for _, c := range []int{2, 3, 4, 5, 8} {
+		m, err := NewPrecomputedMSM(points, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, k := range []int{0, 1, n / 2, n} {
+			var expected, tmp Element
+			expected.SetIdentity()
+			for i := 0; i < k; i++ {
+				tmp.ScalarMultiplication(&points[i], scalars[i].BigInt(new(big.Int)))
+				expected.Add(&expected, &tmp)
+			}
+			res, err := m.MultiExp(scalars[:k])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !res.Equal(&expected) {
+				t.Fatalf("c=%d, %d scalars: mismatch", c, k)
+			}
+		}
+	}
+
+	m, _ := NewPrecomputedMSM(points[:2], 4)
+	if _, err := m.MultiExp(scalars); err == nil {
+		t.Fatal("expected an error")
+	}
+	if _, err := NewPrecomputedMSM(points, 1); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func BenchmarkPrecomputedMSM(b *testing.B) {
+	m, _ := NewPrecomputedMSM(VerkleBasis(), 8)
+	scalars := make([]fr.Element, VerkleBasisSize)
+	for i := range scalars {
+		scalars[i].SetRandom()
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.MultiExp(scalars)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package eddsa
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/twistededwards"
+)
+
+var errInvalidPublicKey = errors.New("public key is not on the curve")
+var errIdentity = errors.New("shared point is the identity")
+
+// ECDH returns the shared secret of privKey and pub, that is the compressed
+// encoding of [c⋅d]A where d is the secret scalar of privKey, A the point of pub
+// and c the cofactor of the curve.
+//
+// Multiplying by the cofactor maps A to the prime order subgroup, so that
+// small order components of a malicious public key can't leak bits of d.
+// The shared secret is not uniformly distributed and must go through a key
+// derivation function before being used as a key, as Encrypt does.
+func (privKey *PrivateKey) ECDH(pub *PublicKey) ([]byte, error) {
+	if !pub.A.IsOnCurve() {
+		return nil, errInvalidPublicKey
+	}
+	curveParams := twistededwards.GetEdwardsCurve()
+
+	var d, c big.Int
+	d.SetBytes(privKey.scalar[:])
+	curveParams.Cofactor.BigInt(&c)
+	d.Mul(&d, &c)
+
+	var shared twistededwards.PointAffine
+	shared.ScalarMultiplication(&pub.A, &d)
+	if shared.IsZero() {
+		return nil, errIdentity
+	}
+	res := shared.Bytes()
+	return res[:], nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bulletproofs
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// maxNbRounds bounds the number of rounds of a decoded proof, that is the log₂ of the
+// total number of proven bits
+const maxNbRounds = 32
+
+var errInvalidLength = errors.New("invalid length")
+
+// WriteTo writes binary encoding of the Proof
+func (proof *Proof) WriteTo(w io.Writer) (int64, error) {
+	if len(proof.InnerProduct.L) != len(proof.InnerProduct.R) {
+		return 0, ErrInvalidProofSize
+	}
+	var n int64
+	write := func(b []byte) error {
+		m, err := w.Write(b)
+		n += int64(m)
+		return err
+	}
+	for _, p := range []*bls12381.G1Affine{&proof.A, &proof.S, &proof.T1, &proof.T2} {
+		b := p.Bytes()
+		if err := write(b[:]); err != nil {
+			return n, err
+		}
+	}
+	for _, e := range []*fr.Element{&proof.TauX, &proof.Mu, &proof.THat} {
+		b := e.Bytes()
+		if err := write(b[:]); err != nil {
+			return n, err
+		}
+	}
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], uint32(len(proof.InnerProduct.L)))
+	if err := write(buf[:]); err != nil {
+		return n, err
+	}
+	for _, points := range [][]bls12381.G1Affine{proof.InnerProduct.L, proof.InnerProduct.R} {
+		for i := range points {
+			b := points[i].Bytes()
+			if err := write(b[:]); err != nil {
+				return n, err
+			}
+		}
+	}
+	for _, e := range []*fr.Element{&proof.InnerProduct.A, &proof.InnerProduct.B} {
+		b := e.Bytes()
+		if err := write(b[:]); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// ReadFrom decodes Proof data from reader.
+func (proof *Proof) ReadFrom(r io.Reader) (int64, error) {
+	var n int64
+	read := func(b []byte) error {
+		m, err := io.ReadFull(r, b)
+		n += int64(m)
+		return err
+	}
+	var buf [bls12381.SizeOfG1AffineCompressed]byte
+	readPoint := func(p *bls12381.G1Affine) error {
+		if err := read(buf[:]); err != nil {
+			return err
+		}
+		_, err := p.SetBytes(buf[:])
+		return err
+	}
+	var bufFr [fr.Bytes]byte
+	readElement := func(e *fr.Element) error {
+		if err := read(bufFr[:]); err != nil {
+			return err
+		}
+		return e.SetBytesCanonical(bufFr[:])
+	}
+
+	for _, p := range []*bls12381.G1Affine{&proof.A, &proof.S, &proof.T1, &proof.T2} {
+		if err := readPoint(p); err != nil {
+			return n, err
+		}
+	}
+	for _, e := range []*fr.Element{&proof.TauX, &proof.Mu, &proof.THat} {
+		if err := readElement(e); err != nil {
+			return n, err
+		}
+	}
+	var bufLen [4]byte
+	if err := read(bufLen[:]); err != nil {
+		return n, err
+	}
+	nbRounds := binary.BigEndian.Uint32(bufLen[:])
+	if nbRounds > maxNbRounds {
+		return n, errInvalidLength
+	}
+	proof.InnerProduct.L = make([]bls12381.G1Affine, nbRounds)
+	proof.InnerProduct.R = make([]bls12381.G1Affine, nbRounds)
+	for _, points := range [][]bls12381.G1Affine{proof.InnerProduct.L, proof.InnerProduct.R} {
+		for i := range points {
+			if err := readPoint(&points[i]); err != nil {
+				return n, err
+			}
+		}
+	}
+	for _, e := range []*fr.Element{&proof.InnerProduct.A, &proof.InnerProduct.B} {
+		if err := readElement(e); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
@@ -16,6 +16,9 @@
 
 // Package ecdsa provides ECDSA signature scheme on the bls12-381 curve.
 //
+// The ECDSA keys can also be used for ECDH key agreement (SEC 1, Section 3.3.1)
+// and ECIES hybrid encryption with ChaCha20-Poly1305, see PrivateKey.ECDH and Encrypt.
+//
 // The implementation is adapted from https://pkg.go.dev/crypto/ecdsa.
 // Copyright 2011 The Go Authors. All rights reserved.
 // Use of this source code is governed by a BSD-style
@@ -25,4 +28,5 @@
 // - Wikipedia: https://en.wikipedia.org/wiki/Elliptic_Curve_Digital_Signature_Algorithm
 // - FIPS 186-4: https://nvlpubs.nist.gov/nistpubs/FIPS/NIST.FIPS.186-4.pdf
 // - SEC 1, v-2: https://www.secg.org/sec1-v2.pdf
+// - RFC 6979: https://datatracker.ietf.org/doc/html/rfc6979
 package ecdsa
@@ -17,10 +17,13 @@
 package kzg
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc"
@@ -163,6 +166,53 @@ func TestSerializationSRS(t *testing.T) {
 	t.Run("whole SRS round-trip", utils.SerializationRoundTrip(srs))
 }
 
+func TestDumpSRS(t *testing.T) {
+	assert := require.New(t)
+
+	srs, err := NewSRS(64, new(big.Int).SetInt64(42))
+	assert.NoError(err)
+
+	var buf bytes.Buffer
+	n, err := srs.WriteDump(&buf)
+	assert.NoError(err)
+	assert.Equal(int64(buf.Len()), n)
+
+	t.Run("read", func(t *testing.T) {
+		assert := require.New(t)
+		var read SRS
+		m, err := read.ReadDump(bytes.NewReader(buf.Bytes()))
+		assert.NoError(err)
+		assert.Equal(n, m)
+		assert.Equal(srs.Pk, read.Pk)
+		assert.Equal(srs.Vk, read.Vk)
+	})
+
+	t.Run("map", func(t *testing.T) {
+		assert := require.New(t)
+		path := filepath.Join(t.TempDir(), "srs")
+		assert.NoError(os.WriteFile(path, buf.Bytes(), 0600))
+		f, err := os.Open(path)
+		assert.NoError(err)
+		defer f.Close()
+
+		mapped, closer, err := MapSRS(f)
+		assert.NoError(err)
+		defer closer.Close()
+		assert.Equal(srs.Pk, mapped.Pk)
+		assert.Equal(srs.Vk, mapped.Vk)
+
+		// the mapped key can be used to commit and open
+		p := randomPolynomial(60)
+		digest, err := Commit(p, mapped.Pk)
+		assert.NoError(err)
+		var point fr.Element
+		point.SetRandom()
+		proof, err := Open(p, point, mapped.Pk)
+		assert.NoError(err)
+		assert.NoError(Verify(&digest, &proof, point, srs.Vk))
+	})
+}
+
 func TestCommit(t *testing.T) {
 
 	// create a polynomial
@@ -0,0 +1,136 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package secretsharing
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+var (
+	ErrInvalidReshare = errors.New("re-sharing does not match the previous commitment")
+	ErrMismatchedSize = errors.New("number of old indices and sub-shares differ")
+)
+
+// Reshare re-shares share to the new shareholders at newIndices, such that any
+// newThreshold of them hold the secret. The returned sub-shares are sent to the
+// new shareholders, and the Feldman commitment to the re-sharing polynomial is
+// broadcast.
+//
+// Once a threshold of the old shareholders re-shared, each new shareholder
+// combines its sub-shares with CombineReshares, and the old shares are erased.
+// The secret is unchanged, but the old and new shares can't be combined
+// (proactive secret sharing). The threshold and the set of shareholders can
+// change in the process.
+func Reshare(share *Share, newThreshold int, newIndices []fr.Element) ([]Share, FeldmanCommitment, error) {
+	if share.Index.IsZero() {
+		return nil, nil, ErrZeroIndex
+	}
+	f, err := NewPolynomial(&share.Value, newThreshold)
+	if err != nil {
+		return nil, nil, err
+	}
+	subShares, err := EvalShares(f, newIndices)
+	if err != nil {
+		return nil, nil, err
+	}
+	return subShares, CommitFeldman(f), nil
+}
+
+// VerifyReshare checks that the re-sharing commitment of the shareholder at
+// oldIndex shares its committed share, i.e. C'₀ = ∑ [oldIndexᵏ]Cₖ where C is the
+// current commitment.
+func (c FeldmanCommitment) VerifyReshare(oldIndex *fr.Element, reshare FeldmanCommitment) error {
+	if oldIndex.IsZero() {
+		return ErrZeroIndex
+	}
+	if len(reshare) == 0 {
+		return ErrCommitmentSize
+	}
+	expected := c.Eval(oldIndex)
+	if !expected.Equal(&reshare[0]) {
+		return ErrInvalidReshare
+	}
+	return nil
+}
+
+// CombineReshares returns the new share from the sub-shares received from the
+// old shareholders at oldIndices, which must be at least the old threshold.
+// The sub-shares should be verified against the re-sharing commitments first.
+func CombineReshares(oldIndices []fr.Element, subShares []Share) (Share, error) {
+	if len(oldIndices) != len(subShares) {
+		return Share{}, ErrMismatchedSize
+	}
+	if len(subShares) == 0 {
+		return Share{}, ErrNotEnoughShares
+	}
+	for i := 1; i < len(subShares); i++ {
+		if !subShares[i].Index.Equal(&subShares[0].Index) {
+			return Share{}, ErrDuplicateIndex
+		}
+	}
+	var zero fr.Element
+	lambdas, err := LagrangeCoefficients(oldIndices, &zero)
+	if err != nil {
+		return Share{}, err
+	}
+	var res Share
+	var tmp fr.Element
+	res.Index.Set(&subShares[0].Index)
+	for i := range subShares {
+		tmp.Mul(&lambdas[i], &subShares[i].Value)
+		res.Value.Add(&res.Value, &tmp)
+	}
+	return res, nil
+}
+
+// CombineCommitments returns the Feldman commitment to the new shares, from the
+// re-sharing commitments of the old shareholders at oldIndices. Its first
+// coefficient is unchanged.
+func CombineCommitments(oldIndices []fr.Element, commitments []FeldmanCommitment) (FeldmanCommitment, error) {
+	if len(oldIndices) != len(commitments) {
+		return nil, ErrMismatchedSize
+	}
+	if len(commitments) == 0 {
+		return nil, ErrNotEnoughShares
+	}
+	for i := 1; i < len(commitments); i++ {
+		if len(commitments[i]) != len(commitments[0]) {
+			return nil, ErrCommitmentSize
+		}
+	}
+	var zero fr.Element
+	lambdas, err := LagrangeCoefficients(oldIndices, &zero)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(FeldmanCommitment, len(commitments[0]))
+	points := make([]bls12381.G1Affine, len(commitments))
+	for k := range res {
+		for i := range commitments {
+			points[i] = commitments[i][k]
+		}
+		if _, err := res[k].MultiExp(points, lambdas, ecc.MultiExpConfig{}); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
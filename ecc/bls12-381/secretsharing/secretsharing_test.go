@@ -0,0 +1,333 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package secretsharing
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+const (
+	nbFuzzShort = 5
+	nbFuzz      = 20
+)
+
+func genParams() gopter.Gen {
+	return gen.IntRange(1, 8).FlatMap(func(t interface{}) gopter.Gen {
+		return gen.IntRange(t.(int), 10).Map(func(n int) [2]int {
+			return [2]int{t.(int), n}
+		})
+	}, nil)
+}
+
+func randomElement() fr.Element {
+	var s fr.Element
+	if _, err := s.SetRandom(); err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func TestShamir(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("[BLS12-381] any threshold shares should reconstruct the secret", prop.ForAll(
+		func(p [2]int) bool {
+			t, n := p[0], p[1]
+			secret := randomElement()
+			shares, err := Split(&secret, t, n)
+			if err != nil || len(shares) != n {
+				return false
+			}
+			// the last t shares
+			res, err := Reconstruct(shares[n-t:], t)
+			if err != nil || !res.Equal(&secret) {
+				return false
+			}
+			// every other share
+			var subset []Share
+			for i := 0; i < n; i += 2 {
+				subset = append(subset, shares[i])
+			}
+			if len(subset) < t {
+				return true
+			}
+			res, err = Reconstruct(subset, t)
+			return err == nil && res.Equal(&secret)
+		},
+		genParams(),
+	))
+
+	properties.Property("[BLS12-381] fewer than threshold shares should not reconstruct the secret", prop.ForAll(
+		func(p [2]int) bool {
+			t, n := p[0], p[1]
+			if t == 1 {
+				return true
+			}
+			secret := randomElement()
+			shares, _ := Split(&secret, t, n)
+			if _, err := Reconstruct(shares[:t-1], t); err != ErrNotEnoughShares {
+				return false
+			}
+			res, err := Reconstruct(shares[:t-1], t-1)
+			return err == nil && !res.Equal(&secret)
+		},
+		genParams(),
+	))
+
+	properties.Property("[BLS12-381] Lagrange coefficients should interpolate the sharing polynomial", prop.ForAll(
+		func(p [2]int) bool {
+			t, n := p[0], p[1]
+			secret := randomElement()
+			f, _ := NewPolynomial(&secret, t)
+			shares, _ := EvalShares(f, Indices(n))
+			x := randomElement()
+			expected := f.Eval(&x)
+			res, err := InterpolateAt(shares, &x)
+			return err == nil && res.Equal(&expected)
+		},
+		genParams(),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestShamirErrors(t *testing.T) {
+	secret := randomElement()
+	if _, err := Split(&secret, 0, 3); err != ErrInvalidThreshold {
+		t.Fatal("expected ErrInvalidThreshold, got", err)
+	}
+	if _, err := Split(&secret, 4, 3); err != ErrInvalidThreshold {
+		t.Fatal("expected ErrInvalidThreshold, got", err)
+	}
+	shares, err := Split(&secret, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Reconstruct([]Share{shares[0], shares[0]}, 2); err != ErrDuplicateIndex {
+		t.Fatal("expected ErrDuplicateIndex, got", err)
+	}
+	shares[1].Index.SetZero()
+	if _, err := Reconstruct(shares, 2); err != ErrZeroIndex {
+		t.Fatal("expected ErrZeroIndex, got", err)
+	}
+}
+
+func TestVSS(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+	properties := gopter.NewProperties(parameters)
+
+	pp, err := NewPedersenParams([]byte("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	properties.Property("[BLS12-381] Feldman: honest shares should verify, tampered ones should not", prop.ForAll(
+		func(p [2]int) bool {
+			t, n := p[0], p[1]
+			secret := randomElement()
+			shares, c, err := SplitFeldman(&secret, t, n)
+			if err != nil || len(c) != t {
+				return false
+			}
+			for i := range shares {
+				if c.Verify(&shares[i]) != nil {
+					return false
+				}
+			}
+			g := g1Generator()
+			var pk bls12381.G1Affine
+			pk.ScalarMultiplication(&g, secret.BigInt(new(big.Int)))
+			if !pk.Equal(&c[0]) {
+				return false
+			}
+			shares[0].Value.Add(&shares[0].Value, &shares[0].Index)
+			if c.Verify(&shares[0]) != ErrInvalidShare {
+				return false
+			}
+			if t == 1 {
+				return true // f is constant, any index verifies
+			}
+			shares[1].Index.Double(&shares[1].Index)
+			return c.Verify(&shares[1]) == ErrInvalidShare
+		},
+		genParams(),
+	))
+
+	properties.Property("[BLS12-381] Pedersen: honest shares should verify, tampered ones should not", prop.ForAll(
+		func(p [2]int) bool {
+			t, n := p[0], p[1]
+			secret := randomElement()
+			shares, blindings, c, err := pp.SplitPedersen(&secret, t, n)
+			if err != nil || len(c) != t {
+				return false
+			}
+			for i := range shares {
+				if c.Verify(&pp, &shares[i], &blindings[i]) != nil {
+					return false
+				}
+			}
+			if n > 1 && c.Verify(&pp, &shares[0], &blindings[1]) != ErrInvalidShare {
+				return false
+			}
+			blindings[0].Value.Add(&blindings[0].Value, &blindings[0].Index)
+			if c.Verify(&pp, &shares[0], &blindings[0]) != ErrInvalidShare {
+				return false
+			}
+			shares[1%n].Value.Add(&shares[1%n].Value, &shares[1%n].Index)
+			return c.Verify(&pp, &shares[1%n], &blindings[1%n]) == ErrInvalidShare
+		},
+		genParams(),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestReshare(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("[BLS12-381] re-shared shares should reconstruct the secret with the new threshold", prop.ForAll(
+		func(oldP, newP [2]int) bool {
+			secret := randomElement()
+			shares, c, err := SplitFeldman(&secret, oldP[0], oldP[1])
+			if err != nil {
+				return false
+			}
+
+			// a threshold of the old shareholders re-share to new ones
+			oldIndices := make([]fr.Element, oldP[0])
+			newIndices := make([]fr.Element, newP[1])
+			for i := range newIndices {
+				newIndices[i].SetUint64(uint64(100 + i))
+			}
+			subShares := make([][]Share, oldP[0])
+			commitments := make([]FeldmanCommitment, oldP[0])
+			for i := range subShares {
+				oldIndices[i] = shares[i].Index
+				subShares[i], commitments[i], err = Reshare(&shares[i], newP[0], newIndices)
+				if err != nil || c.VerifyReshare(&oldIndices[i], commitments[i]) != nil {
+					return false
+				}
+			}
+
+			// each new shareholder verifies and combines its sub-shares
+			newShares := make([]Share, newP[1])
+			received := make([]Share, oldP[0])
+			for j := range newShares {
+				for i := range received {
+					received[i] = subShares[i][j]
+					if commitments[i].Verify(&received[i]) != nil {
+						return false
+					}
+				}
+				if newShares[j], err = CombineReshares(oldIndices, received); err != nil {
+					return false
+				}
+			}
+
+			newCommitment, err := CombineCommitments(oldIndices, commitments)
+			if err != nil || len(newCommitment) != newP[0] || !newCommitment[0].Equal(&c[0]) {
+				return false
+			}
+			for j := range newShares {
+				if newCommitment.Verify(&newShares[j]) != nil {
+					return false
+				}
+			}
+
+			res, err := Reconstruct(newShares[newP[1]-newP[0]:], newP[0])
+			return err == nil && res.Equal(&secret)
+		},
+		genParams(),
+		genParams(),
+	))
+
+	properties.Property("[BLS12-381] re-sharing another share should be detected", prop.ForAll(
+		func(p [2]int) bool {
+			secret := randomElement()
+			shares, c, _ := SplitFeldman(&secret, p[0], p[1])
+			other := Share{Index: shares[0].Index, Value: randomElement()}
+			_, reshare, err := Reshare(&other, p[0], Indices(p[1]))
+			return err == nil && c.VerifyReshare(&shares[0].Index, reshare) == ErrInvalidReshare
+		},
+		genParams(),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func BenchmarkSplit(b *testing.B) {
+	secret := randomElement()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = Split(&secret, 67, 100)
+	}
+}
+
+func BenchmarkReconstruct(b *testing.B) {
+	secret := randomElement()
+	shares, _ := Split(&secret, 67, 100)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = Reconstruct(shares, 67)
+	}
+}
+
+func BenchmarkFeldmanVerify(b *testing.B) {
+	secret := randomElement()
+	shares, c, _ := SplitFeldman(&secret, 67, 100)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = c.Verify(&shares[i%len(shares)])
+	}
+}
+
+func BenchmarkPedersenVerify(b *testing.B) {
+	pp, _ := NewPedersenParams([]byte("bench"))
+	secret := randomElement()
+	shares, blindings, c, _ := pp.SplitPedersen(&secret, 67, 100)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = c.Verify(&pp, &shares[i%len(shares)], &blindings[i%len(shares)])
+	}
+}
@@ -16,6 +16,9 @@
 
 // Package eddsa provides EdDSA signature scheme on bls12-381's twisted edwards curve.
 //
+// The EdDSA keys can also be used for ECDH key agreement and ECIES hybrid
+// encryption with ChaCha20-Poly1305, see PrivateKey.ECDH and Encrypt.
+//
 // # See also
 //
 // https://en.wikipedia.org/wiki/EdDSA
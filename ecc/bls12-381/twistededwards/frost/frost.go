@@ -0,0 +1,463 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package frost
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"math/big"
+	"sort"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/twistededwards"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/twistededwards/eddsa"
+	"golang.org/x/crypto/blake2b"
+)
+
+// contextString separates the domains of the hashes of this package
+const contextString = "FROST-bls12-381-twistededwards-BLAKE2b-v1"
+
+const sizeFr = fr.Bytes
+
+var (
+	ErrInvalidThreshold   = errors.New("threshold must be between 1 and the number of participants")
+	ErrInvalidIdentifier  = errors.New("identifiers must be non zero and distinct")
+	ErrNotEnoughSigners   = errors.New("number of signers is smaller than the threshold")
+	ErrUnknownSigner      = errors.New("signer is not part of the signing set")
+	ErrNonceReused        = errors.New("signing nonces were already used")
+	ErrCommitmentMismatch = errors.New("commitment of the signer doesn't match its nonces")
+	ErrInvalidSignature   = errors.New("invalid aggregated signature")
+	errHashNeeded         = errors.New("hFunc cannot be nil. We need a hash for Fiat-Shamir")
+)
+
+// InvalidContributionError identifies the participant that sent an invalid value, for
+// identifiable aborts.
+type InvalidContributionError struct {
+	ID           uint32
+	Contribution string
+}
+
+func (e *InvalidContributionError) Error() string {
+	return fmt.Sprintf("invalid %s from participant %d", e.Contribution, e.ID)
+}
+
+// KeyShare is the share of the group secret key held by a participant.
+type KeyShare struct {
+	ID             uint32
+	secret         big.Int                    // share of the secret key, f(ID)
+	PublicKey      twistededwards.PointAffine // verification share, secret⋅Base
+	GroupPublicKey eddsa.PublicKey            // public key of the group, f(0)⋅Base
+}
+
+// PublicKeyShares holds the public commitment Cₖ = aₖ⋅Base to the coefficients of the
+// polynomial f = ∑ aₖXᵏ sharing the secret key f(0).
+type PublicKeyShares struct {
+	Threshold  int
+	Commitment []twistededwards.PointAffine
+}
+
+// GroupPublicKey returns the public key of the group, f(0)⋅Base.
+func (pub *PublicKeyShares) GroupPublicKey() eddsa.PublicKey {
+	var pk eddsa.PublicKey
+	pk.A.Set(&pub.Commitment[0])
+	return pk
+}
+
+// VerificationShare returns the verification share f(id)⋅Base = ∑ idᵏ⋅Cₖ of a participant.
+func (pub *PublicKeyShares) VerificationShare(id uint32) twistededwards.PointAffine {
+	return evaluateCommitment(pub.Commitment, id)
+}
+
+// VerifyShare checks that the share is consistent with the public commitment.
+func (pub *PublicKeyShares) VerifyShare(share *KeyShare) error {
+	base := twistededwards.GetEdwardsCurve().Base
+	var p twistededwards.PointAffine
+	p.ScalarMultiplication(&base, &share.secret)
+	expected := pub.VerificationShare(share.ID)
+	groupPublicKey := pub.GroupPublicKey()
+	if !p.Equal(&expected) || !share.PublicKey.Equal(&expected) || !share.GroupPublicKey.A.Equal(&groupPublicKey.A) {
+		return &InvalidContributionError{ID: share.ID, Contribution: "key share"}
+	}
+	return nil
+}
+
+// SigningNonces are the secret nonces of a signer for one signature. They must be used
+// only once, Sign zeroes them.
+type SigningNonces struct {
+	hiding, binding big.Int
+	commitment      SigningCommitment
+}
+
+// SigningCommitment is the public commitment to the nonces of a signer, sent to the
+// coordinator in the first round.
+type SigningCommitment struct {
+	ID      uint32
+	Hiding  twistededwards.PointAffine
+	Binding twistededwards.PointAffine
+}
+
+// SignatureShare is the share of the signature computed by a signer in the second round.
+type SignatureShare struct {
+	ID uint32
+	Z  big.Int
+}
+
+// Commit generates fresh nonces for the signer of the key share, and their commitment.
+func Commit(rand io.Reader, share *KeyShare) (*SigningNonces, SigningCommitment, error) {
+	base := twistededwards.GetEdwardsCurve().Base
+	nonces := new(SigningNonces)
+	nonces.commitment.ID = share.ID
+	var err error
+	if nonces.hiding, err = generateNonce(rand, &share.secret); err != nil {
+		return nil, SigningCommitment{}, err
+	}
+	if nonces.binding, err = generateNonce(rand, &share.secret); err != nil {
+		return nil, SigningCommitment{}, err
+	}
+	nonces.commitment.Hiding.ScalarMultiplication(&base, &nonces.hiding)
+	nonces.commitment.Binding.ScalarMultiplication(&base, &nonces.binding)
+	return nonces, nonces.commitment, nil
+}
+
+// Sign returns the signature share of the signer, for the set of signers given by their
+// commitments. The nonces are zeroed so that they can't be reused.
+//
+// z = d + ρ⋅e + λ⋅c⋅s, where d, e are the nonces, ρ the binding factor of the signer, λ
+// its Lagrange coefficient, c the challenge of the signature and s the key share.
+func Sign(message []byte, hFunc hash.Hash, share *KeyShare, nonces *SigningNonces, commitments []SigningCommitment) (SignatureShare, error) {
+	res := SignatureShare{ID: share.ID}
+	if hFunc == nil {
+		return res, errHashNeeded
+	}
+	if nonces.hiding.Sign() == 0 || nonces.binding.Sign() == 0 {
+		return res, ErrNonceReused
+	}
+	d, e := new(big.Int).Set(&nonces.hiding), new(big.Int).Set(&nonces.binding)
+	commitment := nonces.commitment
+	nonces.hiding.SetUint64(0)
+	nonces.binding.SetUint64(0)
+
+	sc, err := newSigningContext(message, hFunc, &share.GroupPublicKey, commitments)
+	if err != nil {
+		return res, err
+	}
+	i, ok := sc.index[share.ID]
+	if !ok {
+		return res, ErrUnknownSigner
+	}
+	if !sc.commitments[i].Hiding.Equal(&commitment.Hiding) || !sc.commitments[i].Binding.Equal(&commitment.Binding) {
+		return res, ErrCommitmentMismatch
+	}
+
+	order := curveOrder()
+	lambda := sc.lagrange(i)
+	res.Z.Mul(&lambda, &sc.challenge).
+		Mul(&res.Z, &share.secret).
+		Add(&res.Z, d).
+		Add(&res.Z, e.Mul(e, &sc.bindingFactors[i])).
+		Mod(&res.Z, order)
+	return res, nil
+}
+
+// Aggregate aggregates the signature shares into an eddsa signature of the message for
+// the group public key. If the signature is invalid, the signers that sent an invalid
+// share are identified with an InvalidContributionError.
+func Aggregate(message []byte, hFunc hash.Hash, pub *PublicKeyShares, commitments []SigningCommitment, shares []SignatureShare) ([]byte, error) {
+	if hFunc == nil {
+		return nil, errHashNeeded
+	}
+	if len(commitments) < pub.Threshold {
+		return nil, ErrNotEnoughSigners
+	}
+	groupPublicKey := pub.GroupPublicKey()
+	sc, err := newSigningContext(message, hFunc, &groupPublicKey, commitments)
+	if err != nil {
+		return nil, err
+	}
+	if len(shares) != len(sc.commitments) {
+		return nil, ErrUnknownSigner
+	}
+	order := curveOrder()
+	sorted := make([]*SignatureShare, len(shares))
+	var z big.Int
+	for i := range shares {
+		j, ok := sc.index[shares[i].ID]
+		if !ok || sorted[j] != nil {
+			return nil, ErrUnknownSigner
+		}
+		if shares[i].Z.Sign() < 0 || shares[i].Z.Cmp(order) >= 0 {
+			return nil, &InvalidContributionError{ID: shares[i].ID, Contribution: "signature share"}
+		}
+		sorted[j] = &shares[i]
+		z.Add(&z, &shares[i].Z)
+	}
+	z.Mod(&z, order)
+
+	var sig eddsa.Signature
+	sig.R.Set(&sc.r)
+	z.FillBytes(sig.S[:])
+	sigBin := sig.Bytes()
+	if ok, err := groupPublicKey.Verify(sigBin, message, hFunc); err == nil && ok {
+		return sigBin, nil
+	}
+
+	// identify the culprits
+	for i := range sorted {
+		Y := pub.VerificationShare(sorted[i].ID)
+		if !sc.verifyShare(i, sorted[i], &Y) {
+			return nil, &InvalidContributionError{ID: sorted[i].ID, Contribution: "signature share"}
+		}
+	}
+	return nil, ErrInvalidSignature
+}
+
+// VerifyShare checks the signature share of a signer against its verification share.
+//
+// z⋅Base ?= D + ρ⋅E + λ⋅c⋅Y
+func VerifyShare(message []byte, hFunc hash.Hash, pub *PublicKeyShares, commitments []SigningCommitment, share *SignatureShare) error {
+	if hFunc == nil {
+		return errHashNeeded
+	}
+	groupPublicKey := pub.GroupPublicKey()
+	sc, err := newSigningContext(message, hFunc, &groupPublicKey, commitments)
+	if err != nil {
+		return err
+	}
+	i, ok := sc.index[share.ID]
+	if !ok {
+		return ErrUnknownSigner
+	}
+	Y := pub.VerificationShare(share.ID)
+	if !sc.verifyShare(i, share, &Y) {
+		return &InvalidContributionError{ID: share.ID, Contribution: "signature share"}
+	}
+	return nil
+}
+
+// signingContext holds the values shared by the signers of a message
+type signingContext struct {
+	commitments    []SigningCommitment // sorted by identifier
+	index          map[uint32]int
+	bindingFactors []big.Int
+	r              twistededwards.PointAffine // group commitment
+	challenge      big.Int
+}
+
+func newSigningContext(message []byte, hFunc hash.Hash, groupPublicKey *eddsa.PublicKey, commitments []SigningCommitment) (*signingContext, error) {
+	if len(commitments) == 0 {
+		return nil, ErrNotEnoughSigners
+	}
+	sc := &signingContext{
+		commitments:    append([]SigningCommitment{}, commitments...),
+		index:          make(map[uint32]int, len(commitments)),
+		bindingFactors: make([]big.Int, len(commitments)),
+	}
+	sort.Slice(sc.commitments, func(i, j int) bool {
+		return sc.commitments[i].ID < sc.commitments[j].ID
+	})
+
+	// encoded list of the commitments
+	encodedCommitments := make([]byte, 0, len(commitments)*sizeSigningCommitment)
+	for i := range sc.commitments {
+		c := &sc.commitments[i]
+		if c.ID == 0 || (i > 0 && c.ID == sc.commitments[i-1].ID) {
+			return nil, ErrInvalidIdentifier
+		}
+		if !isValidNonceCommitment(&c.Hiding) || !isValidNonceCommitment(&c.Binding) {
+			return nil, &InvalidContributionError{ID: c.ID, Contribution: "commitment"}
+		}
+		sc.index[c.ID] = i
+		encodedCommitments = append(encodedCommitments, c.Bytes()...)
+	}
+
+	// binding factors ρᵢ = H(Y, H(m), H(commitments), i)
+	pk := groupPublicKey.A.Bytes()
+	messageHash := hashBytes("msg", message)
+	commitmentsHash := hashBytes("com", encodedCommitments)
+	order := curveOrder()
+	var identity twistededwards.PointAffine
+	identity.Y.SetOne()
+	var rho, r twistededwards.PointProj
+	r.FromAffine(&identity)
+	for i := range sc.commitments {
+		c := &sc.commitments[i]
+		h := hashBytes("rho", pk[:], messageHash[:], commitmentsHash[:], identifierBytes(c.ID))
+		sc.bindingFactors[i].SetBytes(h[:]).Mod(&sc.bindingFactors[i], order)
+
+		// R = ∑ Dᵢ + ρᵢ⋅Eᵢ
+		rho.FromAffine(&c.Binding)
+		rho.ScalarMultiplication(&rho, &sc.bindingFactors[i]).MixedAdd(&rho, &c.Hiding)
+		r.Add(&r, &rho)
+	}
+	sc.r.FromProj(&r)
+
+	// challenge c = H(R, Y, m) computed as in eddsa
+	hFunc.Reset()
+	rX, rY := sc.r.X.Bytes(), sc.r.Y.Bytes()
+	aX, aY := groupPublicKey.A.X.Bytes(), groupPublicKey.A.Y.Bytes()
+	for _, b := range [][]byte{rX[:], rY[:], aX[:], aY[:], message} {
+		if _, err := hFunc.Write(b); err != nil {
+			return nil, err
+		}
+	}
+	sc.challenge.SetBytes(hFunc.Sum(nil)).Mod(&sc.challenge, order)
+
+	return sc, nil
+}
+
+// lagrange returns the Lagrange coefficient at 0 of the i-th signer, ∏ xⱼ/(xⱼ-xᵢ)
+func (sc *signingContext) lagrange(i int) big.Int {
+	ids := make([]uint32, len(sc.commitments))
+	for j := range sc.commitments {
+		ids[j] = sc.commitments[j].ID
+	}
+	return lagrangeCoefficient(ids, i)
+}
+
+// verifyShare checks z⋅Base = D + ρ⋅E + λ⋅c⋅Y for the i-th signer
+func (sc *signingContext) verifyShare(i int, share *SignatureShare, Y *twistededwards.PointAffine) bool {
+	curve := twistededwards.GetEdwardsCurve()
+	c := &sc.commitments[i]
+	var lhs, rhs, tmp twistededwards.PointAffine
+	lhs.ScalarMultiplication(&curve.Base, &share.Z)
+
+	lambda := sc.lagrange(i)
+	lambda.Mul(&lambda, &sc.challenge).Mod(&lambda, &curve.Order)
+	rhs.ScalarMultiplication(Y, &lambda)
+	tmp.ScalarMultiplication(&c.Binding, &sc.bindingFactors[i])
+	rhs.Add(&rhs, &tmp).Add(&rhs, &c.Hiding)
+
+	return lhs.Equal(&rhs)
+}
+
+// lagrangeCoefficient returns ∏_{j≠i} xⱼ/(xⱼ-xᵢ) mod the order of the curve
+func lagrangeCoefficient(ids []uint32, i int) big.Int {
+	order := curveOrder()
+	num, den := big.NewInt(1), big.NewInt(1)
+	var xi, xj, diff big.Int
+	xi.SetUint64(uint64(ids[i]))
+	for j := range ids {
+		if j == i {
+			continue
+		}
+		xj.SetUint64(uint64(ids[j]))
+		num.Mul(num, &xj).Mod(num, order)
+		diff.Sub(&xj, &xi)
+		den.Mul(den, &diff).Mod(den, order)
+	}
+	var res big.Int
+	res.ModInverse(den, order).Mul(&res, num).Mod(&res, order)
+	return res
+}
+
+// curveOrder returns the order of the prime subgroup of the curve
+func curveOrder() *big.Int {
+	curve := twistededwards.GetEdwardsCurve()
+	return &curve.Order
+}
+
+// generateNonce returns H(random ∥ secret) mod the order of the curve
+func generateNonce(rand io.Reader, secret *big.Int) (big.Int, error) {
+	var res big.Int
+	var buf [32]byte
+	for res.Sign() == 0 {
+		if _, err := io.ReadFull(rand, buf[:]); err != nil {
+			return res, err
+		}
+		var s [sizeFr]byte
+		secret.FillBytes(s[:])
+		h := hashBytes("nonce", buf[:], s[:])
+		res.SetBytes(h[:]).Mod(&res, curveOrder())
+	}
+	return res, nil
+}
+
+// randomScalar returns a uniformly random non zero scalar
+func randomScalar(rand io.Reader) (big.Int, error) {
+	var res big.Int
+	var buf [64]byte
+	for res.Sign() == 0 {
+		if _, err := io.ReadFull(rand, buf[:]); err != nil {
+			return res, err
+		}
+		res.SetBytes(buf[:]).Mod(&res, curveOrder())
+	}
+	return res, nil
+}
+
+// hashBytes returns BLAKE2b-512(contextString ∥ tag ∥ data[0] ∥ data[1] ∥ ...)
+func hashBytes(tag string, data ...[]byte) [blake2b.Size]byte {
+	h, _ := blake2b.New512(nil)
+	h.Write([]byte(contextString))
+	h.Write([]byte(tag))
+	for i := range data {
+		h.Write(data[i])
+	}
+	var res [blake2b.Size]byte
+	h.Sum(res[:0])
+	return res
+}
+
+func identifierBytes(id uint32) []byte {
+	var res [4]byte
+	binary.BigEndian.PutUint32(res[:], id)
+	return res[:]
+}
+
+// isInPrimeSubgroup returns true if p is on the curve and order⋅p = 0.
+//
+// The product is computed with a double-and-add: the scalar multiplication of the
+// twistededwards package may reduce the scalar modulo the order (GLV), which makes
+// order⋅p = 0 for any point, including the ones with a torsion component.
+func isInPrimeSubgroup(p *twistededwards.PointAffine) bool {
+	if !p.IsOnCurve() {
+		return false
+	}
+	order := curveOrder()
+	var res twistededwards.PointProj
+	res.X.SetZero()
+	res.Y.SetOne()
+	res.Z.SetOne()
+	for i := order.BitLen() - 1; i >= 0; i-- {
+		res.Double(&res)
+		if order.Bit(i) == 1 {
+			res.MixedAdd(&res, p)
+		}
+	}
+	return res.IsZero()
+}
+
+// isValidNonceCommitment returns true if p is a non zero point of the prime subgroup
+func isValidNonceCommitment(p *twistededwards.PointAffine) bool {
+	return !p.IsZero() && isInPrimeSubgroup(p)
+}
+
+// evaluateCommitment returns ∑ xᵏ⋅Cₖ
+func evaluateCommitment(commitment []twistededwards.PointAffine, x uint32) twistededwards.PointAffine {
+	var res twistededwards.PointProj
+	bx := new(big.Int).SetUint64(uint64(x))
+	res.FromAffine(&commitment[len(commitment)-1])
+	for k := len(commitment) - 2; k >= 0; k-- {
+		res.ScalarMultiplication(&res, bx).MixedAdd(&res, &commitment[k])
+	}
+	var p twistededwards.PointAffine
+	p.FromProj(&res)
+	return p
+}
@@ -0,0 +1,412 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package frost
+
+import (
+	"crypto/rand"
+	"errors"
+	"hash"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/twistededwards"
+	gchash "github.com/consensys/gnark-crypto/hash"
+)
+
+// party is a participant of the in-process simulation of the protocols
+type party struct {
+	share KeyShare
+	dkg   *DKGParticipant
+	inbox map[uint32]big.Int // secret shares received during the DKG, by sender
+}
+
+// harness simulates the participants, the broadcast and private channels between them
+// and the coordinator. The corrupt callbacks let a participant misbehave.
+type harness struct {
+	t       *testing.T
+	parties map[uint32]*party
+	pub     *PublicKeyShares
+}
+
+func newHarness(t *testing.T) *harness {
+	return &harness{t: t, parties: make(map[uint32]*party)}
+}
+
+// deal shares a key with a trusted dealer
+func (h *harness) deal(threshold int, ids []uint32) {
+	shares, pub, err := TrustedDealerKeyGen(rand.Reader, threshold, ids)
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	for i := range shares {
+		if err := pub.VerifyShare(&shares[i]); err != nil {
+			h.t.Fatal(err)
+		}
+		h.parties[shares[i].ID] = &party{share: shares[i]}
+	}
+	h.pub = pub
+}
+
+// dkgContext is the context string of the sessions of distributed key generation
+var dkgContext = []byte("frost test session")
+
+// runDKG runs the distributed key generation; corruptMessage and corruptShare, if not
+// nil, tamper with the values sent by a participant.
+func (h *harness) runDKG(threshold int, ids []uint32, corruptMessage func(*DKGRound1Message), corruptShare func(from, to uint32, share *big.Int)) error {
+	// round 1, broadcast
+	messages := make([]DKGRound1Message, len(ids))
+	for i, id := range ids {
+		p, msg, err := NewDKGParticipant(rand.Reader, id, threshold, ids, dkgContext)
+		if err != nil {
+			h.t.Fatal(err)
+		}
+		if corruptMessage != nil {
+			corruptMessage(msg)
+		}
+		messages[i] = *msg
+		h.parties[id] = &party{dkg: p, inbox: make(map[uint32]big.Int)}
+	}
+
+	// round 2, private channels
+	for _, from := range ids {
+		for _, to := range ids {
+			share, err := h.parties[from].dkg.SecretShare(to)
+			if err != nil {
+				h.t.Fatal(err)
+			}
+			if corruptShare != nil {
+				corruptShare(from, to, &share)
+			}
+			h.parties[to].inbox[from] = share
+		}
+	}
+
+	for _, id := range ids {
+		share, pub, err := h.parties[id].dkg.Finalize(messages, h.parties[id].inbox)
+		if err != nil {
+			return err
+		}
+		if h.pub != nil {
+			pk, expected := pub.GroupPublicKey(), h.pub.GroupPublicKey()
+			if !pk.Equal(&expected) {
+				h.t.Fatal("participants disagree on the group public key")
+			}
+		}
+		h.pub = pub
+		h.parties[id].share = *share
+		if err := pub.VerifyShare(share); err != nil {
+			h.t.Fatal(err)
+		}
+	}
+	return nil
+}
+
+// sign runs the signing protocol with the given signers; corrupt, if not nil, tampers
+// with the signature shares.
+func (h *harness) sign(message []byte, hFunc hash.Hash, signers []uint32, corrupt func(*SignatureShare)) ([]byte, error) {
+	// round 1
+	nonces := make([]*SigningNonces, len(signers))
+	commitments := make([]SigningCommitment, len(signers))
+	for i, id := range signers {
+		var err error
+		if nonces[i], commitments[i], err = Commit(rand.Reader, &h.parties[id].share); err != nil {
+			h.t.Fatal(err)
+		}
+	}
+
+	// round 2
+	shares := make([]SignatureShare, len(signers))
+	for i, id := range signers {
+		var err error
+		if shares[i], err = Sign(message, hFunc, &h.parties[id].share, nonces[i], commitments); err != nil {
+			return nil, err
+		}
+		if corrupt != nil {
+			corrupt(&shares[i])
+		}
+	}
+	return Aggregate(message, hFunc, h.pub, commitments, shares)
+}
+
+func randomMessage() []byte {
+	var msg fr.Element
+	msg.SetRandom()
+	return msg.Marshal()
+}
+
+func (h *harness) checkSignatures(threshold int, signerSets [][]uint32) {
+	hFunc := gchash.MIMC_BLS12_381.New()
+	groupPublicKey := h.pub.GroupPublicKey()
+	for _, signers := range signerSets {
+		msg := randomMessage()
+		sig, err := h.sign(msg, hFunc, signers, nil)
+		if len(signers) < threshold {
+			if err != ErrNotEnoughSigners {
+				h.t.Fatalf("signers %v: expected ErrNotEnoughSigners", signers)
+			}
+			continue
+		}
+		if err != nil {
+			h.t.Fatalf("signers %v: %v", signers, err)
+		}
+		if ok, err := groupPublicKey.Verify(sig, msg, hFunc); err != nil || !ok {
+			h.t.Fatalf("signers %v: eddsa verification failed", signers)
+		}
+	}
+}
+
+func TestTrustedDealer(t *testing.T) {
+	h := newHarness(t)
+	h.deal(3, []uint32{1, 2, 3, 4, 5})
+	h.checkSignatures(3, [][]uint32{{1, 2, 3}, {5, 2, 4}, {1, 2, 3, 4, 5}, {1, 4}})
+
+	if _, _, err := TrustedDealerKeyGen(rand.Reader, 4, []uint32{1, 2, 3}); err != ErrInvalidThreshold {
+		t.Fatal("expected ErrInvalidThreshold")
+	}
+	if _, _, err := TrustedDealerKeyGen(rand.Reader, 2, []uint32{1, 2, 2}); err != ErrInvalidIdentifier {
+		t.Fatal("expected ErrInvalidIdentifier")
+	}
+}
+
+func TestDKG(t *testing.T) {
+	h := newHarness(t)
+	if err := h.runDKG(2, []uint32{3, 7, 11, 42}, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	h.checkSignatures(2, [][]uint32{{3, 7}, {42, 11}, {3, 7, 11, 42}, {7}})
+}
+
+func TestDKGIdentifiableAbort(t *testing.T) {
+	var contributionErr *InvalidContributionError
+
+	// invalid proof of knowledge
+	err := newHarness(t).runDKG(2, []uint32{1, 2, 3}, func(msg *DKGRound1Message) {
+		if msg.From == 2 {
+			msg.ProofZ.Add(&msg.ProofZ, big.NewInt(1))
+		}
+	}, nil)
+	if !errors.As(err, &contributionErr) || contributionErr.ID != 2 {
+		t.Fatalf("expected an invalid contribution from participant 2, got %v", err)
+	}
+
+	// valid proof of knowledge from another session
+	err = newHarness(t).runDKG(2, []uint32{1, 2, 3}, func(msg *DKGRound1Message) {
+		if msg.From == 2 {
+			_, replayed, err := NewDKGParticipant(rand.Reader, 2, 2, []uint32{1, 2, 3}, []byte("another session"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			*msg = *replayed
+		}
+	}, nil)
+	if !errors.As(err, &contributionErr) || contributionErr.ID != 2 || contributionErr.Contribution != "proof of knowledge" {
+		t.Fatalf("expected an invalid proof of knowledge from participant 2, got %v", err)
+	}
+
+	// invalid secret share
+	err = newHarness(t).runDKG(2, []uint32{1, 2, 3}, nil, func(from, to uint32, share *big.Int) {
+		if from == 3 && to == 1 {
+			share.Add(share, big.NewInt(1))
+		}
+	})
+	if !errors.As(err, &contributionErr) || contributionErr.ID != 3 {
+		t.Fatalf("expected an invalid contribution from participant 3, got %v", err)
+	}
+}
+
+// addTorsion adds the point (0, -1) of order 2 to p, which stays on the curve but leaves
+// the prime order subgroup
+func addTorsion(p *twistededwards.PointAffine) {
+	var torsion twistededwards.PointAffine
+	torsion.Y.SetOne().Neg(&torsion.Y)
+	p.Add(p, &torsion)
+}
+
+func TestSmallOrderComponent(t *testing.T) {
+	var contributionErr *InvalidContributionError
+
+	// a commitment to a coefficient of degree 1 with a torsion component would only
+	// be caught by the secret shares of the participants of odd identifiers
+	err := newHarness(t).runDKG(2, []uint32{1, 2, 3}, func(msg *DKGRound1Message) {
+		if msg.From == 2 {
+			addTorsion(&msg.Commitment[1])
+		}
+	}, nil)
+	if !errors.As(err, &contributionErr) || contributionErr.ID != 2 || contributionErr.Contribution != "commitment" {
+		t.Fatalf("expected an invalid commitment from participant 2, got %v", err)
+	}
+
+	// proof of knowledge nonce
+	_, msg, err := NewDKGParticipant(rand.Reader, 1, 2, []uint32{1, 2}, dkgContext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isInPrimeSubgroup(&msg.ProofR) {
+		t.Fatal("proof of knowledge nonce should be in the prime order subgroup")
+	}
+	addTorsion(&msg.ProofR)
+	if !msg.ProofR.IsOnCurve() || isInPrimeSubgroup(&msg.ProofR) {
+		t.Fatal("a point with a torsion component should be on the curve, outside the prime order subgroup")
+	}
+	if err := msg.verify(2, dkgContext); !errors.As(err, &contributionErr) || contributionErr.Contribution != "proof of knowledge" {
+		t.Fatalf("expected an invalid proof of knowledge, got %v", err)
+	}
+
+	// signing nonces
+	h := newHarness(t)
+	h.deal(2, []uint32{1, 2})
+	hFunc := gchash.MIMC_BLS12_381.New()
+	for _, binding := range []bool{false, true} {
+		// Sign consumes the nonces, even when it fails
+		nonces1, commitment1, err := Commit(rand.Reader, &h.parties[1].share)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, commitment2, err := Commit(rand.Reader, &h.parties[2].share)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if binding {
+			addTorsion(&commitment2.Binding)
+		} else {
+			addTorsion(&commitment2.Hiding)
+		}
+		_, err = Sign(randomMessage(), hFunc, &h.parties[1].share, nonces1, []SigningCommitment{commitment1, commitment2})
+		if !errors.As(err, &contributionErr) || contributionErr.ID != 2 || contributionErr.Contribution != "commitment" {
+			t.Fatalf("expected an invalid commitment from participant 2, got %v", err)
+		}
+	}
+}
+
+func TestSigningIdentifiableAbort(t *testing.T) {
+	h := newHarness(t)
+	h.deal(2, []uint32{1, 2, 3})
+	hFunc := gchash.MIMC_BLS12_381.New()
+
+	_, err := h.sign(randomMessage(), hFunc, []uint32{1, 3}, func(share *SignatureShare) {
+		if share.ID == 3 {
+			share.Z.SetUint64(42)
+		}
+	})
+	var contributionErr *InvalidContributionError
+	if !errors.As(err, &contributionErr) || contributionErr.ID != 3 {
+		t.Fatalf("expected an invalid contribution from participant 3, got %v", err)
+	}
+}
+
+func TestNonceReuse(t *testing.T) {
+	h := newHarness(t)
+	h.deal(2, []uint32{1, 2})
+	hFunc := gchash.MIMC_BLS12_381.New()
+	msg := randomMessage()
+
+	nonces1, commitment1, err := Commit(rand.Reader, &h.parties[1].share)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonces2, commitment2, err := Commit(rand.Reader, &h.parties[2].share)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitments := []SigningCommitment{commitment1, commitment2}
+	share, err := Sign(msg, hFunc, &h.parties[1].share, nonces1, commitments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyShare(msg, hFunc, h.pub, commitments, &share); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Sign(msg, hFunc, &h.parties[1].share, nonces1, commitments); err != ErrNonceReused {
+		t.Fatal("expected ErrNonceReused")
+	}
+	if _, err := Sign(msg, hFunc, &h.parties[1].share, nonces2, commitments); err != ErrCommitmentMismatch {
+		t.Fatal("expected ErrCommitmentMismatch")
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	h := newHarness(t)
+	h.deal(2, []uint32{1, 2})
+	hFunc := gchash.MIMC_BLS12_381.New()
+
+	var share KeyShare
+	if _, err := share.SetBytes(h.parties[2].share.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.pub.VerifyShare(&share); err != nil {
+		t.Fatal(err)
+	}
+
+	nonces, commitment, err := Commit(rand.Reader, &share)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decodedCommitment SigningCommitment
+	if _, err := decodedCommitment.SetBytes(commitment.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if decodedCommitment.ID != 2 || !decodedCommitment.Hiding.Equal(&commitment.Hiding) || !decodedCommitment.Binding.Equal(&commitment.Binding) {
+		t.Fatal("commitment round trip failed")
+	}
+
+	msg := randomMessage()
+	commitments := []SigningCommitment{decodedCommitment}
+	if _, c, err := Commit(rand.Reader, &h.parties[1].share); err != nil {
+		t.Fatal(err)
+	} else {
+		commitments = append(commitments, c)
+	}
+	sigShare, err := Sign(msg, hFunc, &share, nonces, commitments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decodedShare SignatureShare
+	if _, err := decodedShare.SetBytes(sigShare.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyShare(msg, hFunc, h.pub, commitments, &decodedShare); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func BenchmarkSign(b *testing.B) {
+	shares, pub, _ := TrustedDealerKeyGen(rand.Reader, 3, []uint32{1, 2, 3})
+	hFunc := gchash.MIMC_BLS12_381.New()
+	msg := randomMessage()
+	commitments := make([]SigningCommitment, len(shares))
+	nonces := make([]*SigningNonces, len(shares))
+	signatureShares := make([]SignatureShare, len(shares))
+	for i := range shares {
+		nonces[i], commitments[i], _ = Commit(rand.Reader, &shares[i])
+		signatureShares[i], _ = Sign(msg, hFunc, &shares[i], nonces[i], commitments)
+	}
+
+	b.Run("sign", func(b *testing.B) {
+		c := append([]SigningCommitment{}, commitments...)
+		for i := 0; i < b.N; i++ {
+			nonces, commitment, _ := Commit(rand.Reader, &shares[0])
+			c[0] = commitment
+			Sign(msg, hFunc, &shares[0], nonces, c)
+		}
+	})
+	b.Run("aggregate", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Aggregate(msg, hFunc, pub, commitments, signatureShares)
+		}
+	})
+}
@@ -0,0 +1,120 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package frost
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/twistededwards"
+)
+
+const (
+	sizeIdentifier        = 4
+	sizeKeyShare          = sizeIdentifier + 2*sizeFr
+	sizeSigningCommitment = sizeIdentifier + 2*sizeFr
+	sizeSignatureShare    = sizeIdentifier + sizeFr
+)
+
+var errScalarTooBig = errors.New("scalar is not smaller than the order of the curve")
+
+// Bytes returns the binary representation of the key share as
+// ID ∥ secret ∥ groupPublicKey, where ID is a 4 bytes big endian integer, secret is in
+// big endian and groupPublicKey is as eddsa.PublicKey.Bytes().
+func (share *KeyShare) Bytes() []byte {
+	var res [sizeKeyShare]byte
+	binary.BigEndian.PutUint32(res[:sizeIdentifier], share.ID)
+	share.secret.FillBytes(res[sizeIdentifier : sizeIdentifier+sizeFr])
+	copy(res[sizeIdentifier+sizeFr:], share.GroupPublicKey.Bytes())
+	return res[:]
+}
+
+// SetBytes sets the key share from its binary representation, and returns the number
+// of bytes read.
+func (share *KeyShare) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizeKeyShare {
+		return 0, io.ErrShortBuffer
+	}
+	curve := twistededwards.GetEdwardsCurve()
+	var res KeyShare
+	res.ID = binary.BigEndian.Uint32(buf[:sizeIdentifier])
+	res.secret.SetBytes(buf[sizeIdentifier : sizeIdentifier+sizeFr])
+	if res.secret.Cmp(&curve.Order) >= 0 {
+		return 0, errScalarTooBig
+	}
+	if _, err := res.GroupPublicKey.SetBytes(buf[sizeIdentifier+sizeFr : sizeKeyShare]); err != nil {
+		return 0, err
+	}
+	res.PublicKey.ScalarMultiplication(&curve.Base, &res.secret)
+	*share = res
+	return sizeKeyShare, nil
+}
+
+// Bytes returns the binary representation of the commitment as ID ∥ hiding ∥ binding,
+// where ID is a 4 bytes big endian integer and the points are compressed.
+func (c *SigningCommitment) Bytes() []byte {
+	var res [sizeSigningCommitment]byte
+	binary.BigEndian.PutUint32(res[:sizeIdentifier], c.ID)
+	hiding, binding := c.Hiding.Bytes(), c.Binding.Bytes()
+	copy(res[sizeIdentifier:], hiding[:])
+	copy(res[sizeIdentifier+sizeFr:], binding[:])
+	return res[:]
+}
+
+// SetBytes sets the commitment from its binary representation, and returns the number
+// of bytes read.
+func (c *SigningCommitment) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizeSigningCommitment {
+		return 0, io.ErrShortBuffer
+	}
+	var res SigningCommitment
+	res.ID = binary.BigEndian.Uint32(buf[:sizeIdentifier])
+	if _, err := res.Hiding.SetBytes(buf[sizeIdentifier : sizeIdentifier+sizeFr]); err != nil {
+		return 0, err
+	}
+	if _, err := res.Binding.SetBytes(buf[sizeIdentifier+sizeFr : sizeSigningCommitment]); err != nil {
+		return 0, err
+	}
+	*c = res
+	return sizeSigningCommitment, nil
+}
+
+// Bytes returns the binary representation of the signature share as ID ∥ z, where ID is
+// a 4 bytes big endian integer and z is in big endian.
+func (s *SignatureShare) Bytes() []byte {
+	var res [sizeSignatureShare]byte
+	binary.BigEndian.PutUint32(res[:sizeIdentifier], s.ID)
+	s.Z.FillBytes(res[sizeIdentifier:])
+	return res[:]
+}
+
+// SetBytes sets the signature share from its binary representation, and returns the
+// number of bytes read.
+func (s *SignatureShare) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizeSignatureShare {
+		return 0, io.ErrShortBuffer
+	}
+	var res SignatureShare
+	res.ID = binary.BigEndian.Uint32(buf[:sizeIdentifier])
+	res.Z.SetBytes(buf[sizeIdentifier:sizeSignatureShare])
+	if res.Z.Cmp(curveOrder()) >= 0 {
+		return 0, errScalarTooBig
+	}
+	*s = res
+	return sizeSignatureShare, nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecdsa
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315"
+)
+
+var errInvalidPublicKey = errors.New("public key is not in the prime order subgroup")
+var errInfinity = errors.New("shared point is the point at infinity")
+
+// ECDH returns the shared secret of privKey and pub, that is the x coordinate of [d]Q
+// in big endian on sizeFp bytes, where d is the secret scalar of privKey and Q the point of pub.
+//
+// pub is checked to be a point of the prime order subgroup different from the point at
+// infinity (SEC 1, Version 2.0, Section 3.2.2.1).
+// The shared secret is not uniformly distributed and must go through a key derivation
+// function before being used as a key, as Encrypt does.
+//
+// SEC 1, Version 2.0, Section 3.3.1
+func (privKey *PrivateKey) ECDH(pub *PublicKey) ([]byte, error) {
+	if pub.A.IsInfinity() || !pub.A.IsOnCurve() || !pub.A.IsInSubGroup() {
+		return nil, errInvalidPublicKey
+	}
+
+	var d big.Int
+	d.SetBytes(privKey.scalar[:sizeFr])
+
+	var shared bls24315.G1Affine
+	shared.ScalarMultiplication(&pub.A, &d)
+	if shared.IsInfinity() {
+		return nil, errInfinity
+	}
+	x := shared.X.Bytes()
+	return x[:], nil
+}
@@ -43,6 +43,9 @@ const (
 
 var order = fr.Modulus()
 
+// halfOrder is ⌊order/2⌋, the bound of s in low-s signatures
+var halfOrder = new(big.Int).Rsh(order, 1)
+
 // PublicKey represents an ECDSA public key
 type PublicKey struct {
 	A bls24315.G1Affine
@@ -183,58 +186,95 @@ func (privKey *PrivateKey) Public() signature.PublicKey {
 	return &pub
 }
 
-// Sign performs the ECDSA signature
+// SignOption configures the ECDSA signature
+type SignOption func(*signConfig)
+
+type signConfig struct {
+	newHash func() hash.Hash // hash of the RFC 6979 HMAC_DRBG, random nonces if nil
+	lowS    bool
+}
+
+// WithRFC6979 derives the nonce k deterministically from the private key and the hash
+// of the message, as in RFC 6979 with HMAC-newHash, so that signatures are
+// reproducible across implementations.
+func WithRFC6979(newHash func() hash.Hash) SignOption {
+	return func(cfg *signConfig) {
+		cfg.newHash = newHash
+	}
+}
+
+// WithLowS normalizes the signature so that s ≤ order/2, as required by Bitcoin
+// (BIP-62) and Ethereum (EIP-2).
+func WithLowS() SignOption {
+	return func(cfg *signConfig) {
+		cfg.lowS = true
+	}
+}
+
+// sign performs the ECDSA signature and returns public key recovery information
 //
-// k ← 𝔽r (random)
+// k ← 𝔽r (random, or RFC 6979)
 // P = k ⋅ g1Gen
 // r = x_P (mod order)
-// s = k⁻¹ . (m + sk ⋅ r)
-// signature = {r, s}
+// s = k⁻¹ . (m + sk ⋅ r), replaced by order - s if low-s is required and s > order/2
+// v = (div(x_P, order)<<1) || y_P[-1], y_P being negated with s
 //
 // SEC 1, Version 2.0, Section 4.1.3
-func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error) {
-	scalar, r, s, kInv := new(big.Int), new(big.Int), new(big.Int), new(big.Int)
+func (privKey *PrivateKey) sign(message []byte, hFunc hash.Hash, opts ...SignOption) (v uint, r, s *big.Int, err error) {
+	var cfg signConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	hashed := message
+	if hFunc != nil {
+		// compute the hash of the message
+		hFunc.Reset()
+		if _, err := hFunc.Write(message); err != nil {
+			return 0, nil, nil, err
+		}
+		hashed = hFunc.Sum(nil)
+	}
+	m := HashToInt(hashed)
+
+	r, s = new(big.Int), new(big.Int)
+	scalar, kInv := new(big.Int), new(big.Int)
 	scalar.SetBytes(privKey.scalar[:sizeFr])
+
+	var drbg *rfc6979
+	if cfg.newHash != nil {
+		drbg = newRFC6979(cfg.newHash, scalar, hashed)
+	}
 	for {
-		for {
+		var k *big.Int
+		if drbg != nil {
+			k = drbg.next()
+		} else {
 			csprng, err := nonce(privKey, message)
 			if err != nil {
-				return nil, err
+				return 0, nil, nil, err
 			}
-			k, err := randFieldElement(csprng)
-			if err != nil {
-				return nil, err
+			if k, err = randFieldElement(csprng); err != nil {
+				return 0, nil, nil, err
 			}
+		}
 
-			var P bls24315.G1Affine
-			P.ScalarMultiplicationBase(k)
-			kInv.ModInverse(k, order)
-
-			P.X.BigInt(r)
+		var P bls24315.G1Affine
+		P.ScalarMultiplicationBase(k)
+		kInv.ModInverse(k, order)
 
-			r.Mod(r, order)
-			if r.Sign() != 0 {
-				break
-			}
-		}
-		s.Mul(r, scalar)
+		P.X.BigInt(r)
+		// set how many times we overflow the scalar field
+		v = (uint(new(big.Int).Div(r, order).Uint64())) << 1
+		// set if y is even or odd
+		v |= P.Y.BigInt(new(big.Int)).Bit(0)
 
-		var m *big.Int
-		if hFunc != nil {
-			// compute the hash of the message as an integer
-			dataToHash := make([]byte, len(message))
-			copy(dataToHash[:], message[:])
-			hFunc.Reset()
-			_, err := hFunc.Write(dataToHash[:])
-			if err != nil {
-				return nil, err
-			}
-			hramBin := hFunc.Sum(nil)
-			m = HashToInt(hramBin)
-		} else {
-			m = HashToInt(message)
+		r.Mod(r, order)
+		if r.Sign() == 0 {
+			continue
 		}
 
+		s.Mul(r, scalar)
 		s.Add(m, s).
 			Mul(kInv, s).
 			Mod(s, order) // order != 0
@@ -243,6 +283,35 @@ func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error)
 		}
 	}
 
+	if cfg.lowS && s.Cmp(halfOrder) > 0 {
+		// (r, -s) is the signature with -k, whose point has the opposite y
+		s.Sub(order, s)
+		v ^= 1
+	}
+
+	return v, r, s, nil
+}
+
+// Sign performs the ECDSA signature
+//
+// k ← 𝔽r (random)
+// P = k ⋅ g1Gen
+// r = x_P (mod order)
+// s = k⁻¹ . (m + sk ⋅ r)
+// signature = {r, s}
+//
+// SEC 1, Version 2.0, Section 4.1.3
+func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error) {
+	return privKey.SignWithOptions(message, hFunc)
+}
+
+// SignWithOptions performs the ECDSA signature as Sign, with the nonce derivation and
+// the normalization given by the options.
+func (privKey *PrivateKey) SignWithOptions(message []byte, hFunc hash.Hash, opts ...SignOption) ([]byte, error) {
+	_, r, s, err := privKey.sign(message, hFunc, opts...)
+	if err != nil {
+		return nil, err
+	}
 	var sig Signature
 	r.FillBytes(sig.R[:sizeFr])
 	s.FillBytes(sig.S[:sizeFr])
@@ -18,6 +18,7 @@ package ecdsa
 
 import (
 	"crypto/subtle"
+	"encoding/asn1"
 	"errors"
 	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
 	"io"
@@ -28,6 +29,7 @@ var errWrongSize = errors.New("wrong size buffer")
 var errRBiggerThanRMod = errors.New("r >= r_mod")
 var errSBiggerThanRMod = errors.New("s >= r_mod")
 var errZero = errors.New("zero value")
+var errInvalidDER = errors.New("invalid DER encoding")
 
 // Bytes returns the binary representation of the public key
 // follows https://tools.ietf.org/html/rfc8032#section-3.1
@@ -56,7 +58,7 @@ func (pk *PublicKey) SetBytes(buf []byte) (int, error) {
 	if _, err := pk.A.SetBytes(buf[:sizePublicKey]); err != nil {
 		return 0, err
 	}
-	n += sizeFp
+	n += sizePublicKey
 	return n, nil
 }
 
@@ -134,3 +136,67 @@ func (sig *Signature) SetBytes(buf []byte) (int, error) {
 	n += sizeFr
 	return n, nil
 }
+
+// derSignature is the ASN.1 structure of an ECDSA signature
+//
+//	ECDSA-Sig-Value ::= SEQUENCE { r INTEGER, s INTEGER }
+//
+// SEC 1, Version 2.0, Section C.5
+type derSignature struct {
+	R, S *big.Int
+}
+
+// BytesDER returns the ASN.1 DER encoding of sig,
+// as used in X.509 certificates, TLS and Bitcoin transactions.
+func (sig *Signature) BytesDER() ([]byte, error) {
+	return asn1.Marshal(derSignature{
+		R: new(big.Int).SetBytes(sig.R[:]),
+		S: new(big.Int).SetBytes(sig.S[:]),
+	})
+}
+
+// SetBytesDER sets sig from its ASN.1 DER encoding in buf.
+// Non canonical encodings, trailing bytes and out of range r, s are rejected.
+// It returns the number of bytes read from buf.
+func (sig *Signature) SetBytesDER(buf []byte) (int, error) {
+	var d derSignature
+	rest, err := asn1.Unmarshal(buf, &d)
+	if err != nil || len(rest) != 0 {
+		return 0, errInvalidDER
+	}
+
+	// 0 < R, S < R_mod
+	frMod := fr.Modulus()
+	if d.R.Sign() <= 0 || d.S.Sign() <= 0 {
+		return 0, errZero
+	}
+	if d.R.Cmp(frMod) != -1 {
+		return 0, errRBiggerThanRMod
+	}
+	if d.S.Cmp(frMod) != -1 {
+		return 0, errSBiggerThanRMod
+	}
+
+	d.R.FillBytes(sig.R[:sizeFr])
+	d.S.FillBytes(sig.S[:sizeFr])
+	return len(buf), nil
+}
+
+// IsLowS reports whether s ≤ order/2.
+func (sig *Signature) IsLowS() bool {
+	s := new(big.Int).SetBytes(sig.S[:])
+	return s.Cmp(halfOrder) <= 0
+}
+
+// Normalize replaces s by order - s if s > order/2, so that sig is the low-s
+// form of the signature (BIP-62, EIP-2). Both forms verify.
+// It returns true if s was changed.
+func (sig *Signature) Normalize() bool {
+	s := new(big.Int).SetBytes(sig.S[:])
+	if s.Cmp(halfOrder) <= 0 {
+		return false
+	}
+	s.Sub(order, s)
+	s.FillBytes(sig.S[:sizeFr])
+	return true
+}
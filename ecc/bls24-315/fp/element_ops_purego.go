@@ -61,6 +61,26 @@ func reduce(z *Element) {
 	_reduceGeneric(z)
 }
 
+func addVec(res, a, b Vector) {
+	addVecGeneric(res, a, b)
+}
+
+func subVec(res, a, b Vector) {
+	subVecGeneric(res, a, b)
+}
+
+func scalarMulVec(res, a Vector, b *Element) {
+	scalarMulVecGeneric(res, a, b)
+}
+
+func mulVec(res, a, b Vector) {
+	mulVecGeneric(res, a, b)
+}
+
+func butterflyVec(a, b Vector) {
+	butterflyVecGeneric(a, b)
+}
+
 // Mul z = x * y (mod q)
 //
 // x and y must be less than q
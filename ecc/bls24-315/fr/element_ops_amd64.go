@@ -97,6 +97,69 @@ func (z *Element) Mul(x, y *Element) *Element {
 	return z
 }
 
+//go:noescape
+func addVecAsm(res, a, b *Element, n uint64)
+
+//go:noescape
+func subVecAsm(res, a, b *Element, n uint64)
+
+//go:noescape
+func scalarMulVecAsm(res, a, b *Element, n uint64)
+
+//go:noescape
+func mulVecAsm(res, a, b *Element, n uint64)
+
+//go:noescape
+func butterflyVecAsm(a, b *Element, n uint64)
+
+// addVec sets res = a + b element-wise; the vectors have the same length
+func addVec(res, a, b Vector) {
+	if len(a) == 0 {
+		return
+	}
+	addVecAsm(&res[0], &a[0], &b[0], uint64(len(a)))
+}
+
+// subVec sets res = a - b element-wise; the vectors have the same length
+func subVec(res, a, b Vector) {
+	if len(a) == 0 {
+		return
+	}
+	subVecAsm(&res[0], &a[0], &b[0], uint64(len(a)))
+}
+
+// scalarMulVec sets res = a * b element-wise; the vectors have the same length
+func scalarMulVec(res, a Vector, b *Element) {
+	if len(a) == 0 {
+		return
+	}
+	if !supportAdx {
+		scalarMulVecGeneric(res, a, b)
+		return
+	}
+	scalarMulVecAsm(&res[0], &a[0], b, uint64(len(a)))
+}
+
+// mulVec sets res = a * b element-wise; the vectors have the same length
+func mulVec(res, a, b Vector) {
+	if len(a) == 0 {
+		return
+	}
+	if !supportAdx {
+		mulVecGeneric(res, a, b)
+		return
+	}
+	mulVecAsm(&res[0], &a[0], &b[0], uint64(len(a)))
+}
+
+// butterflyVec sets a, b = a + b, a - b element-wise; the vectors have the same length
+func butterflyVec(a, b Vector) {
+	if len(a) == 0 {
+		return
+	}
+	butterflyVecAsm(&a[0], &b[0], uint64(len(a)))
+}
+
 // Square z = x * x (mod q)
 //
 // x must be less than q
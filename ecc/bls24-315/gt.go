@@ -0,0 +1,225 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls24315
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/internal/fptower"
+	"github.com/consensys/gnark-crypto/field/hash"
+)
+
+// SizeOfGTElementCompressed represents the size in bytes that a GTElement need in binary form, compressed
+const SizeOfGTElementCompressed = SizeOfGT / 2
+
+// mGTCompressed flags the most significant bit of a compressed GTElement.
+// The most significant bit of a canonical fp element is always 0.
+const mGTCompressed byte = 0b1 << 7
+
+var (
+	ErrGTNotInSubGroup   = errors.New("element is not in GT")
+	ErrGTInvalidEncoding = errors.New("invalid GT encoding")
+)
+
+// GTElement is an element of the target group of the pairing: the subgroup of
+// order r of the cyclotomic subgroup of the multiplicative group of the extension field.
+//
+// Unlike GT, which is an alias for an arbitrary element of the extension field (for
+// example the output of a Miller loop), a GTElement is always in the subgroup and
+// only exposes group operations. It is serialized in half its size using torus-based
+// compression ("Compression in finite fields and torus-based cryptography", K. Rubin and A. Silverberg).
+type GTElement struct {
+	z GT
+}
+
+// PairGT calculates the reduced pairing ∏ᵢ e(Pᵢ, Qᵢ) as a GTElement.
+//
+// This function doesn't check that the inputs are in the correct subgroup. See IsInSubGroup.
+func PairGT(P []G1Affine, Q []G2Affine) (GTElement, error) {
+	z, err := Pair(P, Q)
+	if err != nil {
+		return GTElement{}, err
+	}
+	return GTElement{z: z}, nil
+}
+
+// SetGT sets e to z and returns it.
+// It returns an error if z is not in the subgroup of order r.
+func (e *GTElement) SetGT(z *GT) (*GTElement, error) {
+	if !z.IsInSubGroup() {
+		return nil, ErrGTNotInSubGroup
+	}
+	e.z.Set(z)
+	return e, nil
+}
+
+// GT returns the underlying extension field element.
+func (e *GTElement) GT() GT {
+	return e.z
+}
+
+// Set sets e to a and returns it.
+func (e *GTElement) Set(a *GTElement) *GTElement {
+	e.z.Set(&a.z)
+	return e
+}
+
+// SetOne sets e to the neutral element and returns it.
+func (e *GTElement) SetOne() *GTElement {
+	e.z.SetOne()
+	return e
+}
+
+// IsOne returns true if e is the neutral element.
+func (e *GTElement) IsOne() bool {
+	return e.z.IsOne()
+}
+
+// Equal returns true if e == a.
+func (e *GTElement) Equal(a *GTElement) bool {
+	return e.z.Equal(&a.z)
+}
+
+// IsInSubGroup returns true if e is in the subgroup of order r.
+// This always holds for elements obtained through the methods of GTElement.
+func (e *GTElement) IsInSubGroup() bool {
+	return e.z.IsInSubGroup()
+}
+
+// Mul sets e = a ⋅ b and returns it.
+func (e *GTElement) Mul(a, b *GTElement) *GTElement {
+	e.z.Mul(&a.z, &b.z)
+	return e
+}
+
+// Inverse sets e = a⁻¹ and returns it.
+// In the cyclotomic subgroup, the inverse is the conjugate.
+func (e *GTElement) Inverse(a *GTElement) *GTElement {
+	e.z.Conjugate(&a.z)
+	return e
+}
+
+// Exp sets e = aᵏ and returns it.
+// k can be negative and is reduced modulo r; it uses the GLV decomposition (see ExpGLV).
+func (e *GTElement) Exp(a *GTElement, k *big.Int) *GTElement {
+	var _k big.Int
+	_k.Mod(k, fr.Modulus())
+	e.z.ExpGLV(a.z, &_k)
+	return e
+}
+
+// Hash expands the canonical (uncompressed) encoding of e to lenInBytes pseudo-random bytes,
+// using expand_message_xmd with the domain separation tag dst.
+// It can be used to derive a symmetric key from a GTElement.
+func (e *GTElement) Hash(dst []byte, lenInBytes int) ([]byte, error) {
+	b := e.RawBytes()
+	return hash.ExpandMsgXmd(b[:], dst, lenInBytes)
+}
+
+// String returns the string representation of e.
+func (e *GTElement) String() string {
+	return e.z.String()
+}
+
+// Marshal converts e to a byte slice (compressed).
+func (e *GTElement) Marshal() []byte {
+	b := e.Bytes()
+	return b[:]
+}
+
+// Unmarshal is an alias for SetBytes.
+func (e *GTElement) Unmarshal(buf []byte) error {
+	_, err := e.SetBytes(buf)
+	return err
+}
+
+// RawBytes returns the binary representation of e (uncompressed), as in GT.Bytes.
+func (e *GTElement) RawBytes() [SizeOfGT]byte {
+	return e.z.Bytes()
+}
+
+// Bytes returns the binary representation of e, compressed on the torus.
+//
+// e = c₀ + c₁⋅w is encoded as y = (c₀ + 1) / c₁, with the most significant bit set.
+// The neutral element (c₁ = 0) is encoded as y = 0.
+func (e *GTElement) Bytes() (res [SizeOfGTElementCompressed]byte) {
+	var t GT
+	if !e.z.D1.IsZero() {
+		y, _ := e.z.CompressTorus()
+		t.D0.Set(&y)
+	}
+	b := t.Bytes()
+	copy(res[:], b[:SizeOfGTElementCompressed])
+	res[0] |= mGTCompressed
+	return
+}
+
+// SetBytes sets e from a compressed (Bytes) or uncompressed (RawBytes) binary representation.
+// It returns the number of bytes read, or an error if the encoding is invalid or the element is not in GT.
+func (e *GTElement) SetBytes(buf []byte) (int, error) {
+	return e.setBytes(buf, true)
+}
+
+func (e *GTElement) setBytes(buf []byte, subGroupCheck bool) (int, error) {
+	if len(buf) == 0 {
+		return 0, ErrGTInvalidEncoding
+	}
+
+	if buf[0]&mGTCompressed == 0 {
+		// uncompressed
+		if len(buf) < SizeOfGT {
+			return 0, ErrGTInvalidEncoding
+		}
+		var z GT
+		if err := z.SetBytes(buf[:SizeOfGT]); err != nil {
+			return 0, err
+		}
+		if subGroupCheck && !z.IsInSubGroup() {
+			return 0, ErrGTNotInSubGroup
+		}
+		e.z.Set(&z)
+		return SizeOfGT, nil
+	}
+
+	if len(buf) < SizeOfGTElementCompressed {
+		return 0, ErrGTInvalidEncoding
+	}
+	var b [SizeOfGT]byte
+	copy(b[:SizeOfGTElementCompressed], buf[:SizeOfGTElementCompressed])
+	b[0] &^= mGTCompressed
+	var t GT
+	if err := t.SetBytes(b[:]); err != nil {
+		return 0, err
+	}
+
+	if t.D0.IsZero() {
+		e.z.SetOne()
+		return SizeOfGTElementCompressed, nil
+	}
+
+	// the decompressed element is in the cyclotomic subgroup, we check the order
+	var y fptower.E12
+	y.Set(&t.D0)
+	z := y.DecompressTorus()
+	if subGroupCheck && !z.IsInSubGroup() {
+		return 0, ErrGTNotInSubGroup
+	}
+	e.z.Set(&z)
+	return SizeOfGTElementCompressed, nil
+}
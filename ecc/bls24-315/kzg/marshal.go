@@ -17,8 +17,12 @@
 package kzg
 
 import (
-	"github.com/consensys/gnark-crypto/ecc/bls24-315"
 	"io"
+	"os"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315"
+	"github.com/consensys/gnark-crypto/utils/mmap"
 )
 
 // WriteTo writes binary encoding of the ProvingKey
@@ -172,6 +176,82 @@ func (srs *SRS) UnsafeReadFrom(r io.Reader) (int64, error) {
 	return pn + vn, err
 }
 
+// WriteDump writes the ProvingKey in the raw layout of package mmap, which can be
+// read back with ReadDump or memory-mapped with MapProvingKey.
+// The dump is not portable across architectures and is meant to be used as a local cache.
+func (pk *ProvingKey) WriteDump(w io.Writer) (int64, error) {
+	return mmap.WriteSlice(w, ecc.BLS24_315, pk.G1)
+}
+
+// ReadDump decodes ProvingKey data written by WriteDump, without sub group checks.
+func (pk *ProvingKey) ReadDump(r io.Reader, options ...mmap.Option) (int64, error) {
+	g1, n, err := mmap.ReadSlice[bls24315.G1Affine](r, ecc.BLS24_315, options...)
+	if err != nil {
+		return n, err
+	}
+	pk.G1 = g1
+	return n, nil
+}
+
+// MapProvingKey returns a read-only view of the ProvingKey written by WriteDump at offset in f.
+//
+// On Linux the points are not copied: pk.G1 is backed by a memory mapping of the file.
+// pk.G1 must not be modified, and must not be used after the returned io.Closer is closed.
+func MapProvingKey(f *os.File, offset int64, options ...mmap.Option) (pk ProvingKey, closer io.Closer, err error) {
+	v, err := mmap.Map[bls24315.G1Affine](f, offset, ecc.BLS24_315, options...)
+	if err != nil {
+		return ProvingKey{}, nil, err
+	}
+	pk.G1 = v.Slice()
+	return pk, v, nil
+}
+
+// WriteDump writes the ProvingKey in the raw layout of package mmap (see ProvingKey.WriteDump),
+// followed by the VerifyingKey without point compression.
+func (srs *SRS) WriteDump(w io.Writer) (int64, error) {
+	var pn, vn int64
+	var err error
+	if pn, err = srs.Pk.WriteDump(w); err != nil {
+		return pn, err
+	}
+	vn, err = srs.Vk.WriteRawTo(w)
+	return pn + vn, err
+}
+
+// ReadDump decodes SRS data written by WriteDump.
+func (srs *SRS) ReadDump(r io.Reader, options ...mmap.Option) (int64, error) {
+	var pn, vn int64
+	var err error
+	if pn, err = srs.Pk.ReadDump(r, options...); err != nil {
+		return pn, err
+	}
+	vn, err = srs.Vk.ReadFrom(r)
+	return pn + vn, err
+}
+
+// MapSRS returns a SRS whose ProvingKey is a read-only view of the dump written by SRS.WriteDump
+// at the beginning of f (see MapProvingKey). The VerifyingKey is decoded in memory.
+func MapSRS(f *os.File, options ...mmap.Option) (srs *SRS, closer io.Closer, err error) {
+	v, err := mmap.Map[bls24315.G1Affine](f, 0, ecc.BLS24_315, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+	srs = new(SRS)
+	srs.Pk.G1 = v.Slice()
+
+	// the VerifyingKey follows the ProvingKey dump
+	fi, err := f.Stat()
+	if err != nil {
+		v.Close()
+		return nil, nil, err
+	}
+	if _, err = srs.Vk.ReadFrom(io.NewSectionReader(f, v.Size(), fi.Size()-v.Size())); err != nil {
+		v.Close()
+		return nil, nil, err
+	}
+	return srs, v, nil
+}
+
 // WriteTo writes binary encoding of a OpeningProof
 func (proof *OpeningProof) WriteTo(w io.Writer) (int64, error) {
 	enc := bls24315.NewEncoder(w)
@@ -0,0 +1,163 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls24315
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+)
+
+// randomEquations returns n equations e([a]g₁, [b]g₂)⋅e(-[ab]g₁, g₂) == 1
+func randomEquations(n int) ([][]G1Affine, [][]G2Affine) {
+	_, _, g1, g2 := Generators()
+	P := make([][]G1Affine, n)
+	Q := make([][]G2Affine, n)
+	var a, b, ab fr.Element
+	var bi big.Int
+	for i := 0; i < n; i++ {
+		a.SetRandom()
+		b.SetRandom()
+		ab.Mul(&a, &b).Neg(&ab)
+		P[i] = make([]G1Affine, 2)
+		Q[i] = make([]G2Affine, 2)
+		P[i][0].ScalarMultiplication(&g1, a.BigInt(&bi))
+		Q[i][0].ScalarMultiplication(&g2, b.BigInt(&bi))
+		P[i][1].ScalarMultiplication(&g1, ab.BigInt(&bi))
+		Q[i][1].Set(&g2)
+	}
+	return P, Q
+}
+
+func TestPairingBatchVerifier(t *testing.T) {
+	t.Parallel()
+
+	const n = 5
+	P, Q := randomEquations(n)
+	_, _, g1, g2 := Generators()
+	g2Lines := PrecomputeLines(g2)
+
+	options := map[string]func() *PairingBatchVerifier{
+		"csprng": func() *PairingBatchVerifier {
+			return NewPairingBatchVerifier()
+		},
+		"transcript": func() *PairingBatchVerifier {
+			return NewPairingBatchVerifier(WithTranscriptHash(sha256.New()))
+		},
+		"fixed Q": func() *PairingBatchVerifier {
+			v := NewPairingBatchVerifier()
+			v.SetLines(&g2, &g2Lines)
+			return v
+		},
+	}
+
+	for name, newVerifier := range options {
+		t.Run(name, func(t *testing.T) {
+			v := newVerifier()
+			for i := 0; i < n; i++ {
+				if err := v.Add(P[i], Q[i]); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if v.Len() != n {
+				t.Fatal("wrong number of equations")
+			}
+			ok, err := v.Verify()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !ok {
+				t.Fatal("valid batch rejected")
+			}
+
+			// an invalid equation makes the whole batch fail
+			var wrong G1Affine
+			wrong.Add(&P[n-1][1], &g1)
+			if err := v.Add([]G1Affine{P[n-1][0], wrong}, Q[n-1]); err != nil {
+				t.Fatal(err)
+			}
+			ok, err = v.Verify()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok {
+				t.Fatal("invalid batch accepted")
+			}
+
+			v.Reset()
+			if _, err := v.Verify(); err != ErrBatchEmpty {
+				t.Fatal("expected ErrBatchEmpty on empty batch")
+			}
+		})
+	}
+
+	t.Run("invalid inputs", func(t *testing.T) {
+		v := NewPairingBatchVerifier()
+		if err := v.Add(P[0], Q[0][:1]); err != ErrBatchInvalidInputs {
+			t.Fatal("expected ErrBatchInvalidInputs")
+		}
+		if err := v.Add(nil, nil); err != ErrBatchInvalidInputs {
+			t.Fatal("expected ErrBatchInvalidInputs")
+		}
+	})
+
+	t.Run("single equation matches PairingCheck", func(t *testing.T) {
+		v := NewPairingBatchVerifier()
+		if err := v.Add(P[0], Q[0]); err != nil {
+			t.Fatal(err)
+		}
+		ok, err := v.Verify()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected, err := PairingCheck(P[0], Q[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok != expected {
+			t.Fatal("batch verifier and PairingCheck disagree")
+		}
+	})
+}
+
+func BenchmarkPairingBatchVerifier(b *testing.B) {
+	const n = 16
+	P, Q := randomEquations(n)
+	_, _, _, g2 := Generators()
+	g2Lines := PrecomputeLines(g2)
+
+	b.Run("individual PairingCheck", func(b *testing.B) {
+		for j := 0; j < b.N; j++ {
+			for i := 0; i < n; i++ {
+				PairingCheck(P[i], Q[i])
+			}
+		}
+	})
+
+	b.Run("batch", func(b *testing.B) {
+		for j := 0; j < b.N; j++ {
+			v := NewPairingBatchVerifier()
+			v.SetLines(&g2, &g2Lines)
+			for i := 0; i < n; i++ {
+				v.Add(P[i], Q[i])
+			}
+			v.Verify()
+		}
+	})
+}
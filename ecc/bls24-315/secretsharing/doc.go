@@ -0,0 +1,33 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package secretsharing implements Shamir secret sharing over the scalar field of bls24-315,
+// and the Feldman and Pedersen verifiable secret sharing schemes with commitments in G1.
+//
+// A secret s is shared with a random polynomial f of degree t-1 such that f(0) = s: the
+// share of the party at index x ≠ 0 is f(x). Any t shares reconstruct s by Lagrange
+// interpolation, fewer reveal nothing about it. In the verifiable schemes, the dealer
+// also publishes commitments to the coefficients of f, against which each party checks
+// its share. Shares can be proactively re-shared to a new set of parties, with a new
+// threshold, without reconstructing the secret.
+//
+// # See also
+//
+//   - Shamir, How to share a secret, 1979
+//   - Feldman, A practical scheme for non-interactive verifiable secret sharing, 1987
+//   - Pedersen, Non-interactive and information-theoretic secure verifiable secret sharing, 1991
+//   - Desmedt and Jajodia, Redistributing secret shares to new access structures, 1997
+package secretsharing
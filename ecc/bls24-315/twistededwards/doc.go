@@ -15,4 +15,6 @@
 // Code generated by consensys/gnark-crypto DO NOT EDIT
 
 // Package twistededwards provides bls24-315's twisted edwards "companion curve" defined on fr.
+//
+// Messages are hashed to the curve with the Elligator 2 map, see HashToCurve (RFC 9380).
 package twistededwards
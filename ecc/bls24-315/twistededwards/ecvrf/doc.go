@@ -0,0 +1,32 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package ecvrf implements a verifiable random function on bls24-315's twistededwards curve,
+// following the ECVRF construction of RFC 9381 with try-and-increment encoding to the curve.
+//
+// The owner of a private key computes from an input alpha a pseudorandom output beta and
+// a proof pi (Prove). pi shows to anyone knowing the public key that beta is the unique
+// output of alpha (Verify), and beta is derived from pi alone (ProofToHash).
+//
+// The hash is a parameter: all the hashed data is a sequence of field elements, so that
+// an arithmetization friendly hash such as MiMC can be used to verify the proofs in a
+// circuit, or SHA-256 outside of circuits. With MiMC, alpha must be a sequence of
+// canonical field elements, as for eddsa messages.
+//
+// # See also
+//
+// https://www.rfc-editor.org/rfc/rfc9381
+package ecvrf
@@ -0,0 +1,338 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecvrf
+
+import (
+	"crypto/subtle"
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/twistededwards"
+	"golang.org/x/crypto/blake2b"
+)
+
+// SuiteString identifies the ciphersuite in all the hashes
+const SuiteString = 0xED
+
+const (
+	sizeFr         = fr.Bytes
+	sizePoint      = sizeFr // ptLen, compressed encoding of the point
+	sizeChallenge  = 16     // cLen
+	sizePublicKey  = sizePoint
+	sizePrivateKey = sizePublicKey + sizeFr + 32
+
+	// SizeProof is the size of a proof pi
+	SizeProof = sizePoint + sizeChallenge + sizeFr
+)
+
+// domain separators of the hashes
+const (
+	encodeToCurveDomain byte = 0x01
+	challengeDomain     byte = 0x02
+	proofToHashDomain   byte = 0x03
+)
+
+var (
+	ErrInvalidProof      = errors.New("invalid VRF proof")
+	ErrInvalidPublicKey  = errors.New("invalid public key")
+	errHashNeeded        = errors.New("hFunc cannot be nil. We need a hash for the VRF")
+	errEncodeToCurve     = errors.New("no point found by try-and-increment")
+	errNotOnCurve        = errors.New("point not on curve")
+	errWrongSize         = errors.New("wrong size buffer")
+	errPublicKeyMismatch = errors.New("public key doesn't match the secret scalar")
+)
+
+// PublicKey represents an ECVRF public key
+type PublicKey struct {
+	Y twistededwards.PointAffine
+}
+
+// PrivateKey represents an ECVRF private key
+type PrivateKey struct {
+	PublicKey PublicKey    // copy of the associated public key
+	scalar    [sizeFr]byte // secret scalar x, in big Endian
+	randSrc   [32]byte     // source of the nonces
+}
+
+// Proof represents an ECVRF proof pi = (Gamma, c, s)
+type Proof struct {
+	Gamma twistededwards.PointAffine
+	C     [sizeChallenge]byte
+	S     [sizeFr]byte
+}
+
+// GenerateKey generates a public and private key pair.
+func GenerateKey(r io.Reader) (*PrivateKey, error) {
+	// hash(seed) = secret scalar || random source, on 32 bytes each
+	var seed [32]byte
+	if _, err := io.ReadFull(r, seed[:]); err != nil {
+		return nil, err
+	}
+	h := blake2b.Sum512(seed[:])
+
+	var privKey PrivateKey
+	copy(privKey.randSrc[:], h[32:])
+	x := new(big.Int).SetBytes(h[:32])
+	x.Mod(x, curveOrder())
+	privKey.setScalar(x)
+
+	return &privKey, nil
+}
+
+func (privKey *PrivateKey) setScalar(x *big.Int) {
+	base := twistededwards.GetEdwardsCurve().Base
+	x.FillBytes(privKey.scalar[:])
+	privKey.PublicKey.Y.ScalarMultiplication(&base, x)
+}
+
+// Prove returns the proof pi of the VRF on alpha, hashing with hFunc (RFC 9381, Section 5.1)
+//
+//	H = encode_to_curve(Y, alpha)
+//	Gamma = x ⋅ H
+//	k = blake2b(randSrc ∥ H) mod q
+//	c = challenge(Y, H, Gamma, k ⋅ B, k ⋅ H)
+//	s = k + c ⋅ x mod q
+//	pi = Gamma ∥ c ∥ s
+func (privKey *PrivateKey) Prove(alpha []byte, hFunc hash.Hash) ([]byte, error) {
+	if hFunc == nil {
+		return nil, errHashNeeded
+	}
+	H, err := encodeToCurve(&privKey.PublicKey.Y, alpha, hFunc)
+	if err != nil {
+		return nil, err
+	}
+	order := curveOrder()
+	x := new(big.Int).SetBytes(privKey.scalar[:])
+
+	var proof Proof
+	proof.Gamma.ScalarMultiplication(&H, x)
+
+	// the nonce is derived from the secret random source and H, as in RFC 8032
+	hX, hY := H.X.Bytes(), H.Y.Bytes()
+	nonceSrc := make([]byte, 0, 32+2*sizeFr)
+	nonceSrc = append(nonceSrc, privKey.randSrc[:]...)
+	nonceSrc = append(nonceSrc, hX[:]...)
+	nonceSrc = append(nonceSrc, hY[:]...)
+	kBytes := blake2b.Sum512(nonceSrc)
+	k := new(big.Int).SetBytes(kBytes[:])
+	k.Mod(k, order)
+
+	base := twistededwards.GetEdwardsCurve().Base
+	var U, V twistededwards.PointAffine
+	U.ScalarMultiplication(&base, k)
+	V.ScalarMultiplication(&H, k)
+	if proof.C, err = challenge(hFunc, &privKey.PublicKey.Y, &H, &proof.Gamma, &U, &V); err != nil {
+		return nil, err
+	}
+
+	s := new(big.Int).SetBytes(proof.C[:])
+	s.Mul(s, x).Add(s, k).Mod(s, order)
+	s.FillBytes(proof.S[:])
+
+	return proof.Bytes(), nil
+}
+
+// ProofToHash returns the output beta of the proof pi (RFC 9381, Section 5.2),
+// without verifying pi:
+//
+//	beta = hFunc(SuiteString ∥ 0x03, cofactor ⋅ Gamma)
+func ProofToHash(pi []byte, hFunc hash.Hash) ([]byte, error) {
+	if hFunc == nil {
+		return nil, errHashNeeded
+	}
+	var proof Proof
+	if _, err := proof.SetBytes(pi); err != nil {
+		return nil, err
+	}
+	return proof.hash(hFunc)
+}
+
+func (proof *Proof) hash(hFunc hash.Hash) ([]byte, error) {
+	var P twistededwards.PointAffine
+	mulByCofactor(&P, &proof.Gamma)
+	hFunc.Reset()
+	if err := writeDomain(hFunc, proofToHashDomain); err != nil {
+		return nil, err
+	}
+	if err := writePoints(hFunc, &P); err != nil {
+		return nil, err
+	}
+	return hFunc.Sum(nil), nil
+}
+
+// Verify checks the proof pi of the VRF on alpha and returns the output beta
+// (RFC 9381, Section 5.3). It returns ErrInvalidProof if pi is not valid.
+//
+//	H = encode_to_curve(Y, alpha)
+//	U = s ⋅ B - c ⋅ Y
+//	V = s ⋅ H - c ⋅ Gamma
+//	c == challenge(Y, H, Gamma, U, V)
+func (pk *PublicKey) Verify(pi, alpha []byte, hFunc hash.Hash) ([]byte, error) {
+	if hFunc == nil {
+		return nil, errHashNeeded
+	}
+	// Y must be on the curve and not of small order
+	var Y8 twistededwards.PointAffine
+	mulByCofactor(&Y8, &pk.Y)
+	if !pk.Y.IsOnCurve() || Y8.IsZero() {
+		return nil, ErrInvalidPublicKey
+	}
+	var proof Proof
+	if _, err := proof.SetBytes(pi); err != nil {
+		return nil, ErrInvalidProof
+	}
+
+	H, err := encodeToCurve(&pk.Y, alpha, hFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	c := new(big.Int).SetBytes(proof.C[:])
+	s := new(big.Int).SetBytes(proof.S[:])
+
+	base := twistededwards.GetEdwardsCurve().Base
+	var U, V, tmp twistededwards.PointAffine
+	U.ScalarMultiplication(&base, s)
+	tmp.ScalarMultiplication(&pk.Y, c)
+	tmp.Neg(&tmp)
+	U.Add(&U, &tmp)
+	V.ScalarMultiplication(&H, s)
+	tmp.ScalarMultiplication(&proof.Gamma, c)
+	tmp.Neg(&tmp)
+	V.Add(&V, &tmp)
+
+	expected, err := challenge(hFunc, &pk.Y, &H, &proof.Gamma, &U, &V)
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare(expected[:], proof.C[:]) != 1 {
+		return nil, ErrInvalidProof
+	}
+	return proof.hash(hFunc)
+}
+
+// encodeToCurve hashes alpha to a point of the prime order subgroup with the
+// try-and-increment method, salted with the public key (RFC 9381, Section 5.4.1.1):
+// for ctr in [0, 255],
+//
+//	y = hFunc(SuiteString ∥ 0x01, Y, alpha, ctr)
+//
+// until y is the ordinate of a point P, then H = cofactor ⋅ P if it is not the identity.
+// The abscissa of P is the one which is not lexicographically largest.
+func encodeToCurve(Y *twistededwards.PointAffine, alpha []byte, hFunc hash.Hash) (twistededwards.PointAffine, error) {
+	curve := twistededwards.GetEdwardsCurve()
+	var H, P twistededwards.PointAffine
+	var one, num, den fr.Element
+	one.SetOne()
+
+	for ctr := uint64(0); ctr < 256; ctr++ {
+		hFunc.Reset()
+		if err := writeDomain(hFunc, encodeToCurveDomain); err != nil {
+			return H, err
+		}
+		if err := writePoints(hFunc, Y); err != nil {
+			return H, err
+		}
+		if _, err := hFunc.Write(alpha); err != nil {
+			return H, err
+		}
+		var ctrElement fr.Element
+		ctrElement.SetUint64(ctr)
+		ctrBytes := ctrElement.Bytes()
+		if _, err := hFunc.Write(ctrBytes[:]); err != nil {
+			return H, err
+		}
+		P.Y.SetBytes(hFunc.Sum(nil))
+
+		// x² = (1 - y²) / (a - d⋅y²)
+		num.Square(&P.Y)
+		den.Mul(&num, &curve.D)
+		num.Sub(&one, &num)
+		den.Sub(&curve.A, &den)
+		if den.IsZero() {
+			continue
+		}
+		num.Div(&num, &den)
+		if P.X.Sqrt(&num) == nil {
+			continue
+		}
+		if P.X.LexicographicallyLargest() {
+			P.X.Neg(&P.X)
+		}
+		if !P.IsOnCurve() {
+			continue
+		}
+		mulByCofactor(&H, &P)
+		if !H.IsZero() {
+			return H, nil
+		}
+	}
+	return H, errEncodeToCurve
+}
+
+// challenge returns the last sizeChallenge bytes of
+// hFunc(SuiteString ∥ 0x02, P₁, …, P₅) (RFC 9381, Section 5.4.3), so that the
+// challenge has its full length when the hash output is a field element.
+func challenge(hFunc hash.Hash, points ...*twistededwards.PointAffine) ([sizeChallenge]byte, error) {
+	var c [sizeChallenge]byte
+	hFunc.Reset()
+	if err := writeDomain(hFunc, challengeDomain); err != nil {
+		return c, err
+	}
+	if err := writePoints(hFunc, points...); err != nil {
+		return c, err
+	}
+	digest := hFunc.Sum(nil)
+	copy(c[:], digest[len(digest)-sizeChallenge:])
+	return c, nil
+}
+
+// writeDomain writes the domain separator SuiteString ∥ tag, as a field element.
+func writeDomain(hFunc hash.Hash, tag byte) error {
+	var d [sizeFr]byte
+	d[sizeFr-2] = SuiteString
+	d[sizeFr-1] = tag
+	_, err := hFunc.Write(d[:])
+	return err
+}
+
+// writePoints writes the coordinates X, Y of the points, as field elements.
+func writePoints(hFunc hash.Hash, points ...*twistededwards.PointAffine) error {
+	for _, p := range points {
+		x, y := p.X.Bytes(), p.Y.Bytes()
+		if _, err := hFunc.Write(x[:]); err != nil {
+			return err
+		}
+		if _, err := hFunc.Write(y[:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func mulByCofactor(res, p *twistededwards.PointAffine) {
+	cofactor := twistededwards.GetEdwardsCurve().Cofactor
+	res.ScalarMultiplication(p, cofactor.BigInt(new(big.Int)))
+}
+
+func curveOrder() *big.Int {
+	curve := twistededwards.GetEdwardsCurve()
+	return &curve.Order
+}
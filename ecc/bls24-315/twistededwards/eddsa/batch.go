@@ -0,0 +1,167 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package eddsa
+
+import (
+	"crypto/rand"
+	"errors"
+	"hash"
+	"math/big"
+	"sort"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/twistededwards"
+)
+
+// batchEntry holds the parsed inputs of the verification equation of a signature
+type batchEntry struct {
+	R, A twistededwards.PointAffine
+	s, h big.Int
+}
+
+// BatchVerify verifies the eddsa signatures sigs[i] of msgs[i] by pubs[i] at once,
+// with a random linear combination of the verification equations checked with a single
+// multi-scalar multiplication
+//
+// cofactor⋅((∑ zᵢsᵢ)⋅Base - ∑ zᵢ⋅Rᵢ - ∑ zᵢhᵢ⋅Aᵢ) ?= 0, zᵢ random on 128 bits.
+//
+// If the batch is invalid, it is split recursively to find the invalid signatures, whose
+// indices are returned in increasing order. A signature that can't be decoded is invalid.
+func BatchVerify(pubs []PublicKey, msgs [][]byte, sigs [][]byte, hFunc hash.Hash) (bool, []int, error) {
+
+	// hFunc cannot be nil.
+	// We need a hash function for the Fiat-Shamir.
+	if hFunc == nil {
+		return false, nil, errHashNeeded
+	}
+	if len(pubs) != len(msgs) || len(pubs) != len(sigs) {
+		return false, nil, errors.New("number of public keys, messages and signatures differ")
+	}
+
+	var invalid []int
+	entries := make([]batchEntry, 0, len(pubs))
+	indices := make([]int, 0, len(pubs))
+	for i := range pubs {
+		var sig Signature
+		if !pubs[i].A.IsOnCurve() {
+			invalid = append(invalid, i)
+			continue
+		}
+		if _, err := sig.SetBytes(sigs[i]); err != nil {
+			invalid = append(invalid, i)
+			continue
+		}
+
+		// compute H(R, A, M) as in Verify
+		hFunc.Reset()
+		sigRX := sig.R.X.Bytes()
+		sigRY := sig.R.Y.Bytes()
+		sigAX := pubs[i].A.X.Bytes()
+		sigAY := pubs[i].A.Y.Bytes()
+		toWrite := [][]byte{sigRX[:], sigRY[:], sigAX[:], sigAY[:], msgs[i]}
+		for _, bytes := range toWrite {
+			if _, err := hFunc.Write(bytes); err != nil {
+				return false, nil, err
+			}
+		}
+
+		var e batchEntry
+		e.R.Set(&sig.R)
+		e.A.Set(&pubs[i].A)
+		e.s.SetBytes(sig.S[:])
+		e.h.SetBytes(hFunc.Sum(nil))
+		entries = append(entries, e)
+		indices = append(indices, i)
+	}
+
+	var err error
+	if invalid, err = findInvalid(entries, indices, invalid); err != nil {
+		return false, nil, err
+	}
+	if len(invalid) != 0 {
+		sort.Ints(invalid)
+		return false, invalid, nil
+	}
+	return true, nil, nil
+}
+
+// findInvalid appends to invalid the indices of the invalid entries, splitting the
+// batch in halves while it fails
+func findInvalid(entries []batchEntry, indices, invalid []int) ([]int, error) {
+	if len(entries) == 0 {
+		return invalid, nil
+	}
+	ok, err := batchCheck(entries)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return invalid, nil
+	}
+	if len(entries) == 1 {
+		return append(invalid, indices[0]), nil
+	}
+	m := len(entries) / 2
+	if invalid, err = findInvalid(entries[:m], indices[:m], invalid); err != nil {
+		return nil, err
+	}
+	return findInvalid(entries[m:], indices[m:], invalid)
+}
+
+// batchCheck checks a random linear combination of the verification equations
+func batchCheck(entries []batchEntry) (bool, error) {
+	curveParams := twistededwards.GetEdwardsCurve()
+	order := &curveParams.Order
+
+	n := len(entries)
+	points := make([]twistededwards.PointAffine, 2*n+1)
+	scalars := make([]big.Int, 2*n+1)
+	points[2*n].Set(&curveParams.Base)
+
+	var buf [16]byte
+	var z, t big.Int
+	for i := range entries {
+		if _, err := rand.Read(buf[:]); err != nil {
+			return false, err
+		}
+		z.SetBytes(buf[:])
+
+		// zᵢsᵢ accumulated on the base point
+		t.Mul(&z, &entries[i].s)
+		scalars[2*n].Add(&scalars[2*n], &t)
+
+		// -zᵢ on Rᵢ and -zᵢhᵢ on Aᵢ
+		points[2*i].Set(&entries[i].R)
+		scalars[2*i].Sub(order, &z)
+		points[2*i+1].Set(&entries[i].A)
+		t.Mul(&z, &entries[i].h).Mod(&t, order)
+		scalars[2*i+1].Sub(order, &t)
+	}
+	scalars[2*n].Mod(&scalars[2*n], order)
+
+	var res twistededwards.PointExtended
+	if _, err := res.MultiExp(points, scalars, ecc.MultiExpConfig{}); err != nil {
+		return false, err
+	}
+
+	// the points may have a component of small order
+	var cofactor big.Int
+	curveParams.Cofactor.BigInt(&cofactor)
+	res.ScalarMultiplication(&res, &cofactor)
+
+	return res.IsZero(), nil
+}
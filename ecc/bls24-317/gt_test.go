@@ -0,0 +1,229 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls24317
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+)
+
+// randomGTElement returns e(g₁, g₂)ˢ for a random s
+func randomGTElement(t *testing.T) GTElement {
+	_, _, g1, g2 := Generators()
+	e, err := PairGT([]G1Affine{g1}, []G2Affine{g2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s fr.Element
+	var bi big.Int
+	s.SetRandom()
+	e.Exp(&e, s.BigInt(&bi))
+	return e
+}
+
+func TestGTElementSerialization(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 5; i++ {
+		e := randomGTElement(t)
+
+		b := e.Bytes()
+		var d GTElement
+		n, err := d.SetBytes(b[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != SizeOfGTElementCompressed || !d.Equal(&e) {
+			t.Fatal("compressed round trip failed")
+		}
+
+		r := e.RawBytes()
+		n, err = d.SetBytes(r[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != SizeOfGT || !d.Equal(&e) {
+			t.Fatal("uncompressed round trip failed")
+		}
+	}
+
+	// neutral element
+	var one, d GTElement
+	one.SetOne()
+	b := one.Bytes()
+	if _, err := d.SetBytes(b[:]); err != nil {
+		t.Fatal(err)
+	}
+	if !d.IsOne() {
+		t.Fatal("neutral element round trip failed")
+	}
+
+	// an element of the extension field outside of GT is rejected
+	var z GT
+	z.SetRandom()
+	if _, err := d.SetGT(&z); err != ErrGTNotInSubGroup {
+		t.Fatal("expected ErrGTNotInSubGroup")
+	}
+	r := z.Bytes()
+	if _, err := d.SetBytes(r[:]); err != ErrGTNotInSubGroup {
+		t.Fatal("expected ErrGTNotInSubGroup")
+	}
+
+	// truncated encodings are rejected
+	e := randomGTElement(t)
+	b = e.Bytes()
+	if _, err := d.SetBytes(b[:SizeOfGTElementCompressed-1]); err != ErrGTInvalidEncoding {
+		t.Fatal("expected ErrGTInvalidEncoding")
+	}
+}
+
+func TestGTElementArithmetic(t *testing.T) {
+	t.Parallel()
+
+	_, _, g1, g2 := Generators()
+	base, err := PairGT([]G1Affine{g1}, []G2Affine{g2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var a, b fr.Element
+	var ab big.Int
+	a.SetRandom()
+	b.SetRandom()
+	var aInt, bInt big.Int
+	a.BigInt(&aInt)
+	b.BigInt(&bInt)
+
+	// e([a]g₁, [b]g₂) == e(g₁, g₂)ᵃᵇ
+	var P G1Affine
+	var Q G2Affine
+	P.ScalarMultiplication(&g1, &aInt)
+	Q.ScalarMultiplication(&g2, &bInt)
+	lhs, err := PairGT([]G1Affine{P}, []G2Affine{Q})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rhs GTElement
+	ab.Mul(&aInt, &bInt)
+	rhs.Exp(&base, &ab)
+	if !lhs.Equal(&rhs) {
+		t.Fatal("bilinearity check failed")
+	}
+
+	// e(g₁, g₂)ᵃ ⋅ e(g₁, g₂)ᵇ == e(g₁, g₂)ᵃ⁺ᵇ
+	var ea, eb, eab GTElement
+	ea.Exp(&base, &aInt)
+	eb.Exp(&base, &bInt)
+	ea.Mul(&ea, &eb)
+	ab.Add(&aInt, &bInt)
+	eab.Exp(&base, &ab)
+	if !ea.Equal(&eab) {
+		t.Fatal("Mul/Exp check failed")
+	}
+
+	// e(g₁, g₂)⁻ᵃ ⋅ e(g₁, g₂)ᵃ == 1
+	ea.Exp(&base, &aInt)
+	ab.Neg(&aInt)
+	eab.Exp(&base, &ab)
+	eb.Inverse(&ea)
+	if !eb.Equal(&eab) {
+		t.Fatal("Inverse check failed")
+	}
+	eb.Mul(&eb, &ea)
+	if !eb.IsOne() {
+		t.Fatal("a⋅a⁻¹ != 1")
+	}
+
+	// Hash depends on the element
+	h1, err := base.Hash([]byte("dst"), 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h2, err := ea.Hash([]byte("dst"), 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(h1, h2) {
+		t.Fatal("distinct elements hash to the same value")
+	}
+}
+
+func TestGTElementEncoder(t *testing.T) {
+	t.Parallel()
+
+	e := randomGTElement(t)
+	s := []GTElement{randomGTElement(t), randomGTElement(t)}
+	z := e.GT()
+	zs := []GT{s[0].GT(), s[1].GT()}
+
+	for _, raw := range []bool{false, true} {
+		var buf bytes.Buffer
+		var enc *Encoder
+		if raw {
+			enc = NewEncoder(&buf, RawEncoding())
+		} else {
+			enc = NewEncoder(&buf)
+		}
+		for _, v := range []interface{}{&e, s, &z, zs} {
+			if err := enc.Encode(v); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		var (
+			de  GTElement
+			ds  []GTElement
+			dz  GT
+			dzs []GT
+		)
+		dec := NewDecoder(&buf)
+		for _, v := range []interface{}{&de, &ds, &dz, &dzs} {
+			if err := dec.Decode(v); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if dec.BytesRead() != enc.BytesWritten() {
+			t.Fatal("bytes read != bytes written")
+		}
+		if !de.Equal(&e) || len(ds) != len(s) || !ds[0].Equal(&s[0]) || !ds[1].Equal(&s[1]) {
+			t.Fatal("GTElement round trip failed")
+		}
+		if !dz.Equal(&z) || len(dzs) != len(zs) || !dzs[0].Equal(&zs[0]) || !dzs[1].Equal(&zs[1]) {
+			t.Fatal("GT round trip failed")
+		}
+	}
+}
+
+func BenchmarkGTElementBytes(b *testing.B) {
+	_, _, g1, g2 := Generators()
+	e, _ := PairGT([]G1Affine{g1}, []G2Affine{g2})
+	buf := e.Bytes()
+	b.Run("compress", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			e.Bytes()
+		}
+	})
+	b.Run("decompress", func(b *testing.B) {
+		var d GTElement
+		for i := 0; i < b.N; i++ {
+			d.SetBytes(buf[:])
+		}
+	})
+}
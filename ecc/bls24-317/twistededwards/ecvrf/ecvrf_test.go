@@ -0,0 +1,161 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecvrf
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"hash"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	gcHash "github.com/consensys/gnark-crypto/hash"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+const (
+	nbFuzzShort = 10
+	nbFuzz      = 100
+)
+
+func TestECVRF(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+	properties := gopter.NewProperties(parameters)
+
+	hashes := []struct {
+		name  string
+		hFunc func() hash.Hash
+	}{
+		{"MiMC", gcHash.MIMC_BLS24_317.New},
+		{"SHA-256", sha256.New},
+	}
+	for _, h := range hashes {
+		hFunc := h.hFunc
+
+		properties.Property("[BLS24-317] "+h.name+": a proof should verify and give the output of ProofToHash", prop.ForAll(
+			func(a0, a1 fr.Element) bool {
+				privKey, _ := GenerateKey(rand.Reader)
+				b0, b1 := a0.Bytes(), a1.Bytes()
+				alpha := append(b0[:], b1[:]...)
+
+				pi, err := privKey.Prove(alpha, hFunc())
+				if err != nil || len(pi) != SizeProof {
+					return false
+				}
+				beta, err := privKey.PublicKey.Verify(pi, alpha, hFunc())
+				if err != nil {
+					return false
+				}
+				beta2, err := ProofToHash(pi, hFunc())
+				if err != nil {
+					return false
+				}
+				// the proof is deterministic
+				pi2, _ := privKey.Prove(alpha, hFunc())
+				return bytes.Equal(beta, beta2) && bytes.Equal(pi, pi2)
+			},
+			genFr(),
+			genFr(),
+		))
+
+		properties.Property("[BLS24-317] "+h.name+": a proof should not verify for another input or another key", prop.ForAll(
+			func(a0, a1 fr.Element) bool {
+				privKey, _ := GenerateKey(rand.Reader)
+				other, _ := GenerateKey(rand.Reader)
+				alpha, alpha2 := a0.Bytes(), a1.Bytes()
+				if a0.Equal(&a1) {
+					return true
+				}
+				pi, _ := privKey.Prove(alpha[:], hFunc())
+				if _, err := privKey.PublicKey.Verify(pi, alpha2[:], hFunc()); err != ErrInvalidProof {
+					return false
+				}
+				if _, err := other.PublicKey.Verify(pi, alpha[:], hFunc()); err != ErrInvalidProof {
+					return false
+				}
+				// tampered c
+				pi[sizePoint] ^= 1
+				_, err := privKey.PublicKey.Verify(pi, alpha[:], hFunc())
+				return err == ErrInvalidProof
+			},
+			genFr(),
+			genFr(),
+		))
+	}
+
+	properties.Property("[BLS24-317] serialization: SetBytes(Bytes()) should stay the same", prop.ForAll(
+		func() bool {
+			privKey, _ := GenerateKey(rand.Reader)
+			var end PrivateKey
+			n, err := end.SetBytes(privKey.Bytes())
+			if err != nil || n != sizePrivateKey {
+				return false
+			}
+			var pk PublicKey
+			if _, err := pk.SetBytes(privKey.PublicKey.Bytes()); err != nil {
+				return false
+			}
+			return end == *privKey && pk.Y.Equal(&privKey.PublicKey.Y)
+		},
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func genFr() gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		var e fr.Element
+		e.SetRandom()
+		return gopter.NewGenResult(e, gopter.NoShrinker)
+	}
+}
+
+// ------------------------------------------------------------
+// benches
+
+func BenchmarkProve(b *testing.B) {
+	privKey, _ := GenerateKey(rand.Reader)
+	hFunc := gcHash.MIMC_BLS24_317.New()
+	var alpha fr.Element
+	alpha.SetRandom()
+	alphaBytes := alpha.Bytes()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		privKey.Prove(alphaBytes[:], hFunc)
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	privKey, _ := GenerateKey(rand.Reader)
+	hFunc := gcHash.MIMC_BLS24_317.New()
+	var alpha fr.Element
+	alpha.SetRandom()
+	alphaBytes := alpha.Bytes()
+	pi, _ := privKey.Prove(alphaBytes[:], hFunc)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		privKey.PublicKey.Verify(pi, alphaBytes[:], hFunc)
+	}
+}
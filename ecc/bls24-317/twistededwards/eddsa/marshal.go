@@ -99,8 +99,8 @@ func (privKey *PrivateKey) SetBytes(buf []byte) (int, error) {
 	}
 	subtle.ConstantTimeCopy(1, privKey.scalar[:], buf[sizeFr:2*sizeFr])
 	n += sizeFr
-	subtle.ConstantTimeCopy(1, privKey.randSrc[:], buf[2*sizeFr:])
-	n += sizeFr
+	subtle.ConstantTimeCopy(1, privKey.randSrc[:], buf[2*sizeFr:sizePrivateKey])
+	n += len(privKey.randSrc)
 	return n, nil
 }
 
@@ -17,6 +17,7 @@
 package ecdsa
 
 import (
+	"bytes"
 	"crypto/rand"
 	"crypto/sha256"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
@@ -62,6 +63,28 @@ func TestECDSA(t *testing.T) {
 		},
 	))
 
+	properties.Property("[BN254] test the deterministic (RFC 6979) low-s signing and verification", prop.ForAll(
+		func() bool {
+
+			privKey, _ := GenerateKey(rand.Reader)
+			publicKey := privKey.PublicKey
+
+			msg := []byte("testing ECDSA")
+			sig1, _ := privKey.SignWithOptions(msg, sha256.New(), WithRFC6979(sha256.New), WithLowS())
+			sig2, _ := privKey.SignWithOptions(msg, sha256.New(), WithRFC6979(sha256.New), WithLowS())
+			if !bytes.Equal(sig1, sig2) {
+				return false
+			}
+			var sig Signature
+			if _, err := sig.SetBytes(sig1); err != nil || !sig.IsLowS() {
+				return false
+			}
+			flag, _ := publicKey.Verify(sig1, msg, sha256.New())
+
+			return flag
+		},
+	))
+
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 func TestRecoverPublicKey(t *testing.T) {
@@ -87,6 +110,35 @@ func TestRecoverPublicKey(t *testing.T) {
 			return pk.Equal(&recovered)
 		},
 	))
+	properties.Property("[BN254] test public key recover from r||s||v, with low-s normalization", prop.ForAll(
+		func() bool {
+			sk, err := GenerateKey(rand.Reader)
+			if err != nil {
+				return false
+			}
+			pk := sk.PublicKey
+			msg := []byte("test")
+			buf, err := sk.SignRecoverable(msg, nil)
+			if err != nil {
+				return false
+			}
+			var sig RecoverableSignature
+			if _, err = sig.SetBytes(buf); err != nil {
+				return false
+			}
+			sig.Normalize()
+			if !sig.IsLowS() {
+				return false
+			}
+			r := new(big.Int).SetBytes(sig.R[:])
+			s := new(big.Int).SetBytes(sig.S[:])
+			var recovered PublicKey
+			if err = recovered.RecoverFrom(msg, uint(sig.V), r, s); err != nil {
+				return false
+			}
+			return pk.Equal(&recovered)
+		},
+	))
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
@@ -18,6 +18,7 @@ package ecdsa
 
 import (
 	"crypto/subtle"
+	"encoding/asn1"
 	"errors"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 	"io"
@@ -30,6 +31,10 @@ var errWrongSize = errors.New("wrong size buffer")
 var errRBiggerThanRMod = errors.New("r >= r_mod")
 var errSBiggerThanRMod = errors.New("s >= r_mod")
 var errZero = errors.New("zero value")
+var errInvalidDER = errors.New("invalid DER encoding")
+var errWrongRecoveryID = errors.New("recovery id > 3")
+
+const sizeRecoverableSignature = sizeSignature + 1
 
 // Bytes returns the binary representation of the public key
 // follows https://tools.ietf.org/html/rfc8032#section-3.1
@@ -58,7 +63,7 @@ func (pk *PublicKey) SetBytes(buf []byte) (int, error) {
 	if _, err := pk.A.SetBytes(buf[:sizePublicKey]); err != nil {
 		return 0, err
 	}
-	n += sizeFp
+	n += sizePublicKey
 	return n, nil
 }
 
@@ -164,3 +169,115 @@ func (sig *Signature) SetBytes(buf []byte) (int, error) {
 	n += sizeFr
 	return n, nil
 }
+
+// derSignature is the ASN.1 structure of an ECDSA signature
+//
+//	ECDSA-Sig-Value ::= SEQUENCE { r INTEGER, s INTEGER }
+//
+// SEC 1, Version 2.0, Section C.5
+type derSignature struct {
+	R, S *big.Int
+}
+
+// BytesDER returns the ASN.1 DER encoding of sig,
+// as used in X.509 certificates, TLS and Bitcoin transactions.
+func (sig *Signature) BytesDER() ([]byte, error) {
+	return asn1.Marshal(derSignature{
+		R: new(big.Int).SetBytes(sig.R[:]),
+		S: new(big.Int).SetBytes(sig.S[:]),
+	})
+}
+
+// SetBytesDER sets sig from its ASN.1 DER encoding in buf.
+// Non canonical encodings, trailing bytes and out of range r, s are rejected.
+// It returns the number of bytes read from buf.
+func (sig *Signature) SetBytesDER(buf []byte) (int, error) {
+	var d derSignature
+	rest, err := asn1.Unmarshal(buf, &d)
+	if err != nil || len(rest) != 0 {
+		return 0, errInvalidDER
+	}
+
+	// 0 < R, S < R_mod
+	frMod := fr.Modulus()
+	if d.R.Sign() <= 0 || d.S.Sign() <= 0 {
+		return 0, errZero
+	}
+	if d.R.Cmp(frMod) != -1 {
+		return 0, errRBiggerThanRMod
+	}
+	if d.S.Cmp(frMod) != -1 {
+		return 0, errSBiggerThanRMod
+	}
+
+	d.R.FillBytes(sig.R[:sizeFr])
+	d.S.FillBytes(sig.S[:sizeFr])
+	return len(buf), nil
+}
+
+// IsLowS reports whether s ≤ order/2.
+func (sig *Signature) IsLowS() bool {
+	s := new(big.Int).SetBytes(sig.S[:])
+	return s.Cmp(halfOrder) <= 0
+}
+
+// Normalize replaces s by order - s if s > order/2, so that sig is the low-s
+// form of the signature (BIP-62, EIP-2). Both forms verify.
+// It returns true if s was changed.
+func (sig *Signature) Normalize() bool {
+	s := new(big.Int).SetBytes(sig.S[:])
+	if s.Cmp(halfOrder) <= 0 {
+		return false
+	}
+	s.Sub(order, s)
+	s.FillBytes(sig.S[:sizeFr])
+	return true
+}
+
+// RecoverableSignature represents an ECDSA signature with the public key
+// recovery information v, as returned by SignRecoverable.
+//
+// V is the raw recovery id in [0, 3] (see SignForRecover), legacy
+// Ethereum encodings which add 27 must be converted by the caller.
+type RecoverableSignature struct {
+	Signature
+	V byte
+}
+
+// Bytes returns the binary representation of sig
+// as a byte array of size 2*sizeFr+1 r||s||v
+func (sig *RecoverableSignature) Bytes() []byte {
+	var res [sizeRecoverableSignature]byte
+	copy(res[:sizeSignature], sig.Signature.Bytes())
+	res[sizeSignature] = sig.V
+	return res[:]
+}
+
+// SetBytes sets sig from a buffer in binary.
+// buf is read interpreted as r||s||v
+// It returns the number of bytes read from buf.
+func (sig *RecoverableSignature) SetBytes(buf []byte) (int, error) {
+	if len(buf) != sizeRecoverableSignature {
+		return 0, errWrongSize
+	}
+	if buf[sizeSignature] > 3 {
+		return 0, errWrongRecoveryID
+	}
+	n, err := sig.Signature.SetBytes(buf[:sizeSignature])
+	if err != nil {
+		return 0, err
+	}
+	sig.V = buf[sizeSignature]
+	return n + 1, nil
+}
+
+// Normalize replaces s by order - s if s > order/2 and flips the parity of the
+// recovery id accordingly, so that the public key recovered is unchanged.
+// It returns true if sig was changed.
+func (sig *RecoverableSignature) Normalize() bool {
+	if !sig.Signature.Normalize() {
+		return false
+	}
+	sig.V ^= 1
+	return true
+}
@@ -97,6 +97,26 @@ func (z *Element) Mul(x, y *Element) *Element {
 	return z
 }
 
+func addVec(res, a, b Vector) {
+	addVecGeneric(res, a, b)
+}
+
+func subVec(res, a, b Vector) {
+	subVecGeneric(res, a, b)
+}
+
+func scalarMulVec(res, a Vector, b *Element) {
+	scalarMulVecGeneric(res, a, b)
+}
+
+func mulVec(res, a, b Vector) {
+	mulVecGeneric(res, a, b)
+}
+
+func butterflyVec(a, b Vector) {
+	butterflyVecGeneric(a, b)
+}
+
 // Square z = x * x (mod q)
 //
 // x must be less than q
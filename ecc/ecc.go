@@ -14,7 +14,7 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-// Package ecc provides bls12-381, bls12-377, bls12-378, bn254, bw6-761, bls24-315, bls24-317, bw6-633, bls12-378, bw6-756, secp256k1 and stark-curve elliptic curves implementation (+pairing).
+// Package ecc provides bls12-381, bls12-377, bls12-378, bn254, bw6-761, bls24-315, bls24-317, bw6-633, bls12-378, bw6-756, secp256k1, stark-curve, grumpkin, pallas and vesta elliptic curves implementation (+pairing).
 //
 // Also
 //
@@ -51,11 +51,16 @@ const (
 	BW6_756
 	STARK_CURVE
 	SECP256K1
+	GRUMPKIN
+	PALLAS
+	VESTA
+	ED25519
+	SECP256R1
 )
 
 // Implemented return the list of curves fully implemented in gnark-crypto
 func Implemented() []ID {
-	return []ID{BN254, BLS12_377, BLS12_381, BW6_761, BLS24_315, BW6_633, BLS12_378, BW6_756, BLS24_317, STARK_CURVE, SECP256K1}
+	return []ID{BN254, BLS12_377, BLS12_381, BW6_761, BLS24_315, BW6_633, BLS12_378, BW6_756, BLS24_317, STARK_CURVE, SECP256K1, GRUMPKIN, PALLAS, VESTA, ED25519, SECP256R1}
 }
 
 func IDFromString(s string) (ID, error) {
@@ -110,6 +115,16 @@ func (id ID) config() *config.Curve {
 		return &config.STARK_CURVE
 	case SECP256K1:
 		return &config.SECP256K1
+	case GRUMPKIN:
+		return &config.GRUMPKIN
+	case PALLAS:
+		return &config.PALLAS
+	case VESTA:
+		return &config.VESTA
+	case ED25519:
+		return &config.ED25519
+	case SECP256R1:
+		return &config.SECP256R1
 	default:
 		panic("unimplemented ecc ID")
 	}
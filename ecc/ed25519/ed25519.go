@@ -0,0 +1,40 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ed25519 efficient elliptic curve implementation for Ed25519, the twisted Edwards form of Curve25519 (RFC 7748, RFC 8032).
+//
+// ed25519: a twisted Edwards curve with
+//
+//	𝔽r: r=7237005577332262213973186563042994240857116359379907606001950938285454250989 (ℓ = 2²⁵²+27742317777372353535851937790883648493)
+//	𝔽p: p=57896044618658097711785492504343953926634992332820282019728792003956564819949 (2²⁵⁵-19)
+//	(E/𝔽p): -x²+y²=1+d⋅x²y², d=-121665/121666
+//	#E(𝔽p) = 8⋅r
+//
+// Points are encoded following RFC 8032: y in little endian with the parity of x in the most
+// significant bit. The sub-packages implement the EdDSA signature scheme of RFC 8032 (eddsa), the
+// X25519 Diffie-Hellman function on the Montgomery form of the curve (x25519) and the prime order
+// group Ristretto255 of RFC 9496 (ristretto255).
+//
+// Security: estimated 126-bit level using Pollard's \rho attack
+// (r is 253 bits)
+//
+// # Warning
+//
+// This code has not been audited and is provided as-is. In particular, there is no security guarantees such as constant time implementation or side-channel attack resistance.
+package ed25519
+
+import "github.com/consensys/gnark-crypto/ecc"
+
+// ID ed25519 ID
+const ID = ecc.ED25519
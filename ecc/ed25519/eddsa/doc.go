@@ -0,0 +1,24 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package eddsa provides the EdDSA signature scheme Ed25519 of RFC 8032 on ed25519,
+// interoperable with crypto/ed25519.
+//
+// Signatures are verified with the cofactored equation [8][S]B = [8]R + [8][k]A, which
+// RFC 8032 permits and which keeps single and batch verification consistent.
+//
+// # See also
+//
+// https://www.rfc-editor.org/rfc/rfc8032
+package eddsa
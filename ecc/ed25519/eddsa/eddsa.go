@@ -0,0 +1,356 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eddsa
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/subtle"
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+	"sort"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/ed25519"
+	"github.com/consensys/gnark-crypto/ecc/ed25519/fr"
+	"github.com/consensys/gnark-crypto/signature"
+)
+
+var errPrehashSize = errors.New("hFunc must output 64 bytes: Ed25519ph pre-hashes with SHA-512")
+
+const (
+	sizeFr         = fr.Bytes
+	SeedSize       = 32
+	sizePublicKey  = sizeFr
+	sizeSignature  = 2 * sizeFr
+	sizePrivateKey = SeedSize + sizePublicKey
+)
+
+// dom2(1, "") of RFC 8032, prefixing the hashes of Ed25519ph
+var dom2 = []byte("SigEd25519 no Ed25519 collisions\x01\x00")
+
+// PublicKey eddsa signature object
+// cf https://en.wikipedia.org/wiki/EdDSA for notation
+type PublicKey struct {
+	A ed25519.PointAffine
+}
+
+// PrivateKey private key of an eddsa instance
+type PrivateKey struct {
+	PublicKey PublicKey      // copy of the associated public key
+	seed      [SeedSize]byte // the private key of RFC 8032
+	scalar    fr.Element     // clamped secret scalar, mod ℓ
+	prefix    [32]byte       // second half of SHA-512(seed), source of the nonces
+}
+
+// Signature represents an eddsa signature
+// cf https://en.wikipedia.org/wiki/EdDSA for notation
+type Signature struct {
+	R ed25519.PointAffine
+	S [sizeFr]byte // in little endian
+}
+
+// GenerateKey generates a public and private key pair.
+func GenerateKey(r io.Reader) (*PrivateKey, error) {
+	seed := make([]byte, SeedSize)
+	if _, err := io.ReadFull(r, seed); err != nil {
+		return nil, err
+	}
+	return NewKeyFromSeed(seed)
+}
+
+// NewKeyFromSeed returns the key pair derived from seed, the 32 bytes private key of RFC 8032.
+func NewKeyFromSeed(seed []byte) (*PrivateKey, error) {
+	if len(seed) != SeedSize {
+		return nil, errWrongSize
+	}
+	var priv PrivateKey
+	copy(priv.seed[:], seed)
+
+	// https://tools.ietf.org/html/rfc8032#section-5.1.5, key generation
+	h := sha512.Sum512(seed)
+	copy(priv.prefix[:], h[32:])
+
+	// prune the key
+	h[0] &= 0xF8
+	h[31] &= 0x7F
+	h[31] |= 0x40
+
+	var a [sizeFr]byte
+	copy(a[:], h[:32])
+	priv.scalar = reduce(a[:])
+
+	var bScalar big.Int
+	priv.scalar.BigInt(&bScalar)
+	curveParams := ed25519.GetEdwardsCurve()
+	priv.PublicKey.A.ScalarMultiplication(&curveParams.Base, &bScalar)
+
+	return &priv, nil
+}
+
+// Seed returns the private key as defined in RFC 8032.
+func (privKey *PrivateKey) Seed() []byte {
+	res := make([]byte, SeedSize)
+	copy(res, privKey.seed[:])
+	return res
+}
+
+// Equal compares 2 public keys
+func (pub *PublicKey) Equal(x signature.PublicKey) bool {
+	xx, ok := x.(*PublicKey)
+	if !ok {
+		return false
+	}
+	bpk := pub.Bytes()
+	bxx := xx.Bytes()
+	return subtle.ConstantTimeCompare(bpk, bxx) == 1
+}
+
+// Public returns the public key associated to the private key.
+func (privKey *PrivateKey) Public() signature.PublicKey {
+	var pub PublicKey
+	pub.A.Set(&privKey.PublicKey.A)
+	return &pub
+}
+
+// Sign signs message following https://tools.ietf.org/html/rfc8032#section-5.1.6.
+//
+// If hFunc is nil, the message is signed as is (Ed25519). Else, the message is pre-hashed
+// with hFunc and the digest is signed with Ed25519ph, for which RFC 8032 mandates SHA-512.
+func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error) {
+	dom, msg, err := prehash(message, hFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	curveParams := ed25519.GetEdwardsCurve()
+
+	var res Signature
+
+	// r = SHA-512(dom || prefix || M) mod ℓ
+	h := sha512.New()
+	h.Write(dom)
+	h.Write(privKey.prefix[:])
+	h.Write(msg)
+	r := reduce(h.Sum(nil))
+
+	// R = [r]B
+	var bR big.Int
+	r.BigInt(&bR)
+	res.R.ScalarMultiplication(&curveParams.Base, &bR)
+
+	// S = r + k⋅s mod ℓ
+	k := challenge(dom, &res.R, &privKey.PublicKey.A, msg)
+	var s fr.Element
+	s.Mul(&k, &privKey.scalar).Add(&s, &r)
+	fr.LittleEndian.PutElement(&res.S, s)
+
+	return res.Bytes(), nil
+}
+
+// Verify verifies an eddsa signature, see Sign for the meaning of hFunc.
+//
+// It checks the cofactored equation [8][S]B = [8]R + [8][k]A, with k = SHA-512(dom || R || A || M).
+func (pub *PublicKey) Verify(sigBin, message []byte, hFunc hash.Hash) (bool, error) {
+	dom, msg, err := prehash(message, hFunc)
+	if err != nil {
+		return false, err
+	}
+
+	// Deserialize the signature
+	var sig Signature
+	if _, err := sig.SetBytes(sigBin); err != nil {
+		return false, err
+	}
+	s, _ := fr.LittleEndian.Element(&sig.S)
+	k := challenge(dom, &sig.R, &pub.A, msg)
+
+	// [S]B - R - [k]A
+	var minusOne, minusK fr.Element
+	minusOne.SetOne().Neg(&minusOne)
+	minusK.Neg(&k)
+
+	curveParams := ed25519.GetEdwardsCurve()
+	return isSmallOrder(
+		[]ed25519.PointAffine{curveParams.Base, sig.R, pub.A},
+		[]fr.Element{s, minusOne, minusK},
+	), nil
+}
+
+// batchEntry holds the terms of the verification equation of a signature
+type batchEntry struct {
+	R, A ed25519.PointAffine
+	s, k fr.Element
+}
+
+// BatchVerify verifies the signatures sigs[i] of msgs[i] under pubs[i], see Sign for the meaning of hFunc.
+//
+// It checks with a single multi-scalar multiplication that ∑ zᵢ⋅([8][Sᵢ]B - [8]Rᵢ - [8][kᵢ]Aᵢ) = 0 for random 128-bit zᵢ,
+// which holds if and only if each signature passes Verify, except with probability 2⁻¹²⁸.
+//
+// If the batch is invalid, it is split recursively to find the invalid signatures, whose
+// indices are returned in increasing order. A signature that can't be decoded is invalid.
+// It returns an error if the inputs are malformed.
+func BatchVerify(pubs []PublicKey, msgs, sigs [][]byte, hFunc hash.Hash) (bool, []int, error) {
+	n := len(pubs)
+	if len(msgs) != n || len(sigs) != n {
+		return false, nil, errors.New("len(pubs), len(msgs) and len(sigs) differ")
+	}
+
+	var invalid []int
+	entries := make([]batchEntry, 0, n)
+	indices := make([]int, 0, n)
+	for i := 0; i < n; i++ {
+		dom, msg, err := prehash(msgs[i], hFunc)
+		if err != nil {
+			return false, nil, err
+		}
+		var sig Signature
+		if _, err := sig.SetBytes(sigs[i]); err != nil {
+			invalid = append(invalid, i)
+			continue
+		}
+		var e batchEntry
+		e.R = sig.R
+		e.A = pubs[i].A
+		e.s, _ = fr.LittleEndian.Element(&sig.S)
+		e.k = challenge(dom, &sig.R, &pubs[i].A, msg)
+		entries = append(entries, e)
+		indices = append(indices, i)
+	}
+
+	var err error
+	if invalid, err = findInvalid(entries, indices, invalid); err != nil {
+		return false, nil, err
+	}
+	if len(invalid) != 0 {
+		sort.Ints(invalid)
+		return false, invalid, nil
+	}
+	return true, nil, nil
+}
+
+// findInvalid appends to invalid the indices of the invalid entries, splitting the
+// batch in halves while it fails
+func findInvalid(entries []batchEntry, indices, invalid []int) ([]int, error) {
+	if len(entries) == 0 {
+		return invalid, nil
+	}
+	ok, err := batchCheck(entries)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return invalid, nil
+	}
+	if len(entries) == 1 {
+		return append(invalid, indices[0]), nil
+	}
+	m := len(entries) / 2
+	if invalid, err = findInvalid(entries[:m], indices[:m], invalid); err != nil {
+		return nil, err
+	}
+	return findInvalid(entries[m:], indices[m:], invalid)
+}
+
+// batchCheck checks a random linear combination of the verification equations
+func batchCheck(entries []batchEntry) (bool, error) {
+	curveParams := ed25519.GetEdwardsCurve()
+
+	// points = B, R₀, A₀, R₁, A₁, ...
+	n := len(entries)
+	points := make([]ed25519.PointAffine, 1+2*n)
+	scalars := make([]fr.Element, 1+2*n)
+	points[0] = curveParams.Base
+
+	var zBytes [16]byte
+	var z, s fr.Element
+	for i := range entries {
+		if _, err := rand.Read(zBytes[:]); err != nil {
+			return false, err
+		}
+		z.SetBytes(zBytes[:])
+
+		// ∑ zᵢ⋅Sᵢ on B, -zᵢ on Rᵢ, -zᵢ⋅kᵢ on Aᵢ
+		s.Mul(&entries[i].s, &z)
+		scalars[0].Add(&scalars[0], &s)
+		points[1+2*i] = entries[i].R
+		scalars[1+2*i].Neg(&z)
+		points[2+2*i] = entries[i].A
+		scalars[2+2*i].Mul(&z, &entries[i].k).Neg(&scalars[2+2*i])
+	}
+
+	return isSmallOrder(points, scalars), nil
+}
+
+// isSmallOrder returns true if [8]∑ scalars[i]⋅points[i] is the identity.
+//
+// The multi-exponentiation reduces its scalars modulo ℓ, which only changes the result
+// by a point of small order (cancelled by the cofactor) when the points have a small
+// order component.
+func isSmallOrder(points []ed25519.PointAffine, scalars []fr.Element) bool {
+	bScalars := make([]big.Int, len(scalars))
+	for i := range scalars {
+		scalars[i].BigInt(&bScalars[i])
+	}
+	var res ed25519.PointExtended
+	if _, err := res.MultiExp(points, bScalars, ecc.MultiExpConfig{}); err != nil {
+		return false
+	}
+	res.Double(&res).Double(&res).Double(&res)
+	return res.IsZero()
+}
+
+// prehash returns the domain separator and the message to sign: (nil, message) for
+// Ed25519 and (dom2(1, ""), hFunc(message)) for Ed25519ph.
+func prehash(message []byte, hFunc hash.Hash) (dom, msg []byte, err error) {
+	if hFunc == nil {
+		return nil, message, nil
+	}
+	hFunc.Reset()
+	if _, err := hFunc.Write(message); err != nil {
+		return nil, nil, err
+	}
+	digest := hFunc.Sum(nil)
+	if len(digest) != sha512.Size {
+		return nil, nil, errPrehashSize
+	}
+	return dom2, digest, nil
+}
+
+// challenge returns k = SHA-512(dom || R || A || M) mod ℓ
+func challenge(dom []byte, R, A *ed25519.PointAffine, msg []byte) fr.Element {
+	h := sha512.New()
+	h.Write(dom)
+	bR := R.Bytes()
+	h.Write(bR[:])
+	bA := A.Bytes()
+	h.Write(bA[:])
+	h.Write(msg)
+	return reduce(h.Sum(nil))
+}
+
+// reduce interprets buf as a little endian integer and reduces it mod ℓ
+func reduce(buf []byte) fr.Element {
+	be := make([]byte, len(buf))
+	for i := range buf {
+		be[len(buf)-1-i] = buf[i]
+	}
+	var res fr.Element
+	res.SetBytes(be)
+	return res
+}
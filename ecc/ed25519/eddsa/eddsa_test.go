@@ -0,0 +1,302 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eddsa
+
+import (
+	"bytes"
+	"crypto"
+	stded25519 "crypto/ed25519"
+	crand "crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/ed25519/fr"
+)
+
+func Example() {
+	// create a eddsa key pair
+	privateKey, _ := GenerateKey(crand.Reader)
+	publicKey := privateKey.PublicKey
+
+	msg := []byte("message")
+
+	// sign the message (pure Ed25519: no pre-hash)
+	signature, _ := privateKey.Sign(msg, nil)
+
+	// verifies signature
+	isValid, _ := publicKey.Verify(signature, msg, nil)
+	if !isValid {
+		fmt.Println("1. invalid signature")
+	} else {
+		fmt.Println("1. valid signature")
+	}
+
+	// Output: 1. valid signature
+}
+
+func TestRFC8032(t *testing.T) {
+	// https://tools.ietf.org/html/rfc8032#section-7.1, TEST 1 and TEST 2
+	vectors := []struct {
+		seed, pub, msg, sig string
+	}{
+		{
+			"9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60",
+			"d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a",
+			"",
+			"e5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b",
+		},
+		{
+			"4ccd089b28ff96da9db6c346ec114e0f5b8a319f35aba624da8cf6ed4fb8a6fb",
+			"3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c",
+			"72",
+			"92a009a9f0d4cab8720e820b5f642540a2b27b5416503f8fb3762223ebdb69da085ac1e43e15996e458f3613d0f11d8c387b2eaeb4302aeeb00d291612bb0c00",
+		},
+	}
+	for i, v := range vectors {
+		seed, _ := hex.DecodeString(v.seed)
+		msg, _ := hex.DecodeString(v.msg)
+		priv, err := NewKeyFromSeed(seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(priv.PublicKey.Bytes()) != v.pub {
+			t.Fatalf("vector %d: wrong public key", i)
+		}
+		sig, err := priv.Sign(msg, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(sig) != v.sig {
+			t.Fatalf("vector %d: wrong signature", i)
+		}
+		if ok, err := priv.PublicKey.Verify(sig, msg, nil); !ok || err != nil {
+			t.Fatalf("vector %d: verification failed", i)
+		}
+	}
+}
+
+func TestCryptoEd25519(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		seed := make([]byte, SeedSize)
+		msg := make([]byte, i*7)
+		crand.Read(seed)
+		crand.Read(msg)
+
+		priv, err := NewKeyFromSeed(seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stdPriv := stded25519.NewKeyFromSeed(seed)
+		if !bytes.Equal(priv.Bytes(), stdPriv) {
+			t.Fatal("private keys differ")
+		}
+
+		// Ed25519
+		sig, err := priv.Sign(msg, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(sig, stded25519.Sign(stdPriv, msg)) {
+			t.Fatal("Ed25519 signatures differ")
+		}
+		if !stded25519.Verify(stdPriv.Public().(stded25519.PublicKey), msg, sig) {
+			t.Fatal("crypto/ed25519 rejects the signature")
+		}
+
+		// Ed25519ph
+		sig, err = priv.Sign(msg, sha512.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		digest := sha512.Sum512(msg)
+		stdSig, err := stdPriv.Sign(nil, digest[:], &stded25519.Options{Hash: crypto.SHA512})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(sig, stdSig) {
+			t.Fatal("Ed25519ph signatures differ")
+		}
+		if ok, err := priv.PublicKey.Verify(stdSig, msg, sha512.New()); !ok || err != nil {
+			t.Fatal("Ed25519ph verification failed")
+		}
+		if ok, _ := priv.PublicKey.Verify(stdSig, msg, nil); ok {
+			t.Fatal("Ed25519ph signature accepted as Ed25519")
+		}
+	}
+
+	// Ed25519ph needs a 64 bytes digest
+	priv, _ := GenerateKey(crand.Reader)
+	if _, err := priv.Sign([]byte("msg"), sha256.New()); err != errPrehashSize {
+		t.Fatal("expected errPrehashSize")
+	}
+}
+
+func TestNonMalleability(t *testing.T) {
+	priv, _ := GenerateKey(crand.Reader)
+	msg := []byte("message")
+	sig, _ := priv.Sign(msg, nil)
+
+	// buffer too big
+	t.Run("buffer_overflow", func(t *testing.T) {
+		var s Signature
+		if _, err := s.SetBytes(append(sig, 0)); err != errWrongSize {
+			t.Fatal("should raise wrong size error")
+		}
+	})
+
+	// S+ℓ encodes the same scalar
+	t.Run("S_overflow", func(t *testing.T) {
+		var s fr.Element
+		var buf [sizeFr]byte
+		copy(buf[:], sig[sizeFr:])
+		s, _ = fr.LittleEndian.Element(&buf)
+		var bS = s.BigInt(new(big.Int))
+		bS.Add(bS, fr.Modulus())
+		if bS.BitLen() > 8*sizeFr {
+			t.Skip("S+ℓ doesn't fit")
+		}
+		malleated := make([]byte, sizeSignature)
+		copy(malleated, sig[:sizeFr])
+		bS.FillBytes(buf[:])
+		for i := range buf {
+			malleated[sizeSignature-1-i] = buf[i]
+		}
+		if _, err := priv.PublicKey.Verify(malleated, msg, nil); err != errSBiggerThanRMod {
+			t.Fatal("should raise s >= r_mod error")
+		}
+	})
+
+	// y ≥ p is not a canonical encoding of R
+	t.Run("R_overflow", func(t *testing.T) {
+		malleated := make([]byte, sizeSignature)
+		copy(malleated, sig)
+		for i := 0; i < sizeFr; i++ {
+			malleated[i] = 0xff
+		}
+		malleated[sizeFr-1] = 0x7f
+		if ok, err := priv.PublicKey.Verify(malleated, msg, nil); ok || err == nil {
+			t.Fatal("should reject non-canonical R")
+		}
+	})
+}
+
+func TestBatchVerify(t *testing.T) {
+	const n = 20
+	pubs := make([]PublicKey, n)
+	msgs := make([][]byte, n)
+	sigs := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		priv, err := GenerateKey(crand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pubs[i] = priv.PublicKey
+		msgs[i] = []byte(fmt.Sprintf("message %d", i))
+		if sigs[i], err = priv.Sign(msgs[i], nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if ok, invalid, err := BatchVerify(pubs, msgs, sigs, nil); !ok || len(invalid) != 0 || err != nil {
+		t.Fatal("valid batch rejected")
+	}
+
+	// wrong message, wrong public key and undecodable signature
+	msgs[3], msgs[4] = msgs[4], msgs[3]
+	pubs[17] = pubs[16]
+	sigs[11] = sigs[11][1:]
+	ok, invalid, err := BatchVerify(pubs, msgs, sigs, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []int{3, 4, 11, 17}
+	if ok || fmt.Sprint(invalid) != fmt.Sprint(expected) {
+		t.Fatalf("expected invalid signatures %v, got %v", expected, invalid)
+	}
+
+	// mismatching lengths
+	if _, _, err := BatchVerify(pubs, msgs[1:], sigs, nil); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	priv, _ := GenerateKey(crand.Reader)
+
+	var priv2 PrivateKey
+	if _, err := priv2.SetBytes(priv.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if !priv.PublicKey.Equal(&priv2.PublicKey) || !bytes.Equal(priv.Seed(), priv2.Seed()) {
+		t.Fatal("private key round trip failed")
+	}
+
+	var pub PublicKey
+	if _, err := pub.SetBytes(priv.PublicKey.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if !pub.Equal(priv.Public()) {
+		t.Fatal("public key round trip failed")
+	}
+
+	// the public key must match the seed
+	buf := priv.Bytes()
+	buf[0] ^= 1
+	if _, err := priv2.SetBytes(buf); err != errWrongPublicKey {
+		t.Fatal("expected errWrongPublicKey")
+	}
+}
+
+// benchmarks
+
+func BenchmarkSign(b *testing.B) {
+	priv, _ := GenerateKey(crand.Reader)
+	msg := []byte("message")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		priv.Sign(msg, nil)
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	priv, _ := GenerateKey(crand.Reader)
+	msg := []byte("message")
+	sig, _ := priv.Sign(msg, nil)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		priv.PublicKey.Verify(sig, msg, nil)
+	}
+}
+
+func BenchmarkBatchVerify(b *testing.B) {
+	const n = 1024
+	pubs := make([]PublicKey, n)
+	msgs := make([][]byte, n)
+	sigs := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		priv, _ := GenerateKey(crand.Reader)
+		pubs[i] = priv.PublicKey
+		msgs[i] = []byte(fmt.Sprintf("message %d", i))
+		sigs[i], _ = priv.Sign(msgs[i], nil)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		BatchVerify(pubs, msgs, sigs, nil)
+	}
+}
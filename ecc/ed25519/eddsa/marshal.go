@@ -0,0 +1,110 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eddsa
+
+import (
+	"crypto/subtle"
+	"errors"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc/ed25519/fr"
+)
+
+var errWrongSize = errors.New("wrong size buffer")
+var errSBiggerThanRMod = errors.New("s >= r_mod")
+var errWrongPublicKey = errors.New("public key doesn't match the seed")
+
+// Bytes returns the binary representation of the public key
+// follows https://tools.ietf.org/html/rfc8032#section-5.1.2
+// and returns a compressed representation of the point (x,y)
+func (pk *PublicKey) Bytes() []byte {
+	pkBin := pk.A.Bytes()
+	return pkBin[:]
+}
+
+// SetBytes sets p from binary representation in buf,
+// a compressed point following https://tools.ietf.org/html/rfc8032#section-5.1.3.
+// It returns the number of bytes read from the buffer.
+func (pk *PublicKey) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizePublicKey {
+		return 0, io.ErrShortBuffer
+	}
+	return pk.A.SetBytes(buf[:sizePublicKey])
+}
+
+// Bytes returns the binary representation of the private key
+// as seed||publicKey, as crypto/ed25519 does, where seed is
+// the private key of RFC 8032 and publicKey is as publicKey.Bytes().
+func (privKey *PrivateKey) Bytes() []byte {
+	var res [sizePrivateKey]byte
+	pubkBin := privKey.PublicKey.A.Bytes()
+	subtle.ConstantTimeCopy(1, res[:SeedSize], privKey.seed[:])
+	subtle.ConstantTimeCopy(1, res[SeedSize:], pubkBin[:])
+	return res[:]
+}
+
+// SetBytes sets the private key from buf, interpreted as seed||publicKey.
+// The key pair is derived from the seed and must match publicKey.
+// It returns the number byte read.
+func (privKey *PrivateKey) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizePrivateKey {
+		return 0, io.ErrShortBuffer
+	}
+	priv, err := NewKeyFromSeed(buf[:SeedSize])
+	if err != nil {
+		return 0, err
+	}
+	pubkBin := priv.PublicKey.A.Bytes()
+	if subtle.ConstantTimeCompare(pubkBin[:], buf[SeedSize:sizePrivateKey]) != 1 {
+		return 0, errWrongPublicKey
+	}
+	*privKey = *priv
+	return sizePrivateKey, nil
+}
+
+// Bytes returns the binary representation of sig
+// as a byte array of size 2*sizeFr R||S where
+//   - R is a compressed point, as PointAffine.Bytes()
+//   - S=r+k⋅s mod ℓ in little endian
+func (sig *Signature) Bytes() []byte {
+	var res [sizeSignature]byte
+	sigRBin := sig.R.Bytes()
+	subtle.ConstantTimeCopy(1, res[:sizeFr], sigRBin[:])
+	subtle.ConstantTimeCopy(1, res[sizeFr:], sig.S[:])
+	return res[:]
+}
+
+// SetBytes sets sig from a buffer in binary, interpreted as R||S (see Bytes).
+// Following https://tools.ietf.org/html/rfc8032#section-5.1.7, it rejects non
+// canonical encodings of R and S ≥ ℓ (to avoid malleability).
+//
+// It returns the number of bytes read from buf.
+func (sig *Signature) SetBytes(buf []byte) (int, error) {
+	if len(buf) != sizeSignature {
+		return 0, errWrongSize
+	}
+
+	var s [sizeFr]byte
+	copy(s[:], buf[sizeFr:])
+	if _, err := fr.LittleEndian.Element(&s); err != nil {
+		return 0, errSBiggerThanRMod
+	}
+
+	if _, err := sig.R.SetBytes(buf[:sizeFr]); err != nil {
+		return 0, err
+	}
+	sig.S = s
+	return sizeSignature, nil
+}
@@ -0,0 +1,258 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fp
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestVectorSort(t *testing.T) {
+	assert := require.New(t)
+
+	v := make(Vector, 3)
+	v[0].SetUint64(2)
+	v[1].SetUint64(3)
+	v[2].SetUint64(1)
+
+	sort.Sort(v)
+
+	assert.Equal("[1,2,3]", v.String())
+}
+
+func TestVectorRoundTrip(t *testing.T) {
+	assert := require.New(t)
+
+	v1 := make(Vector, 3)
+	v1[0].SetUint64(2)
+	v1[1].SetUint64(3)
+	v1[2].SetUint64(1)
+
+	b, err := v1.MarshalBinary()
+	assert.NoError(err)
+
+	var v2, v3 Vector
+
+	err = v2.UnmarshalBinary(b)
+	assert.NoError(err)
+
+	err = v3.unmarshalBinaryAsync(b)
+	assert.NoError(err)
+
+	assert.True(reflect.DeepEqual(v1, v2))
+	assert.True(reflect.DeepEqual(v3, v2))
+}
+
+func TestVectorEmptyRoundTrip(t *testing.T) {
+	assert := require.New(t)
+
+	v1 := make(Vector, 0)
+
+	b, err := v1.MarshalBinary()
+	assert.NoError(err)
+
+	var v2, v3 Vector
+
+	err = v2.UnmarshalBinary(b)
+	assert.NoError(err)
+
+	err = v3.unmarshalBinaryAsync(b)
+	assert.NoError(err)
+
+	assert.True(reflect.DeepEqual(v1, v2))
+	assert.True(reflect.DeepEqual(v3, v2))
+}
+
+func TestVectorOps(t *testing.T) {
+	// sizes around the task boundaries, including 0
+	for _, n := range []int{0, 1, 2, 7, 64, 257} {
+		for _, nbTasks := range []int{1, 4} {
+			t.Run(fmt.Sprintf("n=%d/nbTasks=%d", n, nbTasks), func(t *testing.T) {
+				assert := require.New(t)
+				opt := WithNbTasks(nbTasks)
+
+				a, b := make(Vector, n), make(Vector, n)
+				for i := 0; i < n; i++ {
+					a[i].SetRandom()
+					b[i].SetRandom()
+				}
+				if n > 1 {
+					// edge cases
+					a[0].SetZero()
+					b[1].SetZero()
+				}
+				var s Element
+				s.SetRandom()
+
+				res := make(Vector, n)
+				expected := make(Vector, n)
+
+				res.Add(a, b, opt)
+				for i := 0; i < n; i++ {
+					expected[i].Add(&a[i], &b[i])
+				}
+				assert.True(reflect.DeepEqual(expected, res), "Add failed")
+
+				res.Sub(a, b, opt)
+				for i := 0; i < n; i++ {
+					expected[i].Sub(&a[i], &b[i])
+				}
+				assert.True(reflect.DeepEqual(expected, res), "Sub failed")
+
+				res.ScalarMul(a, &s, opt)
+				for i := 0; i < n; i++ {
+					expected[i].Mul(&a[i], &s)
+				}
+				assert.True(reflect.DeepEqual(expected, res), "ScalarMul failed")
+
+				res.Mul(a, b, opt)
+				var sum, ip Element
+				for i := 0; i < n; i++ {
+					expected[i].Mul(&a[i], &b[i])
+					ip.Add(&ip, &expected[i])
+					sum.Add(&sum, &a[i])
+				}
+				assert.True(reflect.DeepEqual(expected, res), "Mul failed")
+
+				assert.True(sum.Equal(ptr(a.Sum(opt))), "Sum failed")
+				assert.True(ip.Equal(ptr(a.InnerProduct(b, opt))), "InnerProduct failed")
+
+				res.BatchInvert(a, opt)
+				assert.True(reflect.DeepEqual(Vector(BatchInvert(a)), res), "BatchInvert failed")
+
+				// in place operations
+				copy(res, a)
+				res.Add(res, b, opt)
+				res.Sub(res, b, opt)
+				assert.True(reflect.DeepEqual(a, res), "in place Add/Sub failed")
+
+				copy(res, a)
+				c := make(Vector, n)
+				copy(c, b)
+				res.Butterfly(c, opt)
+				for i := 0; i < n; i++ {
+					expected[i].Add(&a[i], &b[i])
+				}
+				assert.True(reflect.DeepEqual(expected, res), "Butterfly failed")
+				for i := 0; i < n; i++ {
+					expected[i].Sub(&a[i], &b[i])
+				}
+				assert.True(reflect.DeepEqual(expected, c), "Butterfly failed")
+			})
+		}
+	}
+}
+
+func ptr(e Element) *Element {
+	return &e
+}
+
+func BenchmarkVectorOps(b *testing.B) {
+	const n = 1 << 20
+	a1, a2, a3 := make(Vector, n), make(Vector, n), make(Vector, n)
+	for i := 0; i < n; i++ {
+		a1[i].SetRandom()
+		a2[i].SetRandom()
+	}
+	var s Element
+	s.SetRandom()
+
+	// each operation is compared with its generic implementation: on amd64, for
+	// 4-word fields, the gap shows that the assembly kernels are used.
+	b.Run("add", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			a3.Add(a1, a2)
+		}
+	})
+	b.Run("add/generic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			addVecGeneric(a3, a1, a2)
+		}
+	})
+	b.Run("sub", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			a3.Sub(a1, a2)
+		}
+	})
+	b.Run("sub/generic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			subVecGeneric(a3, a1, a2)
+		}
+	})
+	b.Run("scalarMul", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			a3.ScalarMul(a1, &s)
+		}
+	})
+	b.Run("scalarMul/generic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			scalarMulVecGeneric(a3, a1, &s)
+		}
+	})
+	b.Run("mul", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			a3.Mul(a1, a2)
+		}
+	})
+	b.Run("mul/generic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			mulVecGeneric(a3, a1, a2)
+		}
+	})
+	b.Run("butterfly", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			a1.Butterfly(a2)
+		}
+	})
+	b.Run("butterfly/generic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			butterflyVecGeneric(a1, a2)
+		}
+	})
+	b.Run("innerProduct", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			a1.InnerProduct(a2)
+		}
+	})
+	b.Run("innerProduct/generic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var res, tmp Element
+			for j := 0; j < n; j++ {
+				tmp.Mul(&a1[j], &a2[j])
+				res.Add(&res, &tmp)
+			}
+		}
+	})
+	b.Run("innerProduct/parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			a1.InnerProduct(a2, WithNbTasks(0))
+		}
+	})
+}
+
+func (vector *Vector) unmarshalBinaryAsync(data []byte) error {
+	r := bytes.NewReader(data)
+	_, err, chErr := vector.AsyncReadFrom(r)
+	if err != nil {
+		return err
+	}
+	return <-chErr
+}
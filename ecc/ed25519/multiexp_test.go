@@ -0,0 +1,174 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ed25519
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+func randomPointsAndScalars(n int) ([]PointAffine, []big.Int) {
+	params := GetEdwardsCurve()
+	points := make([]PointAffine, n)
+	scalars := make([]big.Int, n)
+
+	var s big.Int
+	var p PointExtended
+	for i := 0; i < n; i++ {
+		r, err := rand.Int(rand.Reader, &params.Order)
+		if err != nil {
+			panic(err)
+		}
+		s.Set(r)
+		p.FromAffine(&params.Base)
+		p.ScalarMultiplication(&p, &s)
+		points[i].FromExtended(&p)
+
+		r, err = rand.Int(rand.Reader, &params.Order)
+		if err != nil {
+			panic(err)
+		}
+		scalars[i].Set(r)
+	}
+	return points, scalars
+}
+
+func naiveMultiExp(points []PointAffine, scalars []big.Int) PointAffine {
+	var acc, tmp PointExtended
+	acc.setInfinity()
+	for i := range points {
+		tmp.FromAffine(&points[i])
+		tmp.ScalarMultiplication(&tmp, &scalars[i])
+		acc.Add(&acc, &tmp)
+	}
+	var res PointAffine
+	res.FromExtended(&acc)
+	return res
+}
+
+func TestMultiExp(t *testing.T) {
+	t.Parallel()
+
+	params := GetEdwardsCurve()
+	sizes := []int{1, 2, 17, 128}
+	if !testing.Short() {
+		sizes = append(sizes, 1000)
+	}
+
+	for _, n := range sizes {
+		points, scalars := randomPointsAndScalars(n)
+
+		// edge cases: zero, one, the order and a scalar larger than the order
+		scalars[0].SetUint64(0)
+		if n > 1 {
+			scalars[1].SetUint64(1)
+		}
+		if n > 2 {
+			scalars[2].Set(&params.Order)
+			scalars[3].Add(&scalars[3], &params.Order)
+			// duplicated points fall in the same buckets
+			points[4] = points[3]
+		}
+
+		expected := naiveMultiExp(points, scalars)
+
+		for _, nbTasks := range []int{1, 0} {
+			var res PointExtended
+			_, err := res.MultiExp(points, scalars, ecc.MultiExpConfig{NbTasks: nbTasks})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var resAff PointAffine
+			resAff.FromExtended(&res)
+			if !resAff.Equal(&expected) {
+				t.Fatalf("MultiExp on %d points with %d tasks does not match naive multi-exponentiation", n, nbTasks)
+			}
+		}
+	}
+
+	t.Run("small scalars", func(t *testing.T) {
+		points, scalars := randomPointsAndScalars(50)
+		for i := range scalars {
+			scalars[i].SetUint64(uint64(i))
+		}
+		var res PointExtended
+		if _, err := res.MultiExp(points, scalars, ecc.MultiExpConfig{}); err != nil {
+			t.Fatal(err)
+		}
+		var resAff PointAffine
+		resAff.FromExtended(&res)
+		expected := naiveMultiExp(points, scalars)
+		if !resAff.Equal(&expected) {
+			t.Fatal("MultiExp with small scalars does not match naive multi-exponentiation")
+		}
+	})
+
+	t.Run("invalid inputs", func(t *testing.T) {
+		points, scalars := randomPointsAndScalars(3)
+		var res PointExtended
+		if _, err := res.MultiExp(points, scalars[:2], ecc.MultiExpConfig{}); err == nil {
+			t.Fatal("expected error on len(points) != len(scalars)")
+		}
+		if _, err := res.MultiExp(points, scalars, ecc.MultiExpConfig{NbTasks: 2048}); err == nil {
+			t.Fatal("expected error on invalid config")
+		}
+		if _, err := res.MultiExp(nil, nil, ecc.MultiExpConfig{}); err != nil || !res.IsZero() {
+			t.Fatal("expected empty multi-exponentiation to be the neutral element")
+		}
+	})
+}
+
+func TestBatchFromExtended(t *testing.T) {
+	t.Parallel()
+
+	params := GetEdwardsCurve()
+	points := make([]PointExtended, 20)
+	var base PointExtended
+	base.FromAffine(&params.Base)
+	points[0].setInfinity()
+	for i := 1; i < len(points); i++ {
+		points[i].Add(&points[i-1], &base)
+	}
+
+	result := BatchFromExtended(points)
+	for i := range points {
+		var expected PointAffine
+		expected.FromExtended(&points[i])
+		if !result[i].Equal(&expected) {
+			t.Fatal("BatchFromExtended does not match FromExtended")
+		}
+	}
+}
+
+func BenchmarkMultiExp(b *testing.B) {
+	const maxSize = 1 << 14
+	points, scalars := randomPointsAndScalars(maxSize)
+
+	var res PointExtended
+	for size := 1 << 6; size <= maxSize; size <<= 2 {
+		b.Run(fmt.Sprintf("%d points", size), func(b *testing.B) {
+			b.ResetTimer()
+			for j := 0; j < b.N; j++ {
+				res.MultiExp(points[:size], scalars[:size], ecc.MultiExpConfig{})
+			}
+		})
+	}
+}
@@ -0,0 +1,374 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ristretto255 implements the prime order group ristretto255 of RFC 9496,
+// built from the ed25519 curve.
+//
+// Each group element is represented by an ed25519 point of its coset of the 4-torsion; the
+// encoding is canonical, so that two elements are equal if and only if their encodings are.
+//
+// # See also
+//
+// https://www.rfc-editor.org/rfc/rfc9496
+package ristretto255
+
+import (
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/ed25519"
+	"github.com/consensys/gnark-crypto/ecc/ed25519/fp"
+)
+
+const (
+	// EncodedSize is the size of the encoding of an element, in bytes.
+	EncodedSize = fp.Bytes
+	// UniformSize is the size of the input of SetUniformBytes, in bytes.
+	UniformSize = 2 * fp.Bytes
+)
+
+var errInvalidEncoding = errors.New("invalid ristretto255 encoding")
+
+// constants of https://www.rfc-editor.org/rfc/rfc9496#section-4.1
+var (
+	d              fp.Element
+	sqrtM1         fp.Element
+	invSqrtAMinusD fp.Element
+	sqrtADMinusOne fp.Element
+	oneMinusDSq    fp.Element
+	dMinusOneSq    fp.Element
+
+	// (p-5)/8, exponent of the square roots
+	expSqrt big.Int
+)
+
+func init() {
+	params := ed25519.GetEdwardsCurve()
+	d.Set(&params.D)
+	sqrtM1.SetString("19681161376707505956807079304988542015446066515923890162744021073123829784752")
+	invSqrtAMinusD.SetString("54469307008909316920995813868745141605393597292927456921205312896311721017578")
+	sqrtADMinusOne.SetString("25063068953384623474111414158702152701244531502492656460079210482610430750235")
+	oneMinusDSq.SetString("1159843021668779879193775521855586647937357759715417654439879720876111806838")
+	dMinusOneSq.SetString("40440834346308536858101042469323190826248399146238708352240133220865137265952")
+
+	expSqrt.Sub(fp.Modulus(), big.NewInt(5))
+	expSqrt.Rsh(&expSqrt, 3)
+}
+
+// Element is an element of ristretto255
+type Element struct {
+	p ed25519.PointExtended
+}
+
+// Set sets e to a and returns e
+func (e *Element) Set(a *Element) *Element {
+	e.p.Set(&a.p)
+	return e
+}
+
+// SetIdentity sets e to the identity element and returns e
+func (e *Element) SetIdentity() *Element {
+	e.p.X.SetZero()
+	e.p.Y.SetOne()
+	e.p.Z.SetOne()
+	e.p.T.SetZero()
+	return e
+}
+
+// SetGenerator sets e to the generator of RFC 9496, the class of the ed25519 base point, and returns e
+func (e *Element) SetGenerator() *Element {
+	params := ed25519.GetEdwardsCurve()
+	e.p.FromAffine(&params.Base)
+	return e
+}
+
+// Equal returns true if e and a are the same element
+// (https://www.rfc-editor.org/rfc/rfc9496#section-4.5)
+func (e *Element) Equal(a *Element) bool {
+	var x1y2, y1x2, y1y2, x1x2 fp.Element
+	x1y2.Mul(&e.p.X, &a.p.Y)
+	y1x2.Mul(&e.p.Y, &a.p.X)
+	y1y2.Mul(&e.p.Y, &a.p.Y)
+	x1x2.Mul(&e.p.X, &a.p.X)
+	return x1y2.Equal(&y1x2) || y1y2.Equal(&x1x2)
+}
+
+// Add sets e = a + b and returns e
+func (e *Element) Add(a, b *Element) *Element {
+	e.p.Add(&a.p, &b.p)
+	return e
+}
+
+// Sub sets e = a - b and returns e
+func (e *Element) Sub(a, b *Element) *Element {
+	var nb ed25519.PointExtended
+	nb.Neg(&b.p)
+	e.p.Add(&a.p, &nb)
+	return e
+}
+
+// Neg sets e = -a and returns e
+func (e *Element) Neg(a *Element) *Element {
+	e.p.Neg(&a.p)
+	return e
+}
+
+// ScalarMultiplication sets e = [s]a and returns e
+func (e *Element) ScalarMultiplication(a *Element, s *big.Int) *Element {
+	e.p.ScalarMultiplication(&a.p, s)
+	return e
+}
+
+// MultiExp sets e = ∑ scalars[i]⋅elements[i] and returns e, see ed25519.PointExtended.MultiExp.
+//
+// This call return an error if len(scalars) != len(elements) or if provided config is invalid.
+func (e *Element) MultiExp(elements []Element, scalars []big.Int, config ecc.MultiExpConfig) (*Element, error) {
+	points := make([]ed25519.PointExtended, len(elements))
+	for i := range elements {
+		points[i] = elements[i].p
+	}
+	var res ed25519.PointExtended
+	if _, err := res.MultiExp(ed25519.BatchFromExtended(points), scalars, config); err != nil {
+		return nil, err
+	}
+	e.p = res
+	return e, nil
+}
+
+// Bytes returns the canonical encoding of e
+// (https://www.rfc-editor.org/rfc/rfc9496#section-4.3.2)
+func (e *Element) Bytes() [EncodedSize]byte {
+	x0, y0, z0, t0 := &e.p.X, &e.p.Y, &e.p.Z, &e.p.T
+
+	var u1, u2, tmp fp.Element
+	u1.Add(z0, y0)
+	tmp.Sub(z0, y0)
+	u1.Mul(&u1, &tmp)
+	u2.Mul(x0, y0)
+
+	// invsqrt = 1/√(u1⋅u2²)
+	var one, invSqrt fp.Element
+	one.SetOne()
+	tmp.Square(&u2).Mul(&tmp, &u1)
+	sqrtRatioM1(&invSqrt, &one, &tmp)
+
+	var den1, den2, zInv fp.Element
+	den1.Mul(&invSqrt, &u1)
+	den2.Mul(&invSqrt, &u2)
+	zInv.Mul(&den1, &den2).Mul(&zInv, t0)
+
+	var ix0, iy0, enchantedDenominator fp.Element
+	ix0.Mul(x0, &sqrtM1)
+	iy0.Mul(y0, &sqrtM1)
+	enchantedDenominator.Mul(&den1, &invSqrtAMinusD)
+
+	tmp.Mul(t0, &zInv)
+	rotate := isNegative(&tmp)
+
+	var x, y, denInv fp.Element
+	x.Select(rotate, x0, &iy0)
+	y.Select(rotate, y0, &ix0)
+	denInv.Select(rotate, &den2, &enchantedDenominator)
+
+	tmp.Mul(&x, &zInv)
+	condNeg(&y, isNegative(&tmp))
+
+	var s fp.Element
+	s.Sub(z0, &y).Mul(&s, &denInv)
+	condNeg(&s, isNegative(&s))
+
+	var res [EncodedSize]byte
+	fp.LittleEndian.PutElement(&res, s)
+	return res
+}
+
+// SetBytes decodes buf into e (https://www.rfc-editor.org/rfc/rfc9496#section-4.3.1).
+// It returns the number of bytes read, and an error if buf is too short or is not the
+// canonical encoding of an element.
+func (e *Element) SetBytes(buf []byte) (int, error) {
+	if len(buf) < EncodedSize {
+		return 0, io.ErrShortBuffer
+	}
+	var b [EncodedSize]byte
+	copy(b[:], buf)
+	s, err := fp.LittleEndian.Element(&b)
+	if err != nil || isNegative(&s) == 1 {
+		return 0, errInvalidEncoding
+	}
+
+	var one, ss, u1, u2, u2Sqr, v, tmp fp.Element
+	one.SetOne()
+	ss.Square(&s)
+	u1.Sub(&one, &ss)
+	u2.Add(&one, &ss)
+	u2Sqr.Square(&u2)
+
+	// v = -(D⋅u1²) - u2²
+	v.Square(&u1).Mul(&v, &d).Neg(&v).Sub(&v, &u2Sqr)
+
+	var invSqrt fp.Element
+	tmp.Mul(&v, &u2Sqr)
+	wasSquare := sqrtRatioM1(&invSqrt, &one, &tmp)
+
+	var denX, denY fp.Element
+	denX.Mul(&invSqrt, &u2)
+	denY.Mul(&invSqrt, &denX).Mul(&denY, &v)
+
+	var x, y, t fp.Element
+	x.Double(&s).Mul(&x, &denX)
+	condNeg(&x, isNegative(&x))
+	y.Mul(&u1, &denY)
+	t.Mul(&x, &y)
+
+	if wasSquare == 0 || isNegative(&t) == 1 || y.IsZero() {
+		return 0, errInvalidEncoding
+	}
+
+	e.p.X.Set(&x)
+	e.p.Y.Set(&y)
+	e.p.Z.SetOne()
+	e.p.T.Set(&t)
+	return EncodedSize, nil
+}
+
+// SetUniformBytes sets e to the element derived from 64 uniformly random bytes, as the
+// hash-to-group of https://www.rfc-editor.org/rfc/rfc9496#section-4.3.4, and returns e.
+func (e *Element) SetUniformBytes(b []byte) (*Element, error) {
+	if len(b) != UniformSize {
+		return nil, errors.New("ristretto255: SetUniformBytes input must be 64 bytes")
+	}
+	var b1, b2 [fp.Bytes]byte
+	copy(b1[:], b[:fp.Bytes])
+	copy(b2[:], b[fp.Bytes:])
+
+	// the most significant bit is masked, the remaining 255 bits are reduced mod p
+	var r1, r2 fp.Element
+	b1[fp.Bytes-1] &= 0x7f
+	b2[fp.Bytes-1] &= 0x7f
+	r1.SetBytes(reverse(b1[:]))
+	r2.SetBytes(reverse(b2[:]))
+
+	var p1, p2 ed25519.PointExtended
+	mapToPoint(&p1, &r1)
+	mapToPoint(&p2, &r2)
+	e.p.Add(&p1, &p2)
+	return e, nil
+}
+
+// mapToPoint is the MAP function of https://www.rfc-editor.org/rfc/rfc9496#section-4.3.4
+func mapToPoint(res *ed25519.PointExtended, t *fp.Element) {
+	var one, minusOne fp.Element
+	one.SetOne()
+	minusOne.Neg(&one)
+
+	// r = SQRT_M1⋅t²
+	var r fp.Element
+	r.Square(t).Mul(&r, &sqrtM1)
+
+	// u = (r + 1)⋅ONE_MINUS_D_SQ
+	var u fp.Element
+	u.Add(&r, &one).Mul(&u, &oneMinusDSq)
+
+	// v = (-1 - r⋅D)⋅(r + D)
+	var v, tmp fp.Element
+	v.Mul(&r, &d).Sub(&minusOne, &v)
+	tmp.Add(&r, &d)
+	v.Mul(&v, &tmp)
+
+	var s fp.Element
+	wasSquare := sqrtRatioM1(&s, &u, &v)
+
+	// s' = -|s⋅t|
+	var sPrime fp.Element
+	sPrime.Mul(&s, t)
+	condNeg(&sPrime, isNegative(&sPrime))
+	sPrime.Neg(&sPrime)
+	s.Select(wasSquare, &sPrime, &s)
+
+	var c fp.Element
+	c.Select(wasSquare, &r, &minusOne)
+
+	// N = c⋅(r - 1)⋅D_MINUS_ONE_SQ - v
+	var n fp.Element
+	n.Sub(&r, &one).Mul(&n, &c).Mul(&n, &dMinusOneSq).Sub(&n, &v)
+
+	var w0, w1, w2, w3 fp.Element
+	w0.Double(&s).Mul(&w0, &v)
+	w1.Mul(&n, &sqrtADMinusOne)
+	tmp.Square(&s)
+	w2.Sub(&one, &tmp)
+	w3.Add(&one, &tmp)
+
+	res.X.Mul(&w0, &w3)
+	res.Y.Mul(&w2, &w1)
+	res.Z.Mul(&w1, &w3)
+	res.T.Mul(&w0, &w2)
+}
+
+// sqrtRatioM1 sets r to √(u/v) or √(SQRT_M1⋅u/v), whichever exists, choosing the
+// non-negative root, and returns 1 if u/v is a square, 0 otherwise
+// (https://www.rfc-editor.org/rfc/rfc9496#section-4.2).
+func sqrtRatioM1(r, u, v *fp.Element) int {
+	var v3, v7, check, minusU, minusUSqrtM1, rPrime fp.Element
+	v3.Square(v).Mul(&v3, v)
+	v7.Square(&v3).Mul(&v7, v)
+
+	// r = (u⋅v³)⋅(u⋅v⁷)^((p-5)/8)
+	var uv3, uv7 fp.Element
+	uv3.Mul(u, &v3)
+	uv7.Mul(u, &v7)
+	r.Exp(uv7, &expSqrt).Mul(r, &uv3)
+
+	check.Square(r).Mul(&check, v)
+
+	minusU.Neg(u)
+	minusUSqrtM1.Mul(&minusU, &sqrtM1)
+	correctSignSqrt := check.Equal(u)
+	flippedSignSqrt := check.Equal(&minusU)
+	flippedSignSqrtI := check.Equal(&minusUSqrtM1)
+
+	rPrime.Mul(r, &sqrtM1)
+	r.Select(b2i(flippedSignSqrt || flippedSignSqrtI), r, &rPrime)
+	condNeg(r, isNegative(r))
+
+	return b2i(correctSignSqrt || flippedSignSqrt)
+}
+
+// isNegative returns 1 if the canonical representation of x is odd, 0 otherwise
+func isNegative(x *fp.Element) int {
+	return int(x.Bits()[0] & 1)
+}
+
+// condNeg sets x to -x if c = 1
+func condNeg(x *fp.Element, c int) {
+	var nx fp.Element
+	nx.Neg(x)
+	x.Select(c, x, &nx)
+}
+
+func b2i(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func reverse(b []byte) []byte {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return b
+}
@@ -0,0 +1,189 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ristretto255
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/ed25519"
+	"github.com/consensys/gnark-crypto/ecc/ed25519/fp"
+)
+
+func TestGeneratorMultiples(t *testing.T) {
+	// https://www.rfc-editor.org/rfc/rfc9496#appendix-A.1
+	expected := []string{
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"e2f2ae0a6abc4e71a884a961c500515f58e30b6aa582dd8db6a65945e08d2d76",
+		"6a493210f7499cd17fecb510ae0cea23a110e8d5b901f8acadd3095c73a3b919",
+		"94741f5d5d52755ece4f23f044ee27d5d1ea1e2bd196b462166b16152a9d0259",
+		"da80862773358b466ffadfe0b3293ab3d9fd53c5ea6c955358f568322daf6a57",
+	}
+	var g, e Element
+	g.SetGenerator()
+	e.SetIdentity()
+	for i, exp := range expected {
+		b := e.Bytes()
+		if hex.EncodeToString(b[:]) != exp {
+			t.Fatalf("[%d]B: got %x", i, b)
+		}
+		var decoded Element
+		if _, err := decoded.SetBytes(b[:]); err != nil {
+			t.Fatalf("[%d]B: %v", i, err)
+		}
+		if !decoded.Equal(&e) {
+			t.Fatalf("[%d]B: decoding mismatch", i)
+		}
+		e.Add(&e, &g)
+	}
+}
+
+func TestInvalidEncodings(t *testing.T) {
+	// from https://www.rfc-editor.org/rfc/rfc9496#appendix-A.2
+	invalid := []string{
+		// non-canonical field encodings
+		"edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		"f3ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		// negative field elements
+		"0100000000000000000000000000000000000000000000000000000000000000",
+		"ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+	}
+	for _, v := range invalid {
+		b, _ := hex.DecodeString(v)
+		var e Element
+		if _, err := e.SetBytes(b); err == nil {
+			t.Fatalf("%s should be rejected", v)
+		}
+	}
+}
+
+func TestTorsion(t *testing.T) {
+	// (√-1, 0) has order 4: adding it doesn't change the element
+	var torsion Element
+	torsion.SetIdentity()
+	torsion.p.X.Set(&sqrtM1)
+	torsion.p.Y.SetZero()
+
+	for i := 0; i < 10; i++ {
+		e := randomElement()
+		var f Element
+		f.Add(&e, &torsion)
+		if !f.Equal(&e) || e.Bytes() != f.Bytes() {
+			t.Fatal("encoding depends on the coset representative")
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		e := randomElement()
+		b := e.Bytes()
+		var f Element
+		if _, err := f.SetBytes(b[:]); err != nil {
+			t.Fatal(err)
+		}
+		if !f.Equal(&e) {
+			t.Fatal("round trip failed")
+		}
+
+		// e - e = 0
+		f.Sub(&f, &e)
+		var id Element
+		id.SetIdentity()
+		if !f.Equal(&id) {
+			t.Fatal("e - e != 0")
+		}
+	}
+}
+
+func TestSetUniformBytes(t *testing.T) {
+	// https://www.rfc-editor.org/rfc/rfc9496#appendix-A.3
+	in, _ := hex.DecodeString("5d1be09e3d0c82fc538112490e35701979d99e06ca3e2b5b54bffe8b4dc772c14d98b696a1bbfb5ca32c436cc61c16563790306c79eaca7705668b47dffe5bb6")
+	var h Element
+	h.SetUniformBytes(in)
+	if b := h.Bytes(); hex.EncodeToString(b[:]) != "3066f82a1a747d45120d1740f14358531a8f04bbffe6a819f86dfe50f44a0a46" {
+		t.Fatalf("got %x", b)
+	}
+
+	var buf [UniformSize]byte
+	for i := 0; i < 100; i++ {
+		rand.Read(buf[:])
+		var e, f Element
+		if _, err := e.SetUniformBytes(buf[:]); err != nil {
+			t.Fatal(err)
+		}
+		// the result is a valid element
+		b := e.Bytes()
+		if _, err := f.SetBytes(b[:]); err != nil || !f.Equal(&e) {
+			t.Fatal("invalid element")
+		}
+		var a ed25519.PointAffine
+		if !a.FromExtended(&e.p).IsOnCurve() {
+			t.Fatal("not on curve")
+		}
+	}
+	if _, err := new(Element).SetUniformBytes(buf[1:]); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestMultiExp(t *testing.T) {
+	const n = 50
+	elements := make([]Element, n)
+	scalars := make([]big.Int, n)
+	var expected, tmp Element
+	expected.SetIdentity()
+	for i := 0; i < n; i++ {
+		elements[i] = randomElement()
+		s, _ := rand.Int(rand.Reader, fp.Modulus())
+		scalars[i].Set(s)
+		tmp.ScalarMultiplication(&elements[i], s)
+		expected.Add(&expected, &tmp)
+	}
+	var res Element
+	if _, err := res.MultiExp(elements, scalars, ecc.MultiExpConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	if !res.Equal(&expected) {
+		t.Fatal("MultiExp mismatch")
+	}
+}
+
+func randomElement() Element {
+	var buf [UniformSize]byte
+	rand.Read(buf[:])
+	var e Element
+	e.SetUniformBytes(buf[:])
+	return e
+}
+
+func BenchmarkBytes(b *testing.B) {
+	e := randomElement()
+	for i := 0; i < b.N; i++ {
+		e.Bytes()
+	}
+}
+
+func BenchmarkSetBytes(b *testing.B) {
+	e := randomElement()
+	buf := e.Bytes()
+	var f Element
+	for i := 0; i < b.N; i++ {
+		f.SetBytes(buf[:])
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package x25519 provides the X25519 Diffie-Hellman function of RFC 7748 on
+// Curve25519, the Montgomery form v² = u³ + 486662⋅u² + u of ed25519.
+//
+// # See also
+//
+// https://www.rfc-editor.org/rfc/rfc7748
+package x25519
+
+import (
+	"crypto/subtle"
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/ed25519/fp"
+)
+
+const (
+	// ScalarSize is the size of the scalars used with X25519, in bytes.
+	ScalarSize = 32
+	// PointSize is the size of the u-coordinates used with X25519, in bytes.
+	PointSize = 32
+)
+
+// Basepoint is the canonical Curve25519 generator, u = 9.
+var Basepoint []byte
+
+var errLowOrder = errors.New("x25519: low order point")
+
+func init() {
+	Basepoint = make([]byte, PointSize)
+	Basepoint[0] = 9
+}
+
+// X25519 returns the scalar multiplication of the point (a u-coordinate) by the scalar,
+// following https://tools.ietf.org/html/rfc7748#section-5.
+//
+// The scalar is clamped; the point may be non canonical (it is reduced modulo p, its most
+// significant bit is ignored). As in golang.org/x/crypto/curve25519, an error is returned
+// if the result is the all-zero value, i.e. if the point has low order.
+func X25519(scalar, point []byte) ([]byte, error) {
+	if len(scalar) != ScalarSize {
+		return nil, errors.New("x25519: bad scalar length")
+	}
+	if len(point) != PointSize {
+		return nil, errors.New("x25519: bad point length")
+	}
+
+	var k [ScalarSize]byte
+	copy(k[:], scalar)
+	k[0] &= 248
+	k[31] &= 127
+	k[31] |= 64
+
+	var buf [PointSize]byte
+	copy(buf[:], point)
+	buf[PointSize-1] &= 0x7f
+	var u fp.Element
+	u.SetBytes(reverse(buf[:]))
+
+	x := ladder(&k, &u)
+
+	res := make([]byte, PointSize)
+	fp.LittleEndian.PutElement((*[PointSize]byte)(res), x)
+	if subtle.ConstantTimeCompare(res, make([]byte, PointSize)) == 1 {
+		return nil, errLowOrder
+	}
+	return res, nil
+}
+
+// ladder computes the u-coordinate of [k]P where u is the u-coordinate of P, with
+// the Montgomery ladder (https://tools.ietf.org/html/rfc7748#section-5).
+func ladder(k *[ScalarSize]byte, u *fp.Element) fp.Element {
+	var a24 fp.Element
+	a24.SetUint64(121665)
+
+	var x2, z2, x3, z3 fp.Element
+	x2.SetOne()
+	x3.Set(u)
+	z3.SetOne()
+
+	var A, AA, B, BB, E, C, D, DA, CB fp.Element
+	swap := 0
+	for t := 8*ScalarSize - 1; t >= 0; t-- {
+		kt := int(k[t/8]>>(t%8)) & 1
+		swap ^= kt
+		cswap(swap, &x2, &x3)
+		cswap(swap, &z2, &z3)
+		swap = kt
+
+		A.Add(&x2, &z2)
+		AA.Square(&A)
+		B.Sub(&x2, &z2)
+		BB.Square(&B)
+		E.Sub(&AA, &BB)
+		C.Add(&x3, &z3)
+		D.Sub(&x3, &z3)
+		DA.Mul(&D, &A)
+		CB.Mul(&C, &B)
+		x3.Add(&DA, &CB).Square(&x3)
+		z3.Sub(&DA, &CB).Square(&z3).Mul(&z3, u)
+		x2.Mul(&AA, &BB)
+		z2.Mul(&a24, &E).Add(&z2, &AA).Mul(&z2, &E)
+	}
+	cswap(swap, &x2, &x3)
+	cswap(swap, &z2, &z3)
+
+	// z2 = 0 (point at infinity) gives x2⋅0⁻¹ = 0
+	z2.Inverse(&z2)
+	return *x2.Mul(&x2, &z2)
+}
+
+// cswap swaps a and b if swap = 1, in constant time
+func cswap(swap int, a, b *fp.Element) {
+	var t fp.Element
+	t.Select(swap, a, b)
+	b.Select(swap, b, a)
+	a.Set(&t)
+}
+
+func reverse(b []byte) []byte {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return b
+}
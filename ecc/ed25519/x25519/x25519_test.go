@@ -0,0 +1,123 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package x25519
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"golang.org/x/crypto/curve25519"
+)
+
+func TestRFC7748(t *testing.T) {
+	// https://tools.ietf.org/html/rfc7748#section-5.2
+	vectors := []struct {
+		scalar, point, out string
+	}{
+		{
+			"a546e36bf0527c9d3b16154b82465edd62144c0ac1fc5a18506a2244ba449ac4",
+			"e6db6867583030db3594c1a424b15f7c726624ec26b3353b10a903a6d0ab1c4c",
+			"c3da55379de9c6908e94ea4df28d084f32eccf03491c71f754b4075577a28552",
+		},
+		{
+			"4b66e9d4d1b4673c5ad22691957d6af5c11b6421e0ea01d42ca4169e7918ba0d",
+			"e5210f12786811d3f4b7959d0538ae2c31dbe7106fc03c3efc4cd549c715a493",
+			"95cbde9476e8907d7aade45cb4b873f88b595a68799fa152e6f8f7647aac7957",
+		},
+	}
+	for i, v := range vectors {
+		scalar, _ := hex.DecodeString(v.scalar)
+		point, _ := hex.DecodeString(v.point)
+		out, err := X25519(scalar, point)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(out) != v.out {
+			t.Fatalf("vector %d: got %x", i, out)
+		}
+	}
+
+	// iterated k, u = X25519(k, u), k
+	k := make([]byte, ScalarSize)
+	copy(k, Basepoint)
+	u := make([]byte, PointSize)
+	copy(u, Basepoint)
+	expected := map[int]string{
+		1:    "422c8e7a6227d7bca1350b3e2bb7279f7897b87bb6854b783c60e80311ae3079",
+		1000: "684cf59ba83309552800ef566f2f4d3c1c3887c49360e3875f2eb94d99532c51",
+	}
+	for i := 1; i <= 1000; i++ {
+		r, err := X25519(k, u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		copy(u, k)
+		copy(k, r)
+		if e, ok := expected[i]; ok && hex.EncodeToString(k) != e {
+			t.Fatalf("iteration %d: got %x", i, k)
+		}
+	}
+}
+
+func TestDiffieHellman(t *testing.T) {
+	// https://tools.ietf.org/html/rfc7748#section-6.1
+	alice, _ := hex.DecodeString("77076d0a7318a57d3c16c17251b26645df4c2f87ebc0992ab177fba51db92c2a")
+	bob, _ := hex.DecodeString("5dab087e624a8a4b79e17f8b83800ee66f3bb1292618b6fd1c2f8b27ff88e0eb")
+
+	alicePub, _ := X25519(alice, Basepoint)
+	bobPub, _ := X25519(bob, Basepoint)
+	if hex.EncodeToString(alicePub) != "8520f0098930a754748b7ddcb43ef75a0dbf3a0d26381af4eba4a98eaa9b4e6a" {
+		t.Fatal("wrong public key for Alice")
+	}
+	if hex.EncodeToString(bobPub) != "de9edb7d7b7dc1b4d35b61c2ece435373f8343c85b78674dadfc7e146f882b4f" {
+		t.Fatal("wrong public key for Bob")
+	}
+
+	k1, _ := X25519(alice, bobPub)
+	k2, _ := X25519(bob, alicePub)
+	if !bytes.Equal(k1, k2) || hex.EncodeToString(k1) != "4a5d9d5ba4ce2de1728e3bf480350f25e07e21c947d19e3376f09b3c1e161742" {
+		t.Fatal("wrong shared secret")
+	}
+}
+
+func TestCurve25519(t *testing.T) {
+	scalar := make([]byte, ScalarSize)
+	point := make([]byte, PointSize)
+	for i := 0; i < 50; i++ {
+		rand.Read(scalar)
+		rand.Read(point)
+		res, err := X25519(scalar, point)
+		expected, errExpected := curve25519.X25519(scalar, point)
+		if (err == nil) != (errExpected == nil) || !bytes.Equal(res, expected) {
+			t.Fatalf("mismatch with golang.org/x/crypto/curve25519 on %x, %x", scalar, point)
+		}
+	}
+
+	// low order point
+	point = make([]byte, PointSize)
+	if _, err := X25519(scalar, point); err != errLowOrder {
+		t.Fatal("expected errLowOrder")
+	}
+}
+
+func BenchmarkX25519(b *testing.B) {
+	scalar := make([]byte, ScalarSize)
+	rand.Read(scalar)
+	for i := 0; i < b.N; i++ {
+		X25519(scalar, Basepoint)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grumpkin
+
+import (
+	"math/big"
+	"testing"
+
+	fp "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+
+	fr "github.com/consensys/gnark-crypto/ecc/bn254/fp"
+)
+
+func TestGLVConstants(t *testing.T) {
+	t.Parallel()
+
+	// ω is a primitive cube root of unity in 𝔽p, ω = 3^((p-1)/3)
+	var omega, one, three fp.Element
+	one.SetOne()
+	three.SetUint64(3)
+	pMinusOneOverThree := new(big.Int).Sub(fp.Modulus(), big.NewInt(1))
+	pMinusOneOverThree.Div(pMinusOneOverThree, big.NewInt(3))
+	omega.Exp(three, pMinusOneOverThree)
+	if !omega.Equal(&thirdRootOneG1) {
+		t.Fatal("thirdRootOneG1 ≠ 3^((p-1)/3)")
+	}
+	var cube fp.Element
+	cube.Square(&omega).Mul(&cube, &omega)
+	if omega.Equal(&one) || !cube.Equal(&one) {
+		t.Fatal("thirdRootOneG1 is not a primitive cube root of unity mod p")
+	}
+
+	// λ is a primitive cube root of unity in 𝔽r, λ = 7^((r-1)/3)
+	r := fr.Modulus()
+	rMinusOneOverThree := new(big.Int).Sub(r, big.NewInt(1))
+	rMinusOneOverThree.Div(rMinusOneOverThree, big.NewInt(3))
+	lambda := new(big.Int).Exp(big.NewInt(7), rMinusOneOverThree, r)
+	if lambda.Cmp(&lambdaGLV) != 0 {
+		t.Fatal("lambdaGLV ≠ 7^((r-1)/3)")
+	}
+	lambdaCube := new(big.Int).Exp(&lambdaGLV, big.NewInt(3), r)
+	if lambdaGLV.Cmp(big.NewInt(1)) == 0 || lambdaCube.Cmp(big.NewInt(1)) != 0 {
+		t.Fatal("lambdaGLV is not a primitive cube root of unity mod r")
+	}
+
+	// φ: (x, y) ↦ (ωx, y) acts as [λ] on a random point
+	var s fr.Element
+	if _, err := s.SetRandom(); err != nil {
+		t.Fatal(err)
+	}
+	var p, phiP, lambdaP G1Jac
+	p.mulWindowed(&g1Gen, s.BigInt(new(big.Int)))
+	phiP.phi(&p)
+	lambdaP.mulWindowed(&p, &lambdaGLV)
+	if !phiP.Equal(&lambdaP) {
+		t.Fatal("φ(P) ≠ [λ]P")
+	}
+}
@@ -0,0 +1,62 @@
+package grumpkin
+
+// RFC 9380 does not define a Grumpkin suite: these vectors follow the QUUX test
+// suites of the RFC (expand_message_xmd with SHA-256, SVDW with Z = 1), and were
+// computed with an independent implementation of the RFC.
+func init() {
+	encodeToG1Vector = encodeTestVector{
+		dst: []byte("QUUX-V01-CS02-with-Grumpkin_XMD:SHA-256_SVDW_NU_"),
+		cases: []encodeTestCase{
+			{
+				msg: "", P: point{"0x296b5652414c7f788fadb29a24a832cb4d12726f1f8e5e936e2aa2b29fb8b201", "0x10d393d3d03dcc171bd8ae3a76074842953c82514c9fab1dad447575fe298716"},
+				Q: point{"0x296b5652414c7f788fadb29a24a832cb4d12726f1f8e5e936e2aa2b29fb8b201", "0x10d393d3d03dcc171bd8ae3a76074842953c82514c9fab1dad447575fe298716"},
+				u: "0xd67c2c2bfb4fce9bedeb48c24e5bf06037978a60f7a527cb09de8095428c54",
+			}, {
+				msg: "abc", P: point{"0x1a4b6c3a87abe3f0e828bdf7686a0ea76bd1b988b7c6f85c3a1390c622e5d34c", "0x20847b90fcac277ba7dd6d21f4983ab563f39c00fca9b4a206989886a5410dc9"},
+				Q: point{"0x1a4b6c3a87abe3f0e828bdf7686a0ea76bd1b988b7c6f85c3a1390c622e5d34c", "0x20847b90fcac277ba7dd6d21f4983ab563f39c00fca9b4a206989886a5410dc9"},
+				u: "0x8f06fb196566e02b3782225e21297889e79702d7c2ccfd008adb01649f44ceb",
+			}, {
+				msg: "abcdef0123456789", P: point{"0x288a48d4c50a93570fd3497581f1a735bc05b71a65807f76d373c889f7957721", "0x11b23484a086486cb583ddab464dd3e91433ab5b1246e2b2c40875bddcc12cf8"},
+				Q: point{"0x288a48d4c50a93570fd3497581f1a735bc05b71a65807f76d373c889f7957721", "0x11b23484a086486cb583ddab464dd3e91433ab5b1246e2b2c40875bddcc12cf8"},
+				u: "0x4a28c9d7ec9e134a129cb751365767e15e2e1089482cabe1782336f39dec4c4",
+			}, {
+				msg: "q128_qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq", P: point{"0x20eae29b045c8a388ed682f00f2aa5b434a722521d7760e010674586bff2e055", "0x148a80baf2b6fcd15b81695b4d08b13e5039842423d8f8274194de4eace73287"},
+				Q: point{"0x20eae29b045c8a388ed682f00f2aa5b434a722521d7760e010674586bff2e055", "0x148a80baf2b6fcd15b81695b4d08b13e5039842423d8f8274194de4eace73287"},
+				u: "0x206611f1a121c40c8d5aa413961dc35cd27c4fd63f347ef6d3a02d0ef29952a1",
+			}, {
+				msg: "a512_aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", P: point{"0x24a3ff7ac7d0f5eb83939d59429a07f4190ebe7ead69093f50e4323ed9e7e246", "0xf279e7ba685263e9b0975f91d39bc721d2c1c13231f78c0470fc82359267853"},
+				Q: point{"0x24a3ff7ac7d0f5eb83939d59429a07f4190ebe7ead69093f50e4323ed9e7e246", "0xf279e7ba685263e9b0975f91d39bc721d2c1c13231f78c0470fc82359267853"},
+				u: "0xeff79541cf9704da35ecad27c85fac2a7f79f7b0ef6f53eff23919d58aa5031",
+			},
+		}}
+	hashToG1Vector = hashTestVector{
+		dst: []byte("QUUX-V01-CS02-with-Grumpkin_XMD:SHA-256_SVDW_RO_"),
+		cases: []hashTestCase{
+			{
+				msg: "", P: point{"0x79cb4466f9c8693fb587b9c38d31798019c3d10a65b686acabf9778fb385ffd", "0x265e30ddd1c2b07afca6fe028403db7434df59324750a0d1e5c4bdd60e4481c9"},
+				Q0: point{"0x14e4f1b478373ad622815f0a6a62a706ca7bf11966894a04614a3b4fb866eabe", "0x1ee8673de6b5f8239795c67e344747c40180992bd9585085e180af408c2c1f22"},
+				Q1: point{"0x1c05e3f42b401b7d78f7de78f86ac7142794d57efda5c5c745a2f30c0852e4ae", "0xd116f3ea128e64b40d263d24128a21c50e28a0acf70061a4178e5dd95490945"},
+				u0: "0x1ec155295a014a87d1b0695e1cf54fd6feb7200c576e88cd5a404c88dd17a95e", u1: "0x1638617d78c3c8d3b145419c87dc733b85e880e7326280c3ef209a42f3bd29b7",
+			}, {
+				msg: "abc", P: point{"0x2b6637aeca770ed50e132688627d635932c25617f6ea7a2b02359f56e3fd9625", "0x21d75e9b56d9b30485500f86ac1ef0b30b7a55388bf7ec60f14c9568e05d31c4"},
+				Q0: point{"0x28f6d4fea4435e5909df9245e52ca4c79545be035d9171845206dae60e69d111", "0x28b360b7a7e6281978fe5118f9c06d0443026edf6056e0c79bfcbe30340f63b"},
+				Q1: point{"0x118de07e677771ccc0814c0dc674e4de74f5504676b0282fdb5d32339aa036b3", "0x2e9774b22425026e4f3e576feb79c66e5aa909a12208121604c5b1dcc858fcef"},
+				u0: "0x25954f0e58b081f2b1e91ad578e4272550b881d5c4771be39b184038081423c7", u1: "0x1c1f24045b814910e85b0e307df4fd58bc5797786ac32267ce78b28b8713466f",
+			}, {
+				msg: "abcdef0123456789", P: point{"0x2dfb525e346fe4333d885752e2da7618d472658bd022fea8d0bd15d8ba077f1f", "0x256e3b41825f77c7aacd9ac13facabc03e37b01d917b2e74ec61df37a939b9cd"},
+				Q0: point{"0x2e84d7aef8d15bc7d7a6df56d00d07993df6192c17c19a67b1e5af4f82eb7d53", "0x2638bd143407b70fa1de93be37df2d4471ef7ac52505c5a5ec6e23d21481be84"},
+				Q1: point{"0xdf975dc7c0f8389ed8f18d404f32322fef132c094162bd6ef1a519c4c7feb59", "0x208e3d17bed2189fd51b3f211686b1915b6f332bbae6d96d4d2a9064f9a7fafb"},
+				u0: "0x2f8b232a1971c656814f02ecae924c7459a2f2a827d015b5e409a4338bc74dde", u1: "0xa41f4e9ed5a92295254fed73e941d152dbbb6bef339e6fbc9903f21f5a1aa0b",
+			}, {
+				msg: "q128_qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq", P: point{"0x2150d2d17d164a4e8478b47ff8a13296e26e1c85597c955385fcdc780e48d0f7", "0x2001b82ccedaaebd321b4a7ee9021ac374e6c13bd7b62f9893693e344ea91e87"},
+				Q0: point{"0x13e6053b2054e5c73e9fea7e748a6c050feaf234a1a6fe55bddfa6c7e61ef081", "0x165ae4bd4dc14336e2c27161f9e6f7f03c35a45ff4119bf74d34b684782e631c"},
+				Q1: point{"0xa5b4a5d62dd49e066fab7fa246ac529b62010d77595e4176106f50cfaafef33", "0x63e6970b6576f6ede7198e3299560981d59f141ce4a739c408efa70d3df285f"},
+				u0: "0x140ff9f887c7647b78d63d64940ca237352d62754e3c60bf85149285a0b94c78", u1: "0x2b31485ab85282eec62efb7ceeba91abc060d1a425f8c9b0e614d32d0bbf6d33",
+			}, {
+				msg: "a512_aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", P: point{"0x1bf75f0f3210ac781081f62dd04c0b6d07ba7ac6af721ad4b87e6a482d2f67dc", "0x11893a4f12f548a7105b4557e35be388e6a9c87a5ae07e3fa7f8fccdd5810eec"},
+				Q0: point{"0xaa8fdc4923cd48f37f9d2bbd4daad737c18b0239ae6336e9ee0a3bf7adfc7fc", "0x1a328802739739e4a6a3ee5af72c8eff7fef0335421f338bf288efadd6d9918"},
+				Q1: point{"0xadbcc5b5174bbbe8f6bca051d5839c6c0cfee36f4ca4129bf75630f7431805e", "0x2d266ce99a35dcfcf75685555e46f929793cf65efdfcaee8b4af905951c91477"},
+				u0: "0x15446fd7a0d62fa6cae3c799f529ec68e78109b32af1347916c2eeda6ccf7732", u1: "0x240235f208a0723c54a3f753092c3e63d8e90411fae157470b69f5593bd00915",
+			},
+		}}
+}
@@ -0,0 +1,453 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fp
+
+// expBySqrtExp is equivalent to z.Exp(x, 2000000000000000000000000000000011234c7e04a67c8dcc969876)
+//
+// uses github.com/mmcloughlin/addchain v0.4.0 to generate a shorter addition chain
+func (z *Element) expBySqrtExp(x Element) *Element {
+	// addition chain:
+	//
+	//	_10    = 2*1
+	//	_11    = 1 + _10
+	//	_100   = 1 + _11
+	//	_111   = _11 + _100
+	//	_1001  = _10 + _111
+	//	_1101  = _100 + _1001
+	//	_1111  = _10 + _1101
+	//	_10000 = 1 + _1111
+	//	i149   = ((_10000 << 125 + 1) << 7 + _1001) << 7
+	//	i163   = ((_1101 + i149) << 4 + _11) << 7 + _1111
+	//	i182   = ((i163 << 2 + _11) << 10 + _1001) << 5
+	//	i193   = ((_1001 + i182) << 4 + _1001) << 4 + _1111
+	//	i207   = ((i193 << 3 + 1) << 7 + _1101) << 2
+	//	i220   = ((_11 + i207) << 4 + _11) << 6 + _1001
+	//	i238   = ((i220 << 5 + _1101) << 4 + _11) << 7
+	//	return   2*((_111 + i238) << 3 + _11)
+	//
+	// Operations: 218 squares 26 multiplies
+
+	// Allocate Temporaries.
+	var (
+		t0 = new(Element)
+		t1 = new(Element)
+		t2 = new(Element)
+		t3 = new(Element)
+		t4 = new(Element)
+	)
+
+	// var t0,t1,t2,t3,t4 Element
+	// Step 1: t3 = x^0x2
+	t3.Square(&x)
+
+	// Step 2: z = x^0x3
+	z.Mul(&x, t3)
+
+	// Step 3: t1 = x^0x4
+	t1.Mul(&x, z)
+
+	// Step 4: t0 = x^0x7
+	t0.Mul(z, t1)
+
+	// Step 5: t2 = x^0x9
+	t2.Mul(t3, t0)
+
+	// Step 6: t1 = x^0xd
+	t1.Mul(t1, t2)
+
+	// Step 7: t3 = x^0xf
+	t3.Mul(t3, t1)
+
+	// Step 8: t4 = x^0x10
+	t4.Mul(&x, t3)
+
+	// Step 133: t4 = x^0x200000000000000000000000000000000
+	for s := 0; s < 125; s++ {
+		t4.Square(t4)
+	}
+
+	// Step 134: t4 = x^0x200000000000000000000000000000001
+	t4.Mul(&x, t4)
+
+	// Step 141: t4 = x^0x10000000000000000000000000000000080
+	for s := 0; s < 7; s++ {
+		t4.Square(t4)
+	}
+
+	// Step 142: t4 = x^0x10000000000000000000000000000000089
+	t4.Mul(t2, t4)
+
+	// Step 149: t4 = x^0x800000000000000000000000000000004480
+	for s := 0; s < 7; s++ {
+		t4.Square(t4)
+	}
+
+	// Step 150: t4 = x^0x80000000000000000000000000000000448d
+	t4.Mul(t1, t4)
+
+	// Step 154: t4 = x^0x80000000000000000000000000000000448d0
+	for s := 0; s < 4; s++ {
+		t4.Square(t4)
+	}
+
+	// Step 155: t4 = x^0x80000000000000000000000000000000448d3
+	t4.Mul(z, t4)
+
+	// Step 162: t4 = x^0x400000000000000000000000000000002246980
+	for s := 0; s < 7; s++ {
+		t4.Square(t4)
+	}
+
+	// Step 163: t4 = x^0x40000000000000000000000000000000224698f
+	t4.Mul(t3, t4)
+
+	// Step 165: t4 = x^0x100000000000000000000000000000000891a63c
+	for s := 0; s < 2; s++ {
+		t4.Square(t4)
+	}
+
+	// Step 166: t4 = x^0x100000000000000000000000000000000891a63f
+	t4.Mul(z, t4)
+
+	// Step 176: t4 = x^0x40000000000000000000000000000000224698fc00
+	for s := 0; s < 10; s++ {
+		t4.Square(t4)
+	}
+
+	// Step 177: t4 = x^0x40000000000000000000000000000000224698fc09
+	t4.Mul(t2, t4)
+
+	// Step 182: t4 = x^0x80000000000000000000000000000000448d31f8120
+	for s := 0; s < 5; s++ {
+		t4.Square(t4)
+	}
+
+	// Step 183: t4 = x^0x80000000000000000000000000000000448d31f8129
+	t4.Mul(t2, t4)
+
+	// Step 187: t4 = x^0x80000000000000000000000000000000448d31f81290
+	for s := 0; s < 4; s++ {
+		t4.Square(t4)
+	}
+
+	// Step 188: t4 = x^0x80000000000000000000000000000000448d31f81299
+	t4.Mul(t2, t4)
+
+	// Step 192: t4 = x^0x80000000000000000000000000000000448d31f812990
+	for s := 0; s < 4; s++ {
+		t4.Square(t4)
+	}
+
+	// Step 193: t3 = x^0x80000000000000000000000000000000448d31f81299f
+	t3.Mul(t3, t4)
+
+	// Step 196: t3 = x^0x40000000000000000000000000000000224698fc094cf8
+	for s := 0; s < 3; s++ {
+		t3.Square(t3)
+	}
+
+	// Step 197: t3 = x^0x40000000000000000000000000000000224698fc094cf9
+	t3.Mul(&x, t3)
+
+	// Step 204: t3 = x^0x2000000000000000000000000000000011234c7e04a67c80
+	for s := 0; s < 7; s++ {
+		t3.Square(t3)
+	}
+
+	// Step 205: t3 = x^0x2000000000000000000000000000000011234c7e04a67c8d
+	t3.Mul(t1, t3)
+
+	// Step 207: t3 = x^0x80000000000000000000000000000000448d31f81299f234
+	for s := 0; s < 2; s++ {
+		t3.Square(t3)
+	}
+
+	// Step 208: t3 = x^0x80000000000000000000000000000000448d31f81299f237
+	t3.Mul(z, t3)
+
+	// Step 212: t3 = x^0x80000000000000000000000000000000448d31f81299f2370
+	for s := 0; s < 4; s++ {
+		t3.Square(t3)
+	}
+
+	// Step 213: t3 = x^0x80000000000000000000000000000000448d31f81299f2373
+	t3.Mul(z, t3)
+
+	// Step 219: t3 = x^0x2000000000000000000000000000000011234c7e04a67c8dcc0
+	for s := 0; s < 6; s++ {
+		t3.Square(t3)
+	}
+
+	// Step 220: t2 = x^0x2000000000000000000000000000000011234c7e04a67c8dcc9
+	t2.Mul(t2, t3)
+
+	// Step 225: t2 = x^0x40000000000000000000000000000000224698fc094cf91b9920
+	for s := 0; s < 5; s++ {
+		t2.Square(t2)
+	}
+
+	// Step 226: t1 = x^0x40000000000000000000000000000000224698fc094cf91b992d
+	t1.Mul(t1, t2)
+
+	// Step 230: t1 = x^0x40000000000000000000000000000000224698fc094cf91b992d0
+	for s := 0; s < 4; s++ {
+		t1.Square(t1)
+	}
+
+	// Step 231: t1 = x^0x40000000000000000000000000000000224698fc094cf91b992d3
+	t1.Mul(z, t1)
+
+	// Step 238: t1 = x^0x2000000000000000000000000000000011234c7e04a67c8dcc96980
+	for s := 0; s < 7; s++ {
+		t1.Square(t1)
+	}
+
+	// Step 239: t0 = x^0x2000000000000000000000000000000011234c7e04a67c8dcc96987
+	t0.Mul(t0, t1)
+
+	// Step 242: t0 = x^0x100000000000000000000000000000000891a63f02533e46e64b4c38
+	for s := 0; s < 3; s++ {
+		t0.Square(t0)
+	}
+
+	// Step 243: z = x^0x100000000000000000000000000000000891a63f02533e46e64b4c3b
+	z.Mul(z, t0)
+
+	// Step 244: z = x^0x2000000000000000000000000000000011234c7e04a67c8dcc969876
+	z.Square(z)
+
+	return z
+}
+
+// expByLegendreExp is equivalent to z.Exp(x, 2000000000000000000000000000000011234c7e04a67c8dcc96987680000000)
+//
+// uses github.com/mmcloughlin/addchain v0.4.0 to generate a shorter addition chain
+func (z *Element) expByLegendreExp(x Element) *Element {
+	// addition chain:
+	//
+	//	_10    = 2*1
+	//	_11    = 1 + _10
+	//	_100   = 1 + _11
+	//	_111   = _11 + _100
+	//	_1001  = _10 + _111
+	//	_1101  = _100 + _1001
+	//	_1111  = _10 + _1101
+	//	_10000 = 1 + _1111
+	//	i149   = ((_10000 << 125 + 1) << 7 + _1001) << 7
+	//	i163   = ((_1101 + i149) << 4 + _11) << 7 + _1111
+	//	i182   = ((i163 << 2 + _11) << 10 + _1001) << 5
+	//	i193   = ((_1001 + i182) << 4 + _1001) << 4 + _1111
+	//	i207   = ((i193 << 3 + 1) << 7 + _1101) << 2
+	//	i220   = ((_11 + i207) << 4 + _11) << 6 + _1001
+	//	i238   = ((i220 << 5 + _1101) << 4 + _11) << 7
+	//	return   ((_111 + i238) << 5 + _1101) << 31
+	//
+	// Operations: 250 squares 26 multiplies
+
+	// Allocate Temporaries.
+	var (
+		t0 = new(Element)
+		t1 = new(Element)
+		t2 = new(Element)
+		t3 = new(Element)
+		t4 = new(Element)
+	)
+
+	// var t0,t1,t2,t3,t4 Element
+	// Step 1: t3 = x^0x2
+	t3.Square(&x)
+
+	// Step 2: t1 = x^0x3
+	t1.Mul(&x, t3)
+
+	// Step 3: z = x^0x4
+	z.Mul(&x, t1)
+
+	// Step 4: t0 = x^0x7
+	t0.Mul(t1, z)
+
+	// Step 5: t2 = x^0x9
+	t2.Mul(t3, t0)
+
+	// Step 6: z = x^0xd
+	z.Mul(z, t2)
+
+	// Step 7: t3 = x^0xf
+	t3.Mul(t3, z)
+
+	// Step 8: t4 = x^0x10
+	t4.Mul(&x, t3)
+
+	// Step 133: t4 = x^0x200000000000000000000000000000000
+	for s := 0; s < 125; s++ {
+		t4.Square(t4)
+	}
+
+	// Step 134: t4 = x^0x200000000000000000000000000000001
+	t4.Mul(&x, t4)
+
+	// Step 141: t4 = x^0x10000000000000000000000000000000080
+	for s := 0; s < 7; s++ {
+		t4.Square(t4)
+	}
+
+	// Step 142: t4 = x^0x10000000000000000000000000000000089
+	t4.Mul(t2, t4)
+
+	// Step 149: t4 = x^0x800000000000000000000000000000004480
+	for s := 0; s < 7; s++ {
+		t4.Square(t4)
+	}
+
+	// Step 150: t4 = x^0x80000000000000000000000000000000448d
+	t4.Mul(z, t4)
+
+	// Step 154: t4 = x^0x80000000000000000000000000000000448d0
+	for s := 0; s < 4; s++ {
+		t4.Square(t4)
+	}
+
+	// Step 155: t4 = x^0x80000000000000000000000000000000448d3
+	t4.Mul(t1, t4)
+
+	// Step 162: t4 = x^0x400000000000000000000000000000002246980
+	for s := 0; s < 7; s++ {
+		t4.Square(t4)
+	}
+
+	// Step 163: t4 = x^0x40000000000000000000000000000000224698f
+	t4.Mul(t3, t4)
+
+	// Step 165: t4 = x^0x100000000000000000000000000000000891a63c
+	for s := 0; s < 2; s++ {
+		t4.Square(t4)
+	}
+
+	// Step 166: t4 = x^0x100000000000000000000000000000000891a63f
+	t4.Mul(t1, t4)
+
+	// Step 176: t4 = x^0x40000000000000000000000000000000224698fc00
+	for s := 0; s < 10; s++ {
+		t4.Square(t4)
+	}
+
+	// Step 177: t4 = x^0x40000000000000000000000000000000224698fc09
+	t4.Mul(t2, t4)
+
+	// Step 182: t4 = x^0x80000000000000000000000000000000448d31f8120
+	for s := 0; s < 5; s++ {
+		t4.Square(t4)
+	}
+
+	// Step 183: t4 = x^0x80000000000000000000000000000000448d31f8129
+	t4.Mul(t2, t4)
+
+	// Step 187: t4 = x^0x80000000000000000000000000000000448d31f81290
+	for s := 0; s < 4; s++ {
+		t4.Square(t4)
+	}
+
+	// Step 188: t4 = x^0x80000000000000000000000000000000448d31f81299
+	t4.Mul(t2, t4)
+
+	// Step 192: t4 = x^0x80000000000000000000000000000000448d31f812990
+	for s := 0; s < 4; s++ {
+		t4.Square(t4)
+	}
+
+	// Step 193: t3 = x^0x80000000000000000000000000000000448d31f81299f
+	t3.Mul(t3, t4)
+
+	// Step 196: t3 = x^0x40000000000000000000000000000000224698fc094cf8
+	for s := 0; s < 3; s++ {
+		t3.Square(t3)
+	}
+
+	// Step 197: t3 = x^0x40000000000000000000000000000000224698fc094cf9
+	t3.Mul(&x, t3)
+
+	// Step 204: t3 = x^0x2000000000000000000000000000000011234c7e04a67c80
+	for s := 0; s < 7; s++ {
+		t3.Square(t3)
+	}
+
+	// Step 205: t3 = x^0x2000000000000000000000000000000011234c7e04a67c8d
+	t3.Mul(z, t3)
+
+	// Step 207: t3 = x^0x80000000000000000000000000000000448d31f81299f234
+	for s := 0; s < 2; s++ {
+		t3.Square(t3)
+	}
+
+	// Step 208: t3 = x^0x80000000000000000000000000000000448d31f81299f237
+	t3.Mul(t1, t3)
+
+	// Step 212: t3 = x^0x80000000000000000000000000000000448d31f81299f2370
+	for s := 0; s < 4; s++ {
+		t3.Square(t3)
+	}
+
+	// Step 213: t3 = x^0x80000000000000000000000000000000448d31f81299f2373
+	t3.Mul(t1, t3)
+
+	// Step 219: t3 = x^0x2000000000000000000000000000000011234c7e04a67c8dcc0
+	for s := 0; s < 6; s++ {
+		t3.Square(t3)
+	}
+
+	// Step 220: t2 = x^0x2000000000000000000000000000000011234c7e04a67c8dcc9
+	t2.Mul(t2, t3)
+
+	// Step 225: t2 = x^0x40000000000000000000000000000000224698fc094cf91b9920
+	for s := 0; s < 5; s++ {
+		t2.Square(t2)
+	}
+
+	// Step 226: t2 = x^0x40000000000000000000000000000000224698fc094cf91b992d
+	t2.Mul(z, t2)
+
+	// Step 230: t2 = x^0x40000000000000000000000000000000224698fc094cf91b992d0
+	for s := 0; s < 4; s++ {
+		t2.Square(t2)
+	}
+
+	// Step 231: t1 = x^0x40000000000000000000000000000000224698fc094cf91b992d3
+	t1.Mul(t1, t2)
+
+	// Step 238: t1 = x^0x2000000000000000000000000000000011234c7e04a67c8dcc96980
+	for s := 0; s < 7; s++ {
+		t1.Square(t1)
+	}
+
+	// Step 239: t0 = x^0x2000000000000000000000000000000011234c7e04a67c8dcc96987
+	t0.Mul(t0, t1)
+
+	// Step 244: t0 = x^0x40000000000000000000000000000000224698fc094cf91b992d30e0
+	for s := 0; s < 5; s++ {
+		t0.Square(t0)
+	}
+
+	// Step 245: z = x^0x40000000000000000000000000000000224698fc094cf91b992d30ed
+	z.Mul(z, t0)
+
+	// Step 276: z = x^0x2000000000000000000000000000000011234c7e04a67c8dcc96987680000000
+	for s := 0; s < 31; s++ {
+		z.Square(z)
+	}
+
+	return z
+}
@@ -0,0 +1,98 @@
+package pallas
+
+import "testing"
+
+// The QUUX test suites of RFC 9380 with expand_message_xmd and BLAKE2b-512, as
+// used by the Zcash GroupHash, computed with an independent implementation of
+// the pasta_curves construction.
+func init() {
+	encodeToG1Vector = encodeTestVector{
+		dst: []byte("QUUX-V01-CS02-with-pallas_XMD:BLAKE2b_SSWU_NU_"),
+		cases: []encodeTestCase{
+			{
+				msg: "", P: point{"0x3fdea446f71898d8ff6f666dcb1fdb82770591b832cc1b995a71bf76f648e7f5", "0x1ef42ba0ba3814407eca7796751b4deaffbf84b2518ee55c8becb9eaf2a38536"},
+				Q: point{"0x3fdea446f71898d8ff6f666dcb1fdb82770591b832cc1b995a71bf76f648e7f5", "0x1ef42ba0ba3814407eca7796751b4deaffbf84b2518ee55c8becb9eaf2a38536"},
+				u: "0x2e1e8ead8d59d39fb1645766ef88a970d28c5d08b8b5a2de697a50f63839fe68",
+			}, {
+				msg: "abc", P: point{"0x30f84c66e459a83f2567d2193e8b64fc0f5868e0dc1010e54b5b07878d61913a", "0x19c53b9bc860c02e3634db23b43bdd0ccae8b13c3acbb12a9c6245d0d24a2b22"},
+				Q: point{"0x30f84c66e459a83f2567d2193e8b64fc0f5868e0dc1010e54b5b07878d61913a", "0x19c53b9bc860c02e3634db23b43bdd0ccae8b13c3acbb12a9c6245d0d24a2b22"},
+				u: "0x3f66d52719a56f85b2d32c5bf1ca08c0cd5b1a92b4b65914bc4abd149c059d70",
+			}, {
+				msg: "abcdef0123456789", P: point{"0x3415891e29450116d72ba0bcd5c71cb622dc806a39f97ada64ff686b9355ec3e", "0x34b4595531912a2cf3abfb83f9885c73fb68e4268311438598a787acd7c6b1cc"},
+				Q: point{"0x3415891e29450116d72ba0bcd5c71cb622dc806a39f97ada64ff686b9355ec3e", "0x34b4595531912a2cf3abfb83f9885c73fb68e4268311438598a787acd7c6b1cc"},
+				u: "0x190efa7037bae5803077816c78c8692e24a44ae371e31ae9509c6f135ea474df",
+			}, {
+				msg: "q128_qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq", P: point{"0x23a347642a9729cf488245ee9b3be39c51a659b02839667b430139b18ef18841", "0x3991356058e5305a8c205b2b1045e733b15a2ba45d829c33449f5d20e0244b05"},
+				Q: point{"0x23a347642a9729cf488245ee9b3be39c51a659b02839667b430139b18ef18841", "0x3991356058e5305a8c205b2b1045e733b15a2ba45d829c33449f5d20e0244b05"},
+				u: "0x15f2439400d7fbeefc5e568dc78f44f2dceff40fbeb6a092d8ad214826418299",
+			}, {
+				msg: "a512_aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", P: point{"0x171c76665fe119c74bebce6cb6ecac4c0239fbbb68b6a7834089ca848287f32c", "0x12a8157bc073a043e00cc71a897d49c7dc087d866620f2e6f5c5dab5835d7c3b"},
+				Q: point{"0x171c76665fe119c74bebce6cb6ecac4c0239fbbb68b6a7834089ca848287f32c", "0x12a8157bc073a043e00cc71a897d49c7dc087d866620f2e6f5c5dab5835d7c3b"},
+				u: "0x13be4847954d534b3a12072c8a390910aea44b5ca50033cdb66151080baaf20",
+			},
+		}}
+	hashToG1Vector = hashTestVector{
+		dst: []byte("QUUX-V01-CS02-with-pallas_XMD:BLAKE2b_SSWU_RO_"),
+		cases: []hashTestCase{
+			{
+				msg: "", P: point{"0xf6bb45087b7a5d8ebc9ad4d461e1b4a23b0d8ad1ff76f2b49e7074e11baeec3", "0x31bf3b8ace405925e5a820f99818e747ad49f8cdcd40149cef7d32c6fd55f730"},
+				Q0: point{"0x2b31538f7695d28fa0f520aa06b5e3cf6087916f0095e225ab11e8b440e5a7d0", "0x33863d8389649262059f8fafba106c784db3490691bacf1c61589c451f05716"},
+				Q1: point{"0xc5cbbea0a40972dcf16407a6abc40fded68e3503b43e2ecaae9466e7a7cc52a", "0x3a1f0dbb998e1091c2bbe1a0f3160121106ee059608443ffe706074d9c1fe7f9"},
+				u0: "0x2d7c3b67af8d8631084c676f2d2ff190abef6a2b6d56a61760a1e589b42f201d", u1: "0x120e4ca96143ff941e2f0b9d57f0010196cce42113fa3a1317ea8d31758f3180",
+			}, {
+				msg: "abc", P: point{"0x3e61ee4d94caafaa7a7d620a9e780715f6b742e0065306ad33ac633d28559cff", "0x24d5aedbe26c02ddc63a66f8c925091ab28b0c9df2bab31a4f79c792362ea584"},
+				Q0: point{"0x326842e34e57fa8db20f8b8e4552f326fa85a592fb132e9347ad80b2e7dfe389", "0x263258aba88bf13fd1693f789937cda854d13cdb5c57a254621d88b3e4a6a0ff"},
+				Q1: point{"0x301b8a8295376a4f5fb982deb372c17af14c19f68cb7ed58bd8f4dd48383e713", "0x1439d94092438c07d23b85e787342dd758db544f4f9086e4e5047a459e3f5183"},
+				u0: "0xf13ef2ddf1cb1efb7a19d77de025c12acf04abdb60959306d9bb788a4f452", u1: "0x1fbd0e232e193db728a8a678804a2b6b5231cc3e854f8d9a5c682d2fcc16a344",
+			}, {
+				msg: "abcdef0123456789", P: point{"0x12a7b0252e44a93ef49e5721beb899fb75cb7712912e77a1f404ffea55cc0bee", "0x1a981ff30cb693d712e3b6b51709cdb0c01a1335079686316a950bde7d7478bc"},
+				Q0: point{"0x1b7285efdc64f8357423b30ffbd8defb95f7556b416f52f569e82b0f7b6a1a0c", "0x337ff445a3732941c28091d9569ac3bcc4f86a3980e0bfb847b7ad282ac8a937"},
+				Q1: point{"0x35b5b861213498d22845a00f4d300b1c6cfe7bd1fe08077143185afeb89a03d", "0x45f145ab78adf4ed902ae814648c561b995c0dc8063e22242a534304871082c"},
+				u0: "0x201fc9c8511b717d271fb249b2a5b547ca0868bf5efe1be5441942d9812823f9", u1: "0x12d1482bc5767d9ed4e33b037feceefb5af7606ecd858bd834fb7a75c87329b7",
+			}, {
+				msg: "q128_qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq", P: point{"0x2d081cab7f42ceffc08ae957cf796559efb3385274e7d2a71e98db61fedf8dc4", "0x14f409c05f81543d0f9c6de32f61298519fe1a846254e246c946f7fbb9d9ca2c"},
+				Q0: point{"0x18289aaa6f23ad0d5b0c6df115f054ef3df945473907b8acbe28f8036fddf712", "0x18b560369f763f6c5fc851f982921dfb2c99864588dbf4c40be4cc60d8306e6d"},
+				Q1: point{"0x13a177259e677a9beec8b31b5a9f3442195717af10a27cf750635a587a2962c0", "0x138adae750b9b6f74e5f0bd3a7f1d14d618bd93e13a9c7541f7369c605b6a737"},
+				u0: "0x214f71050ff51bff33f449d66be74a4a5d2dd8e981f25d3344f78e03b68308b2", u1: "0x1a6eec4585943f10851f1f7e7135f1957f4d401c47f1bc6ec874067b00e648c8",
+			}, {
+				msg: "a512_aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", P: point{"0x36d377940f8f516567b0806749e1f35e738517686f9753b8b5fa2477cf55ec77", "0x25787dbd087a91da64b07b7c8fddd030c724200ca061322584729795d42bd0fe"},
+				Q0: point{"0x2135c6f56aec870e2d798f78ad69c55060ab2854b6164ebf0b3e8a61c63c7c90", "0x2d867f5f457e066a89fa98dd9a5d3fd7fa0aa47672dbc530f803d541c8d7e125"},
+				Q1: point{"0xe91f56118313c170c604c688543060dbe3ad5bb44378de10713c0956f1662b4", "0x248af624314e4bc51b4f75e5117cdfa3cf896a865290b37593e7de4a9cc9ef5"},
+				u0: "0x2d47f811144738ff7121b4ed27958ac206355b8e15ec25253408591aeabc4ed6", u1: "0x3fc04c1f0db6fc11bd68cb5ce1b304f863572ac7544a0a2385ce4b08aa33febf",
+			},
+		}}
+}
+
+// TestGroupHashOrchard checks GroupHash against fixed bases of the Orchard
+// protocol (Zcash protocol specification §5.4.1.9 and §5.4.8.3), encoded as
+// in the orchard crate: x in little endian with the parity of y in the top bit.
+func TestGroupHashOrchard(t *testing.T) {
+	for _, c := range []struct {
+		domainPrefix, msg string
+		expected          [32]byte
+	}{
+		{ // spend authorization base G^Orchard
+			"z.cash:Orchard", "G",
+			[32]byte{99, 201, 117, 184, 132, 114, 26, 141, 12, 161, 112, 123, 227, 12, 127, 12, 95, 68, 95, 62, 124, 24, 141, 59, 6, 214, 241, 40, 179, 35, 85, 183},
+		},
+		{ // value commitment base V^Orchard
+			"z.cash:Orchard-cv", "v",
+			[32]byte{103, 67, 249, 58, 110, 189, 167, 42, 140, 124, 90, 43, 127, 163, 4, 254, 50, 178, 155, 79, 112, 106, 168, 247, 66, 15, 61, 142, 122, 89, 112, 47},
+		},
+	} {
+		p, err := GroupHash(c.domainPrefix, []byte(c.msg))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var encoded [32]byte
+		x := p.X.Bytes()
+		for i := range x {
+			encoded[i] = x[len(x)-1-i]
+		}
+		y := p.Y.Bits()
+		encoded[31] |= byte(y[0]&1) << 7
+		if encoded != c.expected {
+			t.Fatalf("GroupHash(%q, %q): expected %v, got %v", c.domainPrefix, c.msg, c.expected, encoded)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ipa
+
+import (
+	"hash"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/pallas"
+	"github.com/consensys/gnark-crypto/ecc/pallas/fr"
+	"github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+// BatchOpeningProof proof of the evaluations of several polynomials, each at its own point.
+//
+// With a challenge r, the prover commits to g = ∑ rⁱ⋅(fᵢ - yᵢ)/(X - zᵢ) in D. With a second
+// challenge t, it opens h - g at t, where h = ∑ rⁱ⋅fᵢ/(t - zᵢ) is committed in ∑ rⁱ/(t - zᵢ)⋅Cᵢ:
+// its value ∑ rⁱ⋅yᵢ/(t - zᵢ) is computed by the verifier.
+type BatchOpeningProof struct {
+	// commitment to the aggregated quotient g
+	D Digest
+
+	// opening proof of h - g at t
+	Proof OpeningProof
+
+	// yᵢ = fᵢ(zᵢ)
+	ClaimedValues []fr.Element
+}
+
+// BatchOpen computes a batch opening proof of polynomials[i], committed in digests[i],
+// at points[i]. The points need not be distinct.
+func BatchOpen(polynomials [][]fr.Element, digests []Digest, points []fr.Element, hf hash.Hash, srs *SRS, dataTranscript ...[]byte) (BatchOpeningProof, error) {
+	if len(polynomials) == 0 {
+		return BatchOpeningProof{}, ErrZeroNbDigests
+	}
+	if len(polynomials) != len(digests) {
+		return BatchOpeningProof{}, ErrInvalidNbDigests
+	}
+	if len(polynomials) != len(points) {
+		return BatchOpeningProof{}, ErrInvalidNbPoints
+	}
+	n := len(srs.Basis)
+	for i := range polynomials {
+		if len(polynomials[i]) == 0 || len(polynomials[i]) > n {
+			return BatchOpeningProof{}, ErrInvalidPolynomialSize
+		}
+	}
+
+	var res BatchOpeningProof
+	res.ClaimedValues = make([]fr.Element, len(polynomials))
+	for i := range polynomials {
+		res.ClaimedValues[i] = innerProduct(polynomials[i], srs.lagrange(&points[i]))
+	}
+
+	fs := fiatshamir.NewTranscript(hf, "r", "t")
+	r, err := deriveR(fs, digests, points, res.ClaimedValues, dataTranscript)
+	if err != nil {
+		return BatchOpeningProof{}, err
+	}
+
+	// g = ∑ rⁱ⋅(fᵢ - yᵢ)/(X - zᵢ)
+	g := make([]fr.Element, n)
+	var ri, tmp fr.Element
+	ri.SetOne()
+	for i := range polynomials {
+		q := srs.quotient(polynomials[i], &points[i], &res.ClaimedValues[i])
+		for j := range g {
+			tmp.Mul(&q[j], &ri)
+			g[j].Add(&g[j], &tmp)
+		}
+		ri.Mul(&ri, &r)
+	}
+	if res.D, err = Commit(g, srs); err != nil {
+		return BatchOpeningProof{}, err
+	}
+
+	t, err := deriveT(fs, &res.D)
+	if err != nil {
+		return BatchOpeningProof{}, err
+	}
+	c, err := aggregationCoefficients(&r, &t, points)
+	if err != nil {
+		return BatchOpeningProof{}, err
+	}
+
+	// h - g, committed in E - D
+	for i := range polynomials {
+		for j := range polynomials[i] {
+			tmp.Mul(&polynomials[i][j], &c[i])
+			g[j].Sub(&g[j], &tmp)
+		}
+	}
+	for j := range g {
+		g[j].Neg(&g[j])
+	}
+	var e Digest
+	if _, err := e.MultiExp(digests, c, ecc.MultiExpConfig{}); err != nil {
+		return BatchOpeningProof{}, err
+	}
+	e.Sub(&e, &res.D)
+
+	if res.Proof, err = Open(g, &e, t, hf, srs, dataTranscript...); err != nil {
+		return BatchOpeningProof{}, err
+	}
+	return res, nil
+}
+
+// BatchVerify verifies a batch opening proof of the polynomials committed in digests,
+// at points.
+func BatchVerify(digests []Digest, points []fr.Element, proof *BatchOpeningProof, hf hash.Hash, srs *SRS, dataTranscript ...[]byte) error {
+	if len(digests) == 0 {
+		return ErrZeroNbDigests
+	}
+	if len(digests) != len(proof.ClaimedValues) {
+		return ErrInvalidNbDigests
+	}
+	if len(digests) != len(points) {
+		return ErrInvalidNbPoints
+	}
+
+	fs := fiatshamir.NewTranscript(hf, "r", "t")
+	r, err := deriveR(fs, digests, points, proof.ClaimedValues, dataTranscript)
+	if err != nil {
+		return err
+	}
+	t, err := deriveT(fs, &proof.D)
+	if err != nil {
+		return err
+	}
+	c, err := aggregationCoefficients(&r, &t, points)
+	if err != nil {
+		return err
+	}
+
+	// (h - g)(t) = ∑ rⁱ⋅yᵢ/(t - zᵢ), since g(t) = h(t) - ∑ rⁱ⋅yᵢ/(t - zᵢ)
+	y := innerProduct(c, proof.ClaimedValues)
+	if !y.Equal(&proof.Proof.ClaimedValue) {
+		return ErrVerifyBatchOpening
+	}
+
+	var e Digest
+	if _, err := e.MultiExp(digests, c, ecc.MultiExpConfig{}); err != nil {
+		return err
+	}
+	e.Sub(&e, &proof.D)
+
+	if err := Verify(&e, &proof.Proof, t, hf, srs, dataTranscript...); err != nil {
+		return ErrVerifyBatchOpening
+	}
+	return nil
+}
+
+// quotient returns the evaluations of (f - y)/(X - z) on the domain, y being f(z)
+func (srs *SRS) quotient(f []fr.Element, z, y *fr.Element) []fr.Element {
+	n := len(srs.Basis)
+	res := make([]fr.Element, n)
+	var tmp fr.Element
+	for i := range res {
+		tmp.SetUint64(uint64(i))
+		res[i].Sub(&tmp, z)
+	}
+	// if z = m is in the domain, the m-th entry is 0 and is left as is
+	res = fr.BatchInvert(res)
+
+	m := srs.inDomain(z)
+	for i := range res {
+		if i == m {
+			continue
+		}
+		if i < len(f) {
+			tmp.Sub(&f[i], y)
+		} else {
+			tmp.Neg(y)
+		}
+		res[i].Mul(&res[i], &tmp)
+	}
+
+	if m >= 0 {
+		// the quotient at m is f'(m) = ∑_{i≠m} (f(i) - f(m))⋅Lᵢ'(m), with Lᵢ'(m) = A'(m)/(A'(i)⋅(m - i)),
+		// that is -A'(m)⋅∑_{i≠m} wᵢ⋅q(i)
+		var acc fr.Element
+		for i := range res {
+			if i == m {
+				continue
+			}
+			tmp.Mul(&res[i], &srs.weights[i])
+			acc.Add(&acc, &tmp)
+		}
+		tmp.Inverse(&srs.weights[m])
+		res[m].Mul(&acc, &tmp).Neg(&res[m])
+	}
+	return res
+}
+
+// aggregationCoefficients returns rⁱ/(t - zᵢ)
+func aggregationCoefficients(r, t *fr.Element, points []fr.Element) ([]fr.Element, error) {
+	res := make([]fr.Element, len(points))
+	for i := range points {
+		res[i].Sub(t, &points[i])
+		if res[i].IsZero() {
+			return nil, errZeroChallenge
+		}
+	}
+	res = fr.BatchInvert(res)
+	var ri fr.Element
+	ri.SetOne()
+	for i := range res {
+		res[i].Mul(&res[i], &ri)
+		ri.Mul(&ri, r)
+	}
+	return res, nil
+}
+
+// deriveR derives the challenge r, binded to the digests, the points, the claimed
+// values and dataTranscript
+func deriveR(fs *fiatshamir.Transcript, digests []Digest, points, claimedValues []fr.Element, dataTranscript [][]byte) (fr.Element, error) {
+	for i := range digests {
+		b := digests[i].RawBytes()
+		if err := fs.Bind("r", b[:]); err != nil {
+			return fr.Element{}, err
+		}
+		if err := fs.Bind("r", points[i].Marshal()); err != nil {
+			return fr.Element{}, err
+		}
+		if err := fs.Bind("r", claimedValues[i].Marshal()); err != nil {
+			return fr.Element{}, err
+		}
+	}
+	for i := range dataTranscript {
+		if err := fs.Bind("r", dataTranscript[i]); err != nil {
+			return fr.Element{}, err
+		}
+	}
+	return computeChallenge(fs, "r")
+}
+
+// deriveT derives the challenge t, binded to the commitment to the aggregated quotient
+func deriveT(fs *fiatshamir.Transcript, d *pallas.G1Affine) (fr.Element, error) {
+	b := d.RawBytes()
+	if err := fs.Bind("t", b[:]); err != nil {
+		return fr.Element{}, err
+	}
+	return computeChallenge(fs, "t")
+}
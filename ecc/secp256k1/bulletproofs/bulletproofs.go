@@ -0,0 +1,699 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bulletproofs
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash"
+	"math/big"
+	"math/bits"
+	"strconv"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/fr"
+	"github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+var (
+	ErrInvalidNbBits        = errors.New("number of bits must be a power of two, at most 64")
+	ErrInvalidAggregation   = errors.New("number of values must be a power of two, at most the maximum aggregation of the parameters")
+	ErrInvalidNbBlindings   = errors.New("number of blindings is not the same as the number of values")
+	ErrValueOutOfRange      = errors.New("value out of range")
+	ErrInvalidNbCommitments = errors.New("number of commitments is not the same as the number of proofs")
+	ErrInvalidProofSize     = errors.New("number of rounds of the proof does not match the number of values")
+	ErrVerifyRangeProof     = errors.New("can't verify range proof")
+	errZeroChallenge        = errors.New("challenge is zero")
+)
+
+// Params public parameters of range proofs of NbBits values. They are transparent:
+// anyone can derive them from a seed with NewParams.
+type Params struct {
+	NbBits int // n, the proven values are in [0, 2ⁿ)
+
+	G, H   secp256k1.G1Affine   // a value v is committed with a blinding γ as v⋅G + γ⋅H
+	U      secp256k1.G1Affine   // binds the inner product
+	Gs, Hs []secp256k1.G1Affine // vector generators, n per aggregated value
+}
+
+// InnerProductProof proof of knowledge of vectors a, b such that P = ⟨a, G⟩ + ⟨b, H⟩ + ⟨a, b⟩⋅U,
+// folded down to single values A and B.
+type InnerProductProof struct {
+	// commitments to the cross terms of each folding round
+	L, R []secp256k1.G1Affine
+
+	A, B fr.Element
+}
+
+// Proof range proof of m values, see Prove.
+type Proof struct {
+	A      secp256k1.G1Affine // commitment to the bits aₗ of the values and to aᵣ = aₗ - 1
+	S      secp256k1.G1Affine // commitment to the blinding vectors sₗ, sᵣ
+	T1, T2 secp256k1.G1Affine // commitments to the coefficients of t(X) = ⟨l(X), r(X)⟩
+
+	TauX fr.Element // blinding of t(x)
+	Mu   fr.Element // blinding of A + x⋅S
+	THat fr.Element // t(x)
+
+	// proof that ⟨l(x), r(x)⟩ = t(x)
+	InnerProduct InnerProductProof
+}
+
+// NewParams derives the parameters of range proofs of nbBits values, aggregating up to
+// maxAggregation values, from seed: each generator is HashToG1(name, seed), where name is
+// "G", "H" or "U", or "G" or "H" followed by the index i of Gᵢ or Hᵢ as a big endian uint64.
+func NewParams(nbBits, maxAggregation int, seed string) (*Params, error) {
+	if nbBits < 1 || nbBits > 64 || nbBits&(nbBits-1) != 0 {
+		return nil, ErrInvalidNbBits
+	}
+	if maxAggregation < 1 || maxAggregation&(maxAggregation-1) != 0 {
+		return nil, ErrInvalidAggregation
+	}
+	dst := []byte(seed)
+	params := Params{NbBits: nbBits}
+
+	var err error
+	if params.G, err = secp256k1.HashToG1([]byte("G"), dst); err != nil {
+		return nil, err
+	}
+	if params.H, err = secp256k1.HashToG1([]byte("H"), dst); err != nil {
+		return nil, err
+	}
+	if params.U, err = secp256k1.HashToG1([]byte("U"), dst); err != nil {
+		return nil, err
+	}
+
+	size := nbBits * maxAggregation
+	params.Gs = make([]secp256k1.G1Affine, size)
+	params.Hs = make([]secp256k1.G1Affine, size)
+	errs := make([]error, size)
+	parallel.Execute(size, func(start, end int) {
+		var msg [9]byte
+		for i := start; i < end; i++ {
+			binary.BigEndian.PutUint64(msg[1:], uint64(i))
+			msg[0] = 'G'
+			if params.Gs[i], errs[i] = secp256k1.HashToG1(msg[:], dst); errs[i] != nil {
+				continue
+			}
+			msg[0] = 'H'
+			params.Hs[i], errs[i] = secp256k1.HashToG1(msg[:], dst)
+		}
+	})
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &params, nil
+}
+
+// MaxAggregation returns the maximum number of values of a proof
+func (params *Params) MaxAggregation() int {
+	return len(params.Gs) / params.NbBits
+}
+
+// checkAggregation returns an error if m values can't be proven together
+func (params *Params) checkAggregation(m int) error {
+	if m < 1 || m&(m-1) != 0 || m > params.MaxAggregation() {
+		return ErrInvalidAggregation
+	}
+	return nil
+}
+
+// Commit returns the Pedersen commitment v⋅G + blinding⋅H
+func Commit(v uint64, blinding *fr.Element, params *Params) secp256k1.G1Affine {
+	var res secp256k1.G1Affine
+	var s [2]fr.Element
+	s[0].SetUint64(v)
+	s[1].Set(blinding)
+	res.MultiExp([]secp256k1.G1Affine{params.G, params.H}, s[:], ecc.MultiExpConfig{})
+	return res
+}
+
+// Prove computes a range proof of values, committed with blindings as Commit(values[j], &blindings[j], params).
+// The number of values must be a power of two.
+//
+// With n = params.NbBits, aₗ the bits of the values, aᵣ = aₗ - 1 and challenges y, z, the prover shows that
+//
+//	t(X) = ⟨l(X), r(X)⟩, where l(X) = aₗ - z + sₗ⋅X and r(X) = yⁿᵐ∘(aᵣ + z + sᵣ⋅X) + ∑ⱼ zʲ⁺²⋅(0ⁿʲ ∥ 2ⁿ ∥ 0ⁿ⁽ᵐ⁻ʲ⁻¹⁾)
+//
+// has constant coefficient ∑ⱼ zʲ⁺²⋅vⱼ + δ(y, z), with an inner product argument at a challenge x.
+func Prove(values []uint64, blindings []fr.Element, params *Params, hf hash.Hash, dataTranscript ...[]byte) (Proof, error) {
+	m := len(values)
+	if err := params.checkAggregation(m); err != nil {
+		return Proof{}, err
+	}
+	if len(blindings) != m {
+		return Proof{}, ErrInvalidNbBlindings
+	}
+	n := params.NbBits
+	for _, v := range values {
+		if n < 64 && v>>n != 0 {
+			return Proof{}, ErrValueOutOfRange
+		}
+	}
+	nm := n * m
+	commitments := make([]secp256k1.G1Affine, m)
+	for j := range values {
+		commitments[j] = Commit(values[j], &blindings[j], params)
+	}
+	gs, hs := params.Gs[:nm], params.Hs[:nm]
+
+	// aₗ holds the bits of the values, aᵣ = aₗ - 1
+	aL := make([]fr.Element, nm)
+	aR := make([]fr.Element, nm)
+	for j, v := range values {
+		for k := 0; k < n; k++ {
+			if (v>>k)&1 == 1 {
+				aL[j*n+k].SetOne()
+			} else {
+				aR[j*n+k].SetOne()
+				aR[j*n+k].Neg(&aR[j*n+k])
+			}
+		}
+	}
+	var alpha, rho fr.Element
+	if _, err := alpha.SetRandom(); err != nil {
+		return Proof{}, err
+	}
+	if _, err := rho.SetRandom(); err != nil {
+		return Proof{}, err
+	}
+	sL, err := randomVector(nm)
+	if err != nil {
+		return Proof{}, err
+	}
+	sR, err := randomVector(nm)
+	if err != nil {
+		return Proof{}, err
+	}
+
+	var proof Proof
+	// A = ⟨aₗ, G⟩ + ⟨aᵣ, H⟩ + α⋅H, S = ⟨sₗ, G⟩ + ⟨sᵣ, H⟩ + ρ⋅H
+	if err := multiExp(&proof.A, [][]secp256k1.G1Affine{gs, hs, {params.H}}, [][]fr.Element{aL, aR, {alpha}}); err != nil {
+		return Proof{}, err
+	}
+	if err := multiExp(&proof.S, [][]secp256k1.G1Affine{gs, hs, {params.H}}, [][]fr.Element{sL, sR, {rho}}); err != nil {
+		return Proof{}, err
+	}
+
+	fs := newTranscript(hf, nm)
+	y, z, err := deriveYZ(fs, n, commitments, &proof.A, &proof.S, dataTranscript)
+	if err != nil {
+		return Proof{}, err
+	}
+
+	var two fr.Element
+	two.SetUint64(2)
+	yPow := powers(&y, nm)
+	zPow := powers(&z, m+2)
+	twoPow := powers(&two, n)
+
+	// l(X) = l₀ + sₗ⋅X, r(X) = r₀ + r₁⋅X
+	l0 := make([]fr.Element, nm)
+	r0 := make([]fr.Element, nm)
+	r1 := make([]fr.Element, nm)
+	var tmp fr.Element
+	for i := 0; i < nm; i++ {
+		l0[i].Sub(&aL[i], &z)
+		r0[i].Add(&aR[i], &z).Mul(&r0[i], &yPow[i])
+		tmp.Mul(&zPow[2+i/n], &twoPow[i%n])
+		r0[i].Add(&r0[i], &tmp)
+		r1[i].Mul(&sR[i], &yPow[i])
+	}
+
+	// t₁ = ⟨l₀, r₁⟩ + ⟨sₗ, r₀⟩, t₂ = ⟨sₗ, r₁⟩
+	t1 := innerProduct(l0, r1)
+	tmp = innerProduct(sL, r0)
+	t1.Add(&t1, &tmp)
+	t2 := innerProduct(sL, r1)
+
+	var tau1, tau2 fr.Element
+	if _, err := tau1.SetRandom(); err != nil {
+		return Proof{}, err
+	}
+	if _, err := tau2.SetRandom(); err != nil {
+		return Proof{}, err
+	}
+	if err := multiExp(&proof.T1, [][]secp256k1.G1Affine{{params.G, params.H}}, [][]fr.Element{{t1, tau1}}); err != nil {
+		return Proof{}, err
+	}
+	if err := multiExp(&proof.T2, [][]secp256k1.G1Affine{{params.G, params.H}}, [][]fr.Element{{t2, tau2}}); err != nil {
+		return Proof{}, err
+	}
+
+	x, err := deriveX(fs, &proof.T1, &proof.T2)
+	if err != nil {
+		return Proof{}, err
+	}
+
+	// l = l(x), r = r(x), t̂ = ⟨l, r⟩
+	l, r := l0, r0
+	for i := 0; i < nm; i++ {
+		tmp.Mul(&sL[i], &x)
+		l[i].Add(&l[i], &tmp)
+		tmp.Mul(&r1[i], &x)
+		r[i].Add(&r[i], &tmp)
+	}
+	proof.THat = innerProduct(l, r)
+
+	// τₓ = τ₂⋅x² + τ₁⋅x + ∑ zʲ⁺²⋅γⱼ, μ = α + ρ⋅x
+	proof.TauX.Mul(&tau2, &x).Add(&proof.TauX, &tau1).Mul(&proof.TauX, &x)
+	for j := range blindings {
+		tmp.Mul(&zPow[2+j], &blindings[j])
+		proof.TauX.Add(&proof.TauX, &tmp)
+	}
+	proof.Mu.Mul(&rho, &x).Add(&proof.Mu, &alpha)
+
+	w, err := deriveW(fs, &proof.TauX, &proof.Mu, &proof.THat)
+	if err != nil {
+		return Proof{}, err
+	}
+	var u secp256k1.G1Affine
+	u.ScalarMultiplication(&params.U, w.BigInt(new(big.Int)))
+
+	// the inner product argument is on the basis G, H' with H'ᵢ = y⁻ⁱ⋅Hᵢ
+	var yInv fr.Element
+	yInv.Inverse(&y)
+	yInvPow := powers(&yInv, nm)
+	g := make([]secp256k1.G1Affine, nm)
+	copy(g, gs)
+	h := make([]secp256k1.G1Affine, nm)
+	parallel.Execute(nm, func(start, end int) {
+		var s big.Int
+		for i := start; i < end; i++ {
+			h[i].ScalarMultiplication(&hs[i], yInvPow[i].BigInt(&s))
+		}
+	})
+
+	if proof.InnerProduct, err = proveInnerProduct(fs, g, h, &u, l, r); err != nil {
+		return Proof{}, err
+	}
+	return proof, nil
+}
+
+// proveInnerProduct proves that P = ⟨a, g⟩ + ⟨b, h⟩ + ⟨a, b⟩⋅u. For each round, the vectors
+// are split in halves (lo, hi) and folded with a challenge xᵢ:
+//
+//	Lᵢ = ⟨a_lo, g_hi⟩ + ⟨b_hi, h_lo⟩ + ⟨a_lo, b_hi⟩⋅u
+//	Rᵢ = ⟨a_hi, g_lo⟩ + ⟨b_lo, h_hi⟩ + ⟨a_hi, b_lo⟩⋅u
+//	a ← xᵢ⋅a_lo + xᵢ⁻¹⋅a_hi,	b ← xᵢ⁻¹⋅b_lo + xᵢ⋅b_hi
+//	g ← xᵢ⁻¹⋅g_lo + xᵢ⋅g_hi,	h ← xᵢ⋅h_lo + xᵢ⁻¹⋅h_hi
+//
+// g, h, a and b are modified.
+func proveInnerProduct(fs *fiatshamir.Transcript, g, h []secp256k1.G1Affine, u *secp256k1.G1Affine, a, b []fr.Element) (InnerProductProof, error) {
+	nbRounds := bits.TrailingZeros(uint(len(a)))
+	var res InnerProductProof
+	res.L = make([]secp256k1.G1Affine, nbRounds)
+	res.R = make([]secp256k1.G1Affine, nbRounds)
+	for i := 0; i < nbRounds; i++ {
+		k := len(a) / 2
+		aLo, aHi := a[:k], a[k:]
+		bLo, bHi := b[:k], b[k:]
+		gLo, gHi := g[:k], g[k:]
+		hLo, hHi := h[:k], h[k:]
+
+		cL, cR := innerProduct(aLo, bHi), innerProduct(aHi, bLo)
+		if err := multiExp(&res.L[i], [][]secp256k1.G1Affine{gHi, hLo, {*u}}, [][]fr.Element{aLo, bHi, {cL}}); err != nil {
+			return InnerProductProof{}, err
+		}
+		if err := multiExp(&res.R[i], [][]secp256k1.G1Affine{gLo, hHi, {*u}}, [][]fr.Element{aHi, bLo, {cR}}); err != nil {
+			return InnerProductProof{}, err
+		}
+
+		x, err := deriveRoundChallenge(fs, i, &res.L[i], &res.R[i])
+		if err != nil {
+			return InnerProductProof{}, err
+		}
+		var xInv, tmp fr.Element
+		xInv.Inverse(&x)
+
+		for j := 0; j < k; j++ {
+			aLo[j].Mul(&aLo[j], &x)
+			tmp.Mul(&aHi[j], &xInv)
+			aLo[j].Add(&aLo[j], &tmp)
+			bLo[j].Mul(&bLo[j], &xInv)
+			tmp.Mul(&bHi[j], &x)
+			bLo[j].Add(&bLo[j], &tmp)
+		}
+		fold(gLo, gHi, &xInv, &x)
+		fold(hLo, hHi, &x, &xInv)
+
+		a, b, g, h = aLo, bLo, gLo, hLo
+	}
+	res.A, res.B = a[0], b[0]
+	return res, nil
+}
+
+// Verify verifies a range proof of the values committed in commitments.
+func Verify(proof *Proof, commitments []secp256k1.G1Affine, params *Params, hf hash.Hash, dataTranscript ...[]byte) error {
+	return BatchVerify([]Proof{*proof}, [][]secp256k1.G1Affine{commitments}, params, hf, dataTranscript...)
+}
+
+// BatchVerify verifies the range proofs proofs[i] of the values committed in commitments[i],
+// with a single multi scalar multiplication.
+//
+// Each proof is valid iff, with s = ⊗ᵢ(xᵢ⁻¹, xᵢ) the folding coefficients of its inner product
+// argument, y⁻ⁿᵐ, zʲ⁺² and 2ⁿ as in Prove and u = [w]U:
+//
+//	t̂⋅G + τₓ⋅H = ∑ zʲ⁺²⋅Vⱼ + δ(y, z)⋅G + x⋅T₁ + x²⋅T₂
+//	A + x⋅S - μ⋅H + ∑ (xᵢ²⋅Lᵢ + xᵢ⁻²⋅Rᵢ) - z⋅⟨1, G⟩ + ⟨z + zʲ⁺²⋅2ⁿ∘y⁻ⁿᵐ, H⟩ + t̂⋅u = a⋅⟨s, G⟩ + b⋅⟨s⁻¹∘y⁻ⁿᵐ, H⟩ + a⋅b⋅u
+//
+// The equations of all proofs are combined with random coefficients.
+func BatchVerify(proofs []Proof, commitments [][]secp256k1.G1Affine, params *Params, hf hash.Hash, dataTranscript ...[]byte) error {
+	if len(proofs) == 0 || len(proofs) != len(commitments) {
+		return ErrInvalidNbCommitments
+	}
+	n := params.NbBits
+	maxNM := 0
+	for p := range proofs {
+		m := len(commitments[p])
+		if err := params.checkAggregation(m); err != nil {
+			return err
+		}
+		nbRounds := bits.TrailingZeros(uint(n * m))
+		if len(proofs[p].InnerProduct.L) != nbRounds || len(proofs[p].InnerProduct.R) != nbRounds {
+			return ErrInvalidProofSize
+		}
+		if n*m > maxNM {
+			maxNM = n * m
+		}
+	}
+
+	// scalars of the shared generators
+	gsScalars := make([]fr.Element, maxNM)
+	hsScalars := make([]fr.Element, maxNM)
+	var gScalar, hScalar, uScalar fr.Element
+
+	// the points specific to each proof, and their scalars
+	var points []secp256k1.G1Affine
+	var scalars []fr.Element
+
+	var two, sum2 fr.Element
+	two.SetUint64(2)
+	twoPow := powers(&two, n)
+	for k := range twoPow {
+		sum2.Add(&sum2, &twoPow[k])
+	}
+
+	for p := range proofs {
+		proof := &proofs[p]
+		m := len(commitments[p])
+		nm := n * m
+
+		fs := newTranscript(hf, nm)
+		y, z, err := deriveYZ(fs, n, commitments[p], &proof.A, &proof.S, dataTranscript)
+		if err != nil {
+			return err
+		}
+		x, err := deriveX(fs, &proof.T1, &proof.T2)
+		if err != nil {
+			return err
+		}
+		w, err := deriveW(fs, &proof.TauX, &proof.Mu, &proof.THat)
+		if err != nil {
+			return err
+		}
+		nbRounds := len(proof.InnerProduct.L)
+		xs := make([]fr.Element, nbRounds)
+		for i := range xs {
+			if xs[i], err = deriveRoundChallenge(fs, i, &proof.InnerProduct.L[i], &proof.InnerProduct.R[i]); err != nil {
+				return err
+			}
+		}
+		xsInv := fr.BatchInvert(xs)
+
+		// c combines the two equations of the proof, and weight the proofs
+		var c, weight fr.Element
+		if _, err := c.SetRandom(); err != nil {
+			return err
+		}
+		if _, err := weight.SetRandom(); err != nil {
+			return err
+		}
+
+		var yInv fr.Element
+		yInv.Inverse(&y)
+		yPow := powers(&y, nm)
+		yInvPow := powers(&yInv, nm)
+		zPow := powers(&z, m+3)
+
+		// δ(y, z) = (z - z²)⋅⟨1, yⁿᵐ⟩ - ∑ zʲ⁺³⋅⟨1, 2ⁿ⟩
+		var delta, sumY, tmp, tmp2 fr.Element
+		for i := range yPow {
+			sumY.Add(&sumY, &yPow[i])
+		}
+		delta.Sub(&z, &zPow[2]).Mul(&delta, &sumY)
+		for j := 0; j < m; j++ {
+			tmp.Mul(&zPow[3+j], &sum2)
+			delta.Sub(&delta, &tmp)
+		}
+
+		// s and s⁻¹, the first round folding the most significant bit of the indices
+		s := make([]fr.Element, 1, nm)
+		sInv := make([]fr.Element, 1, nm)
+		s[0].SetOne()
+		sInv[0].SetOne()
+		for i := 0; i < nbRounds; i++ {
+			k := len(s)
+			s, sInv = s[:2*k], sInv[:2*k]
+			for j := k - 1; j >= 0; j-- {
+				s[2*j+1].Mul(&s[j], &xs[i])
+				s[2*j].Mul(&s[j], &xsInv[i])
+				sInv[2*j+1].Mul(&sInv[j], &xsInv[i])
+				sInv[2*j].Mul(&sInv[j], &xs[i])
+			}
+		}
+
+		a, b := &proof.InnerProduct.A, &proof.InnerProduct.B
+		for i := 0; i < nm; i++ {
+			// Gᵢ: -z - a⋅sᵢ
+			tmp.Mul(a, &s[i]).Add(&tmp, &z).Neg(&tmp).Mul(&tmp, &weight)
+			gsScalars[i].Add(&gsScalars[i], &tmp)
+			// Hᵢ: z + (zʲ⁺²⋅2ᵏ - b⋅sᵢ⁻¹)⋅y⁻ⁱ, i = n⋅j + k
+			tmp.Mul(&zPow[2+i/n], &twoPow[i%n])
+			tmp2.Mul(b, &sInv[i])
+			tmp.Sub(&tmp, &tmp2).Mul(&tmp, &yInvPow[i]).Add(&tmp, &z).Mul(&tmp, &weight)
+			hsScalars[i].Add(&hsScalars[i], &tmp)
+		}
+
+		var cw fr.Element
+		cw.Mul(&c, &weight)
+
+		// G: c⋅(t̂ - δ), H: c⋅τₓ - μ, U: w⋅(t̂ - a⋅b)
+		tmp.Sub(&proof.THat, &delta).Mul(&tmp, &cw)
+		gScalar.Add(&gScalar, &tmp)
+		tmp.Mul(&c, &proof.TauX).Sub(&tmp, &proof.Mu).Mul(&tmp, &weight)
+		hScalar.Add(&hScalar, &tmp)
+		tmp.Mul(a, b).Sub(&proof.THat, &tmp).Mul(&tmp, &w).Mul(&tmp, &weight)
+		uScalar.Add(&uScalar, &tmp)
+
+		// A: 1, S: x, T₁: -c⋅x, T₂: -c⋅x², Vⱼ: -c⋅zʲ⁺², Lᵢ: xᵢ², Rᵢ: xᵢ⁻²
+		points = append(points, proof.A, proof.S, proof.T1, proof.T2)
+		scalars = append(scalars, weight)
+		tmp.Mul(&x, &weight)
+		scalars = append(scalars, tmp)
+		tmp.Mul(&x, &cw).Neg(&tmp)
+		scalars = append(scalars, tmp)
+		tmp.Mul(&tmp, &x)
+		scalars = append(scalars, tmp)
+		for j := range commitments[p] {
+			tmp.Mul(&zPow[2+j], &cw).Neg(&tmp)
+			points = append(points, commitments[p][j])
+			scalars = append(scalars, tmp)
+		}
+		for i := 0; i < nbRounds; i++ {
+			tmp.Square(&xs[i]).Mul(&tmp, &weight)
+			tmp2.Square(&xsInv[i]).Mul(&tmp2, &weight)
+			points = append(points, proof.InnerProduct.L[i], proof.InnerProduct.R[i])
+			scalars = append(scalars, tmp, tmp2)
+		}
+	}
+
+	points = append(points, params.Gs[:maxNM]...)
+	points = append(points, params.Hs[:maxNM]...)
+	points = append(points, params.G, params.H, params.U)
+	scalars = append(scalars, gsScalars...)
+	scalars = append(scalars, hsScalars...)
+	scalars = append(scalars, gScalar, hScalar, uScalar)
+
+	var res secp256k1.G1Jac
+	if _, err := res.MultiExp(points, scalars, ecc.MultiExpConfig{}); err != nil {
+		return err
+	}
+	if !res.Z.IsZero() {
+		return ErrVerifyRangeProof
+	}
+	return nil
+}
+
+// multiExp sets res = ∑ ⟨scalars[i], points[i]⟩
+func multiExp(res *secp256k1.G1Affine, points [][]secp256k1.G1Affine, scalars [][]fr.Element) error {
+	var p []secp256k1.G1Affine
+	var s []fr.Element
+	for i := range points {
+		p = append(p, points[i]...)
+		s = append(s, scalars[i]...)
+	}
+	_, err := res.MultiExp(p, s, ecc.MultiExpConfig{})
+	return err
+}
+
+// fold sets lo[i] = cLo⋅lo[i] + cHi⋅hi[i]
+func fold(lo, hi []secp256k1.G1Affine, cLo, cHi *fr.Element) {
+	var bLo, bHi big.Int
+	cLo.BigInt(&bLo)
+	cHi.BigInt(&bHi)
+	parallel.Execute(len(lo), func(start, end int) {
+		var pLo, pHi secp256k1.G1Jac
+		for i := start; i < end; i++ {
+			pLo.FromAffine(&lo[i])
+			pHi.FromAffine(&hi[i])
+			pLo.ScalarMultiplication(&pLo, &bLo)
+			pHi.ScalarMultiplication(&pHi, &bHi)
+			pLo.AddAssign(&pHi)
+			lo[i].FromJacobian(&pLo)
+		}
+	})
+}
+
+func innerProduct(a, b []fr.Element) fr.Element {
+	var res, tmp fr.Element
+	for i := range a {
+		tmp.Mul(&a[i], &b[i])
+		res.Add(&res, &tmp)
+	}
+	return res
+}
+
+// powers returns [1, x, x², …, xⁿ⁻¹]
+func powers(x *fr.Element, n int) []fr.Element {
+	res := make([]fr.Element, n)
+	res[0].SetOne()
+	for i := 1; i < n; i++ {
+		res[i].Mul(&res[i-1], x)
+	}
+	return res
+}
+
+func randomVector(n int) ([]fr.Element, error) {
+	res := make([]fr.Element, n)
+	for i := range res {
+		if _, err := res[i].SetRandom(); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// newTranscript returns a transcript with the challenges of a range proof of nm
+// bits: y, z, x, w, then one challenge per round of the inner product argument
+func newTranscript(hf hash.Hash, nm int) *fiatshamir.Transcript {
+	nbRounds := bits.TrailingZeros(uint(nm))
+	ids := make([]string, 0, nbRounds+4)
+	ids = append(ids, "y", "z", "x", "w")
+	for i := 0; i < nbRounds; i++ {
+		ids = append(ids, "u"+strconv.Itoa(i))
+	}
+	return fiatshamir.NewTranscript(hf, ids...)
+}
+
+// deriveYZ derives the challenges y and z, binded to the number of bits, the
+// commitments to the values, A, S and dataTranscript
+func deriveYZ(fs *fiatshamir.Transcript, nbBits int, commitments []secp256k1.G1Affine, a, s *secp256k1.G1Affine, dataTranscript [][]byte) (y, z fr.Element, err error) {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(nbBits))
+	if err = fs.Bind("y", buf[:]); err != nil {
+		return
+	}
+	for i := range commitments {
+		if err = bindPoint(fs, "y", &commitments[i]); err != nil {
+			return
+		}
+	}
+	if err = bindPoint(fs, "y", a); err != nil {
+		return
+	}
+	if err = bindPoint(fs, "y", s); err != nil {
+		return
+	}
+	for i := range dataTranscript {
+		if err = fs.Bind("y", dataTranscript[i]); err != nil {
+			return
+		}
+	}
+	if y, err = computeChallenge(fs, "y"); err != nil {
+		return
+	}
+	z, err = computeChallenge(fs, "z")
+	return
+}
+
+// deriveX derives the challenge x, binded to T₁ and T₂
+func deriveX(fs *fiatshamir.Transcript, t1, t2 *secp256k1.G1Affine) (fr.Element, error) {
+	if err := bindPoint(fs, "x", t1); err != nil {
+		return fr.Element{}, err
+	}
+	if err := bindPoint(fs, "x", t2); err != nil {
+		return fr.Element{}, err
+	}
+	return computeChallenge(fs, "x")
+}
+
+// deriveW derives the challenge w, binded to τₓ, μ and t̂
+func deriveW(fs *fiatshamir.Transcript, tauX, mu, tHat *fr.Element) (fr.Element, error) {
+	for _, e := range []*fr.Element{tauX, mu, tHat} {
+		if err := fs.Bind("w", e.Marshal()); err != nil {
+			return fr.Element{}, err
+		}
+	}
+	return computeChallenge(fs, "w")
+}
+
+// deriveRoundChallenge derives the challenge of the i-th round of the inner product
+// argument, binded to its cross terms
+func deriveRoundChallenge(fs *fiatshamir.Transcript, i int, l, r *secp256k1.G1Affine) (fr.Element, error) {
+	id := "u" + strconv.Itoa(i)
+	if err := bindPoint(fs, id, l); err != nil {
+		return fr.Element{}, err
+	}
+	if err := bindPoint(fs, id, r); err != nil {
+		return fr.Element{}, err
+	}
+	return computeChallenge(fs, id)
+}
+
+func bindPoint(fs *fiatshamir.Transcript, id string, p *secp256k1.G1Affine) error {
+	b := p.RawBytes()
+	return fs.Bind(id, b[:])
+}
+
+// computeChallenge returns the challenge id as a non zero field element
+func computeChallenge(fs *fiatshamir.Transcript, id string) (fr.Element, error) {
+	b, err := fs.ComputeChallenge(id)
+	if err != nil {
+		return fr.Element{}, err
+	}
+	var res fr.Element
+	res.SetBytes(b)
+	if res.IsZero() {
+		return fr.Element{}, errZeroChallenge
+	}
+	return res, nil
+}
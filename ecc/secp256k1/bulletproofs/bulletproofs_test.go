@@ -0,0 +1,259 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bulletproofs
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"math"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/secp256k1"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/fr"
+)
+
+var testParams *Params
+
+func init() {
+	var err error
+	testParams, err = NewParams(64, 4, "gnark-crypto bulletproofs test")
+	if err != nil {
+		panic(err)
+	}
+}
+
+func commitValues(values []uint64) ([]secp256k1.G1Affine, []fr.Element) {
+	blindings := make([]fr.Element, len(values))
+	commitments := make([]secp256k1.G1Affine, len(values))
+	for i := range values {
+		blindings[i].SetRandom()
+		commitments[i] = Commit(values[i], &blindings[i], testParams)
+	}
+	return commitments, blindings
+}
+
+func TestNewParams(t *testing.T) {
+	for _, nbBits := range []int{0, 3, 65, 128} {
+		if _, err := NewParams(nbBits, 1, "seed"); err != ErrInvalidNbBits {
+			t.Fatalf("%d bits: expected ErrInvalidNbBits", nbBits)
+		}
+	}
+	for _, m := range []int{0, 3} {
+		if _, err := NewParams(8, m, "seed"); err != ErrInvalidAggregation {
+			t.Fatalf("aggregation %d: expected ErrInvalidAggregation", m)
+		}
+	}
+
+	// deterministic and seed dependent
+	params, err := NewParams(64, 4, "gnark-crypto bulletproofs test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !params.H.Equal(&testParams.H) || !params.Gs[255].Equal(&testParams.Gs[255]) {
+		t.Fatal("NewParams is not deterministic")
+	}
+	if params, err = NewParams(64, 1, "another seed"); err != nil {
+		t.Fatal(err)
+	}
+	if params.Gs[0].Equal(&testParams.Gs[0]) || params.MaxAggregation() != 1 {
+		t.Fatal("NewParams doesn't depend on the seed")
+	}
+}
+
+func TestRangeProof(t *testing.T) {
+	hf := sha256.New()
+
+	for _, v := range []uint64{0, 1, 42, math.MaxUint64} {
+		commitments, blindings := commitValues([]uint64{v})
+		proof, err := Prove([]uint64{v}, blindings, testParams, hf, []byte("data"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := Verify(&proof, commitments, testParams, hf, []byte("data")); err != nil {
+			t.Fatal(err)
+		}
+
+		// another transcript
+		if err := Verify(&proof, commitments, testParams, hf, []byte("other data")); err == nil {
+			t.Fatal("verifying with another transcript should fail")
+		}
+
+		// another commitment
+		other, _ := commitValues([]uint64{v})
+		if err := Verify(&proof, other, testParams, hf, []byte("data")); err == nil {
+			t.Fatal("verifying against another commitment should fail")
+		}
+
+		// tampered proofs
+		for i := 0; i < 5; i++ {
+			wrongProof := proof
+			wrongProof.InnerProduct.L = append([]secp256k1.G1Affine{}, proof.InnerProduct.L...)
+			switch i {
+			case 0:
+				wrongProof.TauX.SetRandom()
+			case 1:
+				wrongProof.THat.SetRandom()
+			case 2:
+				wrongProof.InnerProduct.A.SetRandom()
+			case 3:
+				wrongProof.T1.Add(&wrongProof.T1, &testParams.G)
+			case 4:
+				wrongProof.InnerProduct.L[2].Add(&wrongProof.InnerProduct.L[2], &testParams.G)
+			}
+			if err := Verify(&wrongProof, commitments, testParams, hf, []byte("data")); err == nil {
+				t.Fatalf("tampered proof %d should be rejected", i)
+			}
+		}
+	}
+}
+
+func TestOutOfRange(t *testing.T) {
+	hf := sha256.New()
+	params, err := NewParams(8, 1, "gnark-crypto bulletproofs test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var blinding fr.Element
+	blinding.SetRandom()
+	if _, err := Prove([]uint64{256}, []fr.Element{blinding}, params, hf); err != ErrValueOutOfRange {
+		t.Fatal("expected ErrValueOutOfRange")
+	}
+
+	// a proof of 255 doesn't verify against a commitment to 256 = 255 + 1
+	proof, err := Prove([]uint64{255}, []fr.Element{blinding}, params, hf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitment := Commit(256, &blinding, params)
+	if err := Verify(&proof, []secp256k1.G1Affine{commitment}, params, hf); err == nil {
+		t.Fatal("proof of an out of range value should be rejected")
+	}
+}
+
+func TestAggregatedRangeProof(t *testing.T) {
+	hf := sha256.New()
+
+	values := []uint64{3, 0, math.MaxUint64, 1 << 40}
+	commitments, blindings := commitValues(values)
+	proof, err := Prove(values, blindings, testParams, hf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(proof.InnerProduct.L) != 8 {
+		t.Fatal("wrong number of rounds")
+	}
+	if err := Verify(&proof, commitments, testParams, hf); err != nil {
+		t.Fatal(err)
+	}
+
+	// swapped commitments
+	commitments[0], commitments[1] = commitments[1], commitments[0]
+	if err := Verify(&proof, commitments, testParams, hf); err == nil {
+		t.Fatal("verifying against swapped commitments should fail")
+	}
+
+	if _, err := Prove(values[:3], blindings[:3], testParams, hf); err != ErrInvalidAggregation {
+		t.Fatal("expected ErrInvalidAggregation")
+	}
+	if _, err := Prove(values, blindings[:2], testParams, hf); err != ErrInvalidNbBlindings {
+		t.Fatal("expected ErrInvalidNbBlindings")
+	}
+	if err := Verify(&proof, commitments[:2], testParams, hf); err != ErrInvalidProofSize {
+		t.Fatal("expected ErrInvalidProofSize")
+	}
+}
+
+func TestBatchVerify(t *testing.T) {
+	hf := sha256.New()
+
+	valueSets := [][]uint64{{1}, {2, 3}, {4, 5, 6, 7}, {8}}
+	proofs := make([]Proof, len(valueSets))
+	commitments := make([][]secp256k1.G1Affine, len(valueSets))
+	for i, values := range valueSets {
+		var blindings []fr.Element
+		commitments[i], blindings = commitValues(values)
+		var err error
+		if proofs[i], err = Prove(values, blindings, testParams, hf); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := BatchVerify(proofs, commitments, testParams, hf); err != nil {
+		t.Fatal(err)
+	}
+
+	// one invalid proof invalidates the batch
+	proofs[2].Mu.SetRandom()
+	if err := BatchVerify(proofs, commitments, testParams, hf); err == nil {
+		t.Fatal("batch with an invalid proof should be rejected")
+	}
+	if err := BatchVerify(proofs, commitments[1:], testParams, hf); err != ErrInvalidNbCommitments {
+		t.Fatal("expected ErrInvalidNbCommitments")
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	hf := sha256.New()
+
+	values := []uint64{12, 345}
+	commitments, blindings := commitValues(values)
+	proof, err := Prove(values, blindings, testParams, hf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	written, err := proof.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Proof
+	read, err := decoded.ReadFrom(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read != written || int(written) != buf.Len() {
+		t.Fatal("number of bytes read and written differ")
+	}
+	if err := Verify(&decoded, commitments, testParams, hf); err != nil {
+		t.Fatal(err)
+	}
+
+	// truncated encodings are rejected
+	for _, size := range []int{0, 10, buf.Len() - 1} {
+		if _, err := new(Proof).ReadFrom(bytes.NewReader(buf.Bytes()[:size])); err == nil {
+			t.Fatalf("decoding %d bytes should fail", size)
+		}
+	}
+}
+
+func BenchmarkProve(b *testing.B) {
+	hf := sha256.New()
+	values := []uint64{42}
+	commitments, blindings := commitValues(values)
+
+	b.Run("prove", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Prove(values, blindings, testParams, hf)
+		}
+	})
+	proof, _ := Prove(values, blindings, testParams, hf)
+	b.Run("verify", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Verify(&proof, commitments, testParams, hf)
+		}
+	})
+}
@@ -0,0 +1,27 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package bulletproofs implements the Bulletproofs range proofs of Bünz et al. on secp256k1 G1,
+// without trusted setup.
+//
+// A proof shows that the values committed in Pedersen commitments v⋅G + γ⋅H are in [0, 2ⁿ);
+// proofs for m values can be aggregated, for a size logarithmic in n⋅m, and several proofs
+// can be verified in batch, with a single multi scalar multiplication.
+//
+// # See also
+//
+// https://eprint.iacr.org/2017/1066
+package bulletproofs
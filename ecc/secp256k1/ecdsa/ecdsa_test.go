@@ -17,10 +17,13 @@
 package ecdsa
 
 import (
+	"bytes"
 	"crypto/rand"
 	"crypto/sha256"
+	"encoding/hex"
 	"github.com/consensys/gnark-crypto/ecc/secp256k1/fr"
 	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/leanovate/gopter"
@@ -62,6 +65,28 @@ func TestECDSA(t *testing.T) {
 		},
 	))
 
+	properties.Property("[SECP256K1] test the deterministic (RFC 6979) low-s signing and verification", prop.ForAll(
+		func() bool {
+
+			privKey, _ := GenerateKey(rand.Reader)
+			publicKey := privKey.PublicKey
+
+			msg := []byte("testing ECDSA")
+			sig1, _ := privKey.SignWithOptions(msg, sha256.New(), WithRFC6979(sha256.New), WithLowS())
+			sig2, _ := privKey.SignWithOptions(msg, sha256.New(), WithRFC6979(sha256.New), WithLowS())
+			if !bytes.Equal(sig1, sig2) {
+				return false
+			}
+			var sig Signature
+			if _, err := sig.SetBytes(sig1); err != nil || !sig.IsLowS() {
+				return false
+			}
+			flag, _ := publicKey.Verify(sig1, msg, sha256.New())
+
+			return flag
+		},
+	))
+
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 func TestRecoverPublicKey(t *testing.T) {
@@ -87,9 +112,79 @@ func TestRecoverPublicKey(t *testing.T) {
 			return pk.Equal(&recovered)
 		},
 	))
+	properties.Property("[SECP256K1] test public key recover from r||s||v, with low-s normalization", prop.ForAll(
+		func() bool {
+			sk, err := GenerateKey(rand.Reader)
+			if err != nil {
+				return false
+			}
+			pk := sk.PublicKey
+			msg := []byte("test")
+			buf, err := sk.SignRecoverable(msg, nil)
+			if err != nil {
+				return false
+			}
+			var sig RecoverableSignature
+			if _, err = sig.SetBytes(buf); err != nil {
+				return false
+			}
+			sig.Normalize()
+			if !sig.IsLowS() {
+				return false
+			}
+			r := new(big.Int).SetBytes(sig.R[:])
+			s := new(big.Int).SetBytes(sig.S[:])
+			var recovered PublicKey
+			if err = recovered.RecoverFrom(msg, uint(sig.V), r, s); err != nil {
+				return false
+			}
+			return pk.Equal(&recovered)
+		},
+	))
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestRFC6979(t *testing.T) {
+	t.Parallel()
+	// private key 1, low-s, as in the test vectors of the bitcoin ecosystem
+	const x = "0000000000000000000000000000000000000000000000000000000000000001"
+	vectors := []struct {
+		msg, r, s string
+	}{
+		{
+			msg: "Satoshi Nakamoto",
+			r:   "934b1ea10a4b3c1757e2b0c017d0b6143ce3c9a7e6a4a49860d7a6ab210ee3d8",
+			s:   "2442ce9d2b916064108014783e923ec36b49743e2ffa1c4496f01a512aafd9e5",
+		},
+	}
+	opts := []SignOption{WithLowS()}
+
+	var privKey PrivateKey
+	xBytes, _ := hex.DecodeString(x)
+	copy(privKey.scalar[:], xBytes)
+	privKey.PublicKey.A.ScalarMultiplicationBase(new(big.Int).SetBytes(xBytes))
+
+	for _, v := range vectors {
+		buf, err := privKey.SignWithOptions([]byte(v.msg), sha256.New(), append(opts, WithRFC6979(sha256.New))...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var sig Signature
+		if _, err := sig.SetBytes(buf); err != nil {
+			t.Fatal(err)
+		}
+		if r := hex.EncodeToString(sig.R[:]); !strings.EqualFold(r, v.r) {
+			t.Fatalf("%s: wrong r: %s", v.msg, r)
+		}
+		if s := hex.EncodeToString(sig.S[:]); !strings.EqualFold(s, v.s) {
+			t.Fatalf("%s: wrong s: %s", v.msg, s)
+		}
+		if ok, _ := privKey.PublicKey.Verify(buf, []byte(v.msg), sha256.New()); !ok {
+			t.Fatalf("%s: signature should verify", v.msg)
+		}
+	}
+}
+
 func TestNonMalleability(t *testing.T) {
 
 	// buffer too big
@@ -0,0 +1,427 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ipa
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash"
+	"math/big"
+	"math/bits"
+	"strconv"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/fr"
+	"github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+var (
+	ErrInvalidSRSSize        = errors.New("srs size must be a power of two, at least 2")
+	ErrInvalidPolynomialSize = errors.New("invalid polynomial size (larger than SRS or == 0)")
+	ErrInvalidNbDigests      = errors.New("number of digests is not the same as the number of polynomials")
+	ErrZeroNbDigests         = errors.New("number of digests is zero")
+	ErrInvalidNbPoints       = errors.New("number of points is not the same as the number of polynomials")
+	ErrInvalidProofSize      = errors.New("number of rounds of the proof does not match the SRS size")
+	ErrVerifyOpeningProof    = errors.New("can't verify opening proof")
+	ErrVerifyBatchOpening    = errors.New("can't verify batch opening proof")
+	errZeroChallenge         = errors.New("challenge is zero")
+)
+
+// Digest commitment of a polynomial.
+type Digest = secp256k1.G1Affine
+
+// SRS public parameters of the scheme. They are transparent: anyone can derive
+// them from a seed with NewSRS.
+type SRS struct {
+	Basis []secp256k1.G1Affine // [G₀, G₁, …, Gₙ₋₁], the commitment to p is ∑ p(i)⋅Gᵢ
+	Q     secp256k1.G1Affine   // binds the inner product in opening proofs
+
+	// barycentric weights 1/∏_{j≠i}(i-j) of the domain {0, …, n-1}
+	weights []fr.Element
+}
+
+// OpeningProof proof that a committed polynomial evaluates to ClaimedValue at a point.
+type OpeningProof struct {
+	// commitments to the cross terms of each folding round
+	L, R []secp256k1.G1Affine
+
+	// the vector of evaluations, folded down to a single value
+	A fr.Element
+
+	ClaimedValue fr.Element
+}
+
+// NewSRS derives an SRS of the given size from seed.
+//
+// Gᵢ = HashToG1(i, seed), i being encoded as a big endian uint64, and Q = HashToG1("Q", seed).
+func NewSRS(size uint64, seed string) (*SRS, error) {
+	if size < 2 || size&(size-1) != 0 {
+		return nil, ErrInvalidSRSSize
+	}
+	var srs SRS
+	dst := []byte(seed)
+	srs.Basis = make([]secp256k1.G1Affine, size)
+	errs := make([]error, size)
+	parallel.Execute(int(size), func(start, end int) {
+		var msg [8]byte
+		for i := start; i < end; i++ {
+			binary.BigEndian.PutUint64(msg[:], uint64(i))
+			srs.Basis[i], errs[i] = secp256k1.HashToG1(msg[:], dst)
+		}
+	})
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	var err error
+	if srs.Q, err = secp256k1.HashToG1([]byte("Q"), dst); err != nil {
+		return nil, err
+	}
+	srs.weights = domainWeights(size)
+	return &srs, nil
+}
+
+// Size returns the number of evaluations of the committed polynomials
+func (srs *SRS) Size() int {
+	return len(srs.Basis)
+}
+
+// domainWeights returns 1/A'(i) for i in {0, …, n-1}, where A = ∏(X-j)
+// and A'(i) = ∏_{j≠i}(i-j) = (-1)ⁿ⁻¹⁻ⁱ⋅i!⋅(n-1-i)!
+func domainWeights(n uint64) []fr.Element {
+	factorials := make([]fr.Element, n)
+	factorials[0].SetOne()
+	for i := uint64(1); i < n; i++ {
+		factorials[i].SetUint64(i)
+		factorials[i].Mul(&factorials[i], &factorials[i-1])
+	}
+	res := make([]fr.Element, n)
+	for i := uint64(0); i < n; i++ {
+		res[i].Mul(&factorials[i], &factorials[n-1-i])
+		if (n-1-i)&1 == 1 {
+			res[i].Neg(&res[i])
+		}
+	}
+	return fr.BatchInvert(res)
+}
+
+// inDomain returns i if z = i for some i in {0, …, n-1}, -1 otherwise
+func (srs *SRS) inDomain(z *fr.Element) int {
+	if z.IsUint64() && z.Uint64() < uint64(len(srs.Basis)) {
+		return int(z.Uint64())
+	}
+	return -1
+}
+
+// lagrange returns the evaluations at z of the Lagrange polynomials of the domain
+func (srs *SRS) lagrange(z *fr.Element) []fr.Element {
+	res := make([]fr.Element, len(srs.Basis))
+	if i := srs.inDomain(z); i >= 0 {
+		res[i].SetOne()
+		return res
+	}
+
+	// Lᵢ(z) = A(z)⋅wᵢ/(z-i), A(z) = ∏(z-j)
+	var az, tmp fr.Element
+	az.SetOne()
+	for i := range res {
+		tmp.SetUint64(uint64(i))
+		res[i].Sub(z, &tmp)
+		az.Mul(&az, &res[i])
+	}
+	res = fr.BatchInvert(res)
+	for i := range res {
+		res[i].Mul(&res[i], &srs.weights[i]).Mul(&res[i], &az)
+	}
+	return res
+}
+
+// Evaluate returns p(point), p being given by its evaluations on {0, …, len(p)-1}
+// and being 0 on the remaining points of the domain.
+func Evaluate(p []fr.Element, point fr.Element, srs *SRS) (fr.Element, error) {
+	if len(p) == 0 || len(p) > len(srs.Basis) {
+		return fr.Element{}, ErrInvalidPolynomialSize
+	}
+	return innerProduct(p, srs.lagrange(&point)), nil
+}
+
+// Commit commits to a polynomial given by its evaluations on {0, …, len(p)-1},
+// the remaining evaluations being 0.
+func Commit(p []fr.Element, srs *SRS, nbTasks ...int) (Digest, error) {
+	if len(p) == 0 || len(p) > len(srs.Basis) {
+		return Digest{}, ErrInvalidPolynomialSize
+	}
+
+	config := ecc.MultiExpConfig{}
+	if len(nbTasks) > 0 {
+		config.NbTasks = nbTasks[0]
+	}
+
+	var res Digest
+	if _, err := res.MultiExp(srs.Basis[:len(p)], p, config); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// Open computes an opening proof of the polynomial p, committed in digest, at point.
+//
+// For i = 0, …, log₂(n)-1, the evaluations a of p, the Lagrange coefficients b at point and
+// the basis G are split in halves (lo, hi) and folded with a challenge xᵢ:
+//
+//	Lᵢ = ⟨a_lo, G_hi⟩ + ⟨a_lo, b_hi⟩⋅Q'
+//	Rᵢ = ⟨a_hi, G_lo⟩ + ⟨a_hi, b_lo⟩⋅Q'
+//	a ← a_lo + xᵢ⋅a_hi,	b ← b_lo + xᵢ⁻¹⋅b_hi,	G ← G_lo + xᵢ⁻¹⋅G_hi
+//
+// where Q' = [w]Q for a challenge w binding the statement.
+func Open(p []fr.Element, digest *Digest, point fr.Element, hf hash.Hash, srs *SRS, dataTranscript ...[]byte) (OpeningProof, error) {
+	if len(p) == 0 || len(p) > len(srs.Basis) {
+		return OpeningProof{}, ErrInvalidPolynomialSize
+	}
+	n := len(srs.Basis)
+
+	a := make([]fr.Element, n)
+	copy(a, p)
+	b := srs.lagrange(&point)
+
+	var proof OpeningProof
+	proof.ClaimedValue = innerProduct(a, b)
+
+	fs := newTranscript(hf, n)
+	w, err := deriveW(fs, digest, &point, &proof.ClaimedValue, dataTranscript)
+	if err != nil {
+		return OpeningProof{}, err
+	}
+	var q secp256k1.G1Affine
+	q.ScalarMultiplication(&srs.Q, w.BigInt(new(big.Int)))
+
+	g := make([]secp256k1.G1Affine, n)
+	copy(g, srs.Basis)
+
+	nbRounds := bits.TrailingZeros(uint(n))
+	proof.L = make([]secp256k1.G1Affine, nbRounds)
+	proof.R = make([]secp256k1.G1Affine, nbRounds)
+	for i := 0; i < nbRounds; i++ {
+		m := len(a) / 2
+		aLo, aHi := a[:m], a[m:]
+		bLo, bHi := b[:m], b[m:]
+		gLo, gHi := g[:m], g[m:]
+
+		if err = crossTerm(&proof.L[i], aLo, gHi, bHi, &q); err != nil {
+			return OpeningProof{}, err
+		}
+		if err = crossTerm(&proof.R[i], aHi, gLo, bLo, &q); err != nil {
+			return OpeningProof{}, err
+		}
+
+		x, err := deriveX(fs, i, &proof.L[i], &proof.R[i])
+		if err != nil {
+			return OpeningProof{}, err
+		}
+		var xInv fr.Element
+		xInv.Inverse(&x)
+		xInvBig := xInv.BigInt(new(big.Int))
+
+		var tmp fr.Element
+		for j := 0; j < m; j++ {
+			tmp.Mul(&aHi[j], &x)
+			aLo[j].Add(&aLo[j], &tmp)
+			tmp.Mul(&bHi[j], &xInv)
+			bLo[j].Add(&bLo[j], &tmp)
+		}
+		foldBasis(gLo, gHi, xInvBig)
+
+		a, b, g = aLo, bLo, gLo
+	}
+	proof.A = a[0]
+
+	return proof, nil
+}
+
+// Verify verifies that proof is a valid opening proof of the polynomial committed
+// in commitment, at point.
+//
+// With s = ⊗ᵢ(1, xᵢ⁻¹), the folded basis is ⟨s, G⟩ and the folded Lagrange coefficients
+// ⟨s, b⟩, so that the proof is valid iff
+//
+//	C + y⋅Q' + ∑ (xᵢ⁻¹⋅Lᵢ + xᵢ⋅Rᵢ) = A⋅⟨s, G⟩ + A⋅⟨s, b⟩⋅Q'
+//
+// which is checked with a single multi scalar multiplication.
+func Verify(commitment *Digest, proof *OpeningProof, point fr.Element, hf hash.Hash, srs *SRS, dataTranscript ...[]byte) error {
+	n := len(srs.Basis)
+	nbRounds := bits.TrailingZeros(uint(n))
+	if len(proof.L) != nbRounds || len(proof.R) != nbRounds {
+		return ErrInvalidProofSize
+	}
+
+	fs := newTranscript(hf, n)
+	w, err := deriveW(fs, commitment, &point, &proof.ClaimedValue, dataTranscript)
+	if err != nil {
+		return err
+	}
+	x := make([]fr.Element, nbRounds)
+	for i := range x {
+		if x[i], err = deriveX(fs, i, &proof.L[i], &proof.R[i]); err != nil {
+			return err
+		}
+	}
+	xInv := fr.BatchInvert(x)
+
+	// the first round folds the most significant bit of the indices
+	s := make([]fr.Element, 1, n)
+	s[0].SetOne()
+	for i := 0; i < nbRounds; i++ {
+		m := len(s)
+		s = s[:2*m]
+		for j := m - 1; j >= 0; j-- {
+			s[2*j+1].Mul(&s[j], &xInv[i])
+			s[2*j] = s[j]
+		}
+	}
+	b := srs.lagrange(&point)
+	b0 := innerProduct(s, b)
+
+	// points:  G                   Q                   L         R
+	// scalars: A⋅s     (A⋅⟨s, b⟩ - y)⋅w               -x⁻¹       -x
+	points := make([]secp256k1.G1Affine, 0, n+1+2*nbRounds)
+	scalars := make([]fr.Element, 0, n+1+2*nbRounds)
+	points = append(points, srs.Basis...)
+	for i := range s {
+		s[i].Mul(&s[i], &proof.A)
+	}
+	scalars = append(scalars, s...)
+
+	var qScalar fr.Element
+	qScalar.Mul(&proof.A, &b0).Sub(&qScalar, &proof.ClaimedValue).Mul(&qScalar, &w)
+	points = append(points, srs.Q)
+	scalars = append(scalars, qScalar)
+
+	points = append(points, proof.L...)
+	for i := range xInv {
+		var tmp fr.Element
+		scalars = append(scalars, *tmp.Neg(&xInv[i]))
+	}
+	points = append(points, proof.R...)
+	for i := range x {
+		var tmp fr.Element
+		scalars = append(scalars, *tmp.Neg(&x[i]))
+	}
+
+	var res secp256k1.G1Affine
+	if _, err := res.MultiExp(points, scalars, ecc.MultiExpConfig{}); err != nil {
+		return err
+	}
+	if !res.Equal(commitment) {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+}
+
+// crossTerm sets res = ⟨a, g⟩ + ⟨a, b⟩⋅q
+func crossTerm(res *secp256k1.G1Affine, a []fr.Element, g []secp256k1.G1Affine, b []fr.Element, q *secp256k1.G1Affine) error {
+	points := make([]secp256k1.G1Affine, len(g)+1)
+	copy(points, g)
+	points[len(g)] = *q
+	scalars := make([]fr.Element, len(a)+1)
+	copy(scalars, a)
+	scalars[len(a)] = innerProduct(a, b)
+	_, err := res.MultiExp(points, scalars, ecc.MultiExpConfig{})
+	return err
+}
+
+// foldBasis sets gLo[i] = gLo[i] + [x]gHi[i]
+func foldBasis(gLo, gHi []secp256k1.G1Affine, x *big.Int) {
+	parallel.Execute(len(gLo), func(start, end int) {
+		var tmp secp256k1.G1Affine
+		for i := start; i < end; i++ {
+			tmp.ScalarMultiplication(&gHi[i], x)
+			gLo[i].Add(&gLo[i], &tmp)
+		}
+	})
+}
+
+func innerProduct(a, b []fr.Element) fr.Element {
+	var res, tmp fr.Element
+	for i := range a {
+		tmp.Mul(&a[i], &b[i])
+		res.Add(&res, &tmp)
+	}
+	return res
+}
+
+// newTranscript returns a transcript with the challenges of an opening proof
+// on a domain of size n: w, then one challenge per folding round
+func newTranscript(hf hash.Hash, n int) *fiatshamir.Transcript {
+	nbRounds := bits.TrailingZeros(uint(n))
+	ids := make([]string, nbRounds+1)
+	ids[0] = "w"
+	for i := 0; i < nbRounds; i++ {
+		ids[i+1] = "x" + strconv.Itoa(i)
+	}
+	return fiatshamir.NewTranscript(hf, ids...)
+}
+
+// deriveW derives the challenge w, binded to the commitment, the point, the claimed
+// value and dataTranscript
+func deriveW(fs *fiatshamir.Transcript, commitment *Digest, point, claimedValue *fr.Element, dataTranscript [][]byte) (fr.Element, error) {
+	c := commitment.RawBytes()
+	if err := fs.Bind("w", c[:]); err != nil {
+		return fr.Element{}, err
+	}
+	if err := fs.Bind("w", point.Marshal()); err != nil {
+		return fr.Element{}, err
+	}
+	if err := fs.Bind("w", claimedValue.Marshal()); err != nil {
+		return fr.Element{}, err
+	}
+	for i := range dataTranscript {
+		if err := fs.Bind("w", dataTranscript[i]); err != nil {
+			return fr.Element{}, err
+		}
+	}
+	return computeChallenge(fs, "w")
+}
+
+// deriveX derives the challenge of the i-th folding round, binded to its cross terms
+func deriveX(fs *fiatshamir.Transcript, i int, l, r *secp256k1.G1Affine) (fr.Element, error) {
+	id := "x" + strconv.Itoa(i)
+	bl, br := l.RawBytes(), r.RawBytes()
+	if err := fs.Bind(id, bl[:]); err != nil {
+		return fr.Element{}, err
+	}
+	if err := fs.Bind(id, br[:]); err != nil {
+		return fr.Element{}, err
+	}
+	return computeChallenge(fs, id)
+}
+
+// computeChallenge returns the challenge id as a non zero field element
+func computeChallenge(fs *fiatshamir.Transcript, id string) (fr.Element, error) {
+	b, err := fs.ComputeChallenge(id)
+	if err != nil {
+		return fr.Element{}, err
+	}
+	var res fr.Element
+	res.SetBytes(b)
+	if res.IsZero() {
+		return fr.Element{}, errZeroChallenge
+	}
+	return res, nil
+}
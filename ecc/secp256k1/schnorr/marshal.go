@@ -0,0 +1,98 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schnorr
+
+import (
+	"crypto/subtle"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/fp"
+)
+
+// Bytes returns the 32 bytes x-only representation of the public key, that is the x
+// coordinate of the point as a big endian integer.
+func (pk *PublicKey) Bytes() []byte {
+	res := pk.A.X.Bytes()
+	return res[:]
+}
+
+// SetBytes sets pk from its 32 bytes x-only representation, and returns the number of
+// bytes read from the buffer. It fails if x is not reduced or is not the coordinate of a
+// point on the curve.
+func (pk *PublicKey) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizePublicKey {
+		return 0, io.ErrShortBuffer
+	}
+	var x fp.Element
+	if err := x.SetBytesCanonical(buf[:sizePublicKey]); err != nil {
+		return 0, err
+	}
+	A, err := LiftX(&x)
+	if err != nil {
+		return 0, err
+	}
+	pk.A = A
+	return sizePublicKey, nil
+}
+
+// Bytes returns the binary representation of the private key
+// as publicKey ∥ scalar, where scalar is in big endian.
+func (privKey *PrivateKey) Bytes() []byte {
+	var res [sizePrivateKey]byte
+	pubkBin := privKey.PublicKey.Bytes()
+	subtle.ConstantTimeCopy(1, res[:sizePublicKey], pubkBin)
+	subtle.ConstantTimeCopy(1, res[sizePublicKey:sizePrivateKey], privKey.scalar[:])
+	return res[:]
+}
+
+// SetBytes sets the private key from publicKey ∥ scalar, and checks that publicKey
+// matches the scalar. It returns the number of bytes read.
+func (privKey *PrivateKey) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizePrivateKey {
+		return 0, io.ErrShortBuffer
+	}
+	var pk PublicKey
+	if _, err := pk.SetBytes(buf[:sizePublicKey]); err != nil {
+		return 0, err
+	}
+	var res PrivateKey
+	if err := res.setScalar(buf[sizePublicKey:sizePrivateKey]); err != nil {
+		return 0, err
+	}
+	if !res.PublicKey.A.Equal(&pk.A) {
+		return 0, errPublicKeyMismatch
+	}
+	*privKey = res
+	return sizePrivateKey, nil
+}
+
+// Bytes returns the binary representation of sig as r ∥ s.
+func (sig *Signature) Bytes() []byte {
+	var res [sizeSignature]byte
+	copy(res[:sizeFp], sig.R[:])
+	copy(res[sizeFp:], sig.S[:])
+	return res[:]
+}
+
+// SetBytes sets sig from r ∥ s, and returns the number of bytes read. The ranges of
+// r and s are checked by Verify.
+func (sig *Signature) SetBytes(buf []byte) (int, error) {
+	if len(buf) != sizeSignature {
+		return 0, errWrongSize
+	}
+	copy(sig.R[:], buf[:sizeFp])
+	copy(sig.S[:], buf[sizeFp:])
+	return sizeSignature, nil
+}
@@ -0,0 +1,529 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package musig2 implements the BIP-327 MuSig2 two-round multi-signature scheme on the
+// secp256k1 curve.
+//
+// The n signers aggregate their public keys into a single x-only public key, then:
+//   - round 1: each signer generates a nonce with NonceGen and publishes its PubNonce,
+//     the public nonces are aggregated with NonceAgg;
+//   - round 2: each signer computes a partial signature with Session.Sign, and the
+//     partial signatures are aggregated with Session.PartialSigAgg.
+//
+// The result is a BIP-340 signature, verifiable with package schnorr, for the
+// aggregated public key.
+//
+// Documentation:
+// - BIP-327: https://github.com/bitcoin/bips/blob/master/bip-0327.mediawiki
+// - MuSig2: https://eprint.iacr.org/2020/1261
+package musig2
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+
+	"github.com/consensys/gnark-crypto/ecc/secp256k1"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/fp"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/fr"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/schnorr"
+)
+
+const (
+	// PublicKeySize is the size of a compressed public key
+	PublicKeySize = 1 + fp.Bytes
+	// PubNonceSize is the size of a public nonce, and of an aggregated nonce
+	PubNonceSize = 2 * PublicKeySize
+	// SecNonceSize is the size of a secret nonce
+	SecNonceSize = 2*fr.Bytes + PublicKeySize
+	// PartialSignatureSize is the size of a partial signature
+	PartialSignatureSize = fr.Bytes
+)
+
+// tags of the hashes used by BIP-327
+const (
+	tagKeyAggList        = "KeyAgg list"
+	tagKeyAggCoefficient = "KeyAgg coefficient"
+	tagAux               = "MuSig/aux"
+	tagNonce             = "MuSig/nonce"
+	tagNonceCoefficient  = "MuSig/noncecoef"
+)
+
+var (
+	ErrNoPublicKey          = errors.New("no public key to aggregate")
+	ErrInvalidTweak         = errors.New("tweak is not smaller than the group order")
+	ErrInfinity             = errors.New("the resulting point is the point at infinity")
+	ErrInvalidSecretKey     = errors.New("secret key is zero or not smaller than the group order")
+	ErrInvalidSecretNonce   = errors.New("secret nonce is zero or not smaller than the group order, it might have been used already")
+	ErrSecretNonceMismatch  = errors.New("secret nonce doesn't match the secret key")
+	ErrUnknownPublicKey     = errors.New("public key is not part of the aggregated public key")
+	ErrInvalidPartialSig    = errors.New("invalid partial signature")
+	ErrInvalidNonceGenInput = errors.New("nonce generation inputs have wrong sizes")
+)
+
+// InvalidContributionError identifies the signer, or the aggregator when Signer is
+// negative, that sent an invalid value.
+type InvalidContributionError struct {
+	Signer       int
+	Contribution string // "pubkey", "pubnonce", "aggnonce" or "psig"
+}
+
+func (e *InvalidContributionError) Error() string {
+	if e.Signer < 0 {
+		return fmt.Sprintf("invalid %s from the aggregator", e.Contribution)
+	}
+	return fmt.Sprintf("invalid %s from signer %d", e.Contribution, e.Signer)
+}
+
+// PubNonce is the public nonce of a signer, the compressed encodings of R₁ ∥ R₂.
+type PubNonce [PubNonceSize]byte
+
+// AggNonce is the aggregation of the public nonces of all the signers. Contrary to a
+// PubNonce, its points may be the point at infinity, encoded as 33 zero bytes.
+type AggNonce [PubNonceSize]byte
+
+// SecNonce is the secret nonce k₁ ∥ k₂ ∥ pk of a signer. It must be used only once,
+// Session.Sign zeroes it.
+type SecNonce [SecNonceSize]byte
+
+// PartialSignature is the partial signature of a signer.
+type PartialSignature [PartialSignatureSize]byte
+
+// KeyAggContext is the aggregation of a list of public keys, possibly tweaked.
+type KeyAggContext struct {
+	pubKeys  [][PublicKeySize]byte
+	listHash [32]byte
+	second   [PublicKeySize]byte // second distinct public key in the list
+	q        secp256k1.G1Affine  // aggregated public key
+	gacc     fr.Element          // accumulated sign of the tweaks
+	tacc     fr.Element          // accumulated tweak
+}
+
+// KeySort sorts the public keys in lexicographical order.
+func KeySort(pubKeys [][PublicKeySize]byte) {
+	sort.Slice(pubKeys, func(i, j int) bool {
+		return bytes.Compare(pubKeys[i][:], pubKeys[j][:]) < 0
+	})
+}
+
+// KeyAgg aggregates the public keys as Q = ∑ aᵢ⋅Pᵢ, where the coefficient aᵢ
+// depends on Pᵢ and on the whole (ordered) list.
+func KeyAgg(pubKeys [][PublicKeySize]byte) (*KeyAggContext, error) {
+	if len(pubKeys) == 0 {
+		return nil, ErrNoPublicKey
+	}
+	ctx := &KeyAggContext{
+		pubKeys: append([][PublicKeySize]byte{}, pubKeys...),
+	}
+	list := make([][]byte, len(pubKeys))
+	for i := range pubKeys {
+		list[i] = ctx.pubKeys[i][:]
+		if ctx.second == [PublicKeySize]byte{} && pubKeys[i] != pubKeys[0] {
+			ctx.second = pubKeys[i]
+		}
+	}
+	ctx.listHash = schnorr.TaggedHash(tagKeyAggList, list...)
+
+	var q secp256k1.G1Jac
+	for i := range pubKeys {
+		p, err := cpoint(pubKeys[i][:])
+		if err != nil {
+			return nil, &InvalidContributionError{Signer: i, Contribution: "pubkey"}
+		}
+		a := ctx.coefficient(pubKeys[i])
+		var ap secp256k1.G1Jac
+		ap.ScalarMultiplicationAffine(&p, a.BigInt(new(big.Int)))
+		q.AddAssign(&ap)
+	}
+	if q.Z.IsZero() {
+		return nil, ErrInfinity
+	}
+	ctx.q.FromJacobian(&q)
+	ctx.gacc.SetOne()
+	return ctx, nil
+}
+
+// coefficient returns the key aggregation coefficient of pk
+func (ctx *KeyAggContext) coefficient(pk [PublicKeySize]byte) fr.Element {
+	var a fr.Element
+	if pk == ctx.second {
+		a.SetOne()
+		return a
+	}
+	h := schnorr.TaggedHash(tagKeyAggCoefficient, ctx.listHash[:], pk[:])
+	a.SetBytes(h[:])
+	return a
+}
+
+// hasKey returns true if pk is one of the aggregated public keys
+func (ctx *KeyAggContext) hasKey(pk [PublicKeySize]byte) bool {
+	return ctx.signerIndex(pk) >= 0
+}
+
+// signerIndex returns the index of the first occurrence of pk in the aggregated
+// public keys, or -1 if pk isn't one of them
+func (ctx *KeyAggContext) signerIndex(pk [PublicKeySize]byte) int {
+	for i := range ctx.pubKeys {
+		if ctx.pubKeys[i] == pk {
+			return i
+		}
+	}
+	return -1
+}
+
+// ApplyTweak tweaks the aggregated public key Q into g⋅Q + t⋅G, where g = -1 if the
+// tweak is x-only and Q has an odd y coordinate, and g = 1 otherwise. Plain tweaks are
+// used for BIP-32 derivation, x-only tweaks for BIP-341 (Taproot) commitments.
+func (ctx *KeyAggContext) ApplyTweak(tweak [fr.Bytes]byte, isXOnly bool) error {
+	var t fr.Element
+	if err := t.SetBytesCanonical(tweak[:]); err != nil {
+		return ErrInvalidTweak
+	}
+	var q secp256k1.G1Affine
+	q.Set(&ctx.q)
+	if isXOnly && !hasEvenY(&q) {
+		q.Neg(&q)
+		ctx.gacc.Neg(&ctx.gacc)
+		ctx.tacc.Neg(&ctx.tacc)
+	}
+	var tG secp256k1.G1Affine
+	tG.ScalarMultiplicationBase(t.BigInt(new(big.Int)))
+	q.Add(&q, &tG)
+	if q.IsInfinity() {
+		return ErrInfinity
+	}
+	ctx.q = q
+	ctx.tacc.Add(&ctx.tacc, &t)
+	return nil
+}
+
+// PublicKey returns the compressed encoding of the aggregated public key.
+func (ctx *KeyAggContext) PublicKey() [PublicKeySize]byte {
+	return cbytes(&ctx.q)
+}
+
+// XOnlyPublicKey returns the aggregated public key as a BIP-340 public key.
+func (ctx *KeyAggContext) XOnlyPublicKey() schnorr.PublicKey {
+	var pk schnorr.PublicKey
+	pk.A.Set(&ctx.q)
+	if !hasEvenY(&pk.A) {
+		pk.A.Neg(&pk.A)
+	}
+	return pk
+}
+
+// NonceGen generates a secret nonce and its public nonce, from 32 bytes read from rand.
+// pk is the public key of the signer; the secret key sk (32 bytes), the aggregated x-only
+// public key aggPk (32 bytes), the message msg and extraIn are optional and can be nil.
+// A nil msg is not the same as an empty message.
+//
+// The secret nonce must never be used twice; NonceGen can't be made deterministic
+// safely unless rand is a fresh source of randomness.
+func NonceGen(rand io.Reader, pk [PublicKeySize]byte, sk, aggPk, msg, extraIn []byte) (*SecNonce, PubNonce, error) {
+	var pubNonce PubNonce
+	if (sk != nil && len(sk) != fr.Bytes) || (aggPk != nil && len(aggPk) != fp.Bytes) {
+		return nil, pubNonce, ErrInvalidNonceGenInput
+	}
+	var seed [32]byte
+	if _, err := io.ReadFull(rand, seed[:]); err != nil {
+		return nil, pubNonce, err
+	}
+	if sk != nil {
+		aux := schnorr.TaggedHash(tagAux, seed[:])
+		for i := range seed {
+			seed[i] = sk[i] ^ aux[i]
+		}
+	}
+
+	var prefixedMsg []byte
+	if msg == nil {
+		prefixedMsg = []byte{0}
+	} else {
+		prefixedMsg = make([]byte, 9, 9+len(msg))
+		prefixedMsg[0] = 1
+		binary.BigEndian.PutUint64(prefixedMsg[1:], uint64(len(msg)))
+		prefixedMsg = append(prefixedMsg, msg...)
+	}
+	var extraInLen [4]byte
+	binary.BigEndian.PutUint32(extraInLen[:], uint32(len(extraIn)))
+
+	secNonce := new(SecNonce)
+	for i := 0; i < 2; i++ {
+		h := schnorr.TaggedHash(tagNonce,
+			seed[:],
+			[]byte{PublicKeySize}, pk[:],
+			[]byte{byte(len(aggPk))}, aggPk,
+			prefixedMsg,
+			extraInLen[:], extraIn,
+			[]byte{byte(i)})
+		var k fr.Element
+		k.SetBytes(h[:])
+		if k.IsZero() {
+			return nil, pubNonce, ErrInvalidSecretNonce
+		}
+		kb := k.Bytes()
+		copy(secNonce[i*fr.Bytes:], kb[:])
+
+		var r secp256k1.G1Affine
+		r.ScalarMultiplicationBase(k.BigInt(new(big.Int)))
+		rb := cbytes(&r)
+		copy(pubNonce[i*PublicKeySize:], rb[:])
+	}
+	copy(secNonce[2*fr.Bytes:], pk[:])
+
+	return secNonce, pubNonce, nil
+}
+
+// NonceAgg aggregates the public nonces of the signers.
+func NonceAgg(pubNonces []PubNonce) (AggNonce, error) {
+	var aggNonce AggNonce
+	for j := 0; j < 2; j++ {
+		var r secp256k1.G1Jac
+		for i := range pubNonces {
+			p, err := cpoint(pubNonces[i][j*PublicKeySize : (j+1)*PublicKeySize])
+			if err != nil {
+				return aggNonce, &InvalidContributionError{Signer: i, Contribution: "pubnonce"}
+			}
+			r.AddMixed(&p)
+		}
+		var rAff secp256k1.G1Affine
+		rAff.FromJacobian(&r)
+		rb := cbytesExt(&rAff)
+		copy(aggNonce[j*PublicKeySize:], rb[:])
+	}
+	return aggNonce, nil
+}
+
+// Session holds the values shared by all the signers to sign a message, once the
+// public nonces are aggregated.
+type Session struct {
+	ctx *KeyAggContext
+	msg []byte
+	b   fr.Element         // nonce coefficient
+	r   secp256k1.G1Affine // nonce point of the signature
+	e   fr.Element         // challenge
+}
+
+// NewSession returns the signing session of msg, for the aggregated (and possibly
+// tweaked) public key ctx and the aggregated nonce.
+func NewSession(ctx *KeyAggContext, aggNonce AggNonce, msg []byte) (*Session, error) {
+	s := &Session{
+		ctx: ctx,
+		msg: append([]byte{}, msg...),
+	}
+	qx := ctx.q.X.Bytes()
+	h := schnorr.TaggedHash(tagNonceCoefficient, aggNonce[:], qx[:], msg)
+	s.b.SetBytes(h[:])
+
+	r1, err := cpointExt(aggNonce[:PublicKeySize])
+	if err != nil {
+		return nil, &InvalidContributionError{Signer: -1, Contribution: "aggnonce"}
+	}
+	r2, err := cpointExt(aggNonce[PublicKeySize:])
+	if err != nil {
+		return nil, &InvalidContributionError{Signer: -1, Contribution: "aggnonce"}
+	}
+	var r secp256k1.G1Jac
+	r.ScalarMultiplicationAffine(&r2, s.b.BigInt(new(big.Int)))
+	r.AddMixed(&r1)
+	if r.Z.IsZero() {
+		_, s.r = secp256k1.Generators()
+	} else {
+		s.r.FromJacobian(&r)
+	}
+
+	rx := s.r.X.Bytes()
+	h = schnorr.TaggedHash(schnorr.TagChallenge, rx[:], qx[:], msg)
+	s.e.SetBytes(h[:])
+	return s, nil
+}
+
+// signFactor returns g⋅gacc, where g = -1 if the aggregated key has an odd y coordinate
+// and g = 1 otherwise
+func (s *Session) signFactor() fr.Element {
+	g := s.ctx.gacc
+	if !hasEvenY(&s.ctx.q) {
+		g.Neg(&g)
+	}
+	return g
+}
+
+// Sign returns the partial signature of the signer of secret key sk, with its secret
+// nonce. The secret nonce is zeroed, so that it can't be reused.
+//
+// s = k₁ + b⋅k₂ + e⋅a⋅d, with d = g⋅gacc⋅sk and the kᵢ negated if R has an odd y.
+func (s *Session) Sign(secNonce *SecNonce, sk []byte) (PartialSignature, error) {
+	var psig PartialSignature
+	var k1, k2, d fr.Element
+	err1 := k1.SetBytesCanonical(secNonce[:fr.Bytes])
+	err2 := k2.SetBytesCanonical(secNonce[fr.Bytes : 2*fr.Bytes])
+	// a secret nonce must be used once
+	for i := 0; i < 2*fr.Bytes; i++ {
+		secNonce[i] = 0
+	}
+	if err1 != nil || err2 != nil || k1.IsZero() || k2.IsZero() {
+		return psig, ErrInvalidSecretNonce
+	}
+	if !hasEvenY(&s.r) {
+		k1.Neg(&k1)
+		k2.Neg(&k2)
+	}
+
+	if len(sk) != fr.Bytes || d.SetBytesCanonical(sk) != nil || d.IsZero() {
+		return psig, ErrInvalidSecretKey
+	}
+	var p secp256k1.G1Affine
+	p.ScalarMultiplicationBase(d.BigInt(new(big.Int)))
+	pk := cbytes(&p)
+	if !bytes.Equal(pk[:], secNonce[2*fr.Bytes:]) {
+		return psig, ErrSecretNonceMismatch
+	}
+	if !s.ctx.hasKey(pk) {
+		return psig, ErrUnknownPublicKey
+	}
+	a := s.ctx.coefficient(pk)
+	g := s.signFactor()
+
+	var res fr.Element
+	d.Mul(&d, &g).Mul(&d, &a).Mul(&d, &s.e)
+	res.Mul(&k2, &s.b).Add(&res, &k1).Add(&res, &d)
+	psig = res.Bytes()
+	return psig, nil
+}
+
+// PartialSigVerify checks the partial signature of the signer of public key pk and
+// public nonce pubNonce
+//
+// s⋅G ?= ±(R₁ + b⋅R₂) + e⋅a⋅g⋅gacc⋅P
+func (s *Session) PartialSigVerify(psig PartialSignature, pubNonce PubNonce, pk [PublicKeySize]byte) error {
+	signer := s.ctx.signerIndex(pk)
+	if signer < 0 {
+		return ErrUnknownPublicKey
+	}
+	var sigma fr.Element
+	if err := sigma.SetBytesCanonical(psig[:]); err != nil {
+		return ErrInvalidPartialSig
+	}
+	r1, err := cpoint(pubNonce[:PublicKeySize])
+	if err != nil {
+		return &InvalidContributionError{Signer: signer, Contribution: "pubnonce"}
+	}
+	r2, err := cpoint(pubNonce[PublicKeySize:])
+	if err != nil {
+		return &InvalidContributionError{Signer: signer, Contribution: "pubnonce"}
+	}
+	p, err := cpoint(pk[:])
+	if err != nil {
+		return &InvalidContributionError{Signer: signer, Contribution: "pubkey"}
+	}
+
+	// re = R₁ + b⋅R₂, negated if R has an odd y coordinate
+	var re secp256k1.G1Jac
+	re.ScalarMultiplicationAffine(&r2, s.b.BigInt(new(big.Int)))
+	re.AddMixed(&r1)
+	if !hasEvenY(&s.r) {
+		re.Neg(&re)
+	}
+
+	// s⋅G - e⋅a⋅g⋅gacc⋅P
+	a := s.ctx.coefficient(pk)
+	g := s.signFactor()
+	g.Mul(&g, &a).Mul(&g, &s.e).Neg(&g)
+	var lhs secp256k1.G1Jac
+	lhs.JointScalarMultiplicationBase(&p, sigma.BigInt(new(big.Int)), g.BigInt(new(big.Int)))
+
+	if !lhs.Equal(&re) {
+		return ErrInvalidPartialSig
+	}
+	return nil
+}
+
+// PartialSigAgg aggregates the partial signatures into a BIP-340 signature
+// xbytes(R) ∥ ∑ sᵢ + e⋅g⋅tacc.
+func (s *Session) PartialSigAgg(psigs []PartialSignature) ([]byte, error) {
+	var sum, si fr.Element
+	for i := range psigs {
+		if err := si.SetBytesCanonical(psigs[i][:]); err != nil {
+			return nil, &InvalidContributionError{Signer: i, Contribution: "psig"}
+		}
+		sum.Add(&sum, &si)
+	}
+	g := fr.One()
+	if !hasEvenY(&s.ctx.q) {
+		g.Neg(&g)
+	}
+	g.Mul(&g, &s.e).Mul(&g, &s.ctx.tacc)
+	sum.Add(&sum, &g)
+
+	var sig schnorr.Signature
+	sig.R = s.r.X.Bytes()
+	sig.S = sum.Bytes()
+	return sig.Bytes(), nil
+}
+
+func hasEvenY(p *secp256k1.G1Affine) bool {
+	return p.Y.Bits()[0]&1 == 0
+}
+
+// cbytes returns the 33 bytes compressed encoding of p
+func cbytes(p *secp256k1.G1Affine) [PublicKeySize]byte {
+	var res [PublicKeySize]byte
+	res[0] = 2
+	if !hasEvenY(p) {
+		res[0] = 3
+	}
+	x := p.X.Bytes()
+	copy(res[1:], x[:])
+	return res
+}
+
+// cbytesExt is cbytes, with the point at infinity encoded as 33 zero bytes
+func cbytesExt(p *secp256k1.G1Affine) [PublicKeySize]byte {
+	if p.IsInfinity() {
+		return [PublicKeySize]byte{}
+	}
+	return cbytes(p)
+}
+
+// cpoint decodes a compressed point
+func cpoint(buf []byte) (secp256k1.G1Affine, error) {
+	var p secp256k1.G1Affine
+	if len(buf) != PublicKeySize || (buf[0] != 2 && buf[0] != 3) {
+		return p, errors.New("invalid compressed point")
+	}
+	var x fp.Element
+	if err := x.SetBytesCanonical(buf[1:]); err != nil {
+		return p, err
+	}
+	p, err := schnorr.LiftX(&x)
+	if err != nil {
+		return p, err
+	}
+	if buf[0] == 3 {
+		p.Neg(&p)
+	}
+	return p, nil
+}
+
+// cpointExt is cpoint, with 33 zero bytes decoded as the point at infinity
+func cpointExt(buf []byte) (secp256k1.G1Affine, error) {
+	if bytes.Equal(buf, make([]byte, PublicKeySize)) {
+		return secp256k1.G1Affine{}, nil
+	}
+	return cpoint(buf)
+}
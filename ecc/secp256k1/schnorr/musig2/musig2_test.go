@@ -0,0 +1,620 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package musig2
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/secp256k1"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/schnorr"
+)
+
+func decodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// key aggregation test vectors of BIP-327
+// https://github.com/bitcoin/bips/blob/master/bip-0327/vectors/key_agg_vectors.json
+var keyAggPubKeys = []string{
+	"02F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+	"03DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+	"023590A94E768F8E1815C2F24B4D80A8E3149316C3518CE7B7AD338368D038CA66",
+	"020000000000000000000000000000000000000000000000000000000000000005",
+	"02FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC30",
+	"04F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+	"03935F972DA013F80AE011890FA89B67A27B7BE6CCB24D3274D18B2D4067F261A9",
+}
+
+var keyAggTweaks = []string{
+	"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141",
+	"252E4BD67410A76CDF933D30EAA1608214037F1B105A013ECCD3C5C184A6110B",
+}
+
+func pubKeys(t *testing.T, indices ...int) [][PublicKeySize]byte {
+	res := make([][PublicKeySize]byte, len(indices))
+	for i, j := range indices {
+		copy(res[i][:], decodeHex(t, keyAggPubKeys[j]))
+	}
+	return res
+}
+
+func TestKeyAggVectors(t *testing.T) {
+	valid := []struct {
+		keys     []int
+		expected string
+	}{
+		{[]int{0, 1, 2}, "90539EEDE565F5D054F32CC0C220126889ED1E5D193BAF15AEF344FE59D4610C"},
+		{[]int{2, 1, 0}, "6204DE8B083426DC6EAF9502D27024D53FC826BF7D2012148A0575435DF54B2B"},
+		{[]int{0, 0, 0}, "B436E3BAD62B8CD409969A224731C193D051162D8C5AE8B109306127DA3AA935"},
+		{[]int{0, 0, 1, 1}, "69BC22BFA5D106306E48A20679DE1D7389386124D07571D0D872686028C26A3E"},
+	}
+	for i, v := range valid {
+		ctx, err := KeyAgg(pubKeys(t, v.keys...))
+		if err != nil {
+			t.Fatalf("vector %d: %v", i, err)
+		}
+		pk := ctx.XOnlyPublicKey()
+		if !strings.EqualFold(hex.EncodeToString(pk.Bytes()), v.expected) {
+			t.Fatalf("vector %d: wrong aggregated key %x", i, pk.Bytes())
+		}
+	}
+
+	// invalid public keys
+	for _, v := range []struct {
+		keys   []int
+		signer int
+	}{
+		{[]int{0, 3}, 1}, // not on the curve
+		{[]int{0, 4}, 1}, // exceeds field size
+		{[]int{5, 0}, 0}, // invalid prefix
+	} {
+		_, err := KeyAgg(pubKeys(t, v.keys...))
+		var contributionErr *InvalidContributionError
+		if !errors.As(err, &contributionErr) || contributionErr.Signer != v.signer {
+			t.Fatalf("keys %v: expected an invalid contribution from signer %d, got %v", v.keys, v.signer, err)
+		}
+	}
+
+	// invalid tweaks
+	var tweak [32]byte
+	ctx, err := KeyAgg(pubKeys(t, 0, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	copy(tweak[:], decodeHex(t, keyAggTweaks[0]))
+	if err := ctx.ApplyTweak(tweak, true); err != ErrInvalidTweak {
+		t.Fatal("expected ErrInvalidTweak")
+	}
+	if ctx, err = KeyAgg(pubKeys(t, 6)); err != nil {
+		t.Fatal(err)
+	}
+	copy(tweak[:], decodeHex(t, keyAggTweaks[1]))
+	if err := ctx.ApplyTweak(tweak, false); err != ErrInfinity {
+		t.Fatal("expected ErrInfinity")
+	}
+}
+
+func TestKeySort(t *testing.T) {
+	keys := pubKeys(t, 0, 1, 2, 6)
+	KeySort(keys)
+	expected := pubKeys(t, 2, 0, 6, 1)
+	for i := range keys {
+		if keys[i] != expected[i] {
+			t.Fatal("wrong order")
+		}
+	}
+}
+
+// nonce generation test vector of BIP-327
+// https://github.com/bitcoin/bips/blob/master/bip-0327/vectors/nonce_gen_vectors.json
+func TestNonceGenVector(t *testing.T) {
+	var pk [PublicKeySize]byte
+	copy(pk[:], decodeHex(t, "024D4B6CD1361032CA9BD2AEB9D900AA4D45D9EAD80AC9423374C451A7254D0766"))
+	secNonce, pubNonce, err := NonceGen(
+		bytes.NewReader(bytes.Repeat([]byte{0x0F}, 32)),
+		pk,
+		bytes.Repeat([]byte{0x02}, 32),
+		bytes.Repeat([]byte{0x07}, 32),
+		bytes.Repeat([]byte{0x01}, 32),
+		bytes.Repeat([]byte{0x08}, 32),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.EqualFold(hex.EncodeToString(secNonce[:]), "B114E502BEAA4E301DD08A50264172C84E41650E6CB726B410C0694D59EFFB6495B5CAF28D045B973D63E3C99A44B807BDE375FD6CB39E46DC4A511708D0E9D2024D4B6CD1361032CA9BD2AEB9D900AA4D45D9EAD80AC9423374C451A7254D0766") {
+		t.Fatal("wrong secret nonce")
+	}
+	if !strings.EqualFold(hex.EncodeToString(pubNonce[:]), "02F7BE7089E8376EB355272368766B17E88E7DB72047D05E56AA881EA52B3B35DF02C29C8046FDD0DED4C7E55869137200FBDBFE2EB654267B6D7013602CAED3115A") {
+		t.Fatal("wrong public nonce")
+	}
+}
+
+type signer struct {
+	sk [32]byte
+	pk [PublicKeySize]byte
+}
+
+func newSigners(t *testing.T, n int) []signer {
+	signers := make([]signer, n)
+	for i := range signers {
+		privKey, err := schnorr.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		copy(signers[i].sk[:], privKey.Bytes()[32:])
+		var p secp256k1.G1Affine
+		p.ScalarMultiplicationBase(new(big.Int).SetBytes(signers[i].sk[:]))
+		signers[i].pk = cbytes(&p)
+	}
+	return signers
+}
+
+func TestMuSig2(t *testing.T) {
+	const n = 3
+	signers := newSigners(t, n)
+	pks := make([][PublicKeySize]byte, n)
+	for i := range signers {
+		pks[i] = signers[i].pk
+	}
+	KeySort(pks)
+	ctx, err := KeyAgg(pks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// BIP-32 like plain tweak, then a Taproot like x-only tweak
+	var tweak [32]byte
+	for _, isXOnly := range []bool{false, true} {
+		if _, err := rand.Read(tweak[:16]); err != nil {
+			t.Fatal(err)
+		}
+		if err := ctx.ApplyTweak(tweak, isXOnly); err != nil {
+			t.Fatal(err)
+		}
+	}
+	aggPk := ctx.XOnlyPublicKey()
+	msg := []byte("MuSig2 two rounds multi-signature")
+
+	// round 1
+	secNonces := make([]*SecNonce, n)
+	pubNonces := make([]PubNonce, n)
+	for i := range signers {
+		if secNonces[i], pubNonces[i], err = NonceGen(rand.Reader, signers[i].pk, signers[i].sk[:], aggPk.Bytes(), msg, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	aggNonce, err := NonceAgg(pubNonces)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// round 2
+	session, err := NewSession(ctx, aggNonce, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	psigs := make([]PartialSignature, n)
+	for i := range signers {
+		if psigs[i], err = session.Sign(secNonces[i], signers[i].sk[:]); err != nil {
+			t.Fatal(err)
+		}
+		if err := session.PartialSigVerify(psigs[i], pubNonces[i], signers[i].pk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sig, err := session.PartialSigAgg(psigs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := aggPk.Verify(sig, msg, nil); err != nil || !ok {
+		t.Fatal("aggregated signature rejected")
+	}
+
+	// a secret nonce can't be reused
+	if _, err := session.Sign(secNonces[0], signers[0].sk[:]); err != ErrInvalidSecretNonce {
+		t.Fatal("expected ErrInvalidSecretNonce")
+	}
+
+	// a wrong partial signature is detected
+	if err := session.PartialSigVerify(psigs[0], pubNonces[1], signers[1].pk); err != ErrInvalidPartialSig {
+		t.Fatal("expected ErrInvalidPartialSig")
+	}
+	psigs[1] = psigs[0]
+	if sig, err = session.PartialSigAgg(psigs); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := aggPk.Verify(sig, msg, nil); ok {
+		t.Fatal("invalid aggregated signature accepted")
+	}
+}
+
+// nonce aggregation test vectors of BIP-327
+// https://github.com/bitcoin/bips/blob/master/bip-0327/vectors/nonce_agg_vectors.json
+var nonceAggPubNonces = []string{
+	"020151C80F435648DF67A22B749CD798CE54E0321D034B92B709B567D60A42E66603BA47FBC1834437B3212E89A84D8425E7BF12E0245D98262268EBDCB385D50641",
+	"03FF406FFD8ADB9CD29877E4985014F66A59F6CD01C0E88CAA8E5F3166B1F676A60248C264CDD57D3C24D79990B0F865674EB62A0F9018277A95011B41BFC193B833",
+	"020151C80F435648DF67A22B749CD798CE54E0321D034B92B709B567D60A42E6660279BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798",
+	"03FF406FFD8ADB9CD29877E4985014F66A59F6CD01C0E88CAA8E5F3166B1F676A60379BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798",
+	"04FF406FFD8ADB9CD29877E4985014F66A59F6CD01C0E88CAA8E5F3166B1F676A60248C264CDD57D3C24D79990B0F865674EB62A0F9018277A95011B41BFC193B833",
+	"03FF406FFD8ADB9CD29877E4985014F66A59F6CD01C0E88CAA8E5F3166B1F676A60248C264CDD57D3C24D79990B0F865674EB62A0F9018277A95011B41BFC193B831",
+	"03FF406FFD8ADB9CD29877E4985014F66A59F6CD01C0E88CAA8E5F3166B1F676A602FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC30",
+}
+
+func pubNonces(t *testing.T, list []string, indices ...int) []PubNonce {
+	res := make([]PubNonce, len(indices))
+	for i, j := range indices {
+		copy(res[i][:], decodeHex(t, list[j]))
+	}
+	return res
+}
+
+// expectContribution fails if err doesn't blame the given signer (the aggregator if
+// signer < 0) for an invalid contribution.
+func expectContribution(t *testing.T, err error, signer int, contribution string) {
+	t.Helper()
+	var contributionErr *InvalidContributionError
+	if !errors.As(err, &contributionErr) || contributionErr.Signer != signer || contributionErr.Contribution != contribution {
+		t.Fatalf("expected an invalid %s from signer %d, got %v", contribution, signer, err)
+	}
+}
+
+func TestNonceAggVectors(t *testing.T) {
+	valid := []struct {
+		nonces   []int
+		expected string
+	}{
+		{[]int{0, 1}, "035FE1873B4F2967F52FEA4A06AD5A8ECCBE9D0FD73068012C894E2E87CCB5804B024725377345BDE0E9C33AF3C43C0A29A9249F2F2956FA8CFEB55C8573D0262DC8"},
+		// the second points sum to the point at infinity, encoded as 33 zero bytes
+		{[]int{2, 3}, "035FE1873B4F2967F52FEA4A06AD5A8ECCBE9D0FD73068012C894E2E87CCB5804B000000000000000000000000000000000000000000000000000000000000000000"},
+	}
+	for i, v := range valid {
+		aggNonce, err := NonceAgg(pubNonces(t, nonceAggPubNonces, v.nonces...))
+		if err != nil {
+			t.Fatalf("vector %d: %v", i, err)
+		}
+		if !strings.EqualFold(hex.EncodeToString(aggNonce[:]), v.expected) {
+			t.Fatalf("vector %d: wrong aggregated nonce %x", i, aggNonce[:])
+		}
+	}
+
+	for _, v := range []struct {
+		nonces []int
+		signer int
+	}{
+		{[]int{0, 4}, 1}, // wrong tag in the first half
+		{[]int{5, 1}, 0}, // second half is not an x coordinate
+		{[]int{6, 1}, 0}, // second half exceeds field size
+	} {
+		_, err := NonceAgg(pubNonces(t, nonceAggPubNonces, v.nonces...))
+		expectContribution(t, err, v.signer, "pubnonce")
+	}
+}
+
+// signing and partial signature verification test vectors of BIP-327
+// https://github.com/bitcoin/bips/blob/master/bip-0327/vectors/sign_verify_vectors.json
+var (
+	signVerifySecretKey = "7FB9E0E687ADA1EEBF7ECFE2F21E73EBDB51A7D450948DFE8D76D7F2D1007671"
+	signVerifyPubKeys   = []string{
+		"03935F972DA013F80AE011890FA89B67A27B7BE6CCB24D3274D18B2D4067F261A9",
+		"02F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+		"02DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA661",
+		"020000000000000000000000000000000000000000000000000000000000000007",
+	}
+	signVerifySecNonces = []string{
+		"508B81A611F100A6B2B6B29656590898AF488BCF2E1F55CF22E5CFB84421FE61FA27FD49B1D50085B481285E1CA205D55C82CC1B31FF5CD54A489829355901F703935F972DA013F80AE011890FA89B67A27B7BE6CCB24D3274D18B2D4067F261A9",
+		"0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000003935F972DA013F80AE011890FA89B67A27B7BE6CCB24D3274D18B2D4067F261A9",
+	}
+	signVerifyPubNonces = []string{
+		"0337C87821AFD50A8644D820A8F3E02E499C931865C2360FB43D0A0D20DAFE07EA0287BF891D2A6DEAEBADC909352AA9405D1428C15F4B75F04DAE642A95C2548480",
+		"0279BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F817980279BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798",
+		"032DE2662628C90B03F5E720284EB52FF7D71F4284F627B68A853D78C78E1FFE9303E4C5524E83FFE1493B9077CF1CA6BEB2090C93D930321071AD40B2F44E599046",
+		"0237C87821AFD50A8644D820A8F3E02E499C931865C2360FB43D0A0D20DAFE07EA0387BF891D2A6DEAEBADC909352AA9405D1428C15F4B75F04DAE642A95C2548480",
+		"0200000000000000000000000000000000000000000000000000000000000000090287BF891D2A6DEAEBADC909352AA9405D1428C15F4B75F04DAE642A95C2548480",
+	}
+	signVerifyAggNonces = []string{
+		"028465FCF0BBDBCF443AABCCE533D42B4B5A10966AC09A49655E8C42DAAB8FCD61037496A3CC86926D452CAFCFD55D25972CA1675D549310DE296BFF42F72EEEA8C9",
+		"000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		"048465FCF0BBDBCF443AABCCE533D42B4B5A10966AC09A49655E8C42DAAB8FCD61037496A3CC86926D452CAFCFD55D25972CA1675D549310DE296BFF42F72EEEA8C9",
+		"028465FCF0BBDBCF443AABCCE533D42B4B5A10966AC09A49655E8C42DAAB8FCD61020000000000000000000000000000000000000000000000000000000000000009",
+		"028465FCF0BBDBCF443AABCCE533D42B4B5A10966AC09A49655E8C42DAAB8FCD6102FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC30",
+	}
+	signVerifyMsgs = []string{
+		"F95466D086770E689964664219266FE5ED215C92AE20BAB5C9D79ADDDDF3C0CF",
+		"",
+		"2626262626262626262626262626262626262626262626262626262626262626262626262626",
+	}
+)
+
+func signVerifyKeys(t *testing.T, indices ...int) [][PublicKeySize]byte {
+	res := make([][PublicKeySize]byte, len(indices))
+	for i, j := range indices {
+		copy(res[i][:], decodeHex(t, signVerifyPubKeys[j]))
+	}
+	return res
+}
+
+func signVerifySession(t *testing.T, keys []int, aggNonceIndex, msgIndex int) *Session {
+	t.Helper()
+	ctx, err := KeyAgg(signVerifyKeys(t, keys...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var aggNonce AggNonce
+	copy(aggNonce[:], decodeHex(t, signVerifyAggNonces[aggNonceIndex]))
+	session, err := NewSession(ctx, aggNonce, decodeHex(t, signVerifyMsgs[msgIndex]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return session
+}
+
+func TestSignVerifyVectors(t *testing.T) {
+	sk := decodeHex(t, signVerifySecretKey)
+
+	valid := []struct {
+		keys, nonces      []int
+		aggNonce, msg, me int
+		expected          string
+	}{
+		{[]int{0, 1, 2}, []int{0, 1, 2}, 0, 0, 0, "012ABBCB52B3016AC03AD82395A1A415C48B93DEF78718E62A7A90052FE224FB"},
+		{[]int{1, 0, 2}, []int{1, 0, 2}, 0, 0, 1, "9FF2F7AAA856150CC8819254218D3ADEEB0535269051897724F9DB3789513A52"},
+		{[]int{1, 2, 0}, []int{1, 2, 0}, 0, 0, 2, "FA23C359F6FAC4E7796BB93BC9F0532A95468C539BA20FF86D7C76ED92227900"},
+		// both halves of the aggregated nonce are the point at infinity
+		{[]int{0, 1}, []int{0, 3}, 1, 0, 0, "AE386064B26105404798F75DE2EB9AF5EDA5387B064B83D049CB7C5E08879531"},
+		// empty message
+		{[]int{0, 1, 2}, []int{0, 1, 2}, 0, 1, 0, "D7D63FFD644CCDA4E62BC2BC0B1D02DD32A1DC3030E155195810231D1037D82D"},
+		// 38 bytes message
+		{[]int{0, 1, 2}, []int{0, 1, 2}, 0, 2, 0, "E184351828DA5094A97C79CABDAAA0BFB87608C32E8829A4DF5340A6F243B78C"},
+	}
+	for i, v := range valid {
+		nonces := pubNonces(t, signVerifyPubNonces, v.nonces...)
+		aggNonce, err := NonceAgg(nonces)
+		if err != nil {
+			t.Fatalf("vector %d: %v", i, err)
+		}
+		if !strings.EqualFold(hex.EncodeToString(aggNonce[:]), signVerifyAggNonces[v.aggNonce]) {
+			t.Fatalf("vector %d: wrong aggregated nonce %x", i, aggNonce[:])
+		}
+
+		session := signVerifySession(t, v.keys, v.aggNonce, v.msg)
+		var secNonce SecNonce
+		copy(secNonce[:], decodeHex(t, signVerifySecNonces[0]))
+		psig, err := session.Sign(&secNonce, sk)
+		if err != nil {
+			t.Fatalf("vector %d: %v", i, err)
+		}
+		if !strings.EqualFold(hex.EncodeToString(psig[:]), v.expected) {
+			t.Fatalf("vector %d: wrong partial signature %x", i, psig[:])
+		}
+		pk := signVerifyKeys(t, v.keys[v.me])[0]
+		if err := session.PartialSigVerify(psig, nonces[v.me], pk); err != nil {
+			t.Fatalf("vector %d: %v", i, err)
+		}
+	}
+
+	// signing errors
+	var secNonce SecNonce
+	copy(secNonce[:], decodeHex(t, signVerifySecNonces[0]))
+	session := signVerifySession(t, []int{1, 2}, 0, 0)
+	if _, err := session.Sign(&secNonce, sk); err != ErrUnknownPublicKey {
+		t.Fatalf("signer's public key is not in the list: expected ErrUnknownPublicKey, got %v", err)
+	}
+
+	_, err := KeyAgg(signVerifyKeys(t, 1, 0, 3))
+	expectContribution(t, err, 2, "pubkey")
+
+	ctx, err := KeyAgg(signVerifyKeys(t, 1, 2, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, i := range []int{
+		2, // wrong tag in the first half
+		3, // second half is not an x coordinate
+		4, // second half exceeds field size
+	} {
+		var aggNonce AggNonce
+		copy(aggNonce[:], decodeHex(t, signVerifyAggNonces[i]))
+		_, err := NewSession(ctx, aggNonce, decodeHex(t, signVerifyMsgs[0]))
+		expectContribution(t, err, -1, "aggnonce")
+	}
+
+	// the secret nonce is invalid, which may indicate nonce reuse
+	copy(secNonce[:], decodeHex(t, signVerifySecNonces[1]))
+	session = signVerifySession(t, []int{0, 1, 2}, 0, 0)
+	if _, err := session.Sign(&secNonce, sk); err != ErrInvalidSecretNonce {
+		t.Fatalf("expected ErrInvalidSecretNonce, got %v", err)
+	}
+
+	// verification failures
+	nonces := pubNonces(t, signVerifyPubNonces, 0, 1, 2)
+	keys := signVerifyKeys(t, 0, 1, 2)
+	for _, v := range []struct {
+		sig string
+		me  int
+	}{
+		// negation of the valid partial signature
+		{"FED54434AD4CFE953FC527DC6A5E5BE8F6234907B7C187559557CE87A0541C46", 0},
+		// wrong signer
+		{"012ABBCB52B3016AC03AD82395A1A415C48B93DEF78718E62A7A90052FE224FB", 1},
+		// exceeds group size
+		{"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141", 0},
+	} {
+		var psig PartialSignature
+		copy(psig[:], decodeHex(t, v.sig))
+		if err := session.PartialSigVerify(psig, nonces[v.me], keys[v.me]); err != ErrInvalidPartialSig {
+			t.Fatalf("partial signature %s: expected ErrInvalidPartialSig, got %v", v.sig, err)
+		}
+	}
+
+	// verification errors: the public nonces and keys are checked when aggregated
+	_, err = NonceAgg(pubNonces(t, signVerifyPubNonces, 4, 1, 2))
+	expectContribution(t, err, 0, "pubnonce")
+	_, err = KeyAgg(signVerifyKeys(t, 3, 1, 2))
+	expectContribution(t, err, 0, "pubkey")
+
+	// and by PartialSigVerify, which blames the signer of the public key
+	var psig PartialSignature
+	copy(psig[:], decodeHex(t, "012ABBCB52B3016AC03AD82395A1A415C48B93DEF78718E62A7A90052FE224FB"))
+	invalidNonce := pubNonces(t, signVerifyPubNonces, 4)[0]
+	for signer := range keys {
+		err = session.PartialSigVerify(psig, invalidNonce, keys[signer])
+		expectContribution(t, err, signer, "pubnonce")
+	}
+	// the public key is checked first: an invalid key isn't one of the signers
+	if err = session.PartialSigVerify(psig, invalidNonce, signVerifyKeys(t, 3)[0]); err != ErrUnknownPublicKey {
+		t.Fatalf("expected ErrUnknownPublicKey, got %v", err)
+	}
+}
+
+// partial signature aggregation test vectors of BIP-327
+// https://github.com/bitcoin/bips/blob/master/bip-0327/vectors/sig_agg_vectors.json
+var (
+	sigAggPubKeys = []string{
+		"03935F972DA013F80AE011890FA89B67A27B7BE6CCB24D3274D18B2D4067F261A9",
+		"02D2DC6F5DF7C56ACF38C7FA0AE7A759AE30E19B37359DFDE015872324C7EF6E05",
+		"03C7FB101D97FF930ACD0C6760852EF64E69083DE0B06AC6335724754BB4B0522C",
+		"02352433B21E7E05D3B452B81CAE566E06D2E003ECE16D1074AABA4289E0E3D581",
+	}
+	sigAggPubNonces = []string{
+		"036E5EE6E28824029FEA3E8A9DDD2C8483F5AF98F7177C3AF3CB6F47CAF8D94AE902DBA67E4A1F3680826172DA15AFB1A8CA85C7C5CC88900905C8DC8C328511B53E",
+		"03E4F798DA48A76EEC1C9CC5AB7A880FFBA201A5F064E627EC9CB0031D1D58FC5103E06180315C5A522B7EC7C08B69DCD721C313C940819296D0A7AB8E8795AC1F00",
+		"02C0068FD25523A31578B8077F24F78F5BD5F2422AFF47C1FADA0F36B3CEB6C7D202098A55D1736AA5FCC21CF0729CCE852575C06C081125144763C2C4C4A05C09B6",
+		"031F5C87DCFBFCF330DEE4311D85E8F1DEA01D87A6F1C14CDFC7E4F1D8C441CFA40277BF176E9F747C34F81B0D9F072B1B404A86F402C2D86CF9EA9E9C69876EA3B9",
+		"023F7042046E0397822C4144A17F8B63D78748696A46C3B9F0A901D296EC3406C302022B0B464292CF9751D699F10980AC764E6F671EFCA15069BBE62B0D1C62522A",
+		"02D97DDA5988461DF58C5897444F116A7C74E5711BF77A9446E27806563F3B6C47020CBAD9C363A7737F99FA06B6BE093CEAFF5397316C5AC46915C43767AE867C00",
+	}
+	sigAggTweaks = []string{
+		"B511DA492182A91B0FFB9A98020D55F260AE86D7ECBD0399C7383D59A5F2AF7C",
+		"A815FE049EE3C5AAB66310477FBC8BCCCAC2F3395F59F921C364ACD78A2F48DC",
+		"75448A87274B056468B977BE06EB1E9F657577B7320B0A3376EA51FD420D18A8",
+	}
+	sigAggPartialSigs = []string{
+		"B15D2CD3C3D22B04DAE438CE653F6B4ECF042F42CFDED7C41B64AAF9B4AF53FB",
+		"6193D6AC61B354E9105BBDC8937A3454A6D705B6D57322A5A472A02CE99FCB64",
+		"9A87D3B79EC67228CB97878B76049B15DBD05B8158D17B5B9114D3C226887505",
+		"66F82EA90923689B855D36C6B7E032FB9970301481B99E01CDB4D6AC7C347A15",
+		"4F5AEE41510848A6447DCD1BBC78457EF69024944C87F40250D3EF2C25D33EFE",
+		"DDEF427BBB847CC027BEFF4EDB01038148917832253EBC355FC33F4A8E2FCCE4",
+		"97B890A26C981DA8102D3BC294159D171D72810FDF7C6A691DEF02F0F7AF3FDC",
+		"53FA9E08BA5243CBCB0D797C5EE83BC6728E539EB76C2D0BF0F971EE4E909971",
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141",
+	}
+	sigAggMsg = "599C67EA410D005B9DA90817CF03ED3B1C868E4DA4EDF00A5880B0082C237869"
+)
+
+type sigAggVector struct {
+	aggNonce             string
+	nonces, keys, tweaks []int
+	isXOnly              []bool
+	psigs                []int
+}
+
+func (v *sigAggVector) session(t *testing.T) (*Session, *KeyAggContext) {
+	t.Helper()
+	keys := make([][PublicKeySize]byte, len(v.keys))
+	for i, j := range v.keys {
+		copy(keys[i][:], decodeHex(t, sigAggPubKeys[j]))
+	}
+	ctx, err := KeyAgg(keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, j := range v.tweaks {
+		var tweak [32]byte
+		copy(tweak[:], decodeHex(t, sigAggTweaks[j]))
+		if err := ctx.ApplyTweak(tweak, v.isXOnly[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	aggNonce, err := NonceAgg(pubNonces(t, sigAggPubNonces, v.nonces...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.EqualFold(hex.EncodeToString(aggNonce[:]), v.aggNonce) {
+		t.Fatalf("wrong aggregated nonce %x", aggNonce[:])
+	}
+	session, err := NewSession(ctx, aggNonce, decodeHex(t, sigAggMsg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return session, ctx
+}
+
+func (v *sigAggVector) partialSigs(t *testing.T) []PartialSignature {
+	psigs := make([]PartialSignature, len(v.psigs))
+	for i, j := range v.psigs {
+		copy(psigs[i][:], decodeHex(t, sigAggPartialSigs[j]))
+	}
+	return psigs
+}
+
+func TestSigAggVectors(t *testing.T) {
+	valid := []struct {
+		sigAggVector
+		expected string
+	}{
+		{sigAggVector{
+			aggNonce: "0341432722C5CD0268D829C702CF0D1CBCE57033EED201FD335191385227C3210C03D377F2D258B64AADC0E16F26462323D701D286046A2EA93365656AFD9875982B",
+			nonces:   []int{0, 1}, keys: []int{0, 1}, psigs: []int{0, 1},
+		}, "041DA22223CE65C92C9A0D6C2CAC828AAF1EEE56304FEC371DDF91EBB2B9EF0912F1038025857FEDEB3FF696F8B99FA4BB2C5812F6095A2E0004EC99CE18DE1E"},
+		{sigAggVector{
+			aggNonce: "0224AFD36C902084058B51B5D36676BBA4DC97C775873768E58822F87FE437D792028CB15929099EEE2F5DAE404CD39357591BA32E9AF4E162B8D3E7CB5EFE31CB20",
+			nonces:   []int{0, 2}, keys: []int{0, 2}, psigs: []int{2, 3},
+		}, "1069B67EC3D2F3C7C08291ACCB17A9C9B8F2819A52EB5DF8726E17E7D6B52E9F01800260A7E9DAC450F4BE522DE4CE12BA91AEAF2B4279219EF74BE1D286ADD9"},
+		{sigAggVector{
+			aggNonce: "0208C5C438C710F4F96A61E9FF3C37758814B8C3AE12BFEA0ED2C87FF6954FF186020B1816EA104B4FCA2D304D733E0E19CEAD51303FF6420BFD222335CAA402916D",
+			nonces:   []int{0, 3}, keys: []int{0, 2}, tweaks: []int{0}, isXOnly: []bool{false}, psigs: []int{4, 5},
+		}, "5C558E1DCADE86DA0B2F02626A512E30A22CF5255CAEA7EE32C38E9A71A0E9148BA6C0E6EC7683B64220F0298696F1B878CD47B107B81F7188812D593971E0CC"},
+		{sigAggVector{
+			aggNonce: "02B5AD07AFCD99B6D92CB433FBD2A28FDEB98EAE2EB09B6014EF0F8197CD58403302E8616910F9293CF692C49F351DB86B25E352901F0E237BAFDA11F1C1CEF29FFD",
+			nonces:   []int{0, 4}, keys: []int{0, 3}, tweaks: []int{0, 1, 2}, isXOnly: []bool{true, false, true}, psigs: []int{6, 7},
+		}, "839B08820B681DBA8DAF4CC7B104E8F2638F9388F8D7A555DC17B6E6971D7426CE07BF6AB01F1DB50E4E33719295F4094572B79868E440FB3DEFD3FAC1DB589E"},
+	}
+	for i, v := range valid {
+		session, ctx := v.session(t)
+		sig, err := session.PartialSigAgg(v.partialSigs(t))
+		if err != nil {
+			t.Fatalf("vector %d: %v", i, err)
+		}
+		if !strings.EqualFold(hex.EncodeToString(sig), v.expected) {
+			t.Fatalf("vector %d: wrong signature %x", i, sig)
+		}
+		aggPk := ctx.XOnlyPublicKey()
+		if ok, err := aggPk.Verify(sig, decodeHex(t, sigAggMsg), nil); err != nil || !ok {
+			t.Fatalf("vector %d: aggregated signature rejected", i)
+		}
+	}
+
+	// the second partial signature exceeds the group size
+	v := sigAggVector{
+		aggNonce: "02B5AD07AFCD99B6D92CB433FBD2A28FDEB98EAE2EB09B6014EF0F8197CD58403302E8616910F9293CF692C49F351DB86B25E352901F0E237BAFDA11F1C1CEF29FFD",
+		nonces:   []int{0, 4}, keys: []int{0, 3}, tweaks: []int{0, 1, 2}, isXOnly: []bool{true, false, true}, psigs: []int{7, 8},
+	}
+	session, _ := v.session(t)
+	_, err := session.PartialSigAgg(v.partialSigs(t))
+	expectContribution(t, err, 1, "psig")
+}
@@ -0,0 +1,366 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package schnorr implements the BIP-340 Schnorr signature scheme on the secp256k1 curve.
+//
+// Public keys are x-only: a public key is the x coordinate of the point with even y
+// among ±d⋅G, and the nonce point of a signature is encoded the same way.
+//
+// Documentation:
+// - BIP-340: https://github.com/bitcoin/bips/blob/master/bip-0340.mediawiki
+package schnorr
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/fp"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/fr"
+	"github.com/consensys/gnark-crypto/signature"
+)
+
+const (
+	sizeFr         = fr.Bytes
+	sizeFp         = fp.Bytes
+	sizePublicKey  = sizeFp
+	sizePrivateKey = sizePublicKey + sizeFr
+	sizeSignature  = sizeFp + sizeFr
+)
+
+// tags of the hashes used by BIP-340
+const (
+	TagAux       = "BIP0340/aux"
+	TagNonce     = "BIP0340/nonce"
+	TagChallenge = "BIP0340/challenge"
+)
+
+var (
+	ErrInvalidPublicKey  = errors.New("invalid public key: x is not the coordinate of a point on the curve")
+	ErrZeroScalar        = errors.New("secret scalar is zero")
+	ErrZeroNonce         = errors.New("derived nonce is zero")
+	errWrongSize         = errors.New("wrong size buffer")
+	errPublicKeyMismatch = errors.New("public key doesn't match the secret scalar")
+)
+
+// PublicKey represents a BIP-340 public key, A is the point with even y coordinate
+// of the key pair
+type PublicKey struct {
+	A secp256k1.G1Affine
+}
+
+// PrivateKey represents a BIP-340 private key
+type PrivateKey struct {
+	PublicKey PublicKey
+	scalar    [sizeFr]byte // secret scalar, in big Endian, as given (the public point may have odd y)
+}
+
+// Signature represents a BIP-340 signature
+type Signature struct {
+	R [sizeFp]byte // x coordinate of the nonce point
+	S [sizeFr]byte
+}
+
+// TaggedHash returns SHA256(SHA256(tag) ∥ SHA256(tag) ∥ msg[0] ∥ msg[1] ∥ ...)
+func TaggedHash(tag string, msg ...[]byte) [32]byte {
+	tagHash := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	for i := range msg {
+		h.Write(msg[i])
+	}
+	var res [32]byte
+	h.Sum(res[:0])
+	return res
+}
+
+// GenerateKey generates a public and private key pair.
+func GenerateKey(rand io.Reader) (*PrivateKey, error) {
+	var b [sizeFr + 16]byte
+	var d fr.Element
+	for d.IsZero() {
+		if _, err := io.ReadFull(rand, b[:]); err != nil {
+			return nil, err
+		}
+		d.SetBytes(b[:])
+	}
+	privateKey := new(PrivateKey)
+	privateKey.scalar = d.Bytes()
+	privateKey.PublicKey.A = publicPoint(&d)
+	return privateKey, nil
+}
+
+// NewPrivateKey returns the key pair of the secret scalar d given in big endian.
+func NewPrivateKey(d []byte) (*PrivateKey, error) {
+	var privateKey PrivateKey
+	if len(d) != sizeFr {
+		return nil, errWrongSize
+	}
+	if err := privateKey.setScalar(d); err != nil {
+		return nil, err
+	}
+	return &privateKey, nil
+}
+
+func (privKey *PrivateKey) setScalar(d []byte) error {
+	var s fr.Element
+	if err := s.SetBytesCanonical(d); err != nil {
+		return err
+	}
+	if s.IsZero() {
+		return ErrZeroScalar
+	}
+	copy(privKey.scalar[:], d)
+	privKey.PublicKey.A = publicPoint(&s)
+	return nil
+}
+
+// publicPoint returns the point with even y among ±d⋅G
+func publicPoint(d *fr.Element) secp256k1.G1Affine {
+	var p secp256k1.G1Affine
+	p.ScalarMultiplicationBase(d.BigInt(new(big.Int)))
+	if !hasEvenY(&p) {
+		p.Neg(&p)
+	}
+	return p
+}
+
+// hasEvenY returns true if the y coordinate of p, in canonical form, is even.
+func hasEvenY(p *secp256k1.G1Affine) bool {
+	return p.Y.Bits()[0]&1 == 0
+}
+
+// LiftX returns the point with x coordinate x and even y coordinate, if it exists.
+func LiftX(x *fp.Element) (secp256k1.G1Affine, error) {
+	var p secp256k1.G1Affine
+	_, b := secp256k1.CurveCoefficients()
+	var y2 fp.Element
+	y2.Square(x).Mul(&y2, x).Add(&y2, &b)
+	if p.Y.Sqrt(&y2) == nil {
+		return p, ErrInvalidPublicKey
+	}
+	p.X.Set(x)
+	if !hasEvenY(&p) {
+		p.Y.Neg(&p.Y)
+	}
+	return p, nil
+}
+
+// Equal compares 2 public keys
+func (pub *PublicKey) Equal(x signature.PublicKey) bool {
+	xx, ok := x.(*PublicKey)
+	if !ok {
+		return false
+	}
+	bpk := pub.Bytes()
+	bxx := xx.Bytes()
+	return subtle.ConstantTimeCompare(bpk, bxx) == 1
+}
+
+// Public returns the public key associated to the private key.
+func (privKey *PrivateKey) Public() signature.PublicKey {
+	var pub PublicKey
+	pub.A.Set(&privKey.PublicKey.A)
+	return &pub
+}
+
+// Sign performs the BIP-340 signature of the message with 32 bytes of fresh randomness
+// as auxiliary data. If hFunc is not nil, the signed message is hFunc(message).
+func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error) {
+	var aux [32]byte
+	if _, err := io.ReadFull(rand.Reader, aux[:]); err != nil {
+		return nil, err
+	}
+	return privKey.SignWithAuxRand(message, hFunc, aux)
+}
+
+// SignWithAuxRand performs the BIP-340 signature of the message, with the auxiliary
+// random data aux
+//
+// d = sk if y(P) is even, -sk otherwise
+// t = d ⊕ hash_aux(aux)
+// k = hash_nonce(t ∥ x(P) ∥ m), negated if y(k⋅G) is odd
+// R = k⋅G
+// e = hash_challenge(x(R) ∥ x(P) ∥ m)
+// signature = x(R) ∥ k + e⋅d
+func (privKey *PrivateKey) SignWithAuxRand(message []byte, hFunc hash.Hash, aux [32]byte) ([]byte, error) {
+	message, err := hashMessage(message, hFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	var d, k fr.Element
+	if err := d.SetBytesCanonical(privKey.scalar[:]); err != nil {
+		return nil, err
+	}
+	if d.IsZero() {
+		return nil, ErrZeroScalar
+	}
+	var P secp256k1.G1Affine
+	P.ScalarMultiplicationBase(d.BigInt(new(big.Int)))
+	if !hasEvenY(&P) {
+		d.Neg(&d)
+		P.Neg(&P)
+	}
+
+	t := TaggedHash(TagAux, aux[:])
+	db := d.Bytes()
+	for i := range t {
+		t[i] ^= db[i]
+	}
+	pb := P.X.Bytes()
+	nonce := TaggedHash(TagNonce, t[:], pb[:], message)
+	k.SetBytes(nonce[:])
+	if k.IsZero() {
+		return nil, ErrZeroNonce
+	}
+	var R secp256k1.G1Affine
+	R.ScalarMultiplicationBase(k.BigInt(new(big.Int)))
+	if !hasEvenY(&R) {
+		k.Neg(&k)
+	}
+
+	var sig Signature
+	sig.R = R.X.Bytes()
+	e := challenge(sig.R[:], pb[:], message)
+	e.Mul(&e, &d).Add(&e, &k)
+	sig.S = e.Bytes()
+
+	return sig.Bytes(), nil
+}
+
+// Verify validates the BIP-340 signature. If hFunc is not nil, the signed message
+// is hFunc(message).
+//
+// R = s⋅G - e⋅P, with e = hash_challenge(r ∥ x(P) ∥ m)
+// R ?≠ ∞, y(R) ?= even, x(R) ?= r
+func (publicKey *PublicKey) Verify(sigBin, message []byte, hFunc hash.Hash) (bool, error) {
+	message, err := hashMessage(message, hFunc)
+	if err != nil {
+		return false, err
+	}
+
+	var sig Signature
+	if _, err := sig.SetBytes(sigBin); err != nil {
+		return false, err
+	}
+	var r fp.Element
+	var s fr.Element
+	if err := r.SetBytesCanonical(sig.R[:]); err != nil {
+		return false, nil
+	}
+	if err := s.SetBytesCanonical(sig.S[:]); err != nil {
+		return false, nil
+	}
+
+	pb := publicKey.A.X.Bytes()
+	e := challenge(sig.R[:], pb[:], message)
+	e.Neg(&e)
+
+	var R secp256k1.G1Jac
+	var RAff secp256k1.G1Affine
+	R.JointScalarMultiplicationBase(&publicKey.A, s.BigInt(new(big.Int)), e.BigInt(new(big.Int)))
+	if R.Z.IsZero() {
+		return false, nil
+	}
+	RAff.FromJacobian(&R)
+
+	return hasEvenY(&RAff) && RAff.X.Equal(&r), nil
+}
+
+// BatchVerify validates all the BIP-340 signatures at once, with a single multi scalar
+// multiplication. It returns true if and only if all the signatures are valid (up to a
+// negligible probability). If hFunc is not nil, the signed messages are hFunc(messages[i]).
+//
+// With random a₀ = 1, a₁, ..., it checks (∑ aᵢsᵢ)⋅G - ∑ aᵢ⋅Rᵢ - ∑ aᵢeᵢ⋅Pᵢ ?= ∞
+func BatchVerify(publicKeys []PublicKey, messages [][]byte, signatures [][]byte, hFunc hash.Hash) (bool, error) {
+	n := len(publicKeys)
+	if len(messages) != n || len(signatures) != n {
+		return false, errors.New("number of public keys, messages and signatures differ")
+	}
+	if n == 0 {
+		return true, nil
+	}
+
+	points := make([]secp256k1.G1Affine, 2*n+1)
+	scalars := make([]fr.Element, 2*n+1)
+	_, points[2*n] = secp256k1.Generators()
+	for i := 0; i < n; i++ {
+		message, err := hashMessage(messages[i], hFunc)
+		if err != nil {
+			return false, err
+		}
+		var sig Signature
+		if _, err := sig.SetBytes(signatures[i]); err != nil {
+			return false, err
+		}
+		var r fp.Element
+		var s fr.Element
+		if err := r.SetBytesCanonical(sig.R[:]); err != nil {
+			return false, nil
+		}
+		if err := s.SetBytesCanonical(sig.S[:]); err != nil {
+			return false, nil
+		}
+		if points[2*i], err = LiftX(&r); err != nil {
+			return false, nil
+		}
+		points[2*i+1] = publicKeys[i].A
+
+		var a fr.Element
+		if i == 0 {
+			a.SetOne()
+		} else if _, err := a.SetRandom(); err != nil {
+			return false, err
+		}
+		pb := publicKeys[i].A.X.Bytes()
+		e := challenge(sig.R[:], pb[:], message)
+		scalars[2*i].Neg(&a)
+		scalars[2*i+1].Mul(&a, &e).Neg(&scalars[2*i+1])
+		s.Mul(&s, &a)
+		scalars[2*n].Add(&scalars[2*n], &s)
+	}
+
+	var res secp256k1.G1Jac
+	if _, err := res.MultiExp(points, scalars, ecc.MultiExpConfig{}); err != nil {
+		return false, err
+	}
+	return res.Z.IsZero(), nil
+}
+
+// challenge returns hash_challenge(r ∥ p ∥ m) mod n
+func challenge(r, p, m []byte) fr.Element {
+	var e fr.Element
+	h := TaggedHash(TagChallenge, r, p, m)
+	e.SetBytes(h[:])
+	return e
+}
+
+func hashMessage(message []byte, hFunc hash.Hash) ([]byte, error) {
+	if hFunc == nil {
+		return message, nil
+	}
+	hFunc.Reset()
+	if _, err := hFunc.Write(message); err != nil {
+		return nil, err
+	}
+	return hFunc.Sum(nil), nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecdsa
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+)
+
+// TestECDSAStdlib checks that signatures and public keys are interoperable
+// with the crypto/ecdsa implementation of P-256.
+func TestECDSAStdlib(t *testing.T) {
+	t.Parallel()
+	msg := []byte("testing ECDSA against crypto/ecdsa")
+	digest := sha256.Sum256(msg)
+
+	for i := 0; i < 10; i++ {
+		privKey, err := GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pubBin := privKey.PublicKey.Bytes()
+		std := &ecdsa.PrivateKey{
+			PublicKey: ecdsa.PublicKey{
+				Curve: elliptic.P256(),
+				X:     new(big.Int).SetBytes(pubBin[1 : 1+sizeFp]),
+				Y:     new(big.Int).SetBytes(pubBin[1+sizeFp:]),
+			},
+			D: new(big.Int).SetBytes(privKey.scalar[:]),
+		}
+
+		// the public key has the SEC 1 uncompressed encoding of crypto/ecdh
+		stdPub, err := std.PublicKey.ECDH()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(pubBin, stdPub.Bytes()) {
+			t.Fatal("public key encoding differs from crypto/ecdh")
+		}
+		stdPriv, err := std.ECDH()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(stdPriv.PublicKey().Bytes(), pubBin) {
+			t.Fatal("public key differs from the one derived by crypto/ecdh")
+		}
+
+		// signed here, verified by crypto/ecdsa
+		sig, err := privKey.Sign(msg, sha256.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := new(big.Int).SetBytes(sig[:sizeFr])
+		s := new(big.Int).SetBytes(sig[sizeFr:])
+		if !ecdsa.Verify(&std.PublicKey, digest[:], r, s) {
+			t.Fatal("crypto/ecdsa rejected a signature")
+		}
+
+		// signed by crypto/ecdsa, verified here
+		r, s, err = ecdsa.Sign(rand.Reader, std, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig = make([]byte, sizeSignature)
+		r.FillBytes(sig[:sizeFr])
+		s.FillBytes(sig[sizeFr:])
+		var pub PublicKey
+		if _, err := pub.SetBytes(stdPub.Bytes()); err != nil {
+			t.Fatal(err)
+		}
+		ok, err := pub.Verify(sig, msg, sha256.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			t.Fatal("signature from crypto/ecdsa rejected")
+		}
+	}
+}
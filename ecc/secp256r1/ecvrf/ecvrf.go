@@ -0,0 +1,359 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecvrf
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/secp256r1"
+	"github.com/consensys/gnark-crypto/ecc/secp256r1/fp"
+	"github.com/consensys/gnark-crypto/ecc/secp256r1/fr"
+)
+
+const (
+	// Suite is the name of the ciphersuite
+	Suite = "ECVRF-P256-SHA256-TAI"
+	// SuiteString identifies the ciphersuite in all the hashes
+	SuiteString = 0x01
+)
+
+const (
+	sizeFr         = fr.Bytes
+	sizePoint      = 1 + fp.Bytes // ptLen, SEC 1 compressed encoding
+	sizeChallenge  = 16           // cLen
+	sizePublicKey  = sizePoint
+	sizePrivateKey = sizePublicKey + sizeFr
+
+	// SizeProof is the size of a proof pi
+	SizeProof = sizePoint + sizeChallenge + sizeFr
+	// SizeOutput is the size of an output beta
+	SizeOutput = sha256.Size
+)
+
+// domain separators of the hashes
+const (
+	encodeToCurveDomain byte = 0x01
+	challengeDomain     byte = 0x02
+	proofToHashDomain   byte = 0x03
+	backDomain          byte = 0x00
+)
+
+var (
+	ErrInvalidProof      = errors.New("invalid VRF proof")
+	ErrInvalidPublicKey  = errors.New("invalid public key")
+	ErrZeroScalar        = errors.New("secret scalar is zero")
+	errEncodeToCurve     = errors.New("no point found by try-and-increment")
+	errInvalidPoint      = errors.New("invalid compressed point")
+	errWrongSize         = errors.New("wrong size buffer")
+	errPublicKeyMismatch = errors.New("public key doesn't match the secret scalar")
+)
+
+var order = fr.Modulus()
+
+// PublicKey represents an ECVRF public key
+type PublicKey struct {
+	Y secp256r1.G1Affine
+}
+
+// PrivateKey represents an ECVRF private key
+type PrivateKey struct {
+	PublicKey PublicKey
+	scalar    [sizeFr]byte // secret scalar x, in big Endian
+}
+
+// Proof represents an ECVRF proof pi = (Gamma, c, s)
+type Proof struct {
+	Gamma secp256r1.G1Affine
+	C     [sizeChallenge]byte
+	S     [sizeFr]byte
+}
+
+// GenerateKey generates a public and private key pair.
+func GenerateKey(rand io.Reader) (*PrivateKey, error) {
+	var b [sizeFr + 16]byte
+	var x fr.Element
+	for x.IsZero() {
+		if _, err := io.ReadFull(rand, b[:]); err != nil {
+			return nil, err
+		}
+		x.SetBytes(b[:])
+	}
+	privKey := new(PrivateKey)
+	privKey.setScalar(x.BigInt(new(big.Int)))
+	return privKey, nil
+}
+
+// NewPrivateKey returns the key pair of the secret scalar x given in big endian.
+func NewPrivateKey(x []byte) (*PrivateKey, error) {
+	if len(x) != sizeFr {
+		return nil, errWrongSize
+	}
+	var s fr.Element
+	if err := s.SetBytesCanonical(x); err != nil {
+		return nil, err
+	}
+	if s.IsZero() {
+		return nil, ErrZeroScalar
+	}
+	privKey := new(PrivateKey)
+	privKey.setScalar(s.BigInt(new(big.Int)))
+	return privKey, nil
+}
+
+func (privKey *PrivateKey) setScalar(x *big.Int) {
+	x.FillBytes(privKey.scalar[:])
+	privKey.PublicKey.Y.ScalarMultiplicationBase(x)
+}
+
+// Prove returns the proof pi of the VRF on alpha (RFC 9381, Section 5.1)
+//
+//	H = encode_to_curve(Y, alpha)
+//	Gamma = x ⋅ H
+//	k = RFC 6979 nonce of H
+//	c = challenge(Y, H, Gamma, k ⋅ B, k ⋅ H)
+//	s = k + c ⋅ x mod q
+//	pi = Gamma ∥ c ∥ s
+func (privKey *PrivateKey) Prove(alpha []byte) ([]byte, error) {
+	pkString := pointToString(&privKey.PublicKey.Y)
+	H, err := encodeToCurve(pkString[:], alpha)
+	if err != nil {
+		return nil, err
+	}
+	hString := pointToString(&H)
+	x := new(big.Int).SetBytes(privKey.scalar[:])
+
+	var proof Proof
+	proof.Gamma.ScalarMultiplication(&H, x)
+
+	k := nonce(x, hString[:])
+	var U, V secp256r1.G1Affine
+	U.ScalarMultiplicationBase(k)
+	V.ScalarMultiplication(&H, k)
+	proof.C = challenge(&privKey.PublicKey.Y, &H, &proof.Gamma, &U, &V)
+
+	s := new(big.Int).SetBytes(proof.C[:])
+	s.Mul(s, x).Add(s, k).Mod(s, order)
+	s.FillBytes(proof.S[:])
+
+	return proof.Bytes(), nil
+}
+
+// ProofToHash returns the output beta of the proof pi (RFC 9381, Section 5.2),
+// without verifying pi:
+//
+//	beta = SHA-256(SuiteString ∥ 0x03 ∥ Gamma ∥ 0x00)
+func ProofToHash(pi []byte) ([]byte, error) {
+	var proof Proof
+	if _, err := proof.SetBytes(pi); err != nil {
+		return nil, err
+	}
+	return proof.hash(), nil
+}
+
+func (proof *Proof) hash() []byte {
+	// the cofactor is 1
+	gammaString := pointToString(&proof.Gamma)
+	h := sha256.New()
+	h.Write([]byte{SuiteString, proofToHashDomain})
+	h.Write(gammaString[:])
+	h.Write([]byte{backDomain})
+	return h.Sum(nil)
+}
+
+// Verify checks the proof pi of the VRF on alpha and returns the output beta
+// (RFC 9381, Section 5.3). It returns ErrInvalidProof if pi is not valid.
+//
+//	H = encode_to_curve(Y, alpha)
+//	U = s ⋅ B - c ⋅ Y
+//	V = s ⋅ H - c ⋅ Gamma
+//	c == challenge(Y, H, Gamma, U, V)
+func (pk *PublicKey) Verify(pi, alpha []byte) ([]byte, error) {
+	if pk.Y.IsInfinity() || !pk.Y.IsOnCurve() {
+		return nil, ErrInvalidPublicKey
+	}
+	var proof Proof
+	if _, err := proof.SetBytes(pi); err != nil {
+		return nil, ErrInvalidProof
+	}
+
+	pkString := pointToString(&pk.Y)
+	H, err := encodeToCurve(pkString[:], alpha)
+	if err != nil {
+		return nil, err
+	}
+
+	c := new(big.Int).SetBytes(proof.C[:])
+	s := new(big.Int).SetBytes(proof.S[:])
+	cNeg := new(big.Int).Sub(order, c)
+
+	var U, V secp256r1.G1Affine
+	var tmp, cGamma secp256r1.G1Jac
+	tmp.JointScalarMultiplicationBase(&pk.Y, s, cNeg)
+	U.FromJacobian(&tmp)
+	tmp.ScalarMultiplicationAffine(&H, s)
+	cGamma.ScalarMultiplicationAffine(&proof.Gamma, c)
+	tmp.SubAssign(&cGamma)
+	V.FromJacobian(&tmp)
+
+	expected := challenge(&pk.Y, &H, &proof.Gamma, &U, &V)
+	if subtle.ConstantTimeCompare(expected[:], proof.C[:]) != 1 {
+		return nil, ErrInvalidProof
+	}
+	return proof.hash(), nil
+}
+
+// encodeToCurve hashes alpha to a point with the try-and-increment method,
+// salted with the public key (RFC 9381, Section 5.4.1.1)
+//
+//	H = string_to_point(0x02 ∥ SHA-256(SuiteString ∥ 0x01 ∥ salt ∥ alpha ∥ ctr ∥ 0x00))
+//
+// for the first ctr in [0, 255] for which the string is a valid point.
+func encodeToCurve(salt, alpha []byte) (secp256r1.G1Affine, error) {
+	var H secp256r1.G1Affine
+	h := sha256.New()
+	var buf [sizePoint]byte
+	buf[0] = 0x02
+	for ctr := 0; ctr < 256; ctr++ {
+		h.Reset()
+		h.Write([]byte{SuiteString, encodeToCurveDomain})
+		h.Write(salt)
+		h.Write(alpha)
+		h.Write([]byte{byte(ctr), backDomain})
+		h.Sum(buf[:1])
+		if err := stringToPoint(&H, buf[:]); err == nil {
+			return H, nil
+		}
+	}
+	return H, errEncodeToCurve
+}
+
+// challenge returns the first sizeChallenge bytes of
+// SHA-256(SuiteString ∥ 0x02 ∥ P₁ ∥ … ∥ P₅ ∥ 0x00) (RFC 9381, Section 5.4.3)
+func challenge(points ...*secp256r1.G1Affine) [sizeChallenge]byte {
+	h := sha256.New()
+	h.Write([]byte{SuiteString, challengeDomain})
+	for _, p := range points {
+		pString := pointToString(p)
+		h.Write(pString[:])
+	}
+	h.Write([]byte{backDomain})
+	var c [sizeChallenge]byte
+	copy(c[:], h.Sum(nil))
+	return c
+}
+
+// nonce returns the RFC 6979 nonce of the secret scalar x and h1 = SHA-256(hString),
+// with HMAC-SHA-256 (RFC 9381, Section 5.4.2.1).
+//
+// https://datatracker.ietf.org/doc/html/rfc6979#section-3.2
+func nonce(x *big.Int, hString []byte) *big.Int {
+	h1 := sha256.Sum256(hString)
+
+	// bits2octets(h1) = int2octets(bits2int(h1) mod q)
+	qLen := order.BitLen()
+	rLen := (qLen + 7) / 8
+	xBytes := x.FillBytes(make([]byte, rLen))
+	z := bits2int(h1[:], qLen)
+	z.Mod(z, order)
+	hBytes := z.FillBytes(make([]byte, rLen))
+
+	mac := func(key []byte, data ...[]byte) []byte {
+		m := hmac.New(sha256.New, key)
+		for _, b := range data {
+			m.Write(b)
+		}
+		return m.Sum(nil)
+	}
+
+	v := make([]byte, sha256.Size)
+	k := make([]byte, sha256.Size)
+	for i := range v {
+		v[i] = 0x01
+	}
+	k = mac(k, v, []byte{0x00}, xBytes, hBytes)
+	v = mac(k, v)
+	k = mac(k, v, []byte{0x01}, xBytes, hBytes)
+	v = mac(k, v)
+
+	for {
+		var t []byte
+		for len(t) < rLen {
+			v = mac(k, v)
+			t = append(t, v...)
+		}
+		res := bits2int(t, qLen)
+		if res.Sign() > 0 && res.Cmp(order) < 0 {
+			return res
+		}
+		k = mac(k, v, []byte{0x00})
+		v = mac(k, v)
+	}
+}
+
+// bits2int interprets the left-most qLen bits of b as a big endian integer.
+func bits2int(b []byte, qLen int) *big.Int {
+	res := new(big.Int).SetBytes(b)
+	if excess := len(b)*8 - qLen; excess > 0 {
+		res.Rsh(res, uint(excess))
+	}
+	return res
+}
+
+// pointToString returns the SEC 1 compressed encoding of p: 0x02 or 0x03 (parity of y) ∥ x.
+// The point at infinity is encoded with zeros.
+func pointToString(p *secp256r1.G1Affine) (res [sizePoint]byte) {
+	if p.IsInfinity() {
+		return
+	}
+	res[0] = 0x02
+	if p.Y.Bits()[0]&1 == 1 {
+		res[0] = 0x03
+	}
+	x := p.X.Bytes()
+	copy(res[1:], x[:])
+	return
+}
+
+// stringToPoint sets p from its SEC 1 compressed encoding in buf
+func stringToPoint(p *secp256r1.G1Affine, buf []byte) error {
+	if len(buf) != sizePoint || (buf[0] != 0x02 && buf[0] != 0x03) {
+		return errInvalidPoint
+	}
+	var x, y fp.Element
+	if err := x.SetBytesCanonical(buf[1:]); err != nil {
+		return errInvalidPoint
+	}
+	// y² = x³ + a⋅x + b
+	a, b := secp256r1.CurveCoefficients()
+	var y2, ax fp.Element
+	y2.Square(&x).Mul(&y2, &x)
+	ax.Mul(&a, &x)
+	y2.Add(&y2, &ax).Add(&y2, &b)
+	if y.Sqrt(&y2) == nil {
+		return errInvalidPoint
+	}
+	if byte(y.Bits()[0]&1) != buf[0]&1 {
+		y.Neg(&y)
+	}
+	p.X, p.Y = x, y
+	return nil
+}
@@ -0,0 +1,174 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecvrf
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+const (
+	nbFuzzShort = 10
+	nbFuzz      = 100
+)
+
+func TestECVRF(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("[SECP256R1] a proof should verify and give the output of ProofToHash", prop.ForAll(
+		func(alpha []byte) bool {
+			privKey, _ := GenerateKey(rand.Reader)
+			pi, err := privKey.Prove(alpha)
+			if err != nil || len(pi) != SizeProof {
+				return false
+			}
+			beta, err := privKey.PublicKey.Verify(pi, alpha)
+			if err != nil || len(beta) != SizeOutput {
+				return false
+			}
+			beta2, err := ProofToHash(pi)
+			if err != nil {
+				return false
+			}
+			// the proof is deterministic
+			pi2, _ := privKey.Prove(alpha)
+			return bytes.Equal(beta, beta2) && bytes.Equal(pi, pi2)
+		},
+		gopter.Gen(func(genParams *gopter.GenParameters) *gopter.GenResult {
+			alpha := make([]byte, genParams.Rng.Intn(64))
+			genParams.Rng.Read(alpha)
+			return gopter.NewGenResult(alpha, gopter.NoShrinker)
+		}),
+	))
+
+	properties.Property("[SECP256R1] a proof should not verify for another input or another key", prop.ForAll(
+		func() bool {
+			privKey, _ := GenerateKey(rand.Reader)
+			other, _ := GenerateKey(rand.Reader)
+			pi, _ := privKey.Prove([]byte("alpha"))
+			if _, err := privKey.PublicKey.Verify(pi, []byte("beta")); err != ErrInvalidProof {
+				return false
+			}
+			if _, err := other.PublicKey.Verify(pi, []byte("alpha")); err != ErrInvalidProof {
+				return false
+			}
+			// tampered s
+			pi[SizeProof-1] ^= 1
+			_, err := privKey.PublicKey.Verify(pi, []byte("alpha"))
+			return err == ErrInvalidProof
+		},
+	))
+
+	properties.Property("[SECP256R1] serialization: SetBytes(Bytes()) should stay the same", prop.ForAll(
+		func() bool {
+			privKey, _ := GenerateKey(rand.Reader)
+			var end PrivateKey
+			n, err := end.SetBytes(privKey.Bytes())
+			if err != nil || n != sizePrivateKey {
+				return false
+			}
+			var pk PublicKey
+			if _, err := pk.SetBytes(privKey.PublicKey.Bytes()); err != nil {
+				return false
+			}
+			return end.scalar == privKey.scalar && end.PublicKey.Y.Equal(&privKey.PublicKey.Y) && pk.Y.Equal(&privKey.PublicKey.Y)
+		},
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestVectors(t *testing.T) {
+	// RFC 9381, Appendix B.1. ECVRF-P256-SHA256-TAI
+	vectors := []struct {
+		sk, pk, alpha, pi, beta string
+	}{
+		{
+			sk:    "c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721",
+			pk:    "0360fed4ba255a9d31c961eb74c6356d68c049b8923b61fa6ce669622e60f29fb6",
+			alpha: "73616d706c65",
+			pi:    "035b5c726e8c0e2c488a107c600578ee75cb702343c153cb1eb8dec77f4b5071b4a53f0a46f018bc2c56e58d383f2305e0975972c26feea0eb122fe7893c15af376b33edf7de17c6ea056d4d82de6bc02f",
+			beta:  "a3ad7b0ef73d8fc6655053ea22f9bede8c743f08bbed3d38821f0e16474b505e",
+		},
+		{
+			sk:    "c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721",
+			pk:    "0360fed4ba255a9d31c961eb74c6356d68c049b8923b61fa6ce669622e60f29fb6",
+			alpha: "74657374",
+			pi:    "034dac60aba508ba0c01aa9be80377ebd7562c4a52d74722e0abae7dc3080ddb56c19e067b15a8a8174905b13617804534214f935b94c2287f797e393eb0816969d864f37625b443f30f1a5a33f2b3c854",
+			beta:  "a284f94ceec2ff4b3794629da7cbafa49121972671b466cab4ce170aa365f26d",
+		},
+	}
+	for _, v := range vectors {
+		sk, _ := hex.DecodeString(v.sk)
+		alpha, _ := hex.DecodeString(v.alpha)
+		privKey, err := NewPrivateKey(sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pk := hex.EncodeToString(privKey.PublicKey.Bytes()); pk != v.pk {
+			t.Fatalf("wrong public key: %s", pk)
+		}
+		pi, err := privKey.Prove(alpha)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(pi) != v.pi {
+			t.Fatalf("wrong proof: %x", pi)
+		}
+		beta, err := privKey.PublicKey.Verify(pi, alpha)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(beta) != v.beta {
+			t.Fatalf("wrong output: %x", beta)
+		}
+	}
+}
+
+// ------------------------------------------------------------
+// benches
+
+func BenchmarkProve(b *testing.B) {
+	privKey, _ := GenerateKey(rand.Reader)
+	alpha := []byte("benchmarking ECVRF")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		privKey.Prove(alpha)
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	privKey, _ := GenerateKey(rand.Reader)
+	alpha := []byte("benchmarking ECVRF")
+	pi, _ := privKey.Prove(alpha)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		privKey.PublicKey.Verify(pi, alpha)
+	}
+}
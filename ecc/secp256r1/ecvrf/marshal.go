@@ -0,0 +1,103 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecvrf
+
+import (
+	"crypto/subtle"
+	"io"
+	"math/big"
+)
+
+// Bytes returns the binary representation of the public key,
+// Y in compressed SEC 1 form.
+func (pk *PublicKey) Bytes() []byte {
+	res := pointToString(&pk.Y)
+	return res[:]
+}
+
+// SetBytes sets pk from its compressed SEC 1 representation in buf.
+// It returns the number of bytes read from the buffer.
+func (pk *PublicKey) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizePublicKey {
+		return 0, io.ErrShortBuffer
+	}
+	if err := stringToPoint(&pk.Y, buf[:sizePublicKey]); err != nil {
+		return 0, ErrInvalidPublicKey
+	}
+	return sizePublicKey, nil
+}
+
+// Bytes returns the binary representation of privKey,
+// as byte array publicKey||scalar
+// where publicKey is as publicKey.Bytes(), and
+// scalar is in big endian, of size sizeFr.
+func (privKey *PrivateKey) Bytes() []byte {
+	var res [sizePrivateKey]byte
+	pubkBin := pointToString(&privKey.PublicKey.Y)
+	subtle.ConstantTimeCopy(1, res[:sizePublicKey], pubkBin[:])
+	subtle.ConstantTimeCopy(1, res[sizePublicKey:sizePrivateKey], privKey.scalar[:])
+	return res[:]
+}
+
+// SetBytes sets privKey from buf, where buf is interpreted
+// as publicKey||scalar
+// where publicKey is as publicKey.Bytes(), and
+// scalar is in big endian, of size sizeFr.
+// It returns the number byte read.
+func (privKey *PrivateKey) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizePrivateKey {
+		return 0, io.ErrShortBuffer
+	}
+	res, err := NewPrivateKey(buf[sizePublicKey:sizePrivateKey])
+	if err != nil {
+		return 0, err
+	}
+	pkString := pointToString(&res.PublicKey.Y)
+	if subtle.ConstantTimeCompare(pkString[:], buf[:sizePublicKey]) != 1 {
+		return 0, errPublicKeyMismatch
+	}
+	*privKey = *res
+	return sizePrivateKey, nil
+}
+
+// Bytes returns the binary representation of the proof
+// pi = Gamma ∥ c ∥ s, of size SizeProof.
+func (proof *Proof) Bytes() []byte {
+	var res [SizeProof]byte
+	gammaString := pointToString(&proof.Gamma)
+	copy(res[:sizePoint], gammaString[:])
+	copy(res[sizePoint:sizePoint+sizeChallenge], proof.C[:])
+	copy(res[sizePoint+sizeChallenge:], proof.S[:])
+	return res[:]
+}
+
+// SetBytes sets the proof from pi = Gamma ∥ c ∥ s, with s < q.
+// It returns the number of bytes read from pi.
+func (proof *Proof) SetBytes(pi []byte) (int, error) {
+	if len(pi) != SizeProof {
+		return 0, errWrongSize
+	}
+	if err := stringToPoint(&proof.Gamma, pi[:sizePoint]); err != nil {
+		return 0, err
+	}
+	if new(big.Int).SetBytes(pi[sizePoint+sizeChallenge:]).Cmp(order) >= 0 {
+		return 0, ErrInvalidProof
+	}
+	copy(proof.C[:], pi[sizePoint:sizePoint+sizeChallenge])
+	copy(proof.S[:], pi[sizePoint+sizeChallenge:])
+	return SizeProof, nil
+}
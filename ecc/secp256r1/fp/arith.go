@@ -14,7 +14,7 @@
 
 // Code generated by consensys/gnark-crypto DO NOT EDIT
 
-package goldilocks
+package fp
 
 import (
 	"math/bits"
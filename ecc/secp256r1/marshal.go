@@ -0,0 +1,161 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secp256r1
+
+import (
+	"errors"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc/secp256r1/fp"
+)
+
+// SizeOfG1AffineCompressed represents the size in bytes that a G1Affine need in binary form, compressed
+const SizeOfG1AffineCompressed = 1 + fp.Bytes
+
+// SizeOfG1AffineUncompressed represents the size in bytes that a G1Affine need in binary form, uncompressed
+const SizeOfG1AffineUncompressed = 1 + 2*fp.Bytes
+
+// SEC 1, Version 2.0, Section 2.3.3: the first byte of an encoded point tells its form
+const (
+	mInfinity       byte = 0x00
+	mCompressedEven byte = 0x02
+	mCompressedOdd  byte = 0x03
+	mUncompressed   byte = 0x04
+)
+
+var (
+	errInvalidPrefix   = errors.New("invalid point encoding prefix")
+	errInvalidInfinity = errors.New("invalid encoding of the point at infinity")
+	errNotOnCurve      = errors.New("invalid point: not on the curve")
+	errSubGroupCheck   = errors.New("invalid point: subgroup check failed")
+)
+
+// Bytes returns the SEC 1 compressed representation of p: 0x02 or 0x03 (parity of Y) ∥ X,
+// as returned by elliptic.MarshalCompressed.
+//
+// The point at infinity is encoded as SizeOfG1AffineCompressed zero bytes.
+func (p *G1Affine) Bytes() (res [SizeOfG1AffineCompressed]byte) {
+	if p.IsInfinity() {
+		return
+	}
+
+	res[0] = mCompressedEven
+	if p.Y.Bits()[0]&1 == 1 {
+		res[0] = mCompressedOdd
+	}
+	fp.BigEndian.PutElement((*[fp.Bytes]byte)(res[1:1+fp.Bytes]), p.X)
+
+	return
+}
+
+// RawBytes returns the SEC 1 uncompressed representation of p: 0x04 ∥ X ∥ Y,
+// as returned by elliptic.Marshal and (*ecdh.PublicKey).Bytes.
+//
+// The point at infinity is (0,0) in affine coordinates and is encoded as 0x04 ∥ 0 ∥ 0.
+func (p *G1Affine) RawBytes() (res [SizeOfG1AffineUncompressed]byte) {
+
+	res[0] = mUncompressed
+
+	// we store the X coordinate
+	fp.BigEndian.PutElement((*[fp.Bytes]byte)(res[1:1+fp.Bytes]), p.X)
+
+	// we store the Y coordinate
+	fp.BigEndian.PutElement((*[fp.Bytes]byte)(res[1+fp.Bytes:1+2*fp.Bytes]), p.Y)
+
+	return
+}
+
+// SetBytes sets p from binary representation in buf and returns number of consumed bytes
+//
+// bytes in buf must match either RawBytes() or Bytes() output; the form is read from the
+// first byte.
+//
+// if buf is too short io.ErrShortBuffer is returned
+//
+// this check if the resulting point is on the curve and in the correct subgroup
+func (p *G1Affine) SetBytes(buf []byte) (int, error) {
+	return p.setBytes(buf, true)
+}
+
+func (p *G1Affine) setBytes(buf []byte, subGroupCheck bool) (int, error) {
+	if len(buf) < 1 {
+		return 0, io.ErrShortBuffer
+	}
+
+	switch buf[0] {
+	case mUncompressed:
+		if len(buf) < SizeOfG1AffineUncompressed {
+			return 0, io.ErrShortBuffer
+		}
+
+		// read X and Y coordinates
+		if err := p.X.SetBytesCanonical(buf[1 : 1+fp.Bytes]); err != nil {
+			return 0, err
+		}
+		if err := p.Y.SetBytesCanonical(buf[1+fp.Bytes : 1+2*fp.Bytes]); err != nil {
+			return 0, err
+		}
+
+		// subgroup check
+		if subGroupCheck && !p.IsInSubGroup() {
+			return 0, errSubGroupCheck
+		}
+
+		return SizeOfG1AffineUncompressed, nil
+
+	case mInfinity:
+		if len(buf) < SizeOfG1AffineCompressed {
+			return 0, io.ErrShortBuffer
+		}
+		for i := 1; i < SizeOfG1AffineCompressed; i++ {
+			if buf[i] != 0 {
+				return 0, errInvalidInfinity
+			}
+		}
+		p.setInfinity()
+		return SizeOfG1AffineCompressed, nil
+
+	case mCompressedEven, mCompressedOdd:
+		if len(buf) < SizeOfG1AffineCompressed {
+			return 0, io.ErrShortBuffer
+		}
+
+		// read X coordinate
+		if err := p.X.SetBytesCanonical(buf[1 : 1+fp.Bytes]); err != nil {
+			return 0, err
+		}
+
+		// y² = x³ + ax + b
+		var YSquared, aX fp.Element
+		YSquared.Square(&p.X).Mul(&YSquared, &p.X)
+		aX.Mul(&aCurveCoeff, &p.X)
+		YSquared.Add(&YSquared, &aX).
+			Add(&YSquared, &bCurveCoeff)
+		if p.Y.Sqrt(&YSquared) == nil {
+			return 0, errNotOnCurve
+		}
+
+		// choose the root with the parity given by the prefix
+		if byte(p.Y.Bits()[0]&1) != buf[0]&1 {
+			p.Y.Neg(&p.Y)
+		}
+
+		// the curve has prime order: a point on the curve is in the subgroup
+		return SizeOfG1AffineCompressed, nil
+
+	default:
+		return 0, errInvalidPrefix
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package secp256r1 efficient elliptic curve implementation for secp256r1. This curve is defined in Standards for Efficient Cryptography (SEC) (Certicom Research, http://www.secg.org/sec2-v2.pdf) and in FIPS 186-5 as NIST P-256. It appears in TLS, WebAuthn/passkeys and the crypto/ecdsa package of the Go standard library.
+//
+// secp256r1: A j!=0 curve with
+//
+//	𝔽r: r=115792089210356248762697446949407573529996955224135760342422259061068512044369
+//	𝔽p: p=115792089210356248762697446949407573530086143415290314195533631308867097853951 (2^256 - 2^224 + 2^192 + 2^96 - 1)
+//	(E/𝔽p): Y²=X³-3X+b where b=41058363725152142129326129780047268409114441015993725554835256314039467401291
+//
+// Security: estimated 128-bit level using Pollard's \rho attack
+// (r is 256 bits)
+//
+// # Warning
+//
+// This code has not been audited and is provided as-is. In particular, there is no security guarantees such as constant time implementation or side-channel attack resistance.
+package secp256r1
+
+import (
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/secp256r1/fp"
+)
+
+// ID secp256r1 ID
+const ID = ecc.SECP256R1
+
+// aCurveCoeff is the a coefficients of the curve Y²=X³+ax+b
+var aCurveCoeff fp.Element
+var bCurveCoeff fp.Element
+
+// generator of the r-torsion group
+var g1Gen G1Jac
+
+var g1GenAff G1Affine
+
+// point at infinity
+var g1Infinity G1Jac
+
+func init() {
+	aCurveCoeff.SetInt64(-3)
+	bCurveCoeff.SetString("41058363725152142129326129780047268409114441015993725554835256314039467401291")
+
+	g1Gen.X.SetString("48439561293906451759052585252797914202762949526041747995844080717082404635286")
+	g1Gen.Y.SetString("36134250956749795798585127919587881956611106672985015071877198253568414405109")
+	g1Gen.Z.SetOne()
+
+	g1GenAff.FromJacobian(&g1Gen)
+
+	// (X,Y,Z) = (1,1,0)
+	g1Infinity.X.SetOne()
+	g1Infinity.Y.SetOne()
+
+}
+
+// Generators return the generators of the r-torsion group, resp. in ker(pi-id), ker(Tr)
+func Generators() (g1Jac G1Jac, g1Aff G1Affine) {
+	g1Aff = g1GenAff
+	g1Jac = g1Gen
+	return
+}
+
+// CurveCoefficients returns the a, b coefficients of the curve equation.
+func CurveCoefficients() (a, b fp.Element) {
+	return aCurveCoeff, bCurveCoeff
+}
@@ -0,0 +1,143 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package poseidonhash implements the Poseidon hash of Starknet, built on the
+// Hades permutation over the stark_curve base field with a state of 3 elements.
+//
+// Documentation:
+// - Starknet hash functions: https://docs.starknet.io/architecture-and-concepts/cryptography/hash-functions/#poseidon_hash
+// - Reference implementation: https://github.com/starkware-industries/poseidon
+package poseidonhash
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"strconv"
+
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+)
+
+const (
+	// StateSize is the width of the Hades permutation (rate 2, capacity 1)
+	StateSize = 3
+	// NbFullRounds is the number of rounds where the S-box is applied to the whole state
+	NbFullRounds = 8
+	// NbPartialRounds is the number of rounds where the S-box is applied to the last element only
+	NbPartialRounds = 83
+)
+
+// roundConstants are the constants added to the state at each round
+var roundConstants [NbFullRounds + NbPartialRounds][StateSize]fp.Element
+
+func init() {
+	// The constants are derived as in the reference implementation:
+	// rc[i] = SHA-256("Hades" ∥ i) mod p, for i = 0 … StateSize⋅(NbFullRounds+NbPartialRounds)-1
+	p := fp.Modulus()
+	var c big.Int
+	for i := range roundConstants {
+		for j := range roundConstants[i] {
+			digest := sha256.Sum256([]byte("Hades" + strconv.Itoa(i*StateSize+j)))
+			c.SetBytes(digest[:]).Mod(&c, p)
+			roundConstants[i][j].SetBigInt(&c)
+		}
+	}
+}
+
+// Permutation applies the Hades permutation to state, in place.
+//
+// Each round adds the round constants, applies the S-box x ↦ x³ (to the whole
+// state in the first and last NbFullRounds/2 rounds, to the last element in the
+// NbPartialRounds in between) and multiplies the state by the MDS matrix
+//
+//	⎡3  1  1⎤
+//	⎢1 -1  1⎥
+//	⎣1  1 -2⎦
+func Permutation(state *[StateSize]fp.Element) {
+	for i := range roundConstants {
+		for j := range state {
+			state[j].Add(&state[j], &roundConstants[i][j])
+		}
+		if i < NbFullRounds/2 || i >= NbFullRounds/2+NbPartialRounds {
+			for j := range state {
+				cube(&state[j])
+			}
+		} else {
+			cube(&state[StateSize-1])
+		}
+		mix(state)
+	}
+}
+
+func cube(x *fp.Element) {
+	var x2 fp.Element
+	x2.Square(x)
+	x.Mul(x, &x2)
+}
+
+// mix multiplies the state by the MDS matrix, with t = s₀+s₁+s₂:
+// (s₀, s₁, s₂) ↦ (t + 2s₀, t - 2s₁, t - 3s₂)
+func mix(state *[StateSize]fp.Element) {
+	var t, tmp fp.Element
+	t.Add(&state[0], &state[1]).Add(&t, &state[2])
+
+	tmp.Double(&state[0])
+	state[0].Add(&t, &tmp)
+
+	tmp.Double(&state[1])
+	state[1].Sub(&t, &tmp)
+
+	tmp.Double(&state[2]).Add(&tmp, &state[2])
+	state[2].Sub(&t, &tmp)
+}
+
+// Poseidon returns the Poseidon hash of (a, b), poseidon_hash in Starknet.
+func Poseidon(a, b *fp.Element) fp.Element {
+	state := [StateSize]fp.Element{*a, *b}
+	state[2].SetUint64(2)
+	Permutation(&state)
+	return state[0]
+}
+
+// PoseidonSingle returns the Poseidon hash of a, poseidon_hash_single in Starknet.
+func PoseidonSingle(a *fp.Element) fp.Element {
+	state := [StateSize]fp.Element{*a}
+	state[2].SetOne()
+	Permutation(&state)
+	return state[0]
+}
+
+// PoseidonArray returns the Poseidon hash of elems, poseidon_hash_many in Starknet.
+//
+// The input is padded with 1, then with 0 to an even length, and absorbed two
+// elements at a time in a sponge of rate 2.
+func PoseidonArray(elems ...*fp.Element) fp.Element {
+	var state [StateSize]fp.Element
+	var one fp.Element
+	one.SetOne()
+
+	for i := 0; i <= len(elems); i += 2 {
+		switch {
+		case i+1 < len(elems):
+			state[0].Add(&state[0], elems[i])
+			state[1].Add(&state[1], elems[i+1])
+		case i+1 == len(elems):
+			state[0].Add(&state[0], elems[i])
+			state[1].Add(&state[1], &one)
+		default:
+			state[0].Add(&state[0], &one)
+		}
+		Permutation(&state)
+	}
+	return state[0]
+}
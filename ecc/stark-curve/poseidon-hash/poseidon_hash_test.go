@@ -0,0 +1,102 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package poseidonhash
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+)
+
+func TestPermutation(t *testing.T) {
+	// test vector of the Cairo core library (corelib/src/test/hash_test.cairo)
+	state := [StateSize]fp.Element{}
+	state[0].SetUint64(1)
+	state[1].SetUint64(2)
+	state[2].SetUint64(3)
+	Permutation(&state)
+
+	want := [StateSize]string{
+		"0xfa8c9b6742b6176139365833d001e30e932a9bf7456d009b1b174f36d558c5",
+		"0x4f04deca4cb7f9f2bd16b1d25b817ca2d16fba2151e4252a2e2111cde08bfe6",
+		"0x58dde0a2a785b395ee2dc7b60b79e9472ab826e9bb5383a8018b59772964892",
+	}
+	for i := range want {
+		w, _ := new(fp.Element).SetString(want[i])
+		if !state[i].Equal(w) {
+			t.Errorf("state[%d] = %s, want %s", i, state[i].Text(16), w.Text(16))
+		}
+	}
+}
+
+func TestPoseidonArray(t *testing.T) {
+	// test vectors of the Cairo core library (corelib/src/test/hash_test.cairo)
+	tests := []struct {
+		input []uint64
+		want  string
+	}{
+		{
+			input: []uint64{1, 2, 3},
+			want:  "0x2f0d8840bcf3bc629598d8a6cc80cb7c0d9e52d93dab244bbf9cd0dca0ad082",
+		},
+		{
+			input: []uint64{1, 2, 3, 4},
+			want:  "0x26e3ad8b876e02bc8a4fc43dad40a8f81a6384083cabffa190bcf40d512ae1d",
+		},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("TestHash %d", i), func(t *testing.T) {
+			var data []*fp.Element
+			for _, v := range tt.input {
+				data = append(data, new(fp.Element).SetUint64(v))
+			}
+			want, _ := new(fp.Element).SetString(tt.want)
+			got := PoseidonArray(data...)
+			if !got.Equal(want) {
+				t.Errorf("PoseidonArray(%v) = %s, want %s", tt.input, got.Text(16), want.Text(16))
+			}
+		})
+	}
+}
+
+var feltBench fp.Element
+
+// go test -bench=. -run=^# -cpu=1,2,4,8,16
+func BenchmarkPoseidonArray(b *testing.B) {
+	numOfElems := []int{3, 5, 10, 15, 20, 25, 30, 35, 40}
+	createRandomFelts := func(n int) []*fp.Element {
+		var felts []*fp.Element
+		for i := 0; i < n; i++ {
+			f, err := new(fp.Element).SetRandom()
+			if err != nil {
+				b.Fatalf("error while generating random felt: %x", err)
+			}
+			felts = append(felts, f)
+		}
+		return felts
+	}
+
+	for _, i := range numOfElems {
+		b.Run(fmt.Sprintf("Number of felts: %d", i), func(b *testing.B) {
+			var f fp.Element
+			randomFelts := createRandomFelts(i)
+			for n := 0; n < b.N; n++ {
+				f = PoseidonArray(randomFelts...)
+			}
+			feltBench = f
+		})
+	}
+}
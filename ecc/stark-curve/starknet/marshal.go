@@ -0,0 +1,127 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package starknet
+
+import (
+	"crypto/subtle"
+	"io"
+	"math/big"
+
+	starkcurve "github.com/consensys/gnark-crypto/ecc/stark-curve"
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+)
+
+// Bytes returns the binary representation of the public key,
+// the stark key x in big endian.
+func (pk *PublicKey) Bytes() []byte {
+	b := pk.A.X.Bytes()
+	return b[:]
+}
+
+// SetBytes sets pk from the stark key x in big endian in buf. The y coordinate
+// is either of the two possible ones.
+// It returns the number of bytes read from the buffer.
+func (pk *PublicKey) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizePublicKey {
+		return 0, io.ErrShortBuffer
+	}
+	var x fp.Element
+	if err := x.SetBytesCanonical(buf[:sizePublicKey]); err != nil {
+		return 0, err
+	}
+	A, err := liftX(&x)
+	if err != nil {
+		return 0, err
+	}
+	pk.A = A
+	return sizePublicKey, nil
+}
+
+// liftX returns a point of the curve with x coordinate x.
+func liftX(x *fp.Element) (starkcurve.G1Affine, error) {
+	var p starkcurve.G1Affine
+	a, b := starkcurve.CurveCoefficients()
+	// y² = x³ + a⋅x + b
+	var y2, tmp fp.Element
+	y2.Square(x).Mul(&y2, x)
+	tmp.Mul(&a, x)
+	y2.Add(&y2, &tmp).Add(&y2, &b)
+	if p.Y.Sqrt(&y2) == nil {
+		return p, ErrInvalidPublicKey
+	}
+	p.X.Set(x)
+	return p, nil
+}
+
+// Bytes returns the binary representation of privKey,
+// as byte array publicKey||scalar
+// where publicKey is as publicKey.Bytes(), and
+// scalar is in big endian, of size sizeFr.
+func (privKey *PrivateKey) Bytes() []byte {
+	var res [sizePrivateKey]byte
+	pubkBin := privKey.PublicKey.Bytes()
+	subtle.ConstantTimeCopy(1, res[:sizePublicKey], pubkBin)
+	subtle.ConstantTimeCopy(1, res[sizePublicKey:sizePrivateKey], privKey.scalar[:])
+	return res[:]
+}
+
+// SetBytes sets privKey from buf, where buf is interpreted
+// as publicKey||scalar
+// where publicKey is as publicKey.Bytes(), and
+// scalar is in big endian, of size sizeFr.
+// It returns the number byte read.
+func (privKey *PrivateKey) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizePrivateKey {
+		return 0, io.ErrShortBuffer
+	}
+	res, err := NewPrivateKey(buf[sizePublicKey:sizePrivateKey])
+	if err != nil {
+		return 0, err
+	}
+	if subtle.ConstantTimeCompare(res.PublicKey.Bytes(), buf[:sizePublicKey]) != 1 {
+		return 0, errPublicKeyMismatch
+	}
+	*privKey = *res
+	return sizePrivateKey, nil
+}
+
+// Bytes returns the binary representation of sig
+// as a byte array of size sizeFp+sizeFr r||s
+func (sig *Signature) Bytes() []byte {
+	var res [sizeSignature]byte
+	subtle.ConstantTimeCopy(1, res[:sizeFp], sig.R[:])
+	subtle.ConstantTimeCopy(1, res[sizeFp:], sig.S[:])
+	return res[:]
+}
+
+// SetBytes sets sig from a buffer in binary.
+// buf is read interpreted as r||s, with 1 ≤ r < 2²⁵¹ and 1 ≤ s < order.
+// It returns the number of bytes read from buf.
+func (sig *Signature) SetBytes(buf []byte) (int, error) {
+	if len(buf) != sizeSignature {
+		return 0, errWrongSize
+	}
+	r := new(big.Int).SetBytes(buf[:sizeFp])
+	if r.Sign() == 0 || r.Cmp(elementBound) >= 0 {
+		return 0, errInvalidR
+	}
+	s := new(big.Int).SetBytes(buf[sizeFp:])
+	if s.Sign() == 0 || s.Cmp(order) >= 0 {
+		return 0, errInvalidS
+	}
+	copy(sig.R[:], buf[:sizeFp])
+	copy(sig.S[:], buf[sizeFp:])
+	return sizeSignature, nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package starknet
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"math/big"
+)
+
+// generateK returns the RFC 6979 nonce of the message hash m and the private key
+// d, with SHA-256 and the extra entropy seed, as the reference implementation
+// (python-ecdsa):
+//   - m and seed are encoded on as few bytes as possible (no extra entropy if seed
+//     is nil);
+//   - m is first multiplied by 16 if it is 249 to 252 bits long (at least 248 bits
+//     and 1 to 4 bits more than a multiple of 8, as in cairo-lang), so that it is
+//     left-aligned on 32 bytes when truncated to the 252 bits of the order.
+//
+// https://datatracker.ietf.org/doc/html/rfc6979#section-3.2
+func generateK(m, d, seed *big.Int) *big.Int {
+	if l := m.BitLen(); l >= 248 && l%8 >= 1 && l%8 <= 4 {
+		m = new(big.Int).Lsh(m, 4)
+	}
+	var extraEntropy []byte
+	if seed != nil {
+		extraEntropy = seed.Bytes()
+	}
+
+	qLen := order.BitLen()
+	rLen := (qLen + 7) / 8
+
+	// bits2octets(m) = int2octets(bits2int(m) mod order)
+	h1 := bits2int(m.Bytes(), qLen)
+	if h1.Cmp(order) >= 0 {
+		h1.Sub(h1, order)
+	}
+	xBytes := d.FillBytes(make([]byte, rLen))
+	hBytes := h1.FillBytes(make([]byte, rLen))
+
+	mac := func(key []byte, data ...[]byte) []byte {
+		h := hmac.New(sha256.New, key)
+		for _, b := range data {
+			h.Write(b)
+		}
+		return h.Sum(nil)
+	}
+
+	v := make([]byte, sha256.Size)
+	k := make([]byte, sha256.Size)
+	for i := range v {
+		v[i] = 0x01
+	}
+	k = mac(k, v, []byte{0x00}, xBytes, hBytes, extraEntropy)
+	v = mac(k, v)
+	k = mac(k, v, []byte{0x01}, xBytes, hBytes, extraEntropy)
+	v = mac(k, v)
+
+	for {
+		var t []byte
+		for len(t) < rLen {
+			v = mac(k, v)
+			t = append(t, v...)
+		}
+		res := bits2int(t, qLen)
+		if res.Sign() > 0 && res.Cmp(order) < 0 {
+			return res
+		}
+		k = mac(k, v, []byte{0x00})
+		v = mac(k, v)
+	}
+}
+
+// bits2int interprets the left-most qLen bits of b as a big endian integer.
+func bits2int(b []byte, qLen int) *big.Int {
+	res := new(big.Int).SetBytes(b)
+	if excess := len(b)*8 - qLen; excess > 0 {
+		res.Rsh(res, uint(excess))
+	}
+	return res
+}
@@ -0,0 +1,330 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package starknet implements the signature scheme of Starknet on the stark_curve,
+// the private key derivation by key grinding and the transaction hashes that are signed.
+//
+// Starknet signs field elements (felts) of at most 251 bits with ECDSA, where the
+// nonce is derived as in RFC 6979 with SHA-256, r is the x coordinate of the nonce
+// point (not reduced modulo the order) and r, s⁻¹ and the message hash are all
+// required to be smaller than 2²⁵¹. Public keys are stark keys, the x coordinate of
+// the public point.
+//
+// Documentation:
+// - Stark curve: https://docs.starknet.io/architecture-and-concepts/cryptography/stark-curve/
+// - Reference implementation: https://github.com/starkware-libs/cairo-lang/blob/master/src/starkware/crypto/signature/signature.py
+// - Transaction hashes: https://docs.starknet.io/architecture-and-concepts/network-architecture/transactions/
+package starknet
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+
+	starkcurve "github.com/consensys/gnark-crypto/ecc/stark-curve"
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fr"
+	"github.com/consensys/gnark-crypto/signature"
+)
+
+const (
+	sizeFr         = fr.Bytes
+	sizeFp         = fp.Bytes
+	sizePublicKey  = sizeFp
+	sizePrivateKey = sizePublicKey + sizeFr
+	sizeSignature  = sizeFp + sizeFr
+
+	// NbElementBits bounds the message hashes, r and s⁻¹ of the signatures: they
+	// must be smaller than 2^NbElementBits.
+	NbElementBits = 251
+)
+
+var (
+	ErrMessageTooLarge   = errors.New("message hash must be smaller than 2^251")
+	ErrInvalidPublicKey  = errors.New("invalid public key: x is not the coordinate of a point on the curve")
+	ErrZeroScalar        = errors.New("secret scalar is zero")
+	errWrongSize         = errors.New("wrong size buffer")
+	errSeedTooLarge      = errors.New("key seed must be at most 32 bytes")
+	errPublicKeyMismatch = errors.New("public key doesn't match the secret scalar")
+	errInvalidR          = errors.New("r must be in [1, 2^251)")
+	errInvalidS          = errors.New("s must be in [1, order)")
+)
+
+var (
+	order = fr.Modulus()
+	// elementBound is 2^NbElementBits
+	elementBound = new(big.Int).Lsh(big.NewInt(1), NbElementBits)
+)
+
+// PublicKey represents a Starknet public key. Only the x coordinate of A, the
+// stark key, is meaningful: signatures are verified against both ±A.
+type PublicKey struct {
+	A starkcurve.G1Affine
+}
+
+// PrivateKey represents a Starknet private key
+type PrivateKey struct {
+	PublicKey PublicKey
+	scalar    [sizeFr]byte // secret scalar, in big Endian
+}
+
+// Signature represents a Starknet signature
+type Signature struct {
+	R [sizeFp]byte // x coordinate of the nonce point, smaller than 2²⁵¹
+	S [sizeFr]byte
+}
+
+// GenerateKey generates a public and private key pair.
+func GenerateKey(rand io.Reader) (*PrivateKey, error) {
+	var b [sizeFr + 16]byte
+	var d fr.Element
+	for d.IsZero() {
+		if _, err := io.ReadFull(rand, b[:]); err != nil {
+			return nil, err
+		}
+		d.SetBytes(b[:])
+	}
+	privateKey := new(PrivateKey)
+	privateKey.setScalar(d.BigInt(new(big.Int)))
+	return privateKey, nil
+}
+
+// NewPrivateKey returns the key pair of the secret scalar d given in big endian.
+func NewPrivateKey(d []byte) (*PrivateKey, error) {
+	if len(d) != sizeFr {
+		return nil, errWrongSize
+	}
+	var s fr.Element
+	if err := s.SetBytesCanonical(d); err != nil {
+		return nil, err
+	}
+	if s.IsZero() {
+		return nil, ErrZeroScalar
+	}
+	privateKey := new(PrivateKey)
+	privateKey.setScalar(s.BigInt(new(big.Int)))
+	return privateKey, nil
+}
+
+// GrindKey derives a private key from a key seed, for instance an Ethereum signature
+// of a known message, such that it is uniformly distributed modulo the order:
+//
+//	key = SHA-256(seed ∥ i) for the first index i such that key < 2²⁵⁶ - (2²⁵⁶ mod order)
+//	d = key mod order
+//
+// where i is encoded on as few bytes as possible.
+func GrindKey(seed []byte) (*PrivateKey, error) {
+	if len(seed) > 32 {
+		return nil, errSeedTooLarge
+	}
+	bound := new(big.Int).Lsh(big.NewInt(1), 256)
+	bound.Sub(bound, new(big.Int).Mod(bound, order))
+
+	key := new(big.Int)
+	for i := int64(0); ; i++ {
+		index := big.NewInt(i).Bytes()
+		if len(index) == 0 {
+			index = []byte{0}
+		}
+		h := sha256.New()
+		h.Write(seed)
+		h.Write(index)
+		key.SetBytes(h.Sum(nil))
+		if key.Cmp(bound) < 0 {
+			break
+		}
+	}
+	key.Mod(key, order)
+	if key.Sign() == 0 {
+		return nil, ErrZeroScalar
+	}
+
+	privateKey := new(PrivateKey)
+	privateKey.setScalar(key)
+	return privateKey, nil
+}
+
+func (privKey *PrivateKey) setScalar(d *big.Int) {
+	d.FillBytes(privKey.scalar[:])
+	privKey.PublicKey.A.ScalarMultiplicationBase(d)
+}
+
+// StarkKey returns the stark key, the x coordinate of the public point.
+func (pk *PublicKey) StarkKey() fp.Element {
+	return pk.A.X
+}
+
+// Equal compares 2 public keys
+func (pk *PublicKey) Equal(x signature.PublicKey) bool {
+	xx, ok := x.(*PublicKey)
+	if !ok {
+		return false
+	}
+	bpk := pk.Bytes()
+	bxx := xx.Bytes()
+	return subtle.ConstantTimeCompare(bpk, bxx) == 1
+}
+
+// Public returns the public key associated to the private key.
+func (privKey *PrivateKey) Public() signature.PublicKey {
+	var pub PublicKey
+	pub.A.Set(&privKey.PublicKey.A)
+	return &pub
+}
+
+// Sign signs the message hash given as a felt, as the reference implementation:
+//
+//	k = RFC 6979 nonce with SHA-256 (extra entropy i at the i-th retry)
+//	r = x(k ⋅ G), with 1 ≤ r < 2²⁵¹
+//	w = k ⋅ (m + r ⋅ d)⁻¹ mod order, with 1 ≤ w < 2²⁵¹
+//	s = w⁻¹ mod order
+//
+// The message hash m must be smaller than 2²⁵¹, it is typically a transaction hash.
+func (privKey *PrivateKey) SignHash(msgHash *fp.Element) (*Signature, error) {
+	m := msgHash.BigInt(new(big.Int))
+	if m.Cmp(elementBound) >= 0 {
+		return nil, ErrMessageTooLarge
+	}
+	d := new(big.Int).SetBytes(privKey.scalar[:])
+
+	var P starkcurve.G1Affine
+	r, w, tmp := new(big.Int), new(big.Int), new(big.Int)
+	var seed *big.Int
+	for {
+		k := generateK(m, d, seed)
+		if seed == nil {
+			seed = big.NewInt(1)
+		} else {
+			seed.Add(seed, big.NewInt(1))
+		}
+
+		P.ScalarMultiplicationBase(k)
+		P.X.BigInt(r)
+		if r.Sign() == 0 || r.Cmp(elementBound) >= 0 {
+			continue
+		}
+
+		// m + r ⋅ d
+		tmp.Mul(r, d).Add(tmp, m).Mod(tmp, order)
+		if tmp.Sign() == 0 {
+			continue
+		}
+		w.ModInverse(tmp, order).Mul(w, k).Mod(w, order)
+		if w.Sign() == 0 || w.Cmp(elementBound) >= 0 {
+			continue
+		}
+		break
+	}
+
+	var sig Signature
+	r.FillBytes(sig.R[:])
+	w.ModInverse(w, order).FillBytes(sig.S[:])
+	return &sig, nil
+}
+
+// VerifyHash checks the signature of the message hash given as a felt, with the
+// range checks of the reference implementation: 0 ≤ m < 2²⁵¹, 1 ≤ r < 2²⁵¹,
+// 1 ≤ s < order and 1 ≤ w = s⁻¹ < 2²⁵¹. As the public key is a stark key, the
+// signature is accepted for either of the points ±A.
+func (pk *PublicKey) VerifyHash(sig *Signature, msgHash *fp.Element) bool {
+	m := msgHash.BigInt(new(big.Int))
+	r := new(big.Int).SetBytes(sig.R[:])
+	s := new(big.Int).SetBytes(sig.S[:])
+	if m.Cmp(elementBound) >= 0 ||
+		r.Sign() == 0 || r.Cmp(elementBound) >= 0 ||
+		s.Sign() == 0 || s.Cmp(order) >= 0 {
+		return false
+	}
+	w := new(big.Int).ModInverse(s, order)
+	if w.Cmp(elementBound) >= 0 {
+		return false
+	}
+	if !pk.A.IsOnCurve() || pk.A.IsInfinity() {
+		return false
+	}
+
+	// x((m ⋅ w) ⋅ G ± (r ⋅ w) ⋅ A) == r
+	u1 := new(big.Int).Mul(m, w)
+	u1.Mod(u1, order)
+	u2 := new(big.Int).Mul(r, w)
+	u2.Mod(u2, order)
+
+	var A starkcurve.G1Affine
+	A.Set(&pk.A)
+	var Q starkcurve.G1Jac
+	var qAff starkcurve.G1Affine
+	x := new(big.Int)
+	for i := 0; i < 2; i++ {
+		Q.JointScalarMultiplicationBase(&A, u1, u2)
+		qAff.FromJacobian(&Q)
+		if !qAff.IsInfinity() && qAff.X.BigInt(x).Cmp(r) == 0 {
+			return true
+		}
+		A.Neg(&A)
+	}
+	return false
+}
+
+// Sign signs message as SignHash, as a signature.Signer. If hFunc is nil, message
+// is the big endian encoding of the message hash, else the message hash is the
+// left-most 251 bits of hFunc(message).
+func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error) {
+	msgHash, err := messageHash(message, hFunc)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := privKey.SignHash(msgHash)
+	if err != nil {
+		return nil, err
+	}
+	return sig.Bytes(), nil
+}
+
+// Verify checks the signature sigBin of message as VerifyHash, as a
+// signature.PublicKey. message and hFunc are as in Sign.
+func (pk *PublicKey) Verify(sigBin, message []byte, hFunc hash.Hash) (bool, error) {
+	var sig Signature
+	if _, err := sig.SetBytes(sigBin); err != nil {
+		return false, err
+	}
+	msgHash, err := messageHash(message, hFunc)
+	if err != nil {
+		return false, err
+	}
+	return pk.VerifyHash(&sig, msgHash), nil
+}
+
+func messageHash(message []byte, hFunc hash.Hash) (*fp.Element, error) {
+	m := new(big.Int)
+	if hFunc == nil {
+		m.SetBytes(message)
+		if m.Cmp(elementBound) >= 0 {
+			return nil, ErrMessageTooLarge
+		}
+	} else {
+		hFunc.Reset()
+		if _, err := hFunc.Write(message); err != nil {
+			return nil, err
+		}
+		digest := hFunc.Sum(nil)
+		m.SetBytes(digest)
+		if excess := len(digest)*8 - NbElementBits; excess > 0 {
+			m.Rsh(m, uint(excess))
+		}
+	}
+	return new(fp.Element).SetBigInt(m), nil
+}
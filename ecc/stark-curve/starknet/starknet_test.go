@@ -0,0 +1,227 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package starknet
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+const (
+	nbFuzzShort = 10
+	nbFuzz      = 100
+)
+
+func TestStarkKey(t *testing.T) {
+	// test vector of starknet.js
+	privKey := mustPrivateKey(t, "019800ea6a9a73f94aee6a3d2edf018fc770443e90c7ba121e8303ec6b349279")
+	want, _ := new(fp.Element).SetString("0x33f45f07e1bd1a51b45fc24ec8c8c9908db9e42191be9e169bfcac0c0d99745")
+	if starkKey := privKey.PublicKey.StarkKey(); !starkKey.Equal(want) {
+		t.Fatalf("wrong stark key: %s", starkKey.Text(16))
+	}
+}
+
+func TestSignHash(t *testing.T) {
+	// test vector of starknet.js, signature of a transaction hash
+	privKey := mustPrivateKey(t, "019800ea6a9a73f94aee6a3d2edf018fc770443e90c7ba121e8303ec6b349279")
+	msgHash, _ := new(fp.Element).SetString("0x6d1706bd3d1ba7c517be2a2a335996f63d4738e2f182144d078a1dd9997062e")
+	wantR, _ := new(big.Int).SetString("1427981024487605678086498726488552139932400435436186597196374630267616399345", 10)
+	wantS, _ := new(big.Int).SetString("1853664302719670721837677288395394946745467311923401353018029119631574115563", 10)
+
+	sig, err := privKey.SignHash(msgHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := new(big.Int).SetBytes(sig.R[:]); r.Cmp(wantR) != 0 {
+		t.Fatalf("wrong r: %s", r)
+	}
+	if s := new(big.Int).SetBytes(sig.S[:]); s.Cmp(wantS) != 0 {
+		t.Fatalf("wrong s: %s", s)
+	}
+	if !privKey.PublicKey.VerifyHash(sig, msgHash) {
+		t.Fatal("signature should verify")
+	}
+
+	// the stark key alone is enough
+	var pk PublicKey
+	if _, err := pk.SetBytes(privKey.PublicKey.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if !pk.VerifyHash(sig, msgHash) {
+		t.Fatal("signature should verify against the stark key")
+	}
+
+	// the message hash must be smaller than 2²⁵¹
+	var tooLarge fp.Element
+	tooLarge.SetBigInt(elementBound)
+	if _, err := privKey.SignHash(&tooLarge); err != ErrMessageTooLarge {
+		t.Fatal("expected error for a message hash ≥ 2²⁵¹")
+	}
+}
+
+func TestGrindKey(t *testing.T) {
+	// test vector of starknet.js
+	seed, _ := hex.DecodeString("86F3E7293141F20A8BAFF320E8EE4ACCB9D4A4BF2B4D295E8CEE784DB46E0519")
+	privKey, err := GrindKey(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "05c8c8683596c732541a59e03007b2d30dbbbb873556fe65b5fb63c16688f941"
+	if got := hex.EncodeToString(privKey.scalar[:]); got != want {
+		t.Fatalf("wrong private key: %s", got)
+	}
+}
+
+func TestStarknet(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("[STARK-CURVE] test the signing and verification", prop.ForAll(
+		func() bool {
+			privKey, _ := GenerateKey(rand.Reader)
+			publicKey := privKey.PublicKey
+
+			msg := []byte("testing Starknet signatures")
+			sig, err := privKey.Sign(msg, sha256.New())
+			if err != nil {
+				return false
+			}
+			flag, _ := publicKey.Verify(sig, msg, sha256.New())
+			wrong, _ := publicKey.Verify(sig, []byte("wrong message"), sha256.New())
+
+			return flag && !wrong
+		},
+	))
+
+	properties.Property("[STARK-CURVE] test the signing and verification of a felt", prop.ForAll(
+		func() bool {
+			privKey, _ := GenerateKey(rand.Reader)
+			publicKey := privKey.PublicKey
+
+			var m fp.Element
+			m.SetRandom()
+			mBytes := m.Bytes()
+			mBytes[0] &= 0x07 // m < 2²⁵¹
+			m.SetBytes(mBytes[:])
+
+			sig, err := privKey.SignHash(&m)
+			if err != nil {
+				return false
+			}
+			// deterministic
+			sig2, _ := privKey.SignHash(&m)
+			if *sig != *sig2 {
+				return false
+			}
+			// the signature of m can be checked with the interface, m being pre-hashed
+			flag, _ := publicKey.Verify(sig.Bytes(), mBytes[:], nil)
+
+			return flag && publicKey.VerifyHash(sig, &m)
+		},
+	))
+
+	properties.Property("[STARK-CURVE] serialization: SetBytes(Bytes()) should stay the same", prop.ForAll(
+		func() bool {
+			privKey, _ := GenerateKey(rand.Reader)
+
+			var end PrivateKey
+			n, err := end.SetBytes(privKey.Bytes())
+			if err != nil || n != sizePrivateKey {
+				return false
+			}
+			if !end.PublicKey.Equal(&privKey.PublicKey) || end.scalar != privKey.scalar {
+				return false
+			}
+
+			sig, _ := privKey.Sign([]byte("msg"), sha256.New())
+			var s Signature
+			n, err = s.SetBytes(sig)
+			return err == nil && n == sizeSignature && string(s.Bytes()) == string(sig)
+		},
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestSignatureRanges(t *testing.T) {
+	var buf [sizeSignature]byte
+	var sig Signature
+
+	// r = 0
+	big.NewInt(1).FillBytes(buf[sizeFp:])
+	if _, err := sig.SetBytes(buf[:]); err != errInvalidR {
+		t.Fatal("expected error for r = 0")
+	}
+	// r = 2²⁵¹
+	elementBound.FillBytes(buf[:sizeFp])
+	if _, err := sig.SetBytes(buf[:]); err != errInvalidR {
+		t.Fatal("expected error for r ≥ 2²⁵¹")
+	}
+	// s = order
+	big.NewInt(1).FillBytes(buf[:sizeFp])
+	order.FillBytes(buf[sizeFp:])
+	if _, err := sig.SetBytes(buf[:]); err != errInvalidS {
+		t.Fatal("expected error for s ≥ order")
+	}
+}
+
+func mustPrivateKey(t *testing.T, d string) *PrivateKey {
+	b, err := hex.DecodeString(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privKey, err := NewPrivateKey(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return privKey
+}
+
+// ------------------------------------------------------------
+// benches
+
+func BenchmarkSignHash(b *testing.B) {
+	privKey, _ := GenerateKey(rand.Reader)
+	var m fp.Element
+	m.SetUint64(42)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		privKey.SignHash(&m)
+	}
+}
+
+func BenchmarkVerifyHash(b *testing.B) {
+	privKey, _ := GenerateKey(rand.Reader)
+	var m fp.Element
+	m.SetUint64(42)
+	sig, _ := privKey.SignHash(&m)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		privKey.PublicKey.VerifyHash(sig, &m)
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package starknet
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+	pedersenhash "github.com/consensys/gnark-crypto/ecc/stark-curve/pedersen-hash"
+	poseidonhash "github.com/consensys/gnark-crypto/ecc/stark-curve/poseidon-hash"
+)
+
+var (
+	errShortString = errors.New("short string must be at most 31 ASCII characters")
+	errPrice       = errors.New("max price per unit must be smaller than 2^128")
+)
+
+// transaction hash prefixes and chain ids, as short strings
+var (
+	PrefixInvoke        = mustShortString("invoke")
+	PrefixDeclare       = mustShortString("declare")
+	PrefixDeployAccount = mustShortString("deploy_account")
+	PrefixL1Handler     = mustShortString("l1_handler")
+
+	ChainIDMainnet = mustShortString("SN_MAIN")
+	ChainIDSepolia = mustShortString("SN_SEPOLIA")
+)
+
+// ShortString returns the felt encoding of a Cairo short string, s being read as a
+// big endian integer. s must be at most 31 ASCII characters long.
+func ShortString(s string) (fp.Element, error) {
+	var res fp.Element
+	if len(s) > 31 {
+		return res, errShortString
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return res, errShortString
+		}
+	}
+	res.SetBigInt(new(big.Int).SetBytes([]byte(s)))
+	return res, nil
+}
+
+func mustShortString(s string) fp.Element {
+	res, err := ShortString(s)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// TransactionHash returns the hash of a transaction of version 0, 1 or 2, with the
+// Pedersen hash (calculate_transaction_hash_common in the reference implementation):
+//
+//	h(prefix, version, contractAddress, entryPointSelector, h(calldata), maxFee, chainID, additionalData...)
+//
+// For instance an invoke transaction of version 1 is hashed with prefix PrefixInvoke,
+// a zero entry point selector and the nonce as additional data.
+func TransactionHash(prefix, version, contractAddress, entryPointSelector *fp.Element, calldata []*fp.Element, maxFee, chainID *fp.Element, additionalData ...*fp.Element) fp.Element {
+	calldataHash := pedersenhash.PedersenArray(calldata...)
+	elems := []*fp.Element{prefix, version, contractAddress, entryPointSelector, &calldataHash, maxFee, chainID}
+	return pedersenhash.PedersenArray(append(elems, additionalData...)...)
+}
+
+// DataAvailabilityMode is the data availability mode of the nonce or the fee of a
+// transaction of version 3
+type DataAvailabilityMode uint32
+
+const (
+	DataAvailabilityModeL1 DataAvailabilityMode = iota
+	DataAvailabilityModeL2
+)
+
+// ResourceBounds bounds the amount and the price of a resource paid by a transaction
+// of version 3
+type ResourceBounds struct {
+	Resource        string   // short string name of the resource, e.g. "L1_GAS", "L2_GAS" or "L1_DATA"
+	MaxAmount       uint64   // maximum amount of the resource
+	MaxPricePerUnit *big.Int // maximum price per unit, smaller than 2¹²⁸
+}
+
+// TransactionV3 holds the fields of the transactions of version 3 which are hashed
+// by all of them. Version is 3, or 2¹²⁸ + 3 for query transactions.
+type TransactionV3 struct {
+	Version                   fp.Element
+	SenderAddress             fp.Element
+	Tip                       uint64
+	ResourceBounds            []ResourceBounds
+	PaymasterData             []fp.Element
+	ChainID                   fp.Element
+	Nonce                     fp.Element
+	NonceDataAvailabilityMode DataAvailabilityMode
+	FeeDataAvailabilityMode   DataAvailabilityMode
+}
+
+// InvokeTransactionHashV3 returns the Poseidon hash of an invoke transaction of version 3
+//
+//	h(commonFields..., h(accountDeploymentData), h(calldata))
+func InvokeTransactionHashV3(tx *TransactionV3, accountDeploymentData, calldata []fp.Element) (fp.Element, error) {
+	elems, err := tx.commonFields(&PrefixInvoke)
+	if err != nil {
+		return fp.Element{}, err
+	}
+	deploymentDataHash := poseidonArray(accountDeploymentData)
+	calldataHash := poseidonArray(calldata)
+	elems = append(elems, &deploymentDataHash, &calldataHash)
+	return poseidonhash.PoseidonArray(elems...), nil
+}
+
+// DeclareTransactionHashV3 returns the Poseidon hash of a declare transaction of version 3
+//
+//	h(commonFields..., h(accountDeploymentData), classHash, compiledClassHash)
+func DeclareTransactionHashV3(tx *TransactionV3, accountDeploymentData []fp.Element, classHash, compiledClassHash *fp.Element) (fp.Element, error) {
+	elems, err := tx.commonFields(&PrefixDeclare)
+	if err != nil {
+		return fp.Element{}, err
+	}
+	deploymentDataHash := poseidonArray(accountDeploymentData)
+	elems = append(elems, &deploymentDataHash, classHash, compiledClassHash)
+	return poseidonhash.PoseidonArray(elems...), nil
+}
+
+// DeployAccountTransactionHashV3 returns the Poseidon hash of a deploy account
+// transaction of version 3, where tx.SenderAddress is the address of the deployed
+// contract
+//
+//	h(commonFields..., h(constructorCalldata), classHash, contractAddressSalt)
+func DeployAccountTransactionHashV3(tx *TransactionV3, constructorCalldata []fp.Element, classHash, contractAddressSalt *fp.Element) (fp.Element, error) {
+	elems, err := tx.commonFields(&PrefixDeployAccount)
+	if err != nil {
+		return fp.Element{}, err
+	}
+	calldataHash := poseidonArray(constructorCalldata)
+	elems = append(elems, &calldataHash, classHash, contractAddressSalt)
+	return poseidonhash.PoseidonArray(elems...), nil
+}
+
+// commonFields returns
+//
+//	prefix, version, senderAddress, h(tip, resourceBounds...), h(paymasterData), chainID, nonce, dataAvailabilityModes
+//
+// where each resource bound is encoded as resource ∥ maxAmount (64 bits) ∥ maxPricePerUnit (128 bits)
+// and dataAvailabilityModes as nonceMode ∥ feeMode (32 bits).
+func (tx *TransactionV3) commonFields(prefix *fp.Element) ([]*fp.Element, error) {
+	feeFields := make([]fp.Element, 1+len(tx.ResourceBounds))
+	feeFields[0].SetUint64(tx.Tip)
+	bound := new(big.Int)
+	for i, rb := range tx.ResourceBounds {
+		name, err := ShortString(rb.Resource)
+		if err != nil {
+			return nil, err
+		}
+		if rb.MaxPricePerUnit.Sign() < 0 || rb.MaxPricePerUnit.BitLen() > 128 {
+			return nil, errPrice
+		}
+		name.BigInt(bound)
+		bound.Lsh(bound, 64).Add(bound, new(big.Int).SetUint64(rb.MaxAmount))
+		bound.Lsh(bound, 128).Add(bound, rb.MaxPricePerUnit)
+		feeFields[i+1].SetBigInt(bound)
+	}
+	feeFieldsHash := poseidonArray(feeFields)
+	paymasterDataHash := poseidonArray(tx.PaymasterData)
+
+	var daModes fp.Element
+	daModes.SetUint64(uint64(tx.NonceDataAvailabilityMode)<<32 | uint64(tx.FeeDataAvailabilityMode))
+
+	return []*fp.Element{
+		prefix,
+		&tx.Version,
+		&tx.SenderAddress,
+		&feeFieldsHash,
+		&paymasterDataHash,
+		&tx.ChainID,
+		&tx.Nonce,
+		&daModes,
+	}, nil
+}
+
+func poseidonArray(elems []fp.Element) fp.Element {
+	ptrs := make([]*fp.Element, len(elems))
+	for i := range elems {
+		ptrs[i] = &elems[i]
+	}
+	return poseidonhash.PoseidonArray(ptrs...)
+}
@@ -0,0 +1,246 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package starknet
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+)
+
+func TestShortString(t *testing.T) {
+	tests := []struct {
+		s, want string
+	}{
+		{"SN_MAIN", "0x534e5f4d41494e"},
+		{"deploy", "0x6465706c6f79"},
+		{"", "0x0"},
+	}
+	for _, tt := range tests {
+		got, err := ShortString(tt.s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, _ := new(fp.Element).SetString(tt.want)
+		if !got.Equal(want) {
+			t.Errorf("ShortString(%q) = %s, want %s", tt.s, got.Text(16), want.Text(16))
+		}
+	}
+	if _, err := ShortString("a string of more than 31 characters"); err != errShortString {
+		t.Fatal("expected error for a string of more than 31 characters")
+	}
+	if _, err := ShortString("é"); err != errShortString {
+		t.Fatal("expected error for a non ASCII string")
+	}
+}
+
+// The transactions below were accepted on Starknet: their hashes are the ones
+// of the network, and their fields are the ones returned by the feeder gateway,
+// as recorded in the test data of the Juno node (clients/feeder/testdata).
+
+// feltsFromHex returns the felts of the hexadecimal strings s
+func feltsFromHex(t *testing.T, s ...string) []fp.Element {
+	res := make([]fp.Element, len(s))
+	for i := range s {
+		if _, err := res[i].SetString(s[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return res
+}
+
+// hexBigInt returns the integer of the hexadecimal string s
+func hexBigInt(t *testing.T, s string) *big.Int {
+	res, ok := new(big.Int).SetString(s, 0)
+	if !ok {
+		t.Fatalf("invalid integer %s", s)
+	}
+	return res
+}
+
+func TestInvokeTransactionHashV1(t *testing.T) {
+	// mainnet, block 16730, transaction 0x2897e3cec3e24e4d341df26b8cf1ab84ea1c01a051021836b36c6639145b497
+	fields := feltsFromHex(t,
+		"0x1", // version
+		"0x1fc039de7d864580b57a575e8e6b7114f4d2a954d7d29f876b2eb3dd09394a0", // sender address
+		"0x17f0de82f4be6", // max fee
+		"0x42",            // nonce
+	)
+	calldata := feltsFromHex(t,
+		"0x1",
+		"0x727a63f78ee3f1bd18f78009067411ab369c31dece1ae22e16f567906409905",
+		"0x22de356837ac200bca613c78bd1fcc962a97770c06625f0c8b3edeb6ae4aa59",
+		"0x0",
+		"0xb",
+		"0xb",
+		"0xa",
+		"0x6db793d93ce48bc75a5ab02e6a82aad67f01ce52b7b903090725dbc4000eaa2",
+		"0x6141eac4031dfb422080ed567fe008fb337b9be2561f479a377aa1de1d1b676",
+		"0x27eb1a21fa7593dd12e988c9dd32917a0dea7d77db7e89a809464c09cf951c0",
+		"0x400a29400a34d8f69425e1f4335e6a6c24ce1111db3954e4befe4f90ca18eb7",
+		"0x599e56821170a12cdcf88fb8714057ce364a8728f738853da61d5b3af08a390",
+		"0x46ad66f467df625f3b2dd9d3272e61713e8f74b68adac6718f7497d742cfb17",
+		"0x4f348b585e6c1919d524a4bfe6f97230ecb61736fe57534ec42b628f7020849",
+		"0x19ae40a095ffe79b0c9fc03df2de0d2ab20f59a2692ed98a8c1062dbf691572",
+		"0xe120336994adef6c6e47694f87278686511d4622997d4a6f216bd6e9fa9acc",
+		"0x56e6637a4958d062db8c8198e315772819f64d915e5c7a8d58a99fa90ff0742",
+	)
+	refs := make([]*fp.Element, len(calldata))
+	for i := range calldata {
+		refs[i] = &calldata[i]
+	}
+
+	var zero fp.Element
+	got := TransactionHash(&PrefixInvoke, &fields[0], &fields[1], &zero, refs, &fields[2], &ChainIDMainnet, &fields[3])
+	want, _ := new(fp.Element).SetString("0x2897e3cec3e24e4d341df26b8cf1ab84ea1c01a051021836b36c6639145b497")
+	if !got.Equal(want) {
+		t.Fatalf("wrong transaction hash: %s", got.Text(16))
+	}
+}
+
+// sepoliaTransactionV3 returns the fields common to the transactions of version 3
+// below, accepted on Sepolia with the given sender address, nonce and resource bounds
+// of L1 gas, L2 gas and L1 data gas.
+func sepoliaTransactionV3(t *testing.T, sender, nonce string, bounds [3][2]string) *TransactionV3 {
+	fields := feltsFromHex(t, sender, nonce)
+	tx := &TransactionV3{
+		SenderAddress: fields[0],
+		ChainID:       ChainIDSepolia,
+		Nonce:         fields[1],
+	}
+	tx.Version.SetUint64(3)
+	for i, resource := range []string{"L1_GAS", "L2_GAS", "L1_DATA"} {
+		tx.ResourceBounds = append(tx.ResourceBounds, ResourceBounds{
+			Resource:        resource,
+			MaxAmount:       hexBigInt(t, bounds[i][0]).Uint64(),
+			MaxPricePerUnit: hexBigInt(t, bounds[i][1]),
+		})
+	}
+	return tx
+}
+
+func TestInvokeTransactionHashV3(t *testing.T) {
+	// sepolia, block 567941, transaction 0x76b52e17bc09064bd986ead34263e6305ef3cecfb3ae9e19b86bf4f1a1a20ea
+	tx := sepoliaTransactionV3(t,
+		"0x745d525a3582e91299d8d7c71730ffc4b1f191f5b219d800334bc0edad0983b", "0x9803",
+		[3][2]string{{"0x186a0", "0x2d79883d20000"}, {"0x5f5e100", "0xba43b7400"}, {"0x186a0", "0x2d79883d20000"}},
+	)
+	calldata := feltsFromHex(t,
+		"0x1",
+		"0x4138fd51f90d171df37e9d4419c8cdb67d525840c58f8a5c347be93a1c5277d",
+		"0x2468d193cd15b621b24c2a602b8dbcfa5eaa14f88416c40c09d7fd12592cb4b",
+		"0x0",
+	)
+
+	got, err := InvokeTransactionHashV3(tx, nil, calldata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := new(fp.Element).SetString("0x76b52e17bc09064bd986ead34263e6305ef3cecfb3ae9e19b86bf4f1a1a20ea")
+	if !got.Equal(want) {
+		t.Fatalf("wrong transaction hash: %s", got.Text(16))
+	}
+
+	// the data availability modes are packed in a single felt
+	tx.NonceDataAvailabilityMode = DataAvailabilityModeL2
+	if other, _ := InvokeTransactionHashV3(tx, nil, calldata); other.Equal(want) {
+		t.Fatal("the nonce data availability mode should be hashed")
+	}
+	tx.NonceDataAvailabilityMode = DataAvailabilityModeL1
+
+	// prices are 128 bits
+	tx.ResourceBounds[0].MaxPricePerUnit = new(big.Int).Lsh(big.NewInt(1), 128)
+	if _, err := InvokeTransactionHashV3(tx, nil, calldata); err != errPrice {
+		t.Fatal("expected error for a price ≥ 2¹²⁸")
+	}
+}
+
+func TestDeclareTransactionHashV3(t *testing.T) {
+	// sepolia, block 570000, transaction 0x30c852c522274765e1d681bc8a84ce7c41118370ef2ba7d18a427ed29f5b155
+	tx := sepoliaTransactionV3(t,
+		"0x36d67ab362562a97f9fba8a1051cf8e37ff1a1449530fb9f1f0e32ac2da7d06", "0x2b",
+		[3][2]string{{"0x0", "0x10968159929e"}, {"0x1ff3ec0", "0x197aa1ce3"}, {"0x120", "0x99f"}},
+	)
+	classes := feltsFromHex(t,
+		"0x224518978adb773cfd4862a894e9d333192fbd24bc83841dc7d4167c09b89c5", // class hash
+		"0x6ff9f7df06da94198ee535f41b214dce0b8bafbdb45e6c6b09d4b3b693b1f17", // compiled class hash
+	)
+
+	got, err := DeclareTransactionHashV3(tx, nil, &classes[0], &classes[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := new(fp.Element).SetString("0x30c852c522274765e1d681bc8a84ce7c41118370ef2ba7d18a427ed29f5b155")
+	if !got.Equal(want) {
+		t.Fatalf("wrong transaction hash: %s", got.Text(16))
+	}
+}
+
+// deployAccountTransactionV3 returns the transaction 0x32413f8cee053089d6d7026a72e4108262ca3cfe868dd9159bc1dd160aec975
+// accepted on sepolia in block 571531, which deploys an account of public key
+// publicKey with a salt equal to the public key, and its hash and signature.
+func deployAccountTransactionV3(t *testing.T) (txHash fp.Element, publicKey string, signature [2]string) {
+	publicKey = "0x2e94ba2293dfa45f86dfcf9952d7a33dc50ce2b00b932999fbe0844772604f3"
+	tx := sepoliaTransactionV3(t,
+		"0x48419d3cc27f158917b45255d5376c06a9524484e19a1102279cbdc715c5522", "0x0",
+		[3][2]string{{"0x0", "0x1597b3274d88"}, {"0xe6fa0", "0x1920d1317"}, {"0x210", "0x97c"}},
+	)
+	fields := feltsFromHex(t,
+		"0x61dac032f228abef9c6626f995015233097ae253a7f72d68552db02f2971b8f", // class hash
+		publicKey, // salt and constructor calldata
+	)
+
+	txHash, err := DeployAccountTransactionHashV3(tx, fields[1:], &fields[0], &fields[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature = [2]string{
+		"0x3ef7f047c95592a04d4d754888dd8f125480a48dee23ee86c115d5da2a86573",
+		"0x65e8661ab1526b4f8ea50b76fea1a0e82543de1eb3885e415790d7e1b5a93c7",
+	}
+	return txHash, publicKey, signature
+}
+
+func TestDeployAccountTransactionHashV3(t *testing.T) {
+	got, _, _ := deployAccountTransactionV3(t)
+	want, _ := new(fp.Element).SetString("0x32413f8cee053089d6d7026a72e4108262ca3cfe868dd9159bc1dd160aec975")
+	if !got.Equal(want) {
+		t.Fatalf("wrong transaction hash: %s", got.Text(16))
+	}
+}
+
+func TestVerifyTransactionSignature(t *testing.T) {
+	// the signature of a deploy account transaction is checked against the public key
+	// passed to the constructor of the account
+	txHash, publicKey, signature := deployAccountTransactionV3(t)
+	var pk PublicKey
+	pkBytes := hexBigInt(t, publicKey).FillBytes(make([]byte, sizePublicKey))
+	if _, err := pk.SetBytes(pkBytes); err != nil {
+		t.Fatal(err)
+	}
+	var sig Signature
+	hexBigInt(t, signature[0]).FillBytes(sig.R[:])
+	hexBigInt(t, signature[1]).FillBytes(sig.S[:])
+
+	if !pk.VerifyHash(&sig, &txHash) {
+		t.Fatal("signature of the transaction should verify")
+	}
+	var otherHash fp.Element
+	otherHash.SetOne().Add(&otherHash, &txHash)
+	if pk.VerifyHash(&sig, &otherHash) {
+		t.Fatal("signature of another transaction hash should not verify")
+	}
+}
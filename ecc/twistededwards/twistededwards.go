@@ -17,3 +17,32 @@ const (
 	BW6_756
 	BW6_633
 )
+
+// String returns the name of the curve the twisted Edwards curve is defined
+// on, in lower case, followed by the name of the companion curve if it has one.
+func (id ID) String() string {
+	switch id {
+	case BN254:
+		return "bn254"
+	case BLS12_377:
+		return "bls12_377"
+	case BLS12_378:
+		return "bls12_378"
+	case BLS12_381:
+		return "bls12_381"
+	case BLS12_381_BANDERSNATCH:
+		return "bls12_381_bandersnatch"
+	case BLS24_315:
+		return "bls24_315"
+	case BLS24_317:
+		return "bls24_317"
+	case BW6_761:
+		return "bw6_761"
+	case BW6_756:
+		return "bw6_756"
+	case BW6_633:
+		return "bw6_633"
+	default:
+		return "unknown"
+	}
+}
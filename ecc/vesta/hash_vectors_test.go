@@ -0,0 +1,62 @@
+package vesta
+
+// The QUUX test suites of RFC 9380 with expand_message_xmd and BLAKE2b-512, as
+// used by the Zcash GroupHash, computed with an independent implementation of
+// the pasta_curves construction.
+func init() {
+	encodeToG1Vector = encodeTestVector{
+		dst: []byte("QUUX-V01-CS02-with-vesta_XMD:BLAKE2b_SSWU_NU_"),
+		cases: []encodeTestCase{
+			{
+				msg: "", P: point{"0x2390acc09ac4dccdd15fa87deabc3790e91cda7617ec6c04ee86bced24244659", "0x1669519ba9e06cceff3383e4ece56178682d9c6e8c91ef9415810e4a8bc16a9b"},
+				Q: point{"0x2390acc09ac4dccdd15fa87deabc3790e91cda7617ec6c04ee86bced24244659", "0x1669519ba9e06cceff3383e4ece56178682d9c6e8c91ef9415810e4a8bc16a9b"},
+				u: "0x1437d712a6ad65df1a9f43351ec1b385e14792376d3d8d69a9e8a7d428d4867b",
+			}, {
+				msg: "abc", P: point{"0x30d9bfc118f8bcd65bc4aa17dfe567f8521b817d78c57c1594e882955ecec4b9", "0x1bcc800eba3d4a09ec16cc6ceddc3faf53d7b77c3ac79beef01d95c8b6f20e5f"},
+				Q: point{"0x30d9bfc118f8bcd65bc4aa17dfe567f8521b817d78c57c1594e882955ecec4b9", "0x1bcc800eba3d4a09ec16cc6ceddc3faf53d7b77c3ac79beef01d95c8b6f20e5f"},
+				u: "0x18fdaf6796ce59602588229cd1eea63e0716eefca4456dd8240e47406c4bd0b8",
+			}, {
+				msg: "abcdef0123456789", P: point{"0x1f2f0467a44764ad74226d0f98b8ce593a440af8ba1c3f673ea79ceaa09c966c", "0x342cd2841847391ea3d9d5d923d1a2d98f8bd5c900117dce118661dd9b0e8ff2"},
+				Q: point{"0x1f2f0467a44764ad74226d0f98b8ce593a440af8ba1c3f673ea79ceaa09c966c", "0x342cd2841847391ea3d9d5d923d1a2d98f8bd5c900117dce118661dd9b0e8ff2"},
+				u: "0x3885c7e3f3b5aa4bcada42789a1b50249e0a703874f457cca20d887ba3b7fb1",
+			}, {
+				msg: "q128_qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq", P: point{"0x7dfaa14986aeebb899ad2271630a6b09f5b5fe90a2dd0f31b3fafbf6e7858fb", "0x3169ed9e7d22538e3fd080f9ea7ecec386e39fc57737f6ec019c615bf0721d6f"},
+				Q: point{"0x7dfaa14986aeebb899ad2271630a6b09f5b5fe90a2dd0f31b3fafbf6e7858fb", "0x3169ed9e7d22538e3fd080f9ea7ecec386e39fc57737f6ec019c615bf0721d6f"},
+				u: "0xb8cc2b7dcd6a95298e8d85a1bfe2d4884c8958ceeee22bbd6bfd2f7becd9088",
+			}, {
+				msg: "a512_aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", P: point{"0x1274d4fa62ab453e3a8c435f8131514f1a7b213812bfcac97652015bd0b2eb2b", "0x35cb62d22816d7937efc4b8abd6183b319461b37a32fc498b7a4871b00a3a1a4"},
+				Q: point{"0x1274d4fa62ab453e3a8c435f8131514f1a7b213812bfcac97652015bd0b2eb2b", "0x35cb62d22816d7937efc4b8abd6183b319461b37a32fc498b7a4871b00a3a1a4"},
+				u: "0x30153f7bb00f06339af2a122a5181716a3f7fcd3730131624480b9c09b1620bc",
+			},
+		}}
+	hashToG1Vector = hashTestVector{
+		dst: []byte("QUUX-V01-CS02-with-vesta_XMD:BLAKE2b_SSWU_RO_"),
+		cases: []hashTestCase{
+			{
+				msg: "", P: point{"0x2ddaf8acf9ab04b7a52934722414546491dea1c658f90cc4852c3596cd4a618a", "0x3371e2b5f70df011d7168c74ae7ccff5eaab2d6cc4375034fa9295a66395db8b"},
+				Q0: point{"0x2fcd5a47bf5e21ba98b4786bd5e18c34a3aa4d3ab7e24e697ebb7dee1aad7717", "0x21a542bbf8cb6fb4150e3db6601b771c61b6576d951229218d7e04a78fc01042"},
+				Q1: point{"0x1f1d84baaf8447ff74ff96502a70dc8bd9e4638577ec4a24ab61d8a27c8faad6", "0x294861ac6b8cfdff0f0805d7603d2ff37bbc44ed5c89d0279b947cf85fa261d0"},
+				u0: "0x1a81d853de31f7f67315e6f8f93472b08c480ad6c74910ed32e4f00a127045f8", u1: "0x2b403c74467d97e22bc4b608c746a6caba45a6b43c942b356dbd415701a2ea79",
+			}, {
+				msg: "abc", P: point{"0x1d88301840c1b25168c059688e48a54094feb93f14a30970ea3e470de1571a75", "0x107f8e3a9026b407ea732ee78e43ab2b9ab1aba282cd67bd62a5923a46a2788e"},
+				Q0: point{"0x3db94b4c38e434ac720de8ac063bd5ae4ba384cfebd54d3ee3d6bd0be0d67e4a", "0x275fdbcfbe1cd7089c00b31ce0772e85dab512942b0502a34e3e8e1386588f4b"},
+				Q1: point{"0x385e448678ce080cbcd85b93f2b4b463e3dee40307cc9fdc432026eb1535b0dc", "0x388c64b3069fe21ccfe916fb69de3a158fcb6069d387f6fb64eb3439266cb71a"},
+				u0: "0x19a3863b31b8e55f4a3b21561a49d969833593878db3ef3bc6662dd9568e1c3e", u1: "0x4286346c603c35403a3a24d906213786d475cffe65067cebce2c2703e7b26c1",
+			}, {
+				msg: "abcdef0123456789", P: point{"0x3daddfa6f2789c6197d553941ed44396d4abe0724086e6cead34f62329bf096d", "0x2aaeb4843701051ee257daa75275a5dffd143ffc9f9f52eeddc88380de89e44"},
+				Q0: point{"0x1fe90df390972ac314f3af401055b8e4f3a99e5a72d650b277972bb69ebc8530", "0xbb067e1ced1ace5c95b68d66fc4589d2d0ea1d37c5f2b1e595dfe11392ae094"},
+				Q1: point{"0x1f30cd6ac07182240f40084e4aac21c7f930a62f5026a309420b4b32de8f9ad3", "0x2773deafe0d5c69d1ca65803340782a5682950651efe58e3f1db3f57f593d1d1"},
+				u0: "0x5d9697771db7e657727d1e96bd9577870249d8bfd991381f0a5781700d05454", u1: "0x1b3bd630a23b059686e065ae8397e8213a29c4f69ed7e57d6b4f348adb55d5e6",
+			}, {
+				msg: "q128_qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq", P: point{"0xb2e5e27b5aa61b57e8bcd9a93ca27fbcccdfd214f5087fd637e494a40e6970b", "0x290a568f88a2757e0b69cdca0fa0415cda1a0252aa7399c1cd56680e7eac48ce"},
+				Q0: point{"0x1b2594d5a310ea68b12b601ca3bcd714b44bc6df3f4a04d517933dc73b6920b6", "0x2cc69f0a82e1b45bf60c506fbd3b2c782a71664abb34a2bfd2ab3841ab888410"},
+				Q1: point{"0x27a406ee80a7208d53a1fc6b95655d42aa5461f340adcc13c8de668c4e38e716", "0x1e4f5cd5fca50a766469c2bae0532f4e58fb54bab877251ade20cbcb54690209"},
+				u0: "0x3abfbce0df8ce9859993cfc96791e29060eb9cfe057ef4cf33ef73034af95f9c", u1: "0x3aab639dfbf7cb00f1578404f06a5259171951a47e8629f6955befb20836d85",
+			}, {
+				msg: "a512_aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", P: point{"0x242cb8f2b0fdfc9c81a9aeacefc237bfde2a18dc24b5ec3adec42784a65b6275", "0x2e0e0a1a78da4a09928dd6817ff6e7d181eb37ef52c0daca412db72b33d7a3ac"},
+				Q0: point{"0x240d227e01c55cf96719824e2c88ca5e80072547be69ee935f721771447a2175", "0x3137495a87ce8fc6a4b9335a872b9f9fd0e45f5042a385a2a41af6d3dedf4cfd"},
+				Q1: point{"0xd9aa38ff113ab13c7832ec89b4a60372f61d43d2bb59965b9a500f65cdb664f", "0x23ff8bc3f16edfc36313033b100c72592a0fdf3f93233317d5a6c997c62cdf0a"},
+				u0: "0x1afd4f9a1fe1482658e39de2e30c9bb6caffabde612af23b3f0f545c26f9254f", u1: "0x1f348cccd4e1feb80f97dcbde762ce8657238d917a54ec4c2a54c56d74b25a84",
+			},
+		}}
+}
@@ -0,0 +1,178 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ipa
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc/vesta"
+	"github.com/consensys/gnark-crypto/ecc/vesta/fr"
+)
+
+// maxNbRounds bounds the number of rounds of a decoded proof, that is the log₂ of the SRS size
+const maxNbRounds = 32
+
+var errInvalidLength = errors.New("invalid length")
+
+// WriteTo writes binary encoding of the OpeningProof
+func (proof *OpeningProof) WriteTo(w io.Writer) (int64, error) {
+	if len(proof.L) != len(proof.R) {
+		return 0, ErrInvalidProofSize
+	}
+	n, err := writeUint32(w, uint32(len(proof.L)))
+	if err != nil {
+		return n, err
+	}
+	for _, points := range [][]vesta.G1Affine{proof.L, proof.R} {
+		for i := range points {
+			b := points[i].RawBytes()
+			m, err := w.Write(b[:])
+			n += int64(m)
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	for _, e := range []*fr.Element{&proof.A, &proof.ClaimedValue} {
+		b := e.Bytes()
+		m, err := w.Write(b[:])
+		n += int64(m)
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// ReadFrom decodes OpeningProof data from reader.
+func (proof *OpeningProof) ReadFrom(r io.Reader) (int64, error) {
+	nbRounds, n, err := readUint32(r)
+	if err != nil {
+		return n, err
+	}
+	if nbRounds > maxNbRounds {
+		return n, errInvalidLength
+	}
+	proof.L = make([]vesta.G1Affine, nbRounds)
+	proof.R = make([]vesta.G1Affine, nbRounds)
+	var buf [vesta.SizeOfG1AffineUncompressed]byte
+	for _, points := range [][]vesta.G1Affine{proof.L, proof.R} {
+		for i := range points {
+			m, err := io.ReadFull(r, buf[:])
+			n += int64(m)
+			if err != nil {
+				return n, err
+			}
+			if _, err := points[i].SetBytes(buf[:]); err != nil {
+				return n, err
+			}
+		}
+	}
+	for _, e := range []*fr.Element{&proof.A, &proof.ClaimedValue} {
+		m, err := readElement(r, e)
+		n += m
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// WriteTo writes binary encoding of the BatchOpeningProof
+func (proof *BatchOpeningProof) WriteTo(w io.Writer) (int64, error) {
+	b := proof.D.RawBytes()
+	m, err := w.Write(b[:])
+	n := int64(m)
+	if err != nil {
+		return n, err
+	}
+	m64, err := writeUint32(w, uint32(len(proof.ClaimedValues)))
+	n += m64
+	if err != nil {
+		return n, err
+	}
+	for i := range proof.ClaimedValues {
+		b := proof.ClaimedValues[i].Bytes()
+		m, err := w.Write(b[:])
+		n += int64(m)
+		if err != nil {
+			return n, err
+		}
+	}
+	m64, err = proof.Proof.WriteTo(w)
+	return n + m64, err
+}
+
+// ReadFrom decodes BatchOpeningProof data from reader.
+func (proof *BatchOpeningProof) ReadFrom(r io.Reader) (int64, error) {
+	var buf [vesta.SizeOfG1AffineUncompressed]byte
+	m, err := io.ReadFull(r, buf[:])
+	n := int64(m)
+	if err != nil {
+		return n, err
+	}
+	if _, err := proof.D.SetBytes(buf[:]); err != nil {
+		return n, err
+	}
+	nbValues, m64, err := readUint32(r)
+	n += m64
+	if err != nil {
+		return n, err
+	}
+	// the claimed values are read one by one, so that a corrupted length fails
+	// on a short read rather than on a large allocation
+	proof.ClaimedValues = proof.ClaimedValues[:0]
+	for i := uint32(0); i < nbValues; i++ {
+		var e fr.Element
+		m64, err := readElement(r, &e)
+		n += m64
+		if err != nil {
+			return n, err
+		}
+		proof.ClaimedValues = append(proof.ClaimedValues, e)
+	}
+	m64, err = proof.Proof.ReadFrom(r)
+	return n + m64, err
+}
+
+func writeUint32(w io.Writer, v uint32) (int64, error) {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], v)
+	m, err := w.Write(buf[:])
+	return int64(m), err
+}
+
+func readUint32(r io.Reader) (uint32, int64, error) {
+	var buf [4]byte
+	m, err := io.ReadFull(r, buf[:])
+	if err != nil {
+		return 0, int64(m), err
+	}
+	return binary.BigEndian.Uint32(buf[:]), int64(m), nil
+}
+
+// readElement reads a canonical big endian encoding of e
+func readElement(r io.Reader, e *fr.Element) (int64, error) {
+	var buf [fr.Bytes]byte
+	m, err := io.ReadFull(r, buf[:])
+	if err != nil {
+		return int64(m), err
+	}
+	return int64(m), e.SetBytesCanonical(buf[:])
+}
@@ -0,0 +1,365 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package extensions
+
+import (
+	"errors"
+	"math/big"
+
+	fr "github.com/consensys/gnark-crypto/field/babybear"
+)
+
+// E4 is a degree two extension of E2: E2[v]/(v² - γ) with γ = u
+type E4 struct {
+	B0, B1 E2
+}
+
+// BytesE4 number of bytes needed to represent an E4
+const BytesE4 = 4 * fr.Bytes
+
+// Equal returns true if z equals x, false otherwise
+func (z *E4) Equal(x *E4) bool {
+	return z.B0.Equal(&x.B0) && z.B1.Equal(&x.B1)
+}
+
+// IsZero returns true if z equals 0, false otherwise
+func (z *E4) IsZero() bool {
+	return z.B0.IsZero() && z.B1.IsZero()
+}
+
+// IsOne returns true if z equals 1, false otherwise
+func (z *E4) IsOne() bool {
+	return z.B0.IsOne() && z.B1.IsZero()
+}
+
+// SetZero sets z to 0 and returns z
+func (z *E4) SetZero() *E4 {
+	*z = E4{}
+	return z
+}
+
+// SetOne sets z to 1 and returns z
+func (z *E4) SetOne() *E4 {
+	*z = E4{}
+	z.B0.A0.SetOne()
+	return z
+}
+
+// Set sets z to x and returns z
+func (z *E4) Set(x *E4) *E4 {
+	*z = *x
+	return z
+}
+
+// SetInt64 sets z to v (mod p) and returns z
+func (z *E4) SetInt64(v int64) *E4 {
+	*z = E4{}
+	z.B0.A0.SetInt64(v)
+	return z
+}
+
+// SetUint64 sets z to v (mod p) and returns z
+func (z *E4) SetUint64(v uint64) *E4 {
+	*z = E4{}
+	z.B0.A0.SetUint64(v)
+	return z
+}
+
+// Lift sets z to x ∈ 𝔽p and returns z
+func (z *E4) Lift(x *fr.Element) *E4 {
+	*z = E4{}
+	z.B0.A0 = *x
+	return z
+}
+
+// SetInterface converts provided interface into E4. The interface can be an E4, a *E4,
+// or any value accepted by fr.Element.SetInterface, which is then lifted to E4
+func (z *E4) SetInterface(i1 interface{}) (*E4, error) {
+	switch c1 := i1.(type) {
+	case E4:
+		return z.Set(&c1), nil
+	case *E4:
+		if c1 == nil {
+			return nil, errors.New("can't set extensions.E4 with <nil>")
+		}
+		return z.Set(c1), nil
+	default:
+		var a fr.Element
+		if _, err := a.SetInterface(i1); err != nil {
+			return nil, err
+		}
+		return z.Lift(&a), nil
+	}
+}
+
+// SetRandom sets z to a uniform random value and returns z
+func (z *E4) SetRandom() (*E4, error) {
+	if _, err := z.B0.SetRandom(); err != nil {
+		return nil, err
+	}
+	if _, err := z.B1.SetRandom(); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
+
+// Add sets z = x + y and returns z
+func (z *E4) Add(x, y *E4) *E4 {
+	z.B0.Add(&x.B0, &y.B0)
+	z.B1.Add(&x.B1, &y.B1)
+	return z
+}
+
+// Sub sets z = x - y and returns z
+func (z *E4) Sub(x, y *E4) *E4 {
+	z.B0.Sub(&x.B0, &y.B0)
+	z.B1.Sub(&x.B1, &y.B1)
+	return z
+}
+
+// Double sets z = 2x and returns z
+func (z *E4) Double(x *E4) *E4 {
+	z.B0.Double(&x.B0)
+	z.B1.Double(&x.B1)
+	return z
+}
+
+// Neg sets z = -x and returns z
+func (z *E4) Neg(x *E4) *E4 {
+	z.B0.Neg(&x.B0)
+	z.B1.Neg(&x.B1)
+	return z
+}
+
+// Conjugate sets z = b₀ - b₁v where x = b₀ + b₁v, and returns z
+func (z *E4) Conjugate(x *E4) *E4 {
+	z.B0 = x.B0
+	z.B1.Neg(&x.B1)
+	return z
+}
+
+// MulByElement sets z = x·y with y ∈ 𝔽p and returns z
+func (z *E4) MulByElement(x *E4, y *fr.Element) *E4 {
+	var yCopy fr.Element
+	yCopy.Set(y)
+	z.B0.MulByElement(&x.B0, &yCopy)
+	z.B1.MulByElement(&x.B1, &yCopy)
+	return z
+}
+
+// MulByE2 sets z = x·y with y ∈ E2 and returns z
+func (z *E4) MulByE2(x *E4, y *E2) *E4 {
+	var yCopy E2
+	yCopy.Set(y)
+	z.B0.Mul(&x.B0, &yCopy)
+	z.B1.Mul(&x.B1, &yCopy)
+	return z
+}
+
+// MulByNonResidue sets z = v·x and returns z
+func (z *E4) MulByNonResidue(x *E4) *E4 {
+	z.B1, z.B0 = x.B0, x.B1
+	mulByNonResidueE4(&z.B0, &z.B0)
+	return z
+}
+
+// Mul sets z = x·y and returns z
+func (z *E4) Mul(x, y *E4) *E4 {
+	var a, b, c E2
+	a.Add(&x.B0, &x.B1)
+	b.Add(&y.B0, &y.B1)
+	a.Mul(&a, &b)
+	b.Mul(&x.B0, &y.B0)
+	c.Mul(&x.B1, &y.B1)
+	z.B1.Sub(&a, &b).Sub(&z.B1, &c)
+	mulByNonResidueE4(&c, &c)
+	z.B0.Add(&b, &c)
+	return z
+}
+
+// Square sets z = x² and returns z
+func (z *E4) Square(x *E4) *E4 {
+	// Algorithm 22 from https://eprint.iacr.org/2010/354.pdf
+	var c0, c2, c3 E2
+	c0.Sub(&x.B0, &x.B1)
+	mulByNonResidueE4(&c3, &x.B1)
+	c3.Sub(&x.B0, &c3)
+	c2.Mul(&x.B0, &x.B1)
+	c0.Mul(&c0, &c3).Add(&c0, &c2)
+	z.B1.Double(&c2)
+	mulByNonResidueE4(&c2, &c2)
+	z.B0.Add(&c0, &c2)
+	return z
+}
+
+// Inverse sets z = x⁻¹ and returns z
+//
+// if x == 0, sets and returns z = x
+func (z *E4) Inverse(x *E4) *E4 {
+	// Algorithm 23 from https://eprint.iacr.org/2010/354.pdf
+	var t0, t1, tmp E2
+	t0.Square(&x.B0)
+	t1.Square(&x.B1)
+	mulByNonResidueE4(&tmp, &t1)
+	t0.Sub(&t0, &tmp)
+	t1.Inverse(&t0)
+	z.B0.Mul(&x.B0, &t1)
+	z.B1.Mul(&x.B1, &t1).Neg(&z.B1)
+	return z
+}
+
+// Div sets z = x/y and returns z
+func (z *E4) Div(x, y *E4) *E4 {
+	var r E4
+	r.Inverse(y).Mul(x, &r)
+	return z.Set(&r)
+}
+
+// Exp sets z = xᵏ and returns z
+func (z *E4) Exp(x E4, k *big.Int) *E4 {
+	if k.IsUint64() && k.Uint64() == 0 {
+		return z.SetOne()
+	}
+
+	e := k
+	if k.Sign() == -1 {
+		// negative k, we invert
+		// if k < 0: xᵏ == (x⁻¹)⁻ᵏ
+		x.Inverse(&x)
+		e = new(big.Int).Neg(k)
+	}
+
+	z.SetOne()
+	b := e.Bytes()
+	for i := 0; i < len(b); i++ {
+		w := b[i]
+		for j := 0; j < 8; j++ {
+			z.Square(z)
+			if (w & (0b10000000 >> j)) != 0 {
+				z.Mul(z, &x)
+			}
+		}
+	}
+
+	return z
+}
+
+// Frobenius sets z = xᵖ and returns z
+func (z *E4) Frobenius(x *E4) *E4 {
+	// vᵖ = v·γ^((p-1)/2)
+	z.B0.Frobenius(&x.B0)
+	z.B1.Frobenius(&x.B1)
+	z.B1.MulByElement(&z.B1, &e4Frobenius.A0)
+	return z
+}
+
+// String returns the decimal representation of z
+func (z *E4) String() string {
+	return z.Text(10)
+}
+
+// Text returns the string representation of z in the given base
+func (z *E4) Text(base int) string {
+	if z.B1.IsZero() {
+		return z.B0.Text(base)
+	}
+	return "(" + z.B0.Text(base) + ")+(" + z.B1.Text(base) + ")*v"
+}
+
+// Bytes returns the concatenation of the big-endian encodings of the coordinates of z
+func (z *E4) Bytes() (res [BytesE4]byte) {
+	b := z.B0.Bytes()
+	copy(res[0:BytesE2], b[:])
+	b = z.B1.Bytes()
+	copy(res[BytesE2:], b[:])
+	return
+}
+
+// Marshal returns the value of z as a byte slice (see Bytes)
+func (z *E4) Marshal() []byte {
+	b := z.Bytes()
+	return b[:]
+}
+
+// Unmarshal sets z to the value encoded in e (see Bytes). It returns an error if
+// len(e) != BytesE4 or if a coordinate isn't canonical.
+func (z *E4) Unmarshal(e []byte) error {
+	if len(e) != BytesE4 {
+		return errors.New("invalid extensions.E4 encoding")
+	}
+	if err := z.B0.Unmarshal(e[0:BytesE2]); err != nil {
+		return err
+	}
+	return z.B1.Unmarshal(e[BytesE2:])
+}
+
+// SetBytes splits e in four parts and sets the coordinates of z to their
+// big-endian value (mod p). It is the inverse of Bytes, and can also be used
+// to derive an element from a hash digest (e.g. a Fiat-Shamir challenge).
+func (z *E4) SetBytes(e []byte) *E4 {
+	n := len(e) / 4
+	z.B0.SetBytes(e[:2*n])
+	z.B1.SetBytes(e[2*n:])
+	return z
+}
+
+// BatchInvertE4 returns a new slice with every element of a inverted.
+// Uses Montgomery batch inversion trick.
+//
+// if a[i] == 0, returns result[i] = a[i]
+func BatchInvertE4(a []E4) []E4 {
+	res := make([]E4, len(a))
+	if len(a) == 0 {
+		return res
+	}
+
+	zeroes := make([]bool, len(a))
+	var accumulator E4
+	accumulator.SetOne()
+
+	for i := 0; i < len(a); i++ {
+		if a[i].IsZero() {
+			zeroes[i] = true
+			continue
+		}
+		res[i].Set(&accumulator)
+		accumulator.Mul(&accumulator, &a[i])
+	}
+
+	accumulator.Inverse(&accumulator)
+
+	for i := len(a) - 1; i >= 0; i-- {
+		if zeroes[i] {
+			continue
+		}
+		res[i].Mul(&res[i], &accumulator)
+		accumulator.Mul(&accumulator, &a[i])
+	}
+
+	return res
+}
+
+// e4Frobenius = γ^((p-1)/2)
+var e4Frobenius = E2{
+	A0: fr.NewElement(1728404513),
+	A1: fr.NewElement(0),
+}
+
+// mulByNonResidueE4 sets z = γ·x
+func mulByNonResidueE4(z, x *E2) {
+	z.MulByNonResidue(x)
+}
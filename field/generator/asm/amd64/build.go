@@ -158,6 +158,15 @@ func Generate(w io.Writer, F *config.FieldConfig) error {
 	// fft butterflies
 	f.generateButterfly()
 
+	// vector operations
+	if f.NbWords == VecNbWords {
+		f.generateAddVec()
+		f.generateSubVec()
+		f.generateScalarMulVec()
+		f.generateMulVec()
+		f.generateButterflyVec()
+	}
+
 	return nil
 }
 
@@ -0,0 +1,302 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package amd64
+
+import (
+	"fmt"
+
+	"github.com/consensys/bavard/amd64"
+)
+
+// VecNbWords is the number of words of the fields for which the vector kernels are generated.
+// Other fields use the generic implementation of the vector operations.
+const VecNbWords = 4
+
+// addVecAsm res = a + b
+// func addVecAsm(res, a, b *{{.ElementName}}, n uint64)
+func (f *FFAmd64) generateAddVec() {
+	f.Comment("addVecAsm(res, a, b *Element, n uint64) res[0...n] = a[0...n] + b[0...n]")
+
+	const argSize = 4 * 8
+	stackSize := f.StackSize(4+f.NbWords*2, 0, 0)
+	registers := f.FnHeader("addVecAsm", stackSize, argSize)
+	defer f.AssertCleanStack(stackSize, 0)
+
+	// registers & labels we need
+	addrA := f.Pop(&registers)
+	addrB := f.Pop(&registers)
+	addrRes := f.Pop(&registers)
+	n := f.Pop(&registers)
+
+	a := f.PopN(&registers)
+	t := f.PopN(&registers)
+
+	loop := f.NewLabel()
+	done := f.NewLabel()
+
+	// load arguments
+	f.MOVQ("res+0(FP)", addrRes)
+	f.MOVQ("a+8(FP)", addrA)
+	f.MOVQ("b+16(FP)", addrB)
+	f.MOVQ("n+24(FP)", n)
+
+	f.LABEL(loop)
+
+	f.TESTQ(n, n)
+	f.JEQ(done)
+
+	// a = a + b
+	f.Mov(addrA, a)
+	f.Add(addrB, a)
+
+	// reduce a
+	f.ReduceElement(a, t)
+
+	// save a into res
+	f.Mov(a, addrRes)
+
+	f.Comment("increment pointers to visit next element")
+	f.ADDQ(fmt.Sprintf("$%d", 8*f.NbWords), addrA)
+	f.ADDQ(fmt.Sprintf("$%d", 8*f.NbWords), addrB)
+	f.ADDQ(fmt.Sprintf("$%d", 8*f.NbWords), addrRes)
+	f.DECQ(n)
+	f.JMP(loop)
+
+	f.LABEL(done)
+	f.RET()
+
+	// release registers
+	f.Push(&registers, a...)
+	f.Push(&registers, t...)
+	f.Push(&registers, addrA, addrB, addrRes, n)
+}
+
+// subVecAsm res = a - b
+// func subVecAsm(res, a, b *{{.ElementName}}, n uint64)
+func (f *FFAmd64) generateSubVec() {
+	f.Comment("subVecAsm(res, a, b *Element, n uint64) res[0...n] = a[0...n] - b[0...n]")
+
+	const argSize = 4 * 8
+	stackSize := f.StackSize(5+f.NbWords*2, 0, 0)
+	registers := f.FnHeader("subVecAsm", stackSize, argSize)
+	defer f.AssertCleanStack(stackSize, 0)
+
+	// registers
+	addrA := f.Pop(&registers)
+	addrB := f.Pop(&registers)
+	addrRes := f.Pop(&registers)
+	n := f.Pop(&registers)
+	zero := f.Pop(&registers)
+
+	a := f.PopN(&registers)
+	q := f.PopN(&registers)
+
+	loop := f.NewLabel()
+	done := f.NewLabel()
+
+	// load arguments
+	f.MOVQ("res+0(FP)", addrRes)
+	f.MOVQ("a+8(FP)", addrA)
+	f.MOVQ("b+16(FP)", addrB)
+	f.MOVQ("n+24(FP)", n)
+	f.XORQ(zero, zero)
+
+	f.LABEL(loop)
+
+	f.TESTQ(n, n)
+	f.JEQ(done)
+
+	// a = a - b
+	f.Mov(addrA, a)
+	f.Sub(addrB, a)
+
+	// reduce a: if a borrowed, add q
+	f.Mov(f.Q, q)
+	for i := 0; i < f.NbWords; i++ {
+		f.CMOVQCC(zero, q[i])
+	}
+	f.Add(q, a)
+
+	// save a into res
+	f.Mov(a, addrRes)
+
+	f.Comment("increment pointers to visit next element")
+	f.ADDQ(fmt.Sprintf("$%d", 8*f.NbWords), addrA)
+	f.ADDQ(fmt.Sprintf("$%d", 8*f.NbWords), addrB)
+	f.ADDQ(fmt.Sprintf("$%d", 8*f.NbWords), addrRes)
+	f.DECQ(n)
+	f.JMP(loop)
+
+	f.LABEL(done)
+	f.RET()
+
+	// release registers
+	f.Push(&registers, a...)
+	f.Push(&registers, q...)
+	f.Push(&registers, addrA, addrB, addrRes, n, zero)
+}
+
+// scalarMulVecAsm res = a * b
+// func scalarMulVecAsm(res, a, b *{{.ElementName}}, n uint64)
+//
+// the caller must check that the ADX instructions are supported.
+func (f *FFAmd64) generateScalarMulVec() {
+	f.Comment("scalarMulVecAsm(res, a, b *Element, n uint64) res[0...n] = a[0...n] * b")
+	f.generateMulVecLoop("scalarMulVecAsm", false)
+}
+
+// mulVecAsm res = a * b
+// func mulVecAsm(res, a, b *{{.ElementName}}, n uint64)
+//
+// the caller must check that the ADX instructions are supported.
+func (f *FFAmd64) generateMulVec() {
+	f.Comment("mulVecAsm(res, a, b *Element, n uint64) res[0...n] = a[0...n] * b[0...n]")
+	f.generateMulVecLoop("mulVecAsm", true)
+}
+
+// generateMulVecLoop multiplies n elements of a by b, moving b to the next
+// element at each iteration if incB is set.
+func (f *FFAmd64) generateMulVecLoop(name string, incB bool) {
+	const argSize = 4 * 8
+	reserved := []amd64.Register{amd64.DX, amd64.AX, amd64.R15}
+	stackSize := f.StackSize(4+f.NbWords*2, len(reserved), 0)
+	registers := f.FnHeader(name, stackSize, argSize, reserved...)
+	defer f.AssertCleanStack(stackSize, 0)
+	if stackSize > 0 {
+		f.WriteLn("NO_LOCAL_POINTERS")
+	}
+
+	// registers
+	addrA := f.Pop(&registers)
+	addrB := f.Pop(&registers)
+	addrRes := f.Pop(&registers)
+	n := f.Pop(&registers)
+
+	loop := f.NewLabel()
+	done := f.NewLabel()
+
+	// load arguments
+	f.MOVQ("res+0(FP)", addrRes)
+	f.MOVQ("a+8(FP)", addrA)
+	f.MOVQ("b+16(FP)", addrB)
+	f.MOVQ("n+24(FP)", n)
+
+	f.LABEL(loop)
+
+	f.TESTQ(n, n)
+	f.JEQ(done)
+
+	xat := func(i int) string {
+		return addrA.At(i)
+	}
+	yat := func(i int) string {
+		return addrB.At(i)
+	}
+
+	t := registers.PopN(f.NbWords)
+	f.MulADX(&registers, xat, yat, t)
+
+	// reduce t
+	f.Reduce(&registers, t)
+
+	// save t into res
+	f.Mov(t, addrRes)
+	registers.Push(t...)
+
+	f.Comment("increment pointers to visit next element")
+	f.ADDQ(fmt.Sprintf("$%d", 8*f.NbWords), addrA)
+	if incB {
+		f.ADDQ(fmt.Sprintf("$%d", 8*f.NbWords), addrB)
+	}
+	f.ADDQ(fmt.Sprintf("$%d", 8*f.NbWords), addrRes)
+	f.DECQ(n)
+	f.JMP(loop)
+
+	f.LABEL(done)
+	f.RET()
+
+	// release registers
+	f.Push(&registers, addrA, addrB, addrRes, n)
+}
+
+// butterflyVecAsm a, b = a + b, a - b
+// func butterflyVecAsm(a, b *{{.ElementName}}, n uint64)
+func (f *FFAmd64) generateButterflyVec() {
+	f.Comment("butterflyVecAsm(a, b *Element, n uint64) a[0...n], b[0...n] = a[0...n] + b[0...n], a[0...n] - b[0...n]")
+
+	const argSize = 3 * 8
+	stackSize := f.StackSize(4+f.NbWords*2, 0, 0)
+	registers := f.FnHeader("butterflyVecAsm", stackSize, argSize)
+	defer f.AssertCleanStack(stackSize, 0)
+
+	// registers
+	addrA := f.Pop(&registers)
+	addrB := f.Pop(&registers)
+	n := f.Pop(&registers)
+	zero := f.Pop(&registers)
+
+	t0 := f.PopN(&registers)
+	t1 := f.PopN(&registers)
+
+	loop := f.NewLabel()
+	done := f.NewLabel()
+
+	// load arguments
+	f.MOVQ("a+0(FP)", addrA)
+	f.MOVQ("b+8(FP)", addrB)
+	f.MOVQ("n+16(FP)", n)
+	f.XORQ(zero, zero)
+
+	f.LABEL(loop)
+
+	f.TESTQ(n, n)
+	f.JEQ(done)
+
+	// t0 = a + b, t1 = a - b
+	f.Mov(addrA, t0)
+	f.Mov(t0, t1)
+	f.Add(addrB, t0)
+	f.Sub(addrB, t1)
+
+	// a and b are not read anymore: store t0 in a (MOVQ leaves the borrow
+	// untouched) and use its registers to reduce t1: if t1 borrowed, add q
+	f.Mov(t0, addrA)
+	q := t0
+	f.Mov(f.Q, q)
+	for i := 0; i < f.NbWords; i++ {
+		f.CMOVQCC(zero, q[i])
+	}
+	f.Add(q, t1)
+	f.Mov(t1, addrB)
+
+	// reduce a + b
+	f.Mov(addrA, t0)
+	f.ReduceElement(t0, t1)
+	f.Mov(t0, addrA)
+
+	f.Comment("increment pointers to visit next element")
+	f.ADDQ(fmt.Sprintf("$%d", 8*f.NbWords), addrA)
+	f.ADDQ(fmt.Sprintf("$%d", 8*f.NbWords), addrB)
+	f.DECQ(n)
+	f.JMP(loop)
+
+	f.LABEL(done)
+	f.RET()
+
+	// release registers
+	f.Push(&registers, t0...)
+	f.Push(&registers, t1...)
+	f.Push(&registers, addrA, addrB, n, zero)
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"errors"
+	"math/big"
+)
+
+// ExtensionsConfig describes the small degree extensions of a (small) base field:
+//
+//	E2 = 𝔽p[u]/(u² - α₂)
+//	E3 = 𝔽p[w]/(w³ - α₃)
+//	E4 = E2[v]/(v² - γ), γ = γ₀ + γ₁·u ∈ E2
+type ExtensionsConfig struct {
+	Base            *FieldConfig
+	BasePackagePath string // import path of the base field package
+
+	E2NonResidue int64    // α₂, either -1 or positive
+	E3NonResidue int64    // α₃, positive; 0 if E3 is not generated
+	E4NonResidue [2]int64 // (γ₀, γ₁), non negative
+
+	// E3Frobenius holds (ω, ω²) where ω = α₃^((p-1)/3)
+	E3Frobenius [2]uint64
+	// E4Frobenius holds the coordinates of γ^((p-1)/2) ∈ E2
+	E4Frobenius [2]uint64
+}
+
+// NewExtensionsConfig checks that the given non-residues define field extensions of base
+// and returns the corresponding configuration
+func NewExtensionsConfig(base *FieldConfig, basePackagePath string, e2NonResidue, e3NonResidue int64, e4NonResidue [2]int64) (*ExtensionsConfig, error) {
+	if base.NbBits > 64 {
+		return nil, errors.New("extensions are only generated for fields of at most 64 bits")
+	}
+	if e2NonResidue != -1 && e2NonResidue <= 0 || e3NonResidue < 0 || e4NonResidue[0] < 0 || e4NonResidue[1] < 0 {
+		return nil, errors.New("unsupported non-residue")
+	}
+	p := base.ModulusBig
+	var pMinusOne, e, t big.Int
+	pMinusOne.Sub(p, big.NewInt(1))
+
+	mod := func(x int64) *big.Int {
+		return new(big.Int).Mod(big.NewInt(x), p)
+	}
+
+	// E2: α₂ must not be a square
+	e.Rsh(&pMinusOne, 1)
+	if t.Exp(mod(e2NonResidue), &e, p).Cmp(&pMinusOne) != 0 {
+		return nil, errors.New("E2 non-residue is a square")
+	}
+
+	ret := &ExtensionsConfig{
+		Base:            base,
+		BasePackagePath: basePackagePath,
+		E2NonResidue:    e2NonResidue,
+		E3NonResidue:    e3NonResidue,
+		E4NonResidue:    e4NonResidue,
+	}
+
+	// E3: 3 | p-1 and α₃ must not be a cube
+	if e3NonResidue != 0 {
+		var r big.Int
+		e.DivMod(&pMinusOne, big.NewInt(3), &r)
+		if r.Sign() != 0 {
+			return nil, errors.New("p ≢ 1 mod 3, E3 can't be a Kummer extension")
+		}
+		var omega, omega2 big.Int
+		omega.Exp(mod(e3NonResidue), &e, p)
+		if omega.Cmp(big.NewInt(1)) == 0 {
+			return nil, errors.New("E3 non-residue is a cube")
+		}
+		omega2.Mul(&omega, &omega).Mod(&omega2, p)
+		ret.E3Frobenius = [2]uint64{omega.Uint64(), omega2.Uint64()}
+	}
+
+	// E4: γ must not be a square in E2, i.e. its norm must not be a square in 𝔽p
+	var norm big.Int
+	norm.Mul(mod(e4NonResidue[1]), mod(e4NonResidue[1])).Mul(&norm, mod(e2NonResidue))
+	norm.Sub(new(big.Int).Mul(mod(e4NonResidue[0]), mod(e4NonResidue[0])), &norm).Mod(&norm, p)
+	e.Rsh(&pMinusOne, 1)
+	if t.Exp(&norm, &e, p).Cmp(&pMinusOne) != 0 {
+		return nil, errors.New("E4 non-residue is a square in E2")
+	}
+	e2 := NewTower(base, 2, e2NonResidue)
+	c := e2.Exp(e2.FromInt64(e4NonResidue[0], e4NonResidue[1]), &e)
+	e2.reduce(c)
+	ret.E4Frobenius = [2]uint64{c[0].Uint64(), c[1].Uint64()}
+
+	return ret, nil
+}
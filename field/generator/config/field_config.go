@@ -48,6 +48,8 @@ type FieldConfig struct {
 	P20InversionNbIterations  int
 	UsingP20Inverse           bool
 	IsMSWSaturated            bool // indicates if the most significant word is 0xFFFFF...FFFF
+	F31                       bool // indicates the modulus fits on 31 bits; elements are then stored on a single uint32 word
+	Goldilocks                bool // indicates the modulus is 2⁶⁴ - 2³² + 1; elements are then stored in canonical (non-Montgomery) form
 	Q                         []uint64
 	QInverse                  []uint64
 	QMinusOneHalvedP          []uint64 // ((q-1) / 2 ) + 1
@@ -97,6 +99,11 @@ func NewFieldConfig(packageName, elementName, modulus string, useAddChain bool)
 	F.NbBits = bModulus.BitLen()
 	F.NbWords = len(bModulus.Bits())
 	F.NbBytes = F.NbWords * 8 // (F.NbBits + 7) / 8
+	if F.NbBits <= 31 {
+		F.F31 = true
+		F.NbBytes = 4
+	}
+	F.Goldilocks = F.ModulusHex == "ffffffff00000001"
 
 	F.NbWordsLastIndex = F.NbWords - 1
 
@@ -110,7 +117,7 @@ func NewFieldConfig(packageName, elementName, modulus string, useAddChain bool)
 
 	//  setting qInverse
 	_r := big.NewInt(1)
-	_r.Lsh(_r, uint(F.NbWords)*64)
+	_r.Lsh(_r, F.montBits())
 	_rInv := big.NewInt(1)
 	_qInv := big.NewInt(0)
 	extendedEuclideanAlgo(_r, &bModulus, _rInv, _qInv)
@@ -139,19 +146,19 @@ func NewFieldConfig(packageName, elementName, modulus string, useAddChain bool)
 
 	// rsquare
 	_rSquare := big.NewInt(2)
-	exponent := big.NewInt(int64(F.NbWords) * 64 * 2)
+	exponent := big.NewInt(int64(F.montBits()) * 2)
 	_rSquare.Exp(_rSquare, exponent, &bModulus)
 	F.RSquare = toUint64Slice(_rSquare, F.NbWords)
 
 	var one big.Int
 	one.SetUint64(1)
-	one.Lsh(&one, uint(F.NbWords)*64).Mod(&one, &bModulus)
+	one.Lsh(&one, F.montBits()).Mod(&one, &bModulus)
 	F.One = toUint64Slice(&one, F.NbWords)
 
 	{
 		var n big.Int
 		n.SetUint64(13)
-		n.Lsh(&n, uint(F.NbWords)*64).Mod(&n, &bModulus)
+		n.Lsh(&n, F.montBits()).Mod(&n, &bModulus)
 		F.Thirteen = toUint64Slice(&n, F.NbWords)
 	}
 
@@ -240,7 +247,7 @@ func NewFieldConfig(packageName, elementName, modulus string, useAddChain bool)
 			var g big.Int
 			g.Exp(&nonResidue, &s, &bModulus)
 			// store g in montgomery form
-			g.Lsh(&g, uint(F.NbWords)*64).Mod(&g, &bModulus)
+			g.Lsh(&g, F.montBits()).Mod(&g, &bModulus)
 			F.SqrtG = toUint64Slice(&g, F.NbWords)
 
 			// store non residue in montgomery form
@@ -264,6 +271,18 @@ func NewFieldConfig(packageName, elementName, modulus string, useAddChain bool)
 	return F, nil
 }
 
+// montBits returns log₂(R) where R is the Montgomery constant: 32 bits per word
+// for 31-bit fields, 64 otherwise. Goldilocks elements aren't in Montgomery form (R = 1).
+func (f *FieldConfig) montBits() uint {
+	if f.Goldilocks {
+		return 0
+	}
+	if f.F31 {
+		return 32
+	}
+	return uint(f.NbWords) * 64
+}
+
 func toUint64Slice(b *big.Int, nbWords ...int) (s []uint64) {
 	if len(nbWords) > 0 && nbWords[0] > len(b.Bits()) {
 		s = make([]uint64, nbWords[0])
@@ -324,7 +343,7 @@ func (f *FieldConfig) StringToMont(str string) big.Int {
 
 func (f *FieldConfig) ToMont(nonMont big.Int) big.Int {
 	var mont big.Int
-	mont.Lsh(&nonMont, uint(f.NbWords)*64)
+	mont.Lsh(&nonMont, f.montBits())
 	mont.Mod(&mont, f.ModulusBig)
 	return mont
 }
@@ -335,8 +354,8 @@ func (f *FieldConfig) FromMont(nonMont *big.Int, mont *big.Int) *FieldConfig {
 		nonMont.SetInt64(0)
 		return f
 	}
-	f.halve(nonMont, mont)
-	for i := 1; i < f.NbWords*64; i++ {
+	nonMont.Set(mont)
+	for i := 0; i < int(f.montBits()); i++ {
 		f.halve(nonMont, nonMont)
 	}
 
@@ -14,6 +14,7 @@ import (
 	"github.com/consensys/gnark-crypto/field/generator/config"
 	"github.com/consensys/gnark-crypto/field/generator/internal/addchain"
 	"github.com/consensys/gnark-crypto/field/generator/internal/templates/element"
+	"github.com/consensys/gnark-crypto/field/generator/internal/templates/extensions"
 )
 
 // GenerateFF will generate go (and .s) files in outputDir for modulus (in base 10)
@@ -23,6 +24,13 @@ import (
 //	fp, _ = config.NewField("fp", "Element", fpModulus")
 //	generator.GenerateFF(fp, filepath.Join(baseDir, "fp"))
 func GenerateFF(F *config.FieldConfig, outputDir string) error {
+	if F.F31 {
+		return generateF31(F, outputDir)
+	}
+	if F.Goldilocks {
+		return generateGoldilocks(F, outputDir)
+	}
+
 	// source file templates
 	sourceFiles := []string{
 		element.Base,
@@ -274,3 +282,162 @@ func shorten(input string) string {
 	}
 	return input
 }
+
+// generateF31 generates the field element for moduli fitting on 31 bits; elements
+// are stored on a single uint32 word and there is no assembly.
+func generateF31(F *config.FieldConfig, outputDir string) error {
+	eName := strings.ToLower(F.ElementName)
+
+	funcs := template.FuncMap{}
+	funcs["shorten"] = shorten
+	funcs["ltu64"] = func(a, b uint64) bool {
+		return a < b
+	}
+
+	bavardOpts := []func(*bavard.Bavard) error{
+		bavard.Apache2("ConsenSys Software Inc.", 2020),
+		bavard.Package(F.PackageName),
+		bavard.GeneratedBy("consensys/gnark-crypto"),
+		bavard.Funcs(funcs),
+	}
+
+	entries := []struct {
+		path      string
+		templates []string
+	}{
+		{eName + ".go", []string{element.F31, element.Exp, element.Sqrt}},
+		{"vector.go", []string{element.Vector}},
+		{"doc.go", []string{element.Doc}},
+		{eName + "_test.go", []string{element.Test}},
+		{"vector_test.go", []string{element.TestVector}},
+	}
+	for _, e := range entries {
+		if err := bavard.GenerateFromString(filepath.Join(outputDir, e.path), e.templates, F, bavardOpts...); err != nil {
+			return err
+		}
+	}
+
+	// run go fmt on whole directory
+	cmd := exec.Command("gofmt", "-s", "-w", outputDir)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// generateGoldilocks generates the field element for q = 2⁶⁴ - 2³² + 1; elements
+// are stored in canonical form and reduced using the special form of q; there is no assembly.
+func generateGoldilocks(F *config.FieldConfig, outputDir string) error {
+	eName := strings.ToLower(F.ElementName)
+
+	// remove files of the generic (Montgomery) implementation
+	_ = os.Remove(filepath.Join(outputDir, "arith.go"))
+	_ = os.Remove(filepath.Join(outputDir, eName+"_ops_purego.go"))
+
+	funcs := template.FuncMap{}
+	if F.UseAddChain {
+		for _, f := range addchain.Functions {
+			funcs[f.Name] = f.Func
+		}
+	}
+	funcs["shorten"] = shorten
+	funcs["ltu64"] = func(a, b uint64) bool {
+		return a < b
+	}
+
+	bavardOpts := []func(*bavard.Bavard) error{
+		bavard.Apache2("ConsenSys Software Inc.", 2020),
+		bavard.Package(F.PackageName),
+		bavard.GeneratedBy("consensys/gnark-crypto"),
+		bavard.Funcs(funcs),
+	}
+
+	entries := []struct {
+		path      string
+		templates []string
+	}{
+		{eName + ".go", []string{element.Goldilocks, element.Exp, element.Sqrt}},
+		{"vector.go", []string{element.Vector}},
+		{"doc.go", []string{element.Doc}},
+		{eName + "_test.go", []string{element.Test}},
+		{"vector_test.go", []string{element.TestVector}},
+	}
+	if F.UseAddChain {
+		entries = append(entries, struct {
+			path      string
+			templates []string
+		}{eName + "_exp.go", []string{element.FixedExp}})
+	}
+	for _, e := range entries {
+		if err := bavard.GenerateFromString(filepath.Join(outputDir, e.path), e.templates, F, bavardOpts...); err != nil {
+			return err
+		}
+	}
+
+	// run go fmt on whole directory
+	cmd := exec.Command("gofmt", "-s", "-w", outputDir)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// GenerateExtensions generates the E2, E3 (if conf.E3NonResidue != 0) and E4 extensions of conf.Base
+// in outputDir, as package "extensions".
+//
+// Example usage
+//
+//	ext, _ := config.NewExtensionsConfig(goldilocks, "github.com/consensys/gnark-crypto/field/goldilocks", 7, 2, [2]int64{0, 1})
+//	generator.GenerateExtensions(ext, "../extensions")
+func GenerateExtensions(conf *config.ExtensionsConfig, outputDir string) error {
+	const packageName = "extensions"
+
+	bavardOpts := []func(*bavard.Bavard) error{
+		bavard.Apache2("ConsenSys Software Inc.", 2020),
+		bavard.Package(packageName),
+		bavard.GeneratedBy("consensys/gnark-crypto"),
+	}
+
+	type extensionData struct {
+		*config.ExtensionsConfig
+		Package string
+		Name    string
+		Degree  int
+	}
+
+	data := []extensionData{{conf, packageName, "E2", 2}}
+	if conf.E3NonResidue != 0 {
+		data = append(data, extensionData{conf, packageName, "E3", 3})
+	}
+	data = append(data, extensionData{conf, packageName, "E4", 4})
+
+	sources := map[string]string{
+		"E2": extensions.E2,
+		"E3": extensions.E3,
+		"E4": extensions.E4,
+	}
+	if conf.E3NonResidue == 0 {
+		_ = os.Remove(filepath.Join(outputDir, "e3.go"))
+		_ = os.Remove(filepath.Join(outputDir, "e3_test.go"))
+	}
+
+	for _, d := range data {
+		name := strings.ToLower(d.Name)
+		if err := bavard.GenerateFromString(filepath.Join(outputDir, name+".go"), []string{sources[d.Name]}, d, bavardOpts...); err != nil {
+			return err
+		}
+		if err := bavard.GenerateFromString(filepath.Join(outputDir, name+"_test.go"), []string{extensions.Test}, d, bavardOpts...); err != nil {
+			return err
+		}
+	}
+	if err := bavard.GenerateFromString(filepath.Join(outputDir, "generators_test.go"), []string{extensions.Generators}, data[0], bavardOpts...); err != nil {
+		return err
+	}
+	if err := bavard.GenerateFromString(filepath.Join(outputDir, "doc.go"), []string{extensions.Doc}, data[0], bavardOpts...); err != nil {
+		return err
+	}
+
+	// run go fmt on whole directory
+	cmd := exec.Command("gofmt", "-s", "-w", outputDir)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -7,8 +7,8 @@ const Doc = `
 // 
 // The modulus is hardcoded in all the operations.
 // 
-// Field elements are represented as an array, and assumed to be in Montgomery form in all methods:
-// 	type {{.ElementName}} [{{.NbWords}}]uint64
+// Field elements are represented as an array, and assumed to be in {{if .Goldilocks}}canonical (non-Montgomery){{else}}Montgomery{{end}} form in all methods:
+// 	type {{.ElementName}} {{if .F31}}[1]uint32{{else}}[{{.NbWords}}]uint64{{end}}
 //
 // Usage
 //
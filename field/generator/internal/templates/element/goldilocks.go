@@ -0,0 +1,856 @@
+package element
+
+// Goldilocks is the template for the field of modulus q = 2⁶⁴ - 2³² + 1.
+// Elements are stored on a single uint64 word, in canonical (non-Montgomery) form;
+// the reduction uses 2⁶⁴ ≡ 2³² - 1 (mod q). The generic Exp and Sqrt templates are
+// used along with it.
+const Goldilocks = `
+
+import (
+	"math/big"
+	"math/bits"
+	"io"
+	"crypto/rand"
+	"encoding/binary"
+	"strconv"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/field/hash"
+	"github.com/consensys/gnark-crypto/field/pool"
+	"github.com/bits-and-blooms/bitset"
+)
+
+// {{.ElementName}} represents a field element stored on 1 word (uint64)
+//
+// {{.ElementName}} are assumed to be in canonical form (i.e. not in Montgomery form) in all methods.
+//
+// Modulus q =
+//
+// 	q[base10] = {{.Modulus}}
+// 	q[base16] = 0x{{.ModulusHex}}
+//
+// Warning
+//
+// This code has not been audited and is provided as-is. In particular, there is no security guarantees such as constant time implementation or side-channel attack resistance.
+type {{.ElementName}} [1]uint64
+
+const (
+	Limbs = 1 		// number of 64 bits words needed to represent a {{.ElementName}}
+	Bits = {{.NbBits}} 		// number of bits needed to represent a {{.ElementName}}
+	Bytes = {{.NbBytes}} 	// number of bytes needed to represent a {{.ElementName}}
+)
+
+// Field modulus q
+const (
+	q0 uint64 = {{index .Q 0}}
+	q uint64 = q0
+)
+
+// epsilon = 2⁶⁴ - q = 2³² - 1, i.e. 2⁶⁴ ≡ epsilon (mod q)
+const epsilon uint64 = 0xffffffff
+
+var q{{.ElementName}} = {{.ElementName}}{
+	q0,
+}
+
+var _modulus big.Int // q stored as big.Int
+
+// Modulus returns q as a big.Int
+//
+// 	q[base10] = {{.Modulus}}
+// 	q[base16] = 0x{{.ModulusHex}}
+func Modulus() *big.Int {
+	return new(big.Int).Set(&_modulus)
+}
+
+func init() {
+	_modulus.SetString("{{.ModulusHex}}", 16)
+}
+
+// New{{.ElementName}} returns a new {{.ElementName}} from a uint64 value
+//
+// it is equivalent to
+// 		var v {{.ElementName}}
+// 		v.SetUint64(...)
+func New{{.ElementName}}(v uint64) {{.ElementName}} {
+	z := {{.ElementName}}{v}
+	reduce(&z)
+	return z
+}
+
+// SetUint64 sets z to v and returns z
+func (z *{{.ElementName}}) SetUint64(v uint64) *{{.ElementName}} {
+	// v < 2⁶⁴ < 2q so a single conditional subtraction is enough
+	*z = {{.ElementName}}{v}
+	reduce(z)
+	return z
+}
+
+// SetInt64 sets z to v and returns z
+func (z *{{.ElementName}}) SetInt64(v int64) *{{.ElementName}} {
+
+	// absolute value of v
+	m := v >> 63
+	z.SetUint64(uint64((v ^ m) - m))
+
+	if m != 0 {
+		// v is negative
+		z.Neg(z)
+	}
+
+	return z
+}
+
+// Set z = x and returns z
+func (z *{{.ElementName}}) Set(x *{{.ElementName}}) *{{.ElementName}} {
+	z[0] = x[0]
+	return z
+}
+
+// SetInterface converts provided interface into {{.ElementName}}
+// returns an error if provided type is not supported
+// supported types:
+//  {{.ElementName}}
+//  *{{.ElementName}}
+//  uint64
+//  int
+//  string (see SetString for valid formats)
+//  *big.Int
+//  big.Int
+//  []byte
+func (z *{{.ElementName}}) SetInterface(i1 interface{}) (*{{.ElementName}}, error) {
+	if i1 == nil {
+		return nil, errors.New("can't set {{.PackageName}}.{{.ElementName}} with <nil>")
+	}
+
+	switch c1 := i1.(type) {
+	case {{.ElementName}}:
+		return z.Set(&c1), nil
+	case *{{.ElementName}}:
+		if c1 == nil {
+			return nil, errors.New("can't set {{.PackageName}}.{{.ElementName}} with <nil>")
+		}
+		return z.Set(c1), nil
+	case uint8:
+		return z.SetUint64(uint64(c1)), nil
+	case uint16:
+		return z.SetUint64(uint64(c1)), nil
+	case uint32:
+		return z.SetUint64(uint64(c1)), nil
+	case uint:
+		return z.SetUint64(uint64(c1)), nil
+	case uint64:
+		return z.SetUint64(c1), nil
+	case int8:
+		return z.SetInt64(int64(c1)), nil
+	case int16:
+		return z.SetInt64(int64(c1)), nil
+	case int32:
+		return z.SetInt64(int64(c1)), nil
+	case int64:
+		return z.SetInt64(c1), nil
+	case int:
+		return z.SetInt64(int64(c1)), nil
+	case string:
+		return z.SetString(c1)
+	case *big.Int:
+		if c1 == nil {
+			return nil, errors.New("can't set {{.PackageName}}.{{.ElementName}} with <nil>")
+		}
+		return z.SetBigInt(c1), nil
+	case big.Int:
+		return z.SetBigInt(&c1), nil
+	case []byte:
+		return z.SetBytes(c1), nil
+	default:
+		return nil, errors.New("can't set {{.PackageName}}.{{.ElementName}} from type " + reflect.TypeOf(i1).String())
+	}
+}
+
+// SetZero z = 0
+func (z *{{.ElementName}}) SetZero() *{{.ElementName}} {
+	z[0] = 0
+	return z
+}
+
+// SetOne z = 1
+func (z *{{.ElementName}}) SetOne() *{{.ElementName}} {
+	z[0] = 1
+	return z
+}
+
+// Div z = x*y⁻¹ (mod q)
+func (z *{{.ElementName}}) Div(x, y *{{.ElementName}}) *{{.ElementName}} {
+	var yInv {{.ElementName}}
+	yInv.Inverse(y)
+	z.Mul(x, &yInv)
+	return z
+}
+
+// Equal returns z == x; constant-time
+func (z *{{.ElementName}}) Equal(x *{{.ElementName}}) bool {
+	return z.NotEqual(x) == 0
+}
+
+// NotEqual returns 0 if and only if z == x; constant-time
+func (z *{{.ElementName}}) NotEqual(x *{{.ElementName}}) uint64 {
+	return z[0] ^ x[0]
+}
+
+// IsZero returns z == 0
+func (z *{{.ElementName}}) IsZero() bool {
+	return z[0] == 0
+}
+
+// IsOne returns z == 1
+func (z *{{.ElementName}}) IsOne() bool {
+	return z[0] == 1
+}
+
+// IsUint64 reports whether z can be represented as an uint64.
+func (z *{{.ElementName}}) IsUint64() bool {
+	return true
+}
+
+// Uint64 returns the uint64 representation of x. If x cannot be represented in a uint64, the result is undefined.
+func (z *{{.ElementName}}) Uint64() uint64 {
+	return z[0]
+}
+
+// FitsOnOneWord reports whether z words (except the least significant word) are 0
+func (z *{{.ElementName}}) FitsOnOneWord() bool {
+	return true
+}
+
+// Cmp compares (lexicographic order) z and x and returns:
+//
+//   -1 if z <  x
+//    0 if z == x
+//   +1 if z >  x
+//
+func (z *{{.ElementName}}) Cmp(x *{{.ElementName}}) int {
+	if z[0] > x[0] {
+		return 1
+	} else if z[0] < x[0] {
+		return -1
+	}
+	return 0
+}
+
+// LexicographicallyLargest returns true if this element is strictly lexicographically
+// larger than its negation, false otherwise
+func (z *{{.ElementName}}) LexicographicallyLargest() bool {
+	// we check if the element is larger than (q-1) / 2
+	return z[0] >= {{index .QMinusOneHalvedP 0}}
+}
+
+// SetRandom sets z to a uniform random value in [0, q).
+//
+// This might error only if reading from crypto/rand.Reader errors,
+// in which case, value of z is undefined.
+func (z *{{.ElementName}}) SetRandom() (*{{.ElementName}}, error) {
+	// this code is derived from go/src/crypto/rand/util.go
+	// q is close to 2⁶⁴, so a candidate is rejected with probability < 2⁻³¹.
+	var bytes [8]byte
+
+	for {
+		if _, err := io.ReadFull(rand.Reader, bytes[:]); err != nil {
+			return nil, err
+		}
+
+		z[0] = binary.LittleEndian.Uint64(bytes[:])
+
+		if !z.smallerThanModulus() {
+			continue // ignore the candidate and re-sample
+		}
+
+		return z, nil
+	}
+}
+
+// smallerThanModulus returns true if z < q
+// This is not constant time
+func (z *{{.ElementName}}) smallerThanModulus() bool {
+	return z[0] < q
+}
+
+// One returns 1
+func One() {{.ElementName}} {
+	var one {{.ElementName}}
+	one.SetOne()
+	return one
+}
+
+// Halve sets z to z / 2 (mod q)
+func (z *{{.ElementName}}) Halve() {
+	z[0] = halve(z[0])
+}
+
+// Add z = x + y (mod q)
+func (z *{{.ElementName}}) Add(x, y *{{.ElementName}}) *{{.ElementName}} {
+	// branch-free: the operands are random-looking in most use cases
+	s, carry := bits.Add64(x[0], y[0], 0)
+	// if the sum overflowed, x + y - 2⁶⁴ < q - 2³³ and adding 2⁶⁴ mod q doesn't overflow
+	s += epsilon & -carry
+	// if s ⩾ q → s -= q
+	r, borrow := bits.Sub64(s, q, 0)
+	z[0] = r + (q & -borrow)
+	return z
+}
+
+// Double z = x + x (mod q), aka Lsh 1
+func (z *{{.ElementName}}) Double(x *{{.ElementName}}) *{{.ElementName}} {
+	return z.Add(x, x)
+}
+
+// Sub z = x - y (mod q)
+func (z *{{.ElementName}}) Sub(x, y *{{.ElementName}}) *{{.ElementName}} {
+	d, b := bits.Sub64(x[0], y[0], 0)
+	// if x < y, d = x - y + 2⁶⁴ and we want x - y + q
+	z[0] = d - (epsilon & -b)
+	return z
+}
+
+// Neg z = q - x
+func (z *{{.ElementName}}) Neg(x *{{.ElementName}}) *{{.ElementName}} {
+	if x.IsZero() {
+		z.SetZero()
+		return z
+	}
+	z[0] = q - x[0]
+	return z
+}
+
+// Select is a constant-time conditional move.
+// If c=0, z = x0. Else z = x1
+func (z *{{.ElementName}}) Select(c int, x0 *{{.ElementName}}, x1 *{{.ElementName}}) *{{.ElementName}} {
+	cC := uint64((int64(c) | -int64(c)) >> 63) // "canonicized" into: 0 if c=0, -1 otherwise
+	z[0] = x0[0] ^ cC&(x0[0]^x1[0])
+	return z
+}
+
+// Mul z = x * y (mod q)
+func (z *{{.ElementName}}) Mul(x, y *{{.ElementName}}) *{{.ElementName}} {
+	hi, lo := bits.Mul64(x[0], y[0])
+	z[0] = reduce128(hi, lo)
+	return z
+}
+
+// Square z = x * x (mod q)
+func (z *{{.ElementName}}) Square(x *{{.ElementName}}) *{{.ElementName}} {
+	hi, lo := bits.Mul64(x[0], x[0])
+	z[0] = reduce128(hi, lo)
+	return z
+}
+
+// reduce128 returns hi·2⁶⁴ + lo (mod q), for hi < q.
+//
+// Writing hi = hi₁·2³² + hi₀, since 2⁶⁴ ≡ 2³² - 1 and 2⁹⁶ ≡ -1 (mod q):
+// hi·2⁶⁴ + lo ≡ lo - hi₁ + hi₀·(2³² - 1) (mod q)
+func reduce128(hi, lo uint64) uint64 {
+	hi1 := hi >> 32
+	hi0 := hi & epsilon
+
+	t, b := bits.Sub64(lo, hi1, 0)
+	if b != 0 {
+		// t = lo - hi₁ + 2⁶⁴ ⩾ 2⁶⁴ - 2³², we want lo - hi₁ + q
+		t -= epsilon
+	}
+
+	// hi₀·(2³² - 1) < 2⁶⁴
+	var c uint64
+	t, c = bits.Add64(t, (hi0<<32)-hi0, 0)
+	if c != 0 {
+		// t < (2³² - 1)² so adding 2⁶⁴ mod q doesn't overflow
+		t += epsilon
+	}
+	if t >= q {
+		t -= q
+	}
+	return t
+}
+
+// MulBy3 x *= 3 (mod q)
+func MulBy3(x *{{.ElementName}}) {
+	var y {{.ElementName}}
+	y.Double(x)
+	x.Add(x, &y)
+}
+
+// MulBy5 x *= 5 (mod q)
+func MulBy5(x *{{.ElementName}}) {
+	var y {{.ElementName}}
+	y.Double(x)
+	y.Double(&y)
+	x.Add(x, &y)
+}
+
+// MulBy13 x *= 13 (mod q)
+func MulBy13(x *{{.ElementName}}) {
+	var y = {{.ElementName}}{
+		13,
+	}
+	x.Mul(x, &y)
+}
+
+// Butterfly sets
+//  a = a + b (mod q)
+//  b = a - b (mod q)
+func Butterfly(a, b *{{.ElementName}}) {
+	_butterflyGeneric(a, b)
+}
+
+func reduce(z *{{.ElementName}}) {
+	_reduceGeneric(z)
+}
+
+func addVec(res, a, b Vector) {
+	addVecGeneric(res, a, b)
+}
+
+func subVec(res, a, b Vector) {
+	subVecGeneric(res, a, b)
+}
+
+func scalarMulVec(res, a Vector, b *{{.ElementName}}) {
+	scalarMulVecGeneric(res, a, b)
+}
+
+func mulVec(res, a, b Vector) {
+	mulVecGeneric(res, a, b)
+}
+
+func butterflyVec(a, b Vector) {
+	butterflyVecGeneric(a, b)
+}
+
+// _mulGeneric is the reference multiplication, used for testing purposes.
+func _mulGeneric(z, x, y *{{.ElementName}}) {
+	// same as Mul, but uses a 128-bit division for the reduction
+	hi, lo := bits.Mul64(x[0], y[0])
+	_, z[0] = bits.Div64(hi, lo, q)
+}
+
+func _reduceGeneric(z *{{.ElementName}})  {
+	// if z ⩾ q → z -= q
+	if !z.smallerThanModulus() {
+		z[0] -= q
+	}
+}
+
+func _butterflyGeneric(a, b *{{.ElementName}}) {
+	t := *a
+	a.Add(a, b)
+	b.Sub(&t, b)
+}
+
+// BatchInvert returns a new slice with every element inverted.
+// Uses Montgomery batch inversion trick
+func BatchInvert(a []{{.ElementName}}) []{{.ElementName}} {
+	res := make([]{{.ElementName}}, len(a))
+	if len(a) == 0 {
+		return res
+	}
+
+	zeroes := bitset.New(uint(len(a)))
+	accumulator := One()
+
+	for i:=0; i < len(a); i++ {
+		if a[i].IsZero() {
+			zeroes.Set(uint(i))
+			continue
+		}
+		res[i] = accumulator
+		accumulator.Mul(&accumulator, &a[i])
+	}
+
+	accumulator.Inverse(&accumulator)
+
+	for i := len(a) - 1; i >= 0; i-- {
+		if zeroes.Test(uint(i)) {
+			continue
+		}
+		res[i].Mul(&res[i], &accumulator)
+		accumulator.Mul(&accumulator, &a[i])
+	}
+
+	return res
+}
+
+// BitLen returns the minimum number of bits needed to represent z
+// returns 0 if z == 0
+func (z *{{.ElementName}}) BitLen() int {
+	return bits.Len64(z[0])
+}
+
+// Hash msg to count prime field elements.
+// https://tools.ietf.org/html/draft-irtf-cfrg-hash-to-curve-06#section-5.2
+func Hash(msg, dst []byte, count int) ([]{{.ElementName}}, error) {
+	// 128 bits of security
+	// L = ceil((ceil(log2(p)) + k) / 8), where k is the security parameter = 128
+	const Bytes = 1 + (Bits-1)/8
+	const L = 16 + Bytes
+
+	lenInBytes := count * L
+	pseudoRandomBytes, err := hash.ExpandMsgXmd(msg, dst, lenInBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// get temporary big int from the pool
+	vv := pool.BigInt.Get()
+
+	res := make([]{{.ElementName}}, count)
+	for i := 0; i < count; i++ {
+		vv.SetBytes(pseudoRandomBytes[i*L : (i+1)*L])
+		res[i].SetBigInt(vv)
+	}
+
+	// release object into pool
+	pool.BigInt.Put(vv)
+
+	return res, nil
+}
+
+// String returns the decimal representation of z as generated by
+// z.Text(10).
+func (z *{{.ElementName}}) String() string {
+	return z.Text(10)
+}
+
+// Text returns the string representation of z in the given base.
+// Base must be between 2 and 36, inclusive. The result uses the
+// lower-case letters 'a' to 'z' for digit values 10 to 35.
+// No prefix (such as "0x") is added to the string. If z is a nil
+// pointer it returns "<nil>".
+// If base == 10 and -z fits in a uint16 prefix "-" is added to the string.
+func (z *{{.ElementName}}) Text(base int) string {
+	if base < 2 || base > 36 {
+		panic("invalid base")
+	}
+	if z == nil {
+		return "<nil>"
+	}
+
+	const maxUint16 = 65535
+	if base == 10 {
+		var zzNeg {{.ElementName}}
+		zzNeg.Neg(z)
+		if zzNeg[0] <= maxUint16 && zzNeg[0] != 0 {
+			return "-" + strconv.FormatUint(zzNeg[0], base)
+		}
+	}
+	return strconv.FormatUint(z[0], base)
+}
+
+// BigInt sets and return z as a *big.Int
+func (z *{{.ElementName}}) BigInt(res *big.Int) *big.Int {
+	return res.SetUint64(z[0])
+}
+
+// ToBigIntRegular returns z as a big.Int in regular form
+//
+// Deprecated: use BigInt(*big.Int) instead
+func (z {{.ElementName}}) ToBigIntRegular(res *big.Int) *big.Int {
+	return z.BigInt(res)
+}
+
+// Bits provides access to z by returning its value as a little-endian [1]uint64 array.
+// Bits is intended to support implementation of missing low-level {{.ElementName}}
+// functionality outside this package; it should be avoided otherwise.
+func (z *{{.ElementName}}) Bits() [1]uint64 {
+	return *z
+}
+
+// Bytes returns the value of z as a big-endian byte array
+func (z *{{.ElementName}}) Bytes() (res [Bytes]byte) {
+	BigEndian.PutElement(&res, *z)
+	return
+}
+
+// Marshal returns the value of z as a big-endian byte slice
+func (z *{{.ElementName}}) Marshal() []byte {
+	b := z.Bytes()
+	return b[:]
+}
+
+// Unmarshal is an alias for SetBytes, it sets z to the value of e.
+func (z *{{.ElementName}}) Unmarshal(e []byte) {
+	z.SetBytes(e)
+}
+
+// SetBytes interprets e as the bytes of a big-endian unsigned integer,
+// sets z to that value, and returns z.
+func (z *{{.ElementName}}) SetBytes(e []byte) *{{.ElementName}} {
+	if len(e) == Bytes {
+		// fast path
+		v, err := BigEndian.Element((*[Bytes]byte)(e))
+		if err == nil {
+			*z = v
+			return z
+		}
+	}
+
+	// slow path.
+	// get a big int from our pool
+	vv := pool.BigInt.Get()
+	vv.SetBytes(e)
+
+	// set big int
+	z.SetBigInt(vv)
+
+	// put temporary object back in pool
+	pool.BigInt.Put(vv)
+
+	return z
+}
+
+// SetBytesCanonical interprets e as the bytes of a big-endian {{.NbBytes}}-byte integer.
+// If e is not a {{.NbBytes}}-byte slice or encodes a value higher than q,
+// SetBytesCanonical returns an error.
+func (z *{{.ElementName}}) SetBytesCanonical(e []byte) error {
+	if len(e) != Bytes {
+		return errors.New("invalid {{.PackageName}}.{{.ElementName}} encoding")
+	}
+	v, err := BigEndian.Element((*[Bytes]byte)(e))
+	if err != nil {
+		return err
+	}
+	*z = v
+	return nil
+}
+
+// SetBigInt sets z to v and returns z
+func (z *{{.ElementName}}) SetBigInt(v *big.Int) *{{.ElementName}} {
+	z.SetZero()
+
+	var zero big.Int
+
+	// fast path
+	c := v.Cmp(&_modulus)
+	if c == 0 {
+		// v == 0
+		return z
+	} else if c != 1 && v.Cmp(&zero) != -1 {
+		// 0 < v < q
+		return z.setBigInt(v)
+	}
+
+	// get temporary big int from the pool
+	vv := pool.BigInt.Get()
+
+	// copy input + modular reduction
+	vv.Mod(v, &_modulus)
+
+	// set big int byte value
+	z.setBigInt(vv)
+
+	// release object into pool
+	pool.BigInt.Put(vv)
+	return z
+}
+
+// setBigInt assumes 0 ⩽ v < q
+func (z *{{.ElementName}}) setBigInt(v *big.Int) *{{.ElementName}} {
+	z[0] = v.Uint64()
+	return z
+}
+
+// SetString creates a big.Int with number and calls SetBigInt on z
+//
+// The number prefix determines the actual base: A prefix of
+// ''0b'' or ''0B'' selects base 2, ''0'', ''0o'' or ''0O'' selects base 8,
+// and ''0x'' or ''0X'' selects base 16. Otherwise, the selected base is 10
+// and no prefix is accepted.
+//
+// For base 16, lower and upper case letters are considered the same:
+// The letters 'a' to 'f' and 'A' to 'F' represent digit values 10 to 15.
+//
+// An underscore character ''_'' may appear between a base
+// prefix and an adjacent digit, and between successive digits; such
+// underscores do not change the value of the number.
+// Incorrect placement of underscores is reported as a panic if there
+// are no other errors.
+//
+// If the number is invalid this method leaves z unchanged and returns nil, error.
+func (z *{{.ElementName}}) SetString(number string) (*{{.ElementName}}, error) {
+	// get temporary big int from the pool
+	vv := pool.BigInt.Get()
+
+	if _, ok := vv.SetString(number, 0); !ok {
+		return nil, errors.New("{{.ElementName}}.SetString failed -> can't parse number into a big.Int " + number)
+	}
+
+	z.SetBigInt(vv)
+
+	// release object into pool
+	pool.BigInt.Put(vv)
+
+	return z, nil
+}
+
+// MarshalJSON returns json encoding of z (z.Text(10))
+// If z == nil, returns null
+func (z *{{.ElementName}}) MarshalJSON() ([]byte, error) {
+	if z == nil {
+		return []byte("null"), nil
+	}
+	const maxSafeBound = 15 // we encode it as number if it's small
+	s := z.Text(10)
+	if len(s) <= maxSafeBound {
+		return []byte(s), nil
+	}
+	var sbb strings.Builder
+	sbb.WriteByte('"')
+	sbb.WriteString(s)
+	sbb.WriteByte('"')
+	return []byte(sbb.String()), nil
+}
+
+// UnmarshalJSON accepts numbers and strings as input
+// See {{.ElementName}}.SetString for valid prefixes (0x, 0b, ...)
+func (z *{{.ElementName}}) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if len(s) > Bits*3 {
+		return errors.New("value too large (max = {{.ElementName}}.Bits * 3)")
+	}
+
+	// we accept numbers and strings, remove leading and trailing quotes if any
+	if len(s) > 0 && s[0] == '"' {
+		s = s[1:]
+	}
+	if len(s) > 0 && s[len(s)-1] == '"' {
+		s = s[:len(s)-1]
+	}
+
+	// get temporary big int from the pool
+	vv := pool.BigInt.Get()
+
+	if _, ok := vv.SetString(s, 0); !ok {
+		return errors.New("can't parse into a big.Int: " + s)
+	}
+
+	z.SetBigInt(vv)
+
+	// release object into pool
+	pool.BigInt.Put(vv)
+	return nil
+}
+
+// A ByteOrder specifies how to convert byte slices into a {{.ElementName}}
+type ByteOrder interface {
+	Element(*[Bytes]byte) ({{.ElementName}}, error)
+	PutElement(*[Bytes]byte, {{.ElementName}})
+	String() string
+}
+
+// BigEndian is the big-endian implementation of ByteOrder and AppendByteOrder.
+var BigEndian bigEndian
+
+type bigEndian struct{}
+
+// Element interpret b is a big-endian {{.NbBytes}}-byte slice.
+// If b encodes a value higher than q, Element returns error.
+func (bigEndian) Element(b *[Bytes]byte) ({{.ElementName}}, error) {
+	var z {{.ElementName}}
+	z[0] = binary.BigEndian.Uint64((*b)[0:8])
+
+	if !z.smallerThanModulus() {
+		return {{.ElementName}}{}, errors.New("invalid {{.PackageName}}.{{.ElementName}} encoding")
+	}
+
+	return z, nil
+}
+
+func (bigEndian) PutElement(b *[Bytes]byte, e {{.ElementName}}) {
+	binary.BigEndian.PutUint64((*b)[0:8], e[0])
+}
+
+func (bigEndian) String() string { return "BigEndian" }
+
+// LittleEndian is the little-endian implementation of ByteOrder and AppendByteOrder.
+var LittleEndian littleEndian
+
+type littleEndian struct{}
+
+func (littleEndian) Element(b *[Bytes]byte) ({{.ElementName}}, error) {
+	var z {{.ElementName}}
+	z[0] = binary.LittleEndian.Uint64((*b)[0:8])
+
+	if !z.smallerThanModulus() {
+		return {{.ElementName}}{}, errors.New("invalid {{.PackageName}}.{{.ElementName}} encoding")
+	}
+
+	return z, nil
+}
+
+func (littleEndian) PutElement(b *[Bytes]byte, e {{.ElementName}}) {
+	binary.LittleEndian.PutUint64((*b)[0:8], e[0])
+}
+
+func (littleEndian) String() string { return "LittleEndian" }
+
+// Inverse z = x⁻¹ (mod q)
+//
+// if x == 0, sets and returns z = x
+func (z *{{.ElementName}}) Inverse(x *{{.ElementName}}) *{{.ElementName}} {
+	// Algorithm 16 in "Efficient Software-Implementation of Finite Fields with Applications to Cryptography"
+	// (binary extended Euclid, without the Montgomery correction)
+	if x.IsZero() {
+		z.SetZero()
+		return z
+	}
+
+	var r, s, u, v uint64
+	u = q
+	s = 1
+	r = 0
+	v = x[0]
+
+	for (u != 1) && (v != 1) {
+		for v&1 == 0 {
+			v >>= 1
+			s = halve(s)
+		}
+		for u&1 == 0 {
+			u >>= 1
+			r = halve(r)
+		}
+		if v >= u {
+			v -= u
+			if s >= r {
+				s -= r
+			} else {
+				s = q - (r - s)
+			}
+		} else {
+			u -= v
+			if r >= s {
+				r -= s
+			} else {
+				r = q - (s - r)
+			}
+		}
+	}
+
+	if u == 1 {
+		z[0] = r
+	} else {
+		z[0] = s
+	}
+
+	return z
+}
+
+// halve returns a / 2 (mod q), for a < q
+func halve(a uint64) uint64 {
+	if a&1 == 0 {
+		return a >> 1
+	}
+	a, carry := bits.Add64(a, q, 0)
+	return a>>1 | carry<<63
+}
+
+`
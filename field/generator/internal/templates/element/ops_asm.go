@@ -40,6 +40,93 @@ func (z *{{.ElementName}}) Mul(x, y *{{.ElementName}}) *{{.ElementName}} {
 	return z
 }
 
+{{- if eq .NbWords 4}}
+
+//go:noescape
+func addVecAsm(res, a, b *{{.ElementName}}, n uint64)
+
+//go:noescape
+func subVecAsm(res, a, b *{{.ElementName}}, n uint64)
+
+//go:noescape
+func scalarMulVecAsm(res, a, b *{{.ElementName}}, n uint64)
+
+//go:noescape
+func mulVecAsm(res, a, b *{{.ElementName}}, n uint64)
+
+//go:noescape
+func butterflyVecAsm(a, b *{{.ElementName}}, n uint64)
+
+// addVec sets res = a + b element-wise; the vectors have the same length
+func addVec(res, a, b Vector) {
+	if len(a) == 0 {
+		return
+	}
+	addVecAsm(&res[0], &a[0], &b[0], uint64(len(a)))
+}
+
+// subVec sets res = a - b element-wise; the vectors have the same length
+func subVec(res, a, b Vector) {
+	if len(a) == 0 {
+		return
+	}
+	subVecAsm(&res[0], &a[0], &b[0], uint64(len(a)))
+}
+
+// scalarMulVec sets res = a * b element-wise; the vectors have the same length
+func scalarMulVec(res, a Vector, b *{{.ElementName}}) {
+	if len(a) == 0 {
+		return
+	}
+	if !supportAdx {
+		scalarMulVecGeneric(res, a, b)
+		return
+	}
+	scalarMulVecAsm(&res[0], &a[0], b, uint64(len(a)))
+}
+
+// mulVec sets res = a * b element-wise; the vectors have the same length
+func mulVec(res, a, b Vector) {
+	if len(a) == 0 {
+		return
+	}
+	if !supportAdx {
+		mulVecGeneric(res, a, b)
+		return
+	}
+	mulVecAsm(&res[0], &a[0], &b[0], uint64(len(a)))
+}
+
+// butterflyVec sets a, b = a + b, a - b element-wise; the vectors have the same length
+func butterflyVec(a, b Vector) {
+	if len(a) == 0 {
+		return
+	}
+	butterflyVecAsm(&a[0], &b[0], uint64(len(a)))
+}
+{{- else}}
+
+func addVec(res, a, b Vector) {
+	addVecGeneric(res, a, b)
+}
+
+func subVec(res, a, b Vector) {
+	subVecGeneric(res, a, b)
+}
+
+func scalarMulVec(res, a Vector, b *{{.ElementName}}) {
+	scalarMulVecGeneric(res, a, b)
+}
+
+func mulVec(res, a, b Vector) {
+	mulVecGeneric(res, a, b)
+}
+
+func butterflyVec(a, b Vector) {
+	butterflyVecGeneric(a, b)
+}
+{{- end}}
+
 // Square z = x * x (mod q)
 //
 // x must be less than q
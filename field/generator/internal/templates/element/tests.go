@@ -7,7 +7,9 @@ import (
 	"crypto/rand"
 	"encoding/json"
 	"math/big"
+	{{- if not .F31}}
 	"math/bits"
+	{{- end}}
 	"fmt"
 	{{if .UsingP20Inverse}} 
 	mrand "math/rand" 
@@ -169,6 +171,8 @@ func Benchmark{{toTitle .ElementName}}Div(b *testing.B) {
 }
 
 
+{{- if not .Goldilocks}}
+
 func Benchmark{{toTitle .ElementName}}FromMont(b *testing.B) {
 	benchRes{{.ElementName}}.SetRandom()
 	b.ResetTimer()
@@ -176,6 +180,7 @@ func Benchmark{{toTitle .ElementName}}FromMont(b *testing.B) {
 		benchRes{{.ElementName}}.fromMont()
 	}
 }
+{{- end}}
 
 func Benchmark{{toTitle .ElementName}}Square(b *testing.B) {
 	benchRes{{.ElementName}}.SetRandom()
@@ -328,7 +333,9 @@ var staticTestValues []{{.ElementName}}
 func init() {
 	staticTestValues = append(staticTestValues, {{.ElementName}}{}) // zero
 	staticTestValues = append(staticTestValues, One()) 				// one
+	{{- if not .Goldilocks}}
 	staticTestValues = append(staticTestValues, rSquare) 			// r²
+	{{- end}}
 	var e, one {{.ElementName}}
 	one.SetOne()
 	e.Sub(&q{{.ElementName}}, &one)
@@ -647,7 +654,7 @@ func Test{{toTitle .ElementName}}BitLen(t *testing.T) {
 
 	properties.Property("BitLen should output same result than big.Int.BitLen", prop.ForAll(
 		func(a testPair{{.ElementName}}) bool {
-			return a.element.fromMont().BitLen() ==  a.bigint.BitLen()
+			return a.element{{if not .Goldilocks}}.fromMont(){{end}}.BitLen() ==  a.bigint.BitLen()
 		},
 		genA,
 	))
@@ -1470,6 +1477,8 @@ func Test{{toTitle .ElementName}}BatchInvert(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+{{- if not .Goldilocks}}
+
 func Test{{toTitle .ElementName}}FromMont(t *testing.T) {
 
 	t.Parallel()
@@ -1507,6 +1516,7 @@ func Test{{toTitle .ElementName}}FromMont(t *testing.T) {
 
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
+{{- end}}
 
 
 
@@ -1575,6 +1585,11 @@ func gen() gopter.Gen {
 	return func(genParams *gopter.GenParameters) *gopter.GenResult {
 		var g testPair{{.ElementName}}
 
+		{{if .F31 -}}
+		g.element = {{.ElementName}}{
+			uint32(genParams.NextUint64() % uint64(q)),
+		}
+		{{- else -}}
 		g.element = {{.ElementName}}{
 			{{- range $i := .NbWordsIndexesFull}}
 			genParams.NextUint64(),{{end}}
@@ -1593,6 +1608,7 @@ func gen() gopter.Gen {
 				g.element[{{.NbWordsLastIndex}}] %= (q{{.ElementName}}[{{.NbWordsLastIndex}}] +1 )
 			}
 		}
+		{{- end}}
 
 		g.element.BigInt(&g.bigint)
 		genResult := gopter.NewGenResult(g, gopter.NoShrinker)
@@ -1603,6 +1619,12 @@ func gen() gopter.Gen {
 
 func genFull() gopter.Gen {
 	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		{{- if .F31}}
+		// a random element in [q, 2q), i.e. not reduced
+		a := {{.ElementName}}{
+			uint32(genParams.NextUint64() % uint64(q)) + q,
+		}
+		{{- else}}
 
 		genRandomFq := func() {{.ElementName}} {
 			var g {{.ElementName}}
@@ -1638,6 +1660,7 @@ func genFull() gopter.Gen {
 			a[{{$i}}], carry = bits.Add64(a[{{$i}}], q{{$.ElementName}}[{{$i}}], carry)
 			{{- end}}
 		{{- end}}
+		{{- end}}
 		
 		genResult := gopter.NewGenResult(a, gopter.NoShrinker)
 		return genResult
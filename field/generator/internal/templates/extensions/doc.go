@@ -0,0 +1,20 @@
+package extensions
+
+const Doc = `
+// Package {{.Package}} provides small degree extensions of {{.Base.PackageName}}:
+//
+//	E2 = 𝔽p[u]/(u² - ({{.E2NonResidue}}))
+{{- if .E3NonResidue}}
+//	E3 = 𝔽p[w]/(w³ - {{.E3NonResidue}})
+{{- end}}
+//	E4 = E2[v]/(v² - γ), γ = {{if and (eq (index .E4NonResidue 0) 0) (eq (index .E4NonResidue 1) 1)}}u{{else}}{{index .E4NonResidue 0}} + {{index .E4NonResidue 1}}·u{{end}}
+//
+// The base field is too small to provide enough soundness when the verifier's
+// challenges (Fiat-Shamir) are sampled from it; protocols such as FRI or sumcheck
+// sample them from one of these extensions instead.
+//
+// # Warning
+//
+// This code has not been audited and is provided as-is. In particular, there is no security guarantees such as constant time implementation or side-channel attack resistance.
+package {{.Package}}
+`
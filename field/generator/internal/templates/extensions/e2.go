@@ -0,0 +1,355 @@
+package extensions
+
+const E2 = `
+import (
+	"errors"
+	"math/big"
+
+	fr "{{.BasePackagePath}}"
+)
+
+// E2 is a degree two extension of {{.Base.PackageName}}: 𝔽p[u]/(u² - ({{.E2NonResidue}}))
+type E2 struct {
+	A0, A1 fr.Element
+}
+
+// BytesE2 number of bytes needed to represent an E2
+const BytesE2 = 2 * fr.Bytes
+
+// Equal returns true if z equals x, false otherwise
+func (z *E2) Equal(x *E2) bool {
+	return z.A0.Equal(&x.A0) && z.A1.Equal(&x.A1)
+}
+
+// IsZero returns true if z equals 0, false otherwise
+func (z *E2) IsZero() bool {
+	return z.A0.IsZero() && z.A1.IsZero()
+}
+
+// IsOne returns true if z equals 1, false otherwise
+func (z *E2) IsOne() bool {
+	return z.A0.IsOne() && z.A1.IsZero()
+}
+
+// SetZero sets z to 0 and returns z
+func (z *E2) SetZero() *E2 {
+	z.A0.SetZero()
+	z.A1.SetZero()
+	return z
+}
+
+// SetOne sets z to 1 and returns z
+func (z *E2) SetOne() *E2 {
+	z.A0.SetOne()
+	z.A1.SetZero()
+	return z
+}
+
+// Set sets z to x and returns z
+func (z *E2) Set(x *E2) *E2 {
+	z.A0 = x.A0
+	z.A1 = x.A1
+	return z
+}
+
+// SetInt64 sets z to v (mod p) and returns z
+func (z *E2) SetInt64(v int64) *E2 {
+	z.A0.SetInt64(v)
+	z.A1.SetZero()
+	return z
+}
+
+// SetUint64 sets z to v (mod p) and returns z
+func (z *E2) SetUint64(v uint64) *E2 {
+	z.A0.SetUint64(v)
+	z.A1.SetZero()
+	return z
+}
+
+// Lift sets z to x ∈ 𝔽p and returns z
+func (z *E2) Lift(x *fr.Element) *E2 {
+	z.A0 = *x
+	z.A1.SetZero()
+	return z
+}
+
+// SetInterface converts provided interface into E2. The interface can be an E2, a *E2,
+// or any value accepted by fr.Element.SetInterface, which is then lifted to E2
+func (z *E2) SetInterface(i1 interface{}) (*E2, error) {
+	switch c1 := i1.(type) {
+	case E2:
+		return z.Set(&c1), nil
+	case *E2:
+		if c1 == nil {
+			return nil, errors.New("can't set extensions.E2 with <nil>")
+		}
+		return z.Set(c1), nil
+	default:
+		var a fr.Element
+		if _, err := a.SetInterface(i1); err != nil {
+			return nil, err
+		}
+		return z.Lift(&a), nil
+	}
+}
+
+// SetRandom sets z to a uniform random value and returns z
+func (z *E2) SetRandom() (*E2, error) {
+	if _, err := z.A0.SetRandom(); err != nil {
+		return nil, err
+	}
+	if _, err := z.A1.SetRandom(); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
+
+// Add sets z = x + y and returns z
+func (z *E2) Add(x, y *E2) *E2 {
+	z.A0.Add(&x.A0, &y.A0)
+	z.A1.Add(&x.A1, &y.A1)
+	return z
+}
+
+// Sub sets z = x - y and returns z
+func (z *E2) Sub(x, y *E2) *E2 {
+	z.A0.Sub(&x.A0, &y.A0)
+	z.A1.Sub(&x.A1, &y.A1)
+	return z
+}
+
+// Double sets z = 2x and returns z
+func (z *E2) Double(x *E2) *E2 {
+	z.A0.Double(&x.A0)
+	z.A1.Double(&x.A1)
+	return z
+}
+
+// Neg sets z = -x and returns z
+func (z *E2) Neg(x *E2) *E2 {
+	z.A0.Neg(&x.A0)
+	z.A1.Neg(&x.A1)
+	return z
+}
+
+// Conjugate sets z = a₀ - a₁u where x = a₀ + a₁u, and returns z
+func (z *E2) Conjugate(x *E2) *E2 {
+	z.A0 = x.A0
+	z.A1.Neg(&x.A1)
+	return z
+}
+
+// MulByElement sets z = x·y with y ∈ 𝔽p and returns z
+func (z *E2) MulByElement(x *E2, y *fr.Element) *E2 {
+	var yCopy fr.Element
+	yCopy.Set(y)
+	z.A0.Mul(&x.A0, &yCopy)
+	z.A1.Mul(&x.A1, &yCopy)
+	return z
+}
+
+// MulByNonResidue sets z = u·x and returns z
+func (z *E2) MulByNonResidue(x *E2) *E2 {
+	a := x.A0
+	mulByNonResidueE2(&z.A0, &x.A1)
+	z.A1 = a
+	return z
+}
+
+// Mul sets z = x·y and returns z
+func (z *E2) Mul(x, y *E2) *E2 {
+	var a, b, c fr.Element
+	a.Add(&x.A0, &x.A1)
+	b.Add(&y.A0, &y.A1)
+	a.Mul(&a, &b)
+	b.Mul(&x.A0, &y.A0)
+	c.Mul(&x.A1, &y.A1)
+	z.A1.Sub(&a, &b).Sub(&z.A1, &c)
+	mulByNonResidueE2(&c, &c)
+	z.A0.Add(&b, &c)
+	return z
+}
+
+// Square sets z = x² and returns z
+func (z *E2) Square(x *E2) *E2 {
+	var a, b fr.Element
+	a.Mul(&x.A0, &x.A1)
+	b.Square(&x.A1)
+	mulByNonResidueE2(&b, &b)
+	z.A0.Square(&x.A0).Add(&z.A0, &b)
+	z.A1.Double(&a)
+	return z
+}
+
+// Norm sets x to the norm of z, N(a₀ + a₁u) = a₀² - α₂a₁²
+func (z *E2) Norm(x *fr.Element) {
+	var tmp fr.Element
+	tmp.Square(&z.A1)
+	mulByNonResidueE2(&tmp, &tmp)
+	x.Square(&z.A0).Sub(x, &tmp)
+}
+
+// Inverse sets z = x⁻¹ and returns z
+//
+// if x == 0, sets and returns z = x
+func (z *E2) Inverse(x *E2) *E2 {
+	var t fr.Element
+	x.Norm(&t)
+	t.Inverse(&t)
+	z.A0.Mul(&x.A0, &t)
+	z.A1.Mul(&x.A1, &t).Neg(&z.A1)
+	return z
+}
+
+// Div sets z = x/y and returns z
+func (z *E2) Div(x, y *E2) *E2 {
+	var r E2
+	r.Inverse(y).Mul(x, &r)
+	return z.Set(&r)
+}
+
+// Exp sets z = xᵏ and returns z
+func (z *E2) Exp(x E2, k *big.Int) *E2 {
+	if k.IsUint64() && k.Uint64() == 0 {
+		return z.SetOne()
+	}
+
+	e := k
+	if k.Sign() == -1 {
+		// negative k, we invert
+		// if k < 0: xᵏ == (x⁻¹)⁻ᵏ
+		x.Inverse(&x)
+		e = new(big.Int).Neg(k)
+	}
+
+	z.SetOne()
+	b := e.Bytes()
+	for i := 0; i < len(b); i++ {
+		w := b[i]
+		for j := 0; j < 8; j++ {
+			z.Square(z)
+			if (w & (0b10000000 >> j)) != 0 {
+				z.Mul(z, &x)
+			}
+		}
+	}
+
+	return z
+}
+
+// Frobenius sets z = xᵖ and returns z
+func (z *E2) Frobenius(x *E2) *E2 {
+	// α₂ is not a square, so uᵖ = u·α₂^((p-1)/2) = -u
+	return z.Conjugate(x)
+}
+
+// String returns the decimal representation of z
+func (z *E2) String() string {
+	return z.Text(10)
+}
+
+// Text returns the string representation of z in the given base
+func (z *E2) Text(base int) string {
+	if z.A1.IsZero() {
+		return z.A0.Text(base)
+	}
+	return z.A0.Text(base) + "+" + z.A1.Text(base) + "*u"
+}
+
+// Bytes returns the concatenation of the big-endian encodings of the coordinates of z
+func (z *E2) Bytes() (res [BytesE2]byte) {
+	b := z.A0.Bytes()
+	copy(res[0:fr.Bytes], b[:])
+	b = z.A1.Bytes()
+	copy(res[fr.Bytes:], b[:])
+	return
+}
+
+// Marshal returns the value of z as a byte slice (see Bytes)
+func (z *E2) Marshal() []byte {
+	b := z.Bytes()
+	return b[:]
+}
+
+// Unmarshal sets z to the value encoded in e (see Bytes). It returns an error if
+// len(e) != BytesE2 or if a coordinate isn't canonical.
+func (z *E2) Unmarshal(e []byte) error {
+	if len(e) != BytesE2 {
+		return errors.New("invalid extensions.E2 encoding")
+	}
+	if err := z.A0.SetBytesCanonical(e[0:fr.Bytes]); err != nil {
+		return err
+	}
+	return z.A1.SetBytesCanonical(e[fr.Bytes:])
+}
+
+// SetBytes splits e in two halves and sets the coordinates of z to their
+// big-endian value (mod p). It is the inverse of Bytes, and can also be used
+// to derive an element from a hash digest (e.g. a Fiat-Shamir challenge).
+func (z *E2) SetBytes(e []byte) *E2 {
+	n := len(e) / 2
+	z.A0.SetBytes(e[:n])
+	z.A1.SetBytes(e[n:])
+	return z
+}
+
+// BatchInvertE2 returns a new slice with every element of a inverted.
+// Uses Montgomery batch inversion trick.
+//
+// if a[i] == 0, returns result[i] = a[i]
+func BatchInvertE2(a []E2) []E2 {
+	res := make([]E2, len(a))
+	if len(a) == 0 {
+		return res
+	}
+
+	zeroes := make([]bool, len(a))
+	var accumulator E2
+	accumulator.SetOne()
+
+	for i := 0; i < len(a); i++ {
+		if a[i].IsZero() {
+			zeroes[i] = true
+			continue
+		}
+		res[i].Set(&accumulator)
+		accumulator.Mul(&accumulator, &a[i])
+	}
+
+	accumulator.Inverse(&accumulator)
+
+	for i := len(a) - 1; i >= 0; i-- {
+		if zeroes[i] {
+			continue
+		}
+		res[i].Mul(&res[i], &accumulator)
+		accumulator.Mul(&accumulator, &a[i])
+	}
+
+	return res
+}
+
+// mulByNonResidueE2 sets z = α₂·x
+func mulByNonResidueE2(z, x *fr.Element) {
+	{{- if eq .E2NonResidue -1}}
+	z.Neg(x)
+	{{- else if eq .E2NonResidue 1}}
+	z.Set(x)
+	{{- else if eq .E2NonResidue 2}}
+	z.Double(x)
+	{{- else if eq .E2NonResidue 3}}
+	z.Set(x)
+	fr.MulBy3(z)
+	{{- else if eq .E2NonResidue 5}}
+	z.Set(x)
+	fr.MulBy5(z)
+	{{- else}}
+	z.Mul(x, &e2NonResidue)
+	{{- end}}
+}
+
+{{- if not (or (eq .E2NonResidue -1) (eq .E2NonResidue 1) (eq .E2NonResidue 2) (eq .E2NonResidue 3) (eq .E2NonResidue 5))}}
+
+var e2NonResidue = fr.NewElement({{.E2NonResidue}})
+{{- end}}
+`
@@ -0,0 +1,405 @@
+package extensions
+
+const E3 = `
+import (
+	"errors"
+	"math/big"
+
+	fr "{{.BasePackagePath}}"
+)
+
+// E3 is a degree three extension of {{.Base.PackageName}}: 𝔽p[w]/(w³ - {{.E3NonResidue}})
+type E3 struct {
+	A0, A1, A2 fr.Element
+}
+
+// BytesE3 number of bytes needed to represent an E3
+const BytesE3 = 3 * fr.Bytes
+
+// Equal returns true if z equals x, false otherwise
+func (z *E3) Equal(x *E3) bool {
+	return z.A0.Equal(&x.A0) && z.A1.Equal(&x.A1) && z.A2.Equal(&x.A2)
+}
+
+// IsZero returns true if z equals 0, false otherwise
+func (z *E3) IsZero() bool {
+	return z.A0.IsZero() && z.A1.IsZero() && z.A2.IsZero()
+}
+
+// IsOne returns true if z equals 1, false otherwise
+func (z *E3) IsOne() bool {
+	return z.A0.IsOne() && z.A1.IsZero() && z.A2.IsZero()
+}
+
+// SetZero sets z to 0 and returns z
+func (z *E3) SetZero() *E3 {
+	*z = E3{}
+	return z
+}
+
+// SetOne sets z to 1 and returns z
+func (z *E3) SetOne() *E3 {
+	*z = E3{}
+	z.A0.SetOne()
+	return z
+}
+
+// Set sets z to x and returns z
+func (z *E3) Set(x *E3) *E3 {
+	*z = *x
+	return z
+}
+
+// SetInt64 sets z to v (mod p) and returns z
+func (z *E3) SetInt64(v int64) *E3 {
+	*z = E3{}
+	z.A0.SetInt64(v)
+	return z
+}
+
+// SetUint64 sets z to v (mod p) and returns z
+func (z *E3) SetUint64(v uint64) *E3 {
+	*z = E3{}
+	z.A0.SetUint64(v)
+	return z
+}
+
+// Lift sets z to x ∈ 𝔽p and returns z
+func (z *E3) Lift(x *fr.Element) *E3 {
+	*z = E3{}
+	z.A0 = *x
+	return z
+}
+
+// SetInterface converts provided interface into E3. The interface can be an E3, a *E3,
+// or any value accepted by fr.Element.SetInterface, which is then lifted to E3
+func (z *E3) SetInterface(i1 interface{}) (*E3, error) {
+	switch c1 := i1.(type) {
+	case E3:
+		return z.Set(&c1), nil
+	case *E3:
+		if c1 == nil {
+			return nil, errors.New("can't set extensions.E3 with <nil>")
+		}
+		return z.Set(c1), nil
+	default:
+		var a fr.Element
+		if _, err := a.SetInterface(i1); err != nil {
+			return nil, err
+		}
+		return z.Lift(&a), nil
+	}
+}
+
+// SetRandom sets z to a uniform random value and returns z
+func (z *E3) SetRandom() (*E3, error) {
+	if _, err := z.A0.SetRandom(); err != nil {
+		return nil, err
+	}
+	if _, err := z.A1.SetRandom(); err != nil {
+		return nil, err
+	}
+	if _, err := z.A2.SetRandom(); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
+
+// Add sets z = x + y and returns z
+func (z *E3) Add(x, y *E3) *E3 {
+	z.A0.Add(&x.A0, &y.A0)
+	z.A1.Add(&x.A1, &y.A1)
+	z.A2.Add(&x.A2, &y.A2)
+	return z
+}
+
+// Sub sets z = x - y and returns z
+func (z *E3) Sub(x, y *E3) *E3 {
+	z.A0.Sub(&x.A0, &y.A0)
+	z.A1.Sub(&x.A1, &y.A1)
+	z.A2.Sub(&x.A2, &y.A2)
+	return z
+}
+
+// Double sets z = 2x and returns z
+func (z *E3) Double(x *E3) *E3 {
+	z.A0.Double(&x.A0)
+	z.A1.Double(&x.A1)
+	z.A2.Double(&x.A2)
+	return z
+}
+
+// Neg sets z = -x and returns z
+func (z *E3) Neg(x *E3) *E3 {
+	z.A0.Neg(&x.A0)
+	z.A1.Neg(&x.A1)
+	z.A2.Neg(&x.A2)
+	return z
+}
+
+// MulByElement sets z = x·y with y ∈ 𝔽p and returns z
+func (z *E3) MulByElement(x *E3, y *fr.Element) *E3 {
+	var yCopy fr.Element
+	yCopy.Set(y)
+	z.A0.Mul(&x.A0, &yCopy)
+	z.A1.Mul(&x.A1, &yCopy)
+	z.A2.Mul(&x.A2, &yCopy)
+	return z
+}
+
+// MulByNonResidue sets z = w·x and returns z
+func (z *E3) MulByNonResidue(x *E3) *E3 {
+	a0, a1 := x.A0, x.A1
+	mulByNonResidueE3(&z.A0, &x.A2)
+	z.A1 = a0
+	z.A2 = a1
+	return z
+}
+
+// Mul sets z = x·y and returns z
+func (z *E3) Mul(x, y *E3) *E3 {
+	// Karatsuba, see https://eprint.iacr.org/2006/471.pdf
+	var t0, t1, t2, c0, c1, c2, tmp fr.Element
+	t0.Mul(&x.A0, &y.A0)
+	t1.Mul(&x.A1, &y.A1)
+	t2.Mul(&x.A2, &y.A2)
+
+	c0.Add(&x.A1, &x.A2)
+	tmp.Add(&y.A1, &y.A2)
+	c0.Mul(&c0, &tmp).Sub(&c0, &t1).Sub(&c0, &t2)
+	mulByNonResidueE3(&c0, &c0)
+	c0.Add(&c0, &t0)
+
+	c1.Add(&x.A0, &x.A1)
+	tmp.Add(&y.A0, &y.A1)
+	c1.Mul(&c1, &tmp).Sub(&c1, &t0).Sub(&c1, &t1)
+	mulByNonResidueE3(&tmp, &t2)
+	c1.Add(&c1, &tmp)
+
+	c2.Add(&x.A0, &x.A2)
+	tmp.Add(&y.A0, &y.A2)
+	c2.Mul(&c2, &tmp).Sub(&c2, &t0).Sub(&c2, &t2).Add(&c2, &t1)
+
+	z.A0 = c0
+	z.A1 = c1
+	z.A2 = c2
+	return z
+}
+
+// Square sets z = x² and returns z
+func (z *E3) Square(x *E3) *E3 {
+	// CH-SQR2, see https://eprint.iacr.org/2006/471.pdf
+	var s0, s1, s2, s3, s4 fr.Element
+	s0.Square(&x.A0)
+	s1.Mul(&x.A0, &x.A1).Double(&s1)
+	s2.Sub(&x.A0, &x.A1).Add(&s2, &x.A2).Square(&s2)
+	s3.Mul(&x.A1, &x.A2).Double(&s3)
+	s4.Square(&x.A2)
+
+	z.A2.Add(&s1, &s2).Add(&z.A2, &s3).Sub(&z.A2, &s0).Sub(&z.A2, &s4)
+	mulByNonResidueE3(&s4, &s4)
+	z.A1.Add(&s1, &s4)
+	mulByNonResidueE3(&s3, &s3)
+	z.A0.Add(&s0, &s3)
+	return z
+}
+
+// Inverse sets z = x⁻¹ and returns z
+//
+// if x == 0, sets and returns z = x
+func (z *E3) Inverse(x *E3) *E3 {
+	// for x = a₀ + a₁w + a₂w², x⁻¹ = (c₀ + c₁w + c₂w²)/t where
+	// c₀ = a₀² - α₃a₁a₂, c₁ = α₃a₂² - a₀a₁, c₂ = a₁² - a₀a₂
+	// and t = a₀c₀ + α₃(a₂c₁ + a₁c₂)
+	var c0, c1, c2, t, tmp fr.Element
+	c0.Mul(&x.A1, &x.A2)
+	mulByNonResidueE3(&c0, &c0)
+	tmp.Square(&x.A0)
+	c0.Sub(&tmp, &c0)
+
+	c1.Square(&x.A2)
+	mulByNonResidueE3(&c1, &c1)
+	tmp.Mul(&x.A0, &x.A1)
+	c1.Sub(&c1, &tmp)
+
+	c2.Square(&x.A1)
+	tmp.Mul(&x.A0, &x.A2)
+	c2.Sub(&c2, &tmp)
+
+	t.Mul(&x.A2, &c1)
+	tmp.Mul(&x.A1, &c2)
+	t.Add(&t, &tmp)
+	mulByNonResidueE3(&t, &t)
+	tmp.Mul(&x.A0, &c0)
+	t.Add(&t, &tmp)
+
+	t.Inverse(&t)
+	z.A0.Mul(&c0, &t)
+	z.A1.Mul(&c1, &t)
+	z.A2.Mul(&c2, &t)
+	return z
+}
+
+// Div sets z = x/y and returns z
+func (z *E3) Div(x, y *E3) *E3 {
+	var r E3
+	r.Inverse(y).Mul(x, &r)
+	return z.Set(&r)
+}
+
+// Exp sets z = xᵏ and returns z
+func (z *E3) Exp(x E3, k *big.Int) *E3 {
+	if k.IsUint64() && k.Uint64() == 0 {
+		return z.SetOne()
+	}
+
+	e := k
+	if k.Sign() == -1 {
+		// negative k, we invert
+		// if k < 0: xᵏ == (x⁻¹)⁻ᵏ
+		x.Inverse(&x)
+		e = new(big.Int).Neg(k)
+	}
+
+	z.SetOne()
+	b := e.Bytes()
+	for i := 0; i < len(b); i++ {
+		w := b[i]
+		for j := 0; j < 8; j++ {
+			z.Square(z)
+			if (w & (0b10000000 >> j)) != 0 {
+				z.Mul(z, &x)
+			}
+		}
+	}
+
+	return z
+}
+
+// Frobenius sets z = xᵖ and returns z
+func (z *E3) Frobenius(x *E3) *E3 {
+	// wᵖ = ω·w where ω = α₃^((p-1)/3) is a primitive cube root of unity
+	z.A0 = x.A0
+	z.A1.Mul(&x.A1, &e3Frobenius[0])
+	z.A2.Mul(&x.A2, &e3Frobenius[1])
+	return z
+}
+
+// String returns the decimal representation of z
+func (z *E3) String() string {
+	return z.Text(10)
+}
+
+// Text returns the string representation of z in the given base
+func (z *E3) Text(base int) string {
+	if z.A1.IsZero() && z.A2.IsZero() {
+		return z.A0.Text(base)
+	}
+	return z.A0.Text(base) + "+" + z.A1.Text(base) + "*w+" + z.A2.Text(base) + "*w²"
+}
+
+// Bytes returns the concatenation of the big-endian encodings of the coordinates of z
+func (z *E3) Bytes() (res [BytesE3]byte) {
+	b := z.A0.Bytes()
+	copy(res[0:fr.Bytes], b[:])
+	b = z.A1.Bytes()
+	copy(res[fr.Bytes:2*fr.Bytes], b[:])
+	b = z.A2.Bytes()
+	copy(res[2*fr.Bytes:], b[:])
+	return
+}
+
+// Marshal returns the value of z as a byte slice (see Bytes)
+func (z *E3) Marshal() []byte {
+	b := z.Bytes()
+	return b[:]
+}
+
+// Unmarshal sets z to the value encoded in e (see Bytes). It returns an error if
+// len(e) != BytesE3 or if a coordinate isn't canonical.
+func (z *E3) Unmarshal(e []byte) error {
+	if len(e) != BytesE3 {
+		return errors.New("invalid extensions.E3 encoding")
+	}
+	if err := z.A0.SetBytesCanonical(e[0:fr.Bytes]); err != nil {
+		return err
+	}
+	if err := z.A1.SetBytesCanonical(e[fr.Bytes : 2*fr.Bytes]); err != nil {
+		return err
+	}
+	return z.A2.SetBytesCanonical(e[2*fr.Bytes:])
+}
+
+// SetBytes splits e in three parts and sets the coordinates of z to their
+// big-endian value (mod p). It is the inverse of Bytes, and can also be used
+// to derive an element from a hash digest (e.g. a Fiat-Shamir challenge).
+func (z *E3) SetBytes(e []byte) *E3 {
+	n := len(e) / 3
+	z.A0.SetBytes(e[:n])
+	z.A1.SetBytes(e[n : 2*n])
+	z.A2.SetBytes(e[2*n:])
+	return z
+}
+
+// BatchInvertE3 returns a new slice with every element of a inverted.
+// Uses Montgomery batch inversion trick.
+//
+// if a[i] == 0, returns result[i] = a[i]
+func BatchInvertE3(a []E3) []E3 {
+	res := make([]E3, len(a))
+	if len(a) == 0 {
+		return res
+	}
+
+	zeroes := make([]bool, len(a))
+	var accumulator E3
+	accumulator.SetOne()
+
+	for i := 0; i < len(a); i++ {
+		if a[i].IsZero() {
+			zeroes[i] = true
+			continue
+		}
+		res[i].Set(&accumulator)
+		accumulator.Mul(&accumulator, &a[i])
+	}
+
+	accumulator.Inverse(&accumulator)
+
+	for i := len(a) - 1; i >= 0; i-- {
+		if zeroes[i] {
+			continue
+		}
+		res[i].Mul(&res[i], &accumulator)
+		accumulator.Mul(&accumulator, &a[i])
+	}
+
+	return res
+}
+
+// e3Frobenius holds ω, ω² where ω = α₃^((p-1)/3)
+var e3Frobenius = [2]fr.Element{
+	fr.NewElement({{index .E3Frobenius 0}}),
+	fr.NewElement({{index .E3Frobenius 1}}),
+}
+
+// mulByNonResidueE3 sets z = α₃·x
+func mulByNonResidueE3(z, x *fr.Element) {
+	{{- if eq .E3NonResidue 2}}
+	z.Double(x)
+	{{- else if eq .E3NonResidue 3}}
+	z.Set(x)
+	fr.MulBy3(z)
+	{{- else if eq .E3NonResidue 5}}
+	z.Set(x)
+	fr.MulBy5(z)
+	{{- else}}
+	z.Mul(x, &e3NonResidue)
+	{{- end}}
+}
+
+{{- if not (or (eq .E3NonResidue 2) (eq .E3NonResidue 3) (eq .E3NonResidue 5))}}
+
+var e3NonResidue = fr.NewElement({{.E3NonResidue}})
+{{- end}}
+`
@@ -0,0 +1,352 @@
+package extensions
+
+const Test = `
+import (
+	"math/big"
+	"testing"
+
+	fr "{{.BasePackagePath}}"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+{{- $T := .Name}}
+{{- $degree := .Degree}}
+
+// ------------------------------------------------------------
+// tests
+
+func Test{{$T}}ReceiverIsOperand(t *testing.T) {
+	t.Parallel()
+
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 100
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := Gen{{$T}}()
+	genB := Gen{{$T}}()
+
+	properties.Property("[{{toUpper .Base.PackageName}}] Having the receiver as operand (addition) should output the same result", prop.ForAll(
+		func(a, b *{{$T}}) bool {
+			var c, d {{$T}}
+			d.Set(a)
+			c.Add(a, b)
+			a.Add(a, b)
+			b.Add(&d, b)
+			return a.Equal(b) && a.Equal(&c) && b.Equal(&c)
+		},
+		genA,
+		genB,
+	))
+
+	properties.Property("[{{toUpper .Base.PackageName}}] Having the receiver as operand (sub) should output the same result", prop.ForAll(
+		func(a, b *{{$T}}) bool {
+			var c, d {{$T}}
+			d.Set(a)
+			c.Sub(a, b)
+			a.Sub(a, b)
+			b.Sub(&d, b)
+			return a.Equal(b) && a.Equal(&c) && b.Equal(&c)
+		},
+		genA,
+		genB,
+	))
+
+	properties.Property("[{{toUpper .Base.PackageName}}] Having the receiver as operand (mul) should output the same result", prop.ForAll(
+		func(a, b *{{$T}}) bool {
+			var c, d {{$T}}
+			d.Set(a)
+			c.Mul(a, b)
+			a.Mul(a, b)
+			b.Mul(&d, b)
+			return a.Equal(b) && a.Equal(&c) && b.Equal(&c)
+		},
+		genA,
+		genB,
+	))
+
+	properties.Property("[{{toUpper .Base.PackageName}}] Having the receiver as operand (square) should output the same result", prop.ForAll(
+		func(a *{{$T}}) bool {
+			var b {{$T}}
+			b.Square(a)
+			a.Square(a)
+			return a.Equal(&b)
+		},
+		genA,
+	))
+
+	properties.Property("[{{toUpper .Base.PackageName}}] Having the receiver as operand (mul by non residue) should output the same result", prop.ForAll(
+		func(a *{{$T}}) bool {
+			var b {{$T}}
+			b.MulByNonResidue(a)
+			a.MulByNonResidue(a)
+			return a.Equal(&b)
+		},
+		genA,
+	))
+
+	properties.Property("[{{toUpper .Base.PackageName}}] Having the receiver as operand (Inverse) should output the same result", prop.ForAll(
+		func(a *{{$T}}) bool {
+			var b {{$T}}
+			b.Inverse(a)
+			a.Inverse(a)
+			return a.Equal(&b)
+		},
+		genA,
+	))
+
+	properties.Property("[{{toUpper .Base.PackageName}}] Having the receiver as operand (Frobenius) should output the same result", prop.ForAll(
+		func(a *{{$T}}) bool {
+			var b {{$T}}
+			b.Frobenius(a)
+			a.Frobenius(a)
+			return a.Equal(&b)
+		},
+		genA,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func Test{{$T}}Ops(t *testing.T) {
+	t.Parallel()
+
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 100
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := Gen{{$T}}()
+	genB := Gen{{$T}}()
+	genE := GenFr()
+
+	properties.Property("[{{toUpper .Base.PackageName}}] sub & add should leave an element invariant", prop.ForAll(
+		func(a, b *{{$T}}) bool {
+			var c {{$T}}
+			c.Set(a)
+			c.Add(&c, b).Sub(&c, b)
+			return c.Equal(a)
+		},
+		genA,
+		genB,
+	))
+
+	properties.Property("[{{toUpper .Base.PackageName}}] mul & inverse should leave an element invariant", prop.ForAll(
+		func(a, b *{{$T}}) bool {
+			var c, d {{$T}}
+			d.Inverse(b)
+			c.Set(a)
+			c.Mul(&c, b).Mul(&c, &d)
+			return c.Equal(a)
+		},
+		genA,
+		genB,
+	))
+
+	properties.Property("[{{toUpper .Base.PackageName}}] BatchInvert{{$T}} should output the same result as Inverse", prop.ForAll(
+		func(a, b, c *{{$T}}) bool {
+			batch := BatchInvert{{$T}}([]{{$T}}{*a, *b, *c})
+			a.Inverse(a)
+			b.Inverse(b)
+			c.Inverse(c)
+			return a.Equal(&batch[0]) && b.Equal(&batch[1]) && c.Equal(&batch[2])
+		},
+		genA,
+		genA,
+		genA,
+	))
+
+	properties.Property("[{{toUpper .Base.PackageName}}] inverse twice should leave an element invariant", prop.ForAll(
+		func(a *{{$T}}) bool {
+			var b {{$T}}
+			b.Inverse(a).Inverse(&b)
+			return a.Equal(&b)
+		},
+		genA,
+	))
+
+	properties.Property("[{{toUpper .Base.PackageName}}] square and mul should output the same result", prop.ForAll(
+		func(a *{{$T}}) bool {
+			var b, c {{$T}}
+			b.Mul(a, a)
+			c.Square(a)
+			return b.Equal(&c)
+		},
+		genA,
+	))
+
+	properties.Property("[{{toUpper .Base.PackageName}}] MulByNonResidue should be the multiplication by the generator of the extension", prop.ForAll(
+		func(a *{{$T}}) bool {
+			var b, c, g {{$T}}
+			{{- if eq $T "E4"}}
+			g.B1.SetOne()
+			{{- else}}
+			g.A1.SetOne()
+			{{- end}}
+			b.MulByNonResidue(a)
+			c.Mul(a, &g)
+			return b.Equal(&c)
+		},
+		genA,
+	))
+
+	properties.Property("[{{toUpper .Base.PackageName}}] MulByElement should be the multiplication by the lifted element", prop.ForAll(
+		func(a *{{$T}}, e fr.Element) bool {
+			var b, c, l {{$T}}
+			b.MulByElement(a, &e)
+			c.Mul(a, l.Lift(&e))
+			return b.Equal(&c)
+		},
+		genA,
+		genE,
+	))
+
+	properties.Property("[{{toUpper .Base.PackageName}}] Frobenius should be the exponentiation by p", prop.ForAll(
+		func(a *{{$T}}) bool {
+			var b, c {{$T}}
+			b.Frobenius(a)
+			c.Exp(*a, fr.Modulus())
+			return b.Equal(&c)
+		},
+		genA,
+	))
+
+	properties.Property("[{{toUpper .Base.PackageName}}] x^(p^{{$degree}}-1) should be 1 for x ≠ 0", prop.ForAll(
+		func(a *{{$T}}) bool {
+			if a.IsZero() {
+				return true
+			}
+			var e big.Int
+			e.Exp(fr.Modulus(), big.NewInt({{$degree}}), nil).Sub(&e, big.NewInt(1))
+			var b {{$T}}
+			b.Exp(*a, &e)
+			return b.IsOne()
+		},
+		genA,
+	))
+
+	properties.Property("[{{toUpper .Base.PackageName}}] Exp by a negative exponent should be the inverse of Exp", prop.ForAll(
+		func(a *{{$T}}) bool {
+			var b, c {{$T}}
+			k := big.NewInt(-12345)
+			b.Exp(*a, k)
+			c.Exp(*a, k.Neg(k)).Inverse(&c)
+			return b.Equal(&c)
+		},
+		genA,
+	))
+
+	properties.Property("[{{toUpper .Base.PackageName}}] Unmarshal(Bytes) and SetBytes(Bytes) should leave an element invariant", prop.ForAll(
+		func(a *{{$T}}) bool {
+			var b, c {{$T}}
+			buf := a.Bytes()
+			if err := b.Unmarshal(buf[:]); err != nil {
+				return false
+			}
+			c.SetBytes(buf[:])
+			return a.Equal(&b) && a.Equal(&c)
+		},
+		genA,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func Test{{$T}}Unmarshal(t *testing.T) {
+	var a {{$T}}
+	if err := a.Unmarshal(make([]byte, Bytes{{$T}}-1)); err == nil {
+		t.Fatal("Unmarshal should fail on a short input")
+	}
+	buf := make([]byte, Bytes{{$T}})
+	for i := range buf {
+		buf[i] = 0xff
+	}
+	if err := a.Unmarshal(buf); err == nil {
+		t.Fatal("Unmarshal should fail on non canonical coordinates")
+	}
+}
+
+// ------------------------------------------------------------
+// benches
+
+func Benchmark{{$T}}Mul(b *testing.B) {
+	var a, c {{$T}}
+	_, _ = a.SetRandom()
+	_, _ = c.SetRandom()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		a.Mul(&a, &c)
+	}
+}
+
+func Benchmark{{$T}}Square(b *testing.B) {
+	var a {{$T}}
+	_, _ = a.SetRandom()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		a.Square(&a)
+	}
+}
+
+func Benchmark{{$T}}Inverse(b *testing.B) {
+	var a {{$T}}
+	_, _ = a.SetRandom()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		a.Inverse(&a)
+	}
+}
+`
+
+// Generators holds the gopter generators shared by the tests of the extensions
+const Generators = `
+import (
+	fr "{{.BasePackagePath}}"
+	"github.com/leanovate/gopter"
+)
+
+// GenFr generates an fr.Element
+func GenFr() gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		var elmt fr.Element
+		if _, err := elmt.SetRandom(); err != nil {
+			panic(err)
+		}
+		return gopter.NewGenResult(elmt, gopter.NoShrinker)
+	}
+}
+
+// GenE2 generates an E2 element
+func GenE2() gopter.Gen {
+	return gopter.CombineGens(
+		GenFr(),
+		GenFr(),
+	).Map(func(values []interface{}) *E2 {
+		return &E2{A0: values[0].(fr.Element), A1: values[1].(fr.Element)}
+	})
+}
+{{- if .E3NonResidue}}
+
+// GenE3 generates an E3 element
+func GenE3() gopter.Gen {
+	return gopter.CombineGens(
+		GenFr(),
+		GenFr(),
+		GenFr(),
+	).Map(func(values []interface{}) *E3 {
+		return &E3{A0: values[0].(fr.Element), A1: values[1].(fr.Element), A2: values[2].(fr.Element)}
+	})
+}
+{{- end}}
+
+// GenE4 generates an E4 element
+func GenE4() gopter.Gen {
+	return gopter.CombineGens(
+		GenE2(),
+		GenE2(),
+	).Map(func(values []interface{}) *E4 {
+		return &E4{B0: *values[0].(*E2), B1: *values[1].(*E2)}
+	})
+}
+`
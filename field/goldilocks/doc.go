@@ -20,7 +20,7 @@
 //
 // The modulus is hardcoded in all the operations.
 //
-// Field elements are represented as an array, and assumed to be in Montgomery form in all methods:
+// Field elements are represented as an array, and assumed to be in canonical (non-Montgomery) form in all methods:
 //
 //	type Element [1]uint64
 //
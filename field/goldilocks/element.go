@@ -32,9 +32,9 @@ import (
 	"github.com/consensys/gnark-crypto/field/pool"
 )
 
-// Element represents a field element stored on 1 words (uint64)
+// Element represents a field element stored on 1 word (uint64)
 //
-// Element are assumed to be in Montgomery form in all methods.
+// Element are assumed to be in canonical form (i.e. not in Montgomery form) in all methods.
 //
 // Modulus q =
 //
@@ -58,6 +58,9 @@ const (
 	q  uint64 = q0
 )
 
+// epsilon = 2⁶⁴ - q = 2³² - 1, i.e. 2⁶⁴ ≡ epsilon (mod q)
+const epsilon uint64 = 0xffffffff
+
 var qElement = Element{
 	q0,
 }
@@ -72,10 +75,6 @@ func Modulus() *big.Int {
 	return new(big.Int).Set(&_modulus)
 }
 
-// q + r'.r = 1, i.e., qInvNeg = - q⁻¹ mod r
-// used for Montgomery reduction
-const qInvNeg uint64 = 18446744069414584319
-
 func init() {
 	_modulus.SetString("ffffffff00000001", 16)
 }
@@ -88,15 +87,16 @@ func init() {
 //	v.SetUint64(...)
 func NewElement(v uint64) Element {
 	z := Element{v}
-	z.Mul(&z, &rSquare)
+	reduce(&z)
 	return z
 }
 
 // SetUint64 sets z to v and returns z
 func (z *Element) SetUint64(v uint64) *Element {
-	//  sets z LSB to v (non-Montgomery form) and convert z to Montgomery form
+	// v < 2⁶⁴ < 2q so a single conditional subtraction is enough
 	*z = Element{v}
-	return z.Mul(z, &rSquare) // z.toMont()
+	reduce(z)
+	return z
 }
 
 // SetInt64 sets z to v and returns z
@@ -187,9 +187,9 @@ func (z *Element) SetZero() *Element {
 	return z
 }
 
-// SetOne z = 1 (in Montgomery form)
+// SetOne z = 1
 func (z *Element) SetOne() *Element {
-	z[0] = 4294967295
+	z[0] = 1
 	return z
 }
 
@@ -208,17 +208,17 @@ func (z *Element) Equal(x *Element) bool {
 
 // NotEqual returns 0 if and only if z == x; constant-time
 func (z *Element) NotEqual(x *Element) uint64 {
-	return (z[0] ^ x[0])
+	return z[0] ^ x[0]
 }
 
 // IsZero returns z == 0
 func (z *Element) IsZero() bool {
-	return (z[0]) == 0
+	return z[0] == 0
 }
 
 // IsOne returns z == 1
 func (z *Element) IsOne() bool {
-	return z[0] == 4294967295
+	return z[0] == 1
 }
 
 // IsUint64 reports whether z can be represented as an uint64.
@@ -228,12 +228,10 @@ func (z *Element) IsUint64() bool {
 
 // Uint64 returns the uint64 representation of x. If x cannot be represented in a uint64, the result is undefined.
 func (z *Element) Uint64() uint64 {
-	return z.Bits()[0]
+	return z[0]
 }
 
 // FitsOnOneWord reports whether z words (except the least significant word) are 0
-//
-// It is the responsibility of the caller to convert from Montgomery to Regular form if needed.
 func (z *Element) FitsOnOneWord() bool {
 	return true
 }
@@ -244,11 +242,9 @@ func (z *Element) FitsOnOneWord() bool {
 //	 0 if z == x
 //	+1 if z >  x
 func (z *Element) Cmp(x *Element) int {
-	_z := z.Bits()
-	_x := x.Bits()
-	if _z[0] > _x[0] {
+	if z[0] > x[0] {
 		return 1
-	} else if _z[0] < _x[0] {
+	} else if z[0] < x[0] {
 		return -1
 	}
 	return 0
@@ -257,16 +253,8 @@ func (z *Element) Cmp(x *Element) int {
 // LexicographicallyLargest returns true if this element is strictly lexicographically
 // larger than its negation, false otherwise
 func (z *Element) LexicographicallyLargest() bool {
-	// adapted from github.com/zkcrypto/bls12_381
 	// we check if the element is larger than (q-1) / 2
-	// if z - (((q -1) / 2) + 1) have no underflow, then z > (q-1) / 2
-
-	_z := z.Bits()
-
-	var b uint64
-	_, b = bits.Sub64(_z[0], 9223372034707292161, 0)
-
-	return b == 0
+	return z[0] >= 9223372034707292161
 }
 
 // SetRandom sets z to a uniform random value in [0, q).
@@ -274,36 +262,16 @@ func (z *Element) LexicographicallyLargest() bool {
 // This might error only if reading from crypto/rand.Reader errors,
 // in which case, value of z is undefined.
 func (z *Element) SetRandom() (*Element, error) {
-	// this code is generated for all modulus
-	// and derived from go/src/crypto/rand/util.go
-
-	// l is number of limbs * 8; the number of bytes needed to reconstruct 1 uint64
-	const l = 8
-
-	// bitLen is the maximum bit length needed to encode a value < q.
-	const bitLen = 64
-
-	// k is the maximum byte length needed to encode a value < q.
-	const k = (bitLen + 7) / 8
-
-	// b is the number of bits in the most significant byte of q-1.
-	b := uint(bitLen % 8)
-	if b == 0 {
-		b = 8
-	}
-
-	var bytes [l]byte
+	// this code is derived from go/src/crypto/rand/util.go
+	// q is close to 2⁶⁴, so a candidate is rejected with probability < 2⁻³¹.
+	var bytes [8]byte
 
 	for {
-		// note that bytes[k:l] is always 0
-		if _, err := io.ReadFull(rand.Reader, bytes[:k]); err != nil {
+		if _, err := io.ReadFull(rand.Reader, bytes[:]); err != nil {
 			return nil, err
 		}
 
-		// Clear unused bits in in the most significant byte to increase probability
-		// that the candidate is < q.
-		bytes[k-1] &= uint8(int(1<<b) - 1)
-		z[0] = binary.LittleEndian.Uint64(bytes[0:8])
+		z[0] = binary.LittleEndian.Uint64(bytes[:])
 
 		if !z.smallerThanModulus() {
 			continue // ignore the candidate and re-sample
@@ -328,64 +296,31 @@ func One() Element {
 
 // Halve sets z to z / 2 (mod q)
 func (z *Element) Halve() {
-	var carry uint64
-
-	if z[0]&1 == 1 {
-		// z = z + q
-		z[0], carry = bits.Add64(z[0], q0, 0)
-
-	}
-	// z = z >> 1
-	z[0] >>= 1
-
-	if carry != 0 {
-		// when we added q, the result was larger than our available limbs
-		// when we shift right, we need to set the highest bit
-		z[0] |= (1 << 63)
-	}
-
-}
-
-// fromMont converts z in place (i.e. mutates) from Montgomery to regular representation
-// sets and returns z = z * 1
-func (z *Element) fromMont() *Element {
-	fromMont(z)
-	return z
+	z[0] = halve(z[0])
 }
 
 // Add z = x + y (mod q)
 func (z *Element) Add(x, y *Element) *Element {
-
-	var carry uint64
-	z[0], carry = bits.Add64(x[0], y[0], 0)
-	if carry != 0 || z[0] >= q {
-		z[0] -= q
-	}
+	// branch-free: the operands are random-looking in most use cases
+	s, carry := bits.Add64(x[0], y[0], 0)
+	// if the sum overflowed, x + y - 2⁶⁴ < q - 2³³ and adding 2⁶⁴ mod q doesn't overflow
+	s += epsilon & -carry
+	// if s ⩾ q → s -= q
+	r, borrow := bits.Sub64(s, q, 0)
+	z[0] = r + (q & -borrow)
 	return z
 }
 
 // Double z = x + x (mod q), aka Lsh 1
 func (z *Element) Double(x *Element) *Element {
-	if x[0]&(1<<63) == (1 << 63) {
-		// if highest bit is set, then we have a carry to x + x, we shift and subtract q
-		z[0] = (x[0] << 1) - q
-	} else {
-		// highest bit is not set, but x + x can still be >= q
-		z[0] = (x[0] << 1)
-		if z[0] >= q {
-			z[0] -= q
-		}
-	}
-	return z
+	return z.Add(x, x)
 }
 
 // Sub z = x - y (mod q)
 func (z *Element) Sub(x, y *Element) *Element {
-	var b uint64
-	z[0], b = bits.Sub64(x[0], y[0], 0)
-	if b != 0 {
-		z[0] += q
-	}
+	d, b := bits.Sub64(x[0], y[0], 0)
+	// if x < y, d = x - y + 2⁶⁴ and we want x - y + q
+	z[0] = d - (epsilon & -b)
 	return z
 }
 
@@ -407,98 +342,122 @@ func (z *Element) Select(c int, x0 *Element, x1 *Element) *Element {
 	return z
 }
 
-// _mulGeneric is unoptimized textbook CIOS
-// it is a fallback solution on x86 when ADX instruction set is not available
-// and is used for testing purposes.
-func _mulGeneric(z, x, y *Element) {
+// Mul z = x * y (mod q)
+func (z *Element) Mul(x, y *Element) *Element {
+	hi, lo := bits.Mul64(x[0], y[0])
+	z[0] = reduce128(hi, lo)
+	return z
+}
 
-	// Implements CIOS multiplication -- section 2.3.2 of Tolga Acar's thesis
-	// https://www.microsoft.com/en-us/research/wp-content/uploads/1998/06/97Acar.pdf
-	//
-	// The algorithm:
-	//
-	// for i=0 to N-1
-	// 		C := 0
-	// 		for j=0 to N-1
-	// 			(C,t[j]) := t[j] + x[j]*y[i] + C
-	// 		(t[N+1],t[N]) := t[N] + C
-	//
-	// 		C := 0
-	// 		m := t[0]*q'[0] mod D
-	// 		(C,_) := t[0] + m*q[0]
-	// 		for j=1 to N-1
-	// 			(C,t[j-1]) := t[j] + m*q[j] + C
-	//
-	// 		(C,t[N-1]) := t[N] + C
-	// 		t[N] := t[N+1] + C
-	//
-	// → N is the number of machine words needed to store the modulus q
-	// → D is the word size. For example, on a 64-bit architecture D is 2	64
-	// → x[i], y[i], q[i] is the ith word of the numbers x,y,q
-	// → q'[0] is the lowest word of the number -q⁻¹ mod r. This quantity is pre-computed, as it does not depend on the inputs.
-	// → t is a temporary array of size N+2
-	// → C, S are machine words. A pair (C,S) refers to (hi-bits, lo-bits) of a two-word number
-
-	var t [2]uint64
-	var D uint64
-	var m, C uint64
-	// -----------------------------------
-	// First loop
-
-	C, t[0] = bits.Mul64(y[0], x[0])
-
-	t[1], D = bits.Add64(t[1], C, 0)
-
-	// m = t[0]n'[0] mod W
-	m = t[0] * qInvNeg
-
-	// -----------------------------------
-	// Second loop
-	C = madd0(m, q0, t[0])
-
-	t[0], C = bits.Add64(t[1], C, 0)
-	t[1], _ = bits.Add64(0, D, C)
-
-	if t[1] != 0 {
-		// we need to reduce, we have a result on 2 words
-		z[0], _ = bits.Sub64(t[0], q0, 0)
-		return
-	}
+// Square z = x * x (mod q)
+func (z *Element) Square(x *Element) *Element {
+	hi, lo := bits.Mul64(x[0], x[0])
+	z[0] = reduce128(hi, lo)
+	return z
+}
 
-	// copy t into z
-	z[0] = t[0]
+// reduce128 returns hi·2⁶⁴ + lo (mod q), for hi < q.
+//
+// Writing hi = hi₁·2³² + hi₀, since 2⁶⁴ ≡ 2³² - 1 and 2⁹⁶ ≡ -1 (mod q):
+// hi·2⁶⁴ + lo ≡ lo - hi₁ + hi₀·(2³² - 1) (mod q)
+func reduce128(hi, lo uint64) uint64 {
+	hi1 := hi >> 32
+	hi0 := hi & epsilon
 
-	// if z ⩾ q → z -= q
-	if !z.smallerThanModulus() {
-		z[0] -= q
+	t, b := bits.Sub64(lo, hi1, 0)
+	if b != 0 {
+		// t = lo - hi₁ + 2⁶⁴ ⩾ 2⁶⁴ - 2³², we want lo - hi₁ + q
+		t -= epsilon
 	}
-}
 
-func _fromMontGeneric(z *Element) {
-	// the following lines implement z = z * 1
-	// with a modified CIOS montgomery multiplication
-	// see Mul for algorithm documentation
-	{
-		// m = z[0]n'[0] mod W
-		m := z[0] * qInvNeg
-		C := madd0(m, q0, z[0])
-		z[0] = C
+	// hi₀·(2³² - 1) < 2⁶⁴
+	var c uint64
+	t, c = bits.Add64(t, (hi0<<32)-hi0, 0)
+	if c != 0 {
+		// t < (2³² - 1)² so adding 2⁶⁴ mod q doesn't overflow
+		t += epsilon
+	}
+	if t >= q {
+		t -= q
 	}
+	return t
+}
 
-	// if z ⩾ q → z -= q
-	if !z.smallerThanModulus() {
-		z[0] -= q
+// MulBy3 x *= 3 (mod q)
+func MulBy3(x *Element) {
+	var y Element
+	y.Double(x)
+	x.Add(x, &y)
+}
+
+// MulBy5 x *= 5 (mod q)
+func MulBy5(x *Element) {
+	var y Element
+	y.Double(x)
+	y.Double(&y)
+	x.Add(x, &y)
+}
+
+// MulBy13 x *= 13 (mod q)
+func MulBy13(x *Element) {
+	var y = Element{
+		13,
 	}
+	x.Mul(x, &y)
 }
 
-func _reduceGeneric(z *Element) {
+// Butterfly sets
+//
+//	a = a + b (mod q)
+//	b = a - b (mod q)
+func Butterfly(a, b *Element) {
+	_butterflyGeneric(a, b)
+}
+
+func reduce(z *Element) {
+	_reduceGeneric(z)
+}
+
+func addVec(res, a, b Vector) {
+	addVecGeneric(res, a, b)
+}
+
+func subVec(res, a, b Vector) {
+	subVecGeneric(res, a, b)
+}
+
+func scalarMulVec(res, a Vector, b *Element) {
+	scalarMulVecGeneric(res, a, b)
+}
 
+func mulVec(res, a, b Vector) {
+	mulVecGeneric(res, a, b)
+}
+
+func butterflyVec(a, b Vector) {
+	butterflyVecGeneric(a, b)
+}
+
+// _mulGeneric is the reference multiplication, used for testing purposes.
+func _mulGeneric(z, x, y *Element) {
+	// same as Mul, but uses a 128-bit division for the reduction
+	hi, lo := bits.Mul64(x[0], y[0])
+	_, z[0] = bits.Div64(hi, lo, q)
+}
+
+func _reduceGeneric(z *Element) {
 	// if z ⩾ q → z -= q
 	if !z.smallerThanModulus() {
 		z[0] -= q
 	}
 }
 
+func _butterflyGeneric(a, b *Element) {
+	t := *a
+	a.Add(a, b)
+	b.Sub(&t, b)
+}
+
 // BatchInvert returns a new slice with every element inverted.
 // Uses Montgomery batch inversion trick
 func BatchInvert(a []Element) []Element {
@@ -532,12 +491,6 @@ func BatchInvert(a []Element) []Element {
 	return res
 }
 
-func _butterflyGeneric(a, b *Element) {
-	t := *a
-	a.Add(a, b)
-	b.Sub(&t, b)
-}
-
 // BitLen returns the minimum number of bits needed to represent z
 // returns 0 if z == 0
 func (z *Element) BitLen() int {
@@ -573,64 +526,12 @@ func Hash(msg, dst []byte, count int) ([]Element, error) {
 	return res, nil
 }
 
-// Exp z = xᵏ (mod q)
-func (z *Element) Exp(x Element, k *big.Int) *Element {
-	if k.IsUint64() && k.Uint64() == 0 {
-		return z.SetOne()
-	}
-
-	e := k
-	if k.Sign() == -1 {
-		// negative k, we invert
-		// if k < 0: xᵏ (mod q) == (x⁻¹)ᵏ (mod q)
-		x.Inverse(&x)
-
-		// we negate k in a temp big.Int since
-		// Int.Bit(_) of k and -k is different
-		e = pool.BigInt.Get()
-		defer pool.BigInt.Put(e)
-		e.Neg(k)
-	}
-
-	z.Set(&x)
-
-	for i := e.BitLen() - 2; i >= 0; i-- {
-		z.Square(z)
-		if e.Bit(i) == 1 {
-			z.Mul(z, &x)
-		}
-	}
-
-	return z
-}
-
-// rSquare where r is the Montgommery constant
-// see section 2.3.2 of Tolga Acar's thesis
-// https://www.microsoft.com/en-us/research/wp-content/uploads/1998/06/97Acar.pdf
-var rSquare = Element{
-	18446744065119617025,
-}
-
-// toMont converts z to Montgomery form
-// sets and returns z = z * r²
-func (z *Element) toMont() *Element {
-	return z.Mul(z, &rSquare)
-}
-
 // String returns the decimal representation of z as generated by
 // z.Text(10).
 func (z *Element) String() string {
 	return z.Text(10)
 }
 
-// toBigInt returns z as a big.Int in Montgomery form
-func (z *Element) toBigInt(res *big.Int) *big.Int {
-	var b [Bytes]byte
-	binary.BigEndian.PutUint64(b[0:8], z[0])
-
-	return res.SetBytes(b[:])
-}
-
 // Text returns the string representation of z in the given base.
 // Base must be between 2 and 36, inclusive. The result uses the
 // lower-case letters 'a' to 'z' for digit values 10 to 35.
@@ -649,37 +550,30 @@ func (z *Element) Text(base int) string {
 	if base == 10 {
 		var zzNeg Element
 		zzNeg.Neg(z)
-		zzNeg.fromMont()
 		if zzNeg[0] <= maxUint16 && zzNeg[0] != 0 {
 			return "-" + strconv.FormatUint(zzNeg[0], base)
 		}
 	}
-	zz := z.Bits()
-	return strconv.FormatUint(zz[0], base)
+	return strconv.FormatUint(z[0], base)
 }
 
 // BigInt sets and return z as a *big.Int
 func (z *Element) BigInt(res *big.Int) *big.Int {
-	_z := *z
-	_z.fromMont()
-	return _z.toBigInt(res)
+	return res.SetUint64(z[0])
 }
 
 // ToBigIntRegular returns z as a big.Int in regular form
 //
 // Deprecated: use BigInt(*big.Int) instead
 func (z Element) ToBigIntRegular(res *big.Int) *big.Int {
-	z.fromMont()
-	return z.toBigInt(res)
+	return z.BigInt(res)
 }
 
 // Bits provides access to z by returning its value as a little-endian [1]uint64 array.
 // Bits is intended to support implementation of missing low-level Element
 // functionality outside this package; it should be avoided otherwise.
 func (z *Element) Bits() [1]uint64 {
-	_z := *z
-	fromMont(&_z)
-	return _z
+	return *z
 }
 
 // Bytes returns the value of z as a big-endian byte array
@@ -772,23 +666,8 @@ func (z *Element) SetBigInt(v *big.Int) *Element {
 
 // setBigInt assumes 0 ⩽ v < q
 func (z *Element) setBigInt(v *big.Int) *Element {
-	vBits := v.Bits()
-
-	if bits.UintSize == 64 {
-		for i := 0; i < len(vBits); i++ {
-			z[i] = uint64(vBits[i])
-		}
-	} else {
-		for i := 0; i < len(vBits); i++ {
-			if i%2 == 0 {
-				z[i/2] = uint64(vBits[i])
-			} else {
-				z[i/2] |= uint64(vBits[i]) << 32
-			}
-		}
-	}
-
-	return z.toMont()
+	z[0] = v.Uint64()
+	return z
 }
 
 // SetString creates a big.Int with number and calls SetBigInt on z
@@ -894,12 +773,10 @@ func (bigEndian) Element(b *[Bytes]byte) (Element, error) {
 		return Element{}, errors.New("invalid goldilocks.Element encoding")
 	}
 
-	z.toMont()
 	return z, nil
 }
 
 func (bigEndian) PutElement(b *[Bytes]byte, e Element) {
-	e.fromMont()
 	binary.BigEndian.PutUint64((*b)[0:8], e[0])
 }
 
@@ -918,17 +795,107 @@ func (littleEndian) Element(b *[Bytes]byte) (Element, error) {
 		return Element{}, errors.New("invalid goldilocks.Element encoding")
 	}
 
-	z.toMont()
 	return z, nil
 }
 
 func (littleEndian) PutElement(b *[Bytes]byte, e Element) {
-	e.fromMont()
 	binary.LittleEndian.PutUint64((*b)[0:8], e[0])
 }
 
 func (littleEndian) String() string { return "LittleEndian" }
 
+// Inverse z = x⁻¹ (mod q)
+//
+// if x == 0, sets and returns z = x
+func (z *Element) Inverse(x *Element) *Element {
+	// Algorithm 16 in "Efficient Software-Implementation of Finite Fields with Applications to Cryptography"
+	// (binary extended Euclid, without the Montgomery correction)
+	if x.IsZero() {
+		z.SetZero()
+		return z
+	}
+
+	var r, s, u, v uint64
+	u = q
+	s = 1
+	r = 0
+	v = x[0]
+
+	for (u != 1) && (v != 1) {
+		for v&1 == 0 {
+			v >>= 1
+			s = halve(s)
+		}
+		for u&1 == 0 {
+			u >>= 1
+			r = halve(r)
+		}
+		if v >= u {
+			v -= u
+			if s >= r {
+				s -= r
+			} else {
+				s = q - (r - s)
+			}
+		} else {
+			u -= v
+			if r >= s {
+				r -= s
+			} else {
+				r = q - (s - r)
+			}
+		}
+	}
+
+	if u == 1 {
+		z[0] = r
+	} else {
+		z[0] = s
+	}
+
+	return z
+}
+
+// halve returns a / 2 (mod q), for a < q
+func halve(a uint64) uint64 {
+	if a&1 == 0 {
+		return a >> 1
+	}
+	a, carry := bits.Add64(a, q, 0)
+	return a>>1 | carry<<63
+}
+
+// Exp z = xᵏ (mod q)
+func (z *Element) Exp(x Element, k *big.Int) *Element {
+	if k.IsUint64() && k.Uint64() == 0 {
+		return z.SetOne()
+	}
+
+	e := k
+	if k.Sign() == -1 {
+		// negative k, we invert
+		// if k < 0: xᵏ (mod q) == (x⁻¹)ᵏ (mod q)
+		x.Inverse(&x)
+
+		// we negate k in a temp big.Int since
+		// Int.Bit(_) of k and -k is different
+		e = pool.BigInt.Get()
+		defer pool.BigInt.Put(e)
+		e.Neg(k)
+	}
+
+	z.Set(&x)
+
+	for i := e.BitLen() - 2; i >= 0; i-- {
+		z.Square(z)
+		if e.Bit(i) == 1 {
+			z.Mul(z, &x)
+		}
+	}
+
+	return z
+}
+
 // Legendre returns the Legendre symbol of z (either +1, -1, or 0.)
 func (z *Element) Legendre() int {
 	var l Element
@@ -966,7 +933,7 @@ func (z *Element) Sqrt(x *Element) *Element {
 
 	// g = nonResidue ^ s
 	var g = Element{
-		15733474329512464024,
+		1753635133440165772,
 	}
 	r := uint64(32)
 
@@ -1010,71 +977,3 @@ func (z *Element) Sqrt(x *Element) *Element {
 		r = m
 	}
 }
-
-// Inverse z = x⁻¹ (mod q)
-//
-// if x == 0, sets and returns z = x
-func (z *Element) Inverse(x *Element) *Element {
-	// Algorithm 16 in "Efficient Software-Implementation of Finite Fields with Applications to Cryptography"
-	const q uint64 = q0
-	if x.IsZero() {
-		z.SetZero()
-		return z
-	}
-
-	var r, s, u, v uint64
-	u = q
-	s = 18446744065119617025 // s = r²
-	r = 0
-	v = x[0]
-
-	var carry, borrow uint64
-
-	for (u != 1) && (v != 1) {
-		for v&1 == 0 {
-			v >>= 1
-			if s&1 == 0 {
-				s >>= 1
-			} else {
-				s, carry = bits.Add64(s, q, 0)
-				s >>= 1
-				if carry != 0 {
-					s |= (1 << 63)
-				}
-			}
-		}
-		for u&1 == 0 {
-			u >>= 1
-			if r&1 == 0 {
-				r >>= 1
-			} else {
-				r, carry = bits.Add64(r, q, 0)
-				r >>= 1
-				if carry != 0 {
-					r |= (1 << 63)
-				}
-			}
-		}
-		if v >= u {
-			v -= u
-			s, borrow = bits.Sub64(s, r, 0)
-			if borrow == 1 {
-				s += q
-			}
-		} else {
-			u -= v
-			r, borrow = bits.Sub64(r, s, 0)
-			if borrow == 1 {
-				r += q
-			}
-		}
-	}
-
-	if u == 1 {
-		z[0] = r
-	} else {
-		z[0] = s
-	}
-
-	return z
-}
@@ -175,14 +175,6 @@ func BenchmarkElementDiv(b *testing.B) {
 	}
 }
 
-func BenchmarkElementFromMont(b *testing.B) {
-	benchResElement.SetRandom()
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		benchResElement.fromMont()
-	}
-}
-
 func BenchmarkElementSquare(b *testing.B) {
 	benchResElement.SetRandom()
 	b.ResetTimer()
@@ -203,7 +195,7 @@ func BenchmarkElementSqrt(b *testing.B) {
 
 func BenchmarkElementMul(b *testing.B) {
 	x := Element{
-		18446744065119617025,
+		1,
 	}
 	benchResElement.SetOne()
 	b.ResetTimer()
@@ -214,7 +206,7 @@ func BenchmarkElementMul(b *testing.B) {
 
 func BenchmarkElementCmp(b *testing.B) {
 	x := Element{
-		18446744065119617025,
+		1,
 	}
 	benchResElement = x
 	benchResElement[0] = 0
@@ -282,7 +274,6 @@ var staticTestValues []Element
 func init() {
 	staticTestValues = append(staticTestValues, Element{}) // zero
 	staticTestValues = append(staticTestValues, One())     // one
-	staticTestValues = append(staticTestValues, rSquare)   // r²
 	var e, one Element
 	one.SetOne()
 	e.Sub(&qElement, &one)
@@ -576,7 +567,7 @@ func TestElementBitLen(t *testing.T) {
 
 	properties.Property("BitLen should output same result than big.Int.BitLen", prop.ForAll(
 		func(a testPairElement) bool {
-			return a.element.fromMont().BitLen() == a.bigint.BitLen()
+			return a.element.BitLen() == a.bigint.BitLen()
 		},
 		genA,
 	))
@@ -2093,43 +2084,6 @@ func TestElementBatchInvert(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
-func TestElementFromMont(t *testing.T) {
-
-	t.Parallel()
-	parameters := gopter.DefaultTestParameters()
-	if testing.Short() {
-		parameters.MinSuccessfulTests = nbFuzzShort
-	} else {
-		parameters.MinSuccessfulTests = nbFuzz
-	}
-
-	properties := gopter.NewProperties(parameters)
-
-	genA := gen()
-
-	properties.Property("Assembly implementation must be consistent with generic one", prop.ForAll(
-		func(a testPairElement) bool {
-			c := a.element
-			d := a.element
-			c.fromMont()
-			_fromMontGeneric(&d)
-			return c.Equal(&d)
-		},
-		genA,
-	))
-
-	properties.Property("x.fromMont().toMont() == x", prop.ForAll(
-		func(a testPairElement) bool {
-			c := a.element
-			c.fromMont().toMont()
-			return c.Equal(&a.element)
-		},
-		genA,
-	))
-
-	properties.TestingRun(t, gopter.ConsoleReporter(false))
-}
-
 func TestElementJSON(t *testing.T) {
 	assert := require.New(t)
 
@@ -17,5 +17,13 @@ func main() {
 	if err := generator.GenerateFF(goldilocks, "../"); err != nil {
 		panic(err)
 	}
+
+	ext, err := config.NewExtensionsConfig(goldilocks, "github.com/consensys/gnark-crypto/field/goldilocks", 7, 2, [2]int64{0, 1})
+	if err != nil {
+		panic(err)
+	}
+	if err := generator.GenerateExtensions(ext, "../extensions"); err != nil {
+		panic(err)
+	}
 	fmt.Println("successfully generated goldilocks field")
 }
@@ -3,14 +3,20 @@ package hash
 import (
 	"crypto/sha256"
 	"errors"
+	"hash"
 )
 
-// ExpandMsgXmd expands msg to a slice of lenInBytes bytes.
+// ExpandMsgXmd expands msg to a slice of lenInBytes bytes, with SHA-256.
 // https://datatracker.ietf.org/doc/html/rfc9380#name-expand_message_xmd
 // https://datatracker.ietf.org/doc/html/rfc9380#name-utility-functions (I2OSP/O2ISP)
 func ExpandMsgXmd(msg, dst []byte, lenInBytes int) ([]byte, error) {
+	return ExpandMsgXmdWith(sha256.New(), msg, dst, lenInBytes)
+}
 
-	h := sha256.New()
+// ExpandMsgXmdWith expands msg to a slice of lenInBytes bytes, with the hash
+// function h (e.g. BLAKE2b-512 for the Zcash group hash on the Pasta curves).
+// The input block size of h is used for Z_pad.
+func ExpandMsgXmdWith(h hash.Hash, msg, dst []byte, lenInBytes int) ([]byte, error) {
 	ell := (lenInBytes + h.Size() - 1) / h.Size() // ceil(len_in_bytes / b_in_bytes)
 	if ell > 255 {
 		return nil, errors.New("invalid lenInBytes")
@@ -59,7 +65,7 @@ func ExpandMsgXmd(msg, dst []byte, lenInBytes int) ([]byte, error) {
 	b1 := h.Sum(nil)
 
 	res := make([]byte, lenInBytes)
-	copy(res[:h.Size()], b1)
+	copy(res[:min(h.Size(), len(res))], b1)
 
 	for i := 2; i <= ell; i++ {
 		// b_i = H(strxor(b₀, b_(i - 1)) ∥ I2OSP(i, 1) ∥ DST_prime)
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/field/generator"
+	"github.com/consensys/gnark-crypto/field/generator/config"
+)
+
+//go:generate go run main.go
+func main() {
+	const modulus = "0x7f000001"
+	koalabear, err := config.NewFieldConfig("koalabear", "Element", modulus, false)
+	if err != nil {
+		panic(err)
+	}
+	if err := generator.GenerateFF(koalabear, "../"); err != nil {
+		panic(err)
+	}
+
+	ext, err := config.NewExtensionsConfig(koalabear, "github.com/consensys/gnark-crypto/field/koalabear", 3, 0, [2]int64{0, 1})
+	if err != nil {
+		panic(err)
+	}
+	if err := generator.GenerateExtensions(ext, "../extensions"); err != nil {
+		panic(err)
+	}
+	fmt.Println("successfully generated koalabear field")
+}
@@ -0,0 +1,30 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package extensions provides small degree extensions of mersenne31:
+//
+//	E2 = 𝔽p[u]/(u² - (-1))
+//	E3 = 𝔽p[w]/(w³ - 5)
+//	E4 = E2[v]/(v² - γ), γ = 2 + 1·u
+//
+// The base field is too small to provide enough soundness when the verifier's
+// challenges (Fiat-Shamir) are sampled from it; protocols such as FRI or sumcheck
+// sample them from one of these extensions instead.
+//
+// # Warning
+//
+// This code has not been audited and is provided as-is. In particular, there is no security guarantees such as constant time implementation or side-channel attack resistance.
+package extensions
@@ -0,0 +1,30 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fft provides the circle-group FFT over the Mersenne31 field.
+//
+// p = 2³¹ - 1 has 2-adicity 1, so the multiplicative group of 𝔽p has no large
+// subgroups of order a power of two. Instead, we use the circle group
+//
+//	C(𝔽p) = {(x, y) ∈ 𝔽p² : x² + y² = 1}
+//
+// which has order p + 1 = 2³¹. The evaluation domains are standard position cosets of C(𝔽p),
+// and polynomials are represented in the circle FFT basis
+//
+//	bⱼ(x, y) = y^j₀ · x^j₁ · π(x)^j₂ · π²(x)^j₃ ⋯
+//
+// where j = Σ jᵢ·2ⁱ and π(x) = 2x² - 1 is the x-coordinate of the doubling map.
+//
+// See "Circle STARKs" (Haböck, Levit, Papini), https://eprint.iacr.org/2024/278.
+package fft
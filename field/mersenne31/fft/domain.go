@@ -0,0 +1,182 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fft
+
+import (
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	fr "github.com/consensys/gnark-crypto/field/mersenne31"
+)
+
+// maxOrderRoot is the 2-adicity of the circle group; a domain of cardinality n
+// is built from a point of order 2n, so n ⩽ 2³⁰.
+const maxOrderRoot = 31
+
+// circleGenerator generates the circle group, of order 2³¹
+var circleGenerator = Point{
+	X: fr.NewElement(2),
+	Y: fr.NewElement(1268011823),
+}
+
+// Point is a point (X, Y) of the circle x² + y² = 1 over 𝔽p
+type Point struct {
+	X, Y fr.Element
+}
+
+// Mul sets p = a·b using the group law (x₀, y₀)·(x₁, y₁) = (x₀x₁ - y₀y₁, x₀y₁ + y₀x₁) and returns p
+func (p *Point) Mul(a, b *Point) *Point {
+	var x, y, t fr.Element
+	x.Mul(&a.X, &b.X)
+	t.Mul(&a.Y, &b.Y)
+	x.Sub(&x, &t)
+	y.Mul(&a.X, &b.Y)
+	t.Mul(&a.Y, &b.X)
+	y.Add(&y, &t)
+	p.X, p.Y = x, y
+	return p
+}
+
+// Square sets p = a·a and returns p
+func (p *Point) Square(a *Point) *Point {
+	// (x, y)² = (2x² - 1, 2xy)
+	var x, y fr.Element
+	one := fr.One()
+	x.Square(&a.X).Double(&x).Sub(&x, &one)
+	y.Mul(&a.X, &a.Y).Double(&y)
+	p.X, p.Y = x, y
+	return p
+}
+
+// Conjugate sets p = J(a) = (x, -y), the inverse of a, and returns p
+func (p *Point) Conjugate(a *Point) *Point {
+	p.X = a.X
+	p.Y.Neg(&a.Y)
+	return p
+}
+
+// IsOnCircle returns true if x² + y² = 1
+func (p *Point) IsOnCircle() bool {
+	var x2, y2 fr.Element
+	x2.Square(&p.X)
+	y2.Square(&p.Y)
+	return x2.Add(&x2, &y2).IsOne()
+}
+
+// Domain is a standard position coset of the circle group, of cardinality n = 2ᵏ:
+//
+//	D = h·<h²>, where h has order 2n.
+//
+// D is stable under the conjugation J(x, y) = (x, -y) and its image under the doubling map
+// is the standard position coset of cardinality n/2; this is what the circle FFT relies on.
+//
+// Evaluations on D are ordered as follows: for i < n/2, the i-th point is h·gⁱ, where
+// g = h⁴ generates the subgroup of order n/2, and the (n/2 + i)-th point is its conjugate.
+type Domain struct {
+	Cardinality    uint64
+	CardinalityInv fr.Element
+	Generator      Point // h, of order 2·Cardinality
+
+	// twiddles[0] holds the y coordinates of the first n/2 points of the domain,
+	// twiddles[t] the x coordinates used at stage t ⩾ 1 of the FFT.
+	twiddles    [][]fr.Element
+	twiddlesInv [][]fr.Element
+}
+
+// NewDomain returns a standard position coset of the circle group of cardinality n ⩾ m,
+// n a power of 2 (n ⩾ 2).
+func NewDomain(m uint64) *Domain {
+	domain := &Domain{}
+	n := ecc.NextPowerOfTwo(m)
+	if n < 2 {
+		n = 2
+	}
+	logN := uint64(bits.TrailingZeros64(n))
+	if logN+1 > maxOrderRoot {
+		panic("m is too big: the circle group doesn't have a subgroup of order 2m")
+	}
+	domain.Cardinality = n
+	domain.CardinalityInv.SetUint64(n).Inverse(&domain.CardinalityInv)
+
+	// h = generator^(2³¹ / 2n)
+	domain.Generator = circleGenerator
+	for i := logN + 1; i < maxOrderRoot; i++ {
+		domain.Generator.Square(&domain.Generator)
+	}
+
+	domain.preComputeTwiddles()
+
+	return domain
+}
+
+// At returns the i-th point of the domain (see Domain for the ordering)
+func (d *Domain) At(i uint64) Point {
+	half := d.Cardinality / 2
+	var g, res Point
+	g.Square(&d.Generator).Square(&g)
+	res = d.Generator
+	for e := i % half; e != 0; e >>= 1 {
+		if e&1 == 1 {
+			res.Mul(&res, &g)
+		}
+		g.Square(&g)
+	}
+	if i >= half {
+		res.Conjugate(&res)
+	}
+	return res
+}
+
+func (d *Domain) preComputeTwiddles() {
+	n := d.Cardinality
+	nbStages := bits.TrailingZeros64(n)
+	d.twiddles = make([][]fr.Element, nbStages)
+	d.twiddlesInv = make([][]fr.Element, nbStages)
+
+	// first n/2 points h·gⁱ
+	var g Point
+	g.Square(&d.Generator).Square(&g)
+	points := make([]Point, n/2)
+	points[0] = d.Generator
+	for i := 1; i < len(points); i++ {
+		points[i].Mul(&points[i-1], &g)
+	}
+
+	d.twiddles[0] = make([]fr.Element, n/2)
+	for i := range points {
+		d.twiddles[0][i] = points[i].Y
+	}
+
+	if nbStages > 1 {
+		// stage 1 uses the x coordinates of the first n/4 points, then each stage
+		// applies the doubling map π(x) = 2x² - 1 to the first half of the previous one
+		d.twiddles[1] = make([]fr.Element, n/4)
+		for i := range d.twiddles[1] {
+			d.twiddles[1][i] = points[i].X
+		}
+		one := fr.One()
+		for t := 2; t < nbStages; t++ {
+			d.twiddles[t] = make([]fr.Element, n>>(t+1))
+			for i := range d.twiddles[t] {
+				x := &d.twiddles[t][i]
+				x.Square(&d.twiddles[t-1][i]).Double(x).Sub(x, &one)
+			}
+		}
+	}
+
+	for t := range d.twiddles {
+		d.twiddlesInv[t] = fr.BatchInvert(d.twiddles[t])
+	}
+}
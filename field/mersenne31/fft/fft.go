@@ -0,0 +1,103 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fft
+
+import (
+	"math/bits"
+
+	fr "github.com/consensys/gnark-crypto/field/mersenne31"
+)
+
+// FFT evaluates, in place, the polynomial whose coefficients in the circle FFT basis
+// are given in a (natural order), on the points of the domain.
+//
+// len(a) must be equal to domain.Cardinality.
+func (domain *Domain) FFT(a []fr.Element) {
+	n := len(a)
+	if uint64(n) != domain.Cardinality {
+		panic("fft: len(a) must be equal to the domain cardinality")
+	}
+	bitReverse(a)
+
+	// x stages, from the innermost one:
+	// g(x) = g₀(π(x)) + x·g₁(π(x)) and g(-x) = g₀(π(x)) - x·g₁(π(x))
+	for t := len(domain.twiddles) - 1; t >= 1; t-- {
+		m := n >> (t + 1)
+		twiddles := domain.twiddles[t]
+		for start := 0; start < n; start += 2 * m {
+			for i := 0; i < m; i++ {
+				a[start+i+m].Mul(&a[start+i+m], &twiddles[i])
+				fr.Butterfly(&a[start+i], &a[start+i+m])
+			}
+		}
+	}
+
+	// y stage: f(P) = f₀(x) + y·f₁(x) and f(J(P)) = f₀(x) - y·f₁(x)
+	m := n >> 1
+	for i := 0; i < m; i++ {
+		a[i+m].Mul(&a[i+m], &domain.twiddles[0][i])
+		fr.Butterfly(&a[i], &a[i+m])
+	}
+}
+
+// FFTInverse interpolates, in place, the evaluations of a polynomial on the points of the domain
+// and returns its coefficients in the circle FFT basis (natural order).
+//
+// len(a) must be equal to domain.Cardinality.
+func (domain *Domain) FFTInverse(a []fr.Element) {
+	n := len(a)
+	if uint64(n) != domain.Cardinality {
+		panic("fft: len(a) must be equal to the domain cardinality")
+	}
+
+	// y stage: f₀(x) = (f(P) + f(J(P))) / 2 and f₁(x) = (f(P) - f(J(P))) / 2y
+	m := n >> 1
+	for i := 0; i < m; i++ {
+		fr.Butterfly(&a[i], &a[i+m])
+		a[i+m].Mul(&a[i+m], &domain.twiddlesInv[0][i])
+	}
+
+	// x stages: g₀(π(x)) = (g(x) + g(-x)) / 2 and g₁(π(x)) = (g(x) - g(-x)) / 2x
+	for t := 1; t < len(domain.twiddlesInv); t++ {
+		m := n >> (t + 1)
+		twiddlesInv := domain.twiddlesInv[t]
+		for start := 0; start < n; start += 2 * m {
+			for i := 0; i < m; i++ {
+				fr.Butterfly(&a[start+i], &a[start+i+m])
+				a[start+i+m].Mul(&a[start+i+m], &twiddlesInv[i])
+			}
+		}
+	}
+
+	// the divisions by 2 of each stage are done at once
+	for i := 0; i < n; i++ {
+		a[i].Mul(&a[i], &domain.CardinalityInv)
+	}
+
+	bitReverse(a)
+}
+
+// bitReverse applies the bit-reversal permutation to a; len(a) must be a power of 2
+func bitReverse(a []fr.Element) {
+	n := uint64(len(a))
+	nn := uint64(64 - bits.TrailingZeros64(n))
+
+	for i := uint64(0); i < n; i++ {
+		iRev := bits.Reverse64(i) >> nn
+		if iRev > i {
+			a[i], a[iRev] = a[iRev], a[i]
+		}
+	}
+}
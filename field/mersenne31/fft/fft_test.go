@@ -0,0 +1,140 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fft
+
+import (
+	"testing"
+
+	fr "github.com/consensys/gnark-crypto/field/mersenne31"
+)
+
+// evalBasis evaluates the polynomial of coefficients c in the circle FFT basis at p
+func evalBasis(c []fr.Element, p Point) fr.Element {
+	var res fr.Element
+	one := fr.One()
+	for j := range c {
+		b := one
+		if j&1 == 1 {
+			b.Mul(&b, &p.Y)
+		}
+		x := p.X
+		for i := 1; (j >> i) != 0; i++ {
+			if (j>>i)&1 == 1 {
+				b.Mul(&b, &x)
+			}
+			x.Square(&x).Double(&x).Sub(&x, &one)
+		}
+		b.Mul(&b, &c[j])
+		res.Add(&res, &b)
+	}
+	return res
+}
+
+func TestDomain(t *testing.T) {
+	for _, n := range []uint64{1, 2, 4, 8, 64} {
+		domain := NewDomain(n)
+		if !circleGenerator.IsOnCircle() || !domain.Generator.IsOnCircle() {
+			t.Fatal("generator is not on the circle")
+		}
+		// h has order 2n
+		g := domain.Generator
+		for i := uint64(1); i < 2*domain.Cardinality; i <<= 1 {
+			if g.X.IsOne() {
+				t.Fatal("generator has a smaller order than expected")
+			}
+			g.Square(&g)
+		}
+		if !g.X.IsOne() || !g.Y.IsZero() {
+			t.Fatal("generator doesn't have order 2n")
+		}
+		seen := make(map[Point]bool)
+		for i := uint64(0); i < domain.Cardinality; i++ {
+			p := domain.At(i)
+			if !p.IsOnCircle() {
+				t.Fatal("point is not on the circle")
+			}
+			if seen[p] {
+				t.Fatal("domain points are not distinct")
+			}
+			seen[p] = true
+		}
+	}
+}
+
+func TestFFT(t *testing.T) {
+	for _, n := range []uint64{2, 4, 8, 16, 32, 64} {
+		domain := NewDomain(n)
+
+		coeffs := make([]fr.Element, n)
+		for i := range coeffs {
+			coeffs[i].SetRandom()
+		}
+
+		evals := make([]fr.Element, n)
+		copy(evals, coeffs)
+		domain.FFT(evals)
+		for i := uint64(0); i < n; i++ {
+			expected := evalBasis(coeffs, domain.At(i))
+			if !evals[i].Equal(&expected) {
+				t.Fatalf("n=%d: FFT doesn't match the naive evaluation at %d", n, i)
+			}
+		}
+
+		domain.FFTInverse(evals)
+		for i := range evals {
+			if !evals[i].Equal(&coeffs[i]) {
+				t.Fatalf("n=%d: FFTInverse(FFT(a)) != a", n)
+			}
+		}
+	}
+}
+
+func TestFFTInverseOfX(t *testing.T) {
+	// f(x, y) = x is b₂
+	const n = 16
+	domain := NewDomain(n)
+	evals := make([]fr.Element, n)
+	for i := range evals {
+		evals[i] = domain.At(uint64(i)).X
+	}
+	domain.FFTInverse(evals)
+	for i := range evals {
+		if (i == 2) != evals[i].IsOne() || (i != 2 && !evals[i].IsZero()) {
+			t.Fatalf("unexpected coefficient %d: %s", i, evals[i].String())
+		}
+	}
+}
+
+func BenchmarkFFT(b *testing.B) {
+	const maxSize = 1 << 20
+	pol := make([]fr.Element, maxSize)
+	for i := range pol {
+		pol[i].SetRandom()
+	}
+	domain := NewDomain(maxSize)
+
+	b.Run("FFT", func(b *testing.B) {
+		b.ResetTimer()
+		for j := 0; j < b.N; j++ {
+			domain.FFT(pol)
+		}
+	})
+	b.Run("FFTInverse", func(b *testing.B) {
+		b.ResetTimer()
+		for j := 0; j < b.N; j++ {
+			domain.FFTInverse(pol)
+		}
+	})
+}
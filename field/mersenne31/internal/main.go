@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/field/generator"
+	"github.com/consensys/gnark-crypto/field/generator/config"
+)
+
+//go:generate go run main.go
+func main() {
+	const modulus = "0x7fffffff"
+	mersenne31, err := config.NewFieldConfig("mersenne31", "Element", modulus, false)
+	if err != nil {
+		panic(err)
+	}
+	if err := generator.GenerateFF(mersenne31, "../"); err != nil {
+		panic(err)
+	}
+
+	ext, err := config.NewExtensionsConfig(mersenne31, "github.com/consensys/gnark-crypto/field/mersenne31", -1, 5, [2]int64{2, 1})
+	if err != nil {
+		panic(err)
+	}
+	if err := generator.GenerateExtensions(ext, "../extensions"); err != nil {
+		panic(err)
+	}
+	fmt.Println("successfully generated mersenne31 field")
+}
@@ -29,6 +29,8 @@ import (
 	bw633 "github.com/consensys/gnark-crypto/ecc/bw6-633/fr/mimc"
 	bw756 "github.com/consensys/gnark-crypto/ecc/bw6-756/fr/mimc"
 	bw761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr/mimc"
+	pallas "github.com/consensys/gnark-crypto/ecc/pallas/fr/mimc"
+	vesta "github.com/consensys/gnark-crypto/ecc/vesta/fr/mimc"
 )
 
 type Hash uint
@@ -43,6 +45,8 @@ const (
 	MIMC_BLS24_317
 	MIMC_BW6_633
 	MIMC_BW6_756
+	MIMC_PALLAS
+	MIMC_VESTA
 )
 
 // size of digests in bytes
@@ -56,6 +60,8 @@ var digestSize = []uint8{
 	MIMC_BLS24_317: 48,
 	MIMC_BW6_633:   80,
 	MIMC_BW6_756:   96,
+	MIMC_PALLAS:    32,
+	MIMC_VESTA:     32,
 }
 
 // New creates the corresponding mimc hash function.
@@ -79,6 +85,10 @@ func (m Hash) New() hash.Hash {
 		return bw633.NewMiMC()
 	case MIMC_BW6_756:
 		return bw756.NewMiMC()
+	case MIMC_PALLAS:
+		return pallas.NewMiMC()
+	case MIMC_VESTA:
+		return vesta.NewMiMC()
 	default:
 		panic("Unknown mimc ID")
 	}
@@ -105,6 +115,10 @@ func (m Hash) String() string {
 		return "MIMC_BW633"
 	case MIMC_BW6_756:
 		return "MIMC_BW756"
+	case MIMC_PALLAS:
+		return "MIMC_PALLAS"
+	case MIMC_VESTA:
+		return "MIMC_VESTA"
 	default:
 		panic("Unknown mimc ID")
 	}
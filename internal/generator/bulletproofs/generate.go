@@ -0,0 +1,38 @@
+package bulletproofs
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+type templateData struct {
+	config.Curve
+	Encode    string // method returning the encoding of a G1Affine point
+	PointSize string // size of this encoding
+}
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+
+	// bulletproofs range proofs
+	conf.Package = "bulletproofs"
+	data := templateData{
+		Curve:     conf,
+		Encode:    "Bytes",
+		PointSize: "SizeOfG1AffineCompressed",
+	}
+	if conf.Equal(config.SECP256K1) {
+		// only the uncompressed encoding is implemented
+		data.Encode = "RawBytes"
+		data.PointSize = "SizeOfG1AffineUncompressed"
+	}
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "bulletproofs.go"), Templates: []string{"bulletproofs.go.tmpl"}},
+		{File: filepath.Join(baseDir, "bulletproofs_test.go"), Templates: []string{"bulletproofs.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
+	}
+	return bgen.Generate(data, conf.Package, "./bulletproofs/template/", entries...)
+
+}
@@ -305,15 +305,16 @@ var BLS12_377 = Curve{
 }
 
 var tBLS12_77 = TwistedEdwardsCurve{
-	Name:     BLS12_377.Name,
-	Package:  "twistededwards",
-	EnumID:   BLS12_377.EnumID,
-	A:        "-1",
-	D:        "3021",
-	Cofactor: "4",
-	Order:    "2111115437357092606062206234695386632838870926408408195193685246394721360383",
-	BaseX:    "717051916204163000937139483451426116831771857428389560441264442629694842243",
-	BaseY:    "882565546457454111605105352482086902132191855952243170543452705048019814192",
+	Name:        BLS12_377.Name,
+	Package:     "twistededwards",
+	EnumID:      BLS12_377.EnumID,
+	A:           "-1",
+	D:           "3021",
+	Cofactor:    "4",
+	Order:       "2111115437357092606062206234695386632838870926408408195193685246394721360383",
+	BaseX:       "717051916204163000937139483451426116831771857428389560441264442629694842243",
+	BaseY:       "882565546457454111605105352482086902132191855952243170543452705048019814192",
+	Elligator2Z: "11",
 }
 
 func init() {
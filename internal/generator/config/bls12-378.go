@@ -57,15 +57,16 @@ var BLS12_378 = Curve{
 }
 
 var tBLS12_78 = TwistedEdwardsCurve{
-	Name:     BLS12_378.Name,
-	Package:  "twistededwards",
-	EnumID:   BLS12_378.EnumID,
-	A:        "16249",
-	D:        "826857503717340716663906603396009292766308904506333520048618402505612607353",
-	Cofactor: "8",
-	Order:    "1860429383364016612493789857641020908721690454530426945748883177201355593303",
-	BaseX:    "6772953896463446981848394912418300623023000177913479948380771331313783560843",
-	BaseY:    "9922290044608088599966879240752111513195706854076002240583420830067351093249",
+	Name:        BLS12_378.Name,
+	Package:     "twistededwards",
+	EnumID:      BLS12_378.EnumID,
+	A:           "16249",
+	D:           "826857503717340716663906603396009292766308904506333520048618402505612607353",
+	Cofactor:    "8",
+	Order:       "1860429383364016612493789857641020908721690454530426945748883177201355593303",
+	BaseX:       "6772953896463446981848394912418300623023000177913479948380771331313783560843",
+	BaseY:       "9922290044608088599966879240752111513195706854076002240583420830067351093249",
+	Elligator2Z: "5",
 }
 
 func init() {
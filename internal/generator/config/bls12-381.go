@@ -134,27 +134,30 @@ var BLS12_381 = Curve{
 }
 
 var tBLS12_381 = TwistedEdwardsCurve{
-	Name:     BLS12_381.Name,
-	Package:  "twistededwards",
-	EnumID:   BLS12_381.EnumID,
-	A:        "-1",
-	D:        "19257038036680949359750312669786877991949435402254120286184196891950884077233",
-	Cofactor: "8",
-	Order:    "6554484396890773809930967563523245729705921265872317281365359162392183254199",
-	BaseX:    "23426137002068529236790192115758361610982344002369094106619281483467893291614",
-	BaseY:    "39325435222430376843701388596190331198052476467368316772266670064146548432123",
+	Name:        BLS12_381.Name,
+	Package:     "twistededwards",
+	EnumID:      BLS12_381.EnumID,
+	A:           "-1",
+	D:           "19257038036680949359750312669786877991949435402254120286184196891950884077233",
+	Cofactor:    "8",
+	Order:       "6554484396890773809930967563523245729705921265872317281365359162392183254199",
+	BaseX:       "23426137002068529236790192115758361610982344002369094106619281483467893291614",
+	BaseY:       "39325435222430376843701388596190331198052476467368316772266670064146548432123",
+	Elligator2Z: "5",
 }
 
 var bandersnatch = TwistedEdwardsCurve{
 	Name:            BLS12_381.Name,
 	Package:         "bandersnatch",
 	EnumID:          BLS12_381.EnumID,
+	ScalarField:     true,
 	A:               "-5",
 	D:               "45022363124591815672509500913686876175488063829319466900776701791074614335719",
 	Cofactor:        "4",
 	Order:           "13108968793781547619861935127046491459309155893440570251786403306729687672801",
 	BaseX:           "18886178867200960497001835917649091219057080094937609519140440539760939937304",
 	BaseY:           "19188667384257783945677642223292697773471335439753913231509108946878080696678",
+	Elligator2Z:     "5",
 	HasEndomorphism: true,
 	Endo0:           "37446463827641770816307242315180085052603635617490163568005256780843403514036",
 	Endo1:           "49199877423542878313146170939139662862850515542392585932876811575731455068989",
@@ -57,15 +57,16 @@ var BLS24_315 = Curve{
 }
 
 var tBLS24_315 = TwistedEdwardsCurve{
-	Name:     BLS24_315.Name,
-	Package:  "twistededwards",
-	EnumID:   BLS24_315.EnumID,
-	A:        "-1",
-	D:        "8771873785799030510227956919069912715983412030268481769609515223557738569779",
-	Cofactor: "8",
-	Order:    "1437753473921907580703509300571927811987591765799164617677716990775193563777",
-	BaseX:    "750878639751052675245442739791837325424717022593512121860796337974109802674",
-	BaseY:    "1210739767513185331118744674165833946943116652645479549122735386298364723201",
+	Name:        BLS24_315.Name,
+	Package:     "twistededwards",
+	EnumID:      BLS24_315.EnumID,
+	A:           "-1",
+	D:           "8771873785799030510227956919069912715983412030268481769609515223557738569779",
+	Cofactor:    "8",
+	Order:       "1437753473921907580703509300571927811987591765799164617677716990775193563777",
+	BaseX:       "750878639751052675245442739791837325424717022593512121860796337974109802674",
+	BaseY:       "1210739767513185331118744674165833946943116652645479549122735386298364723201",
+	Elligator2Z: "7",
 }
 
 func init() {
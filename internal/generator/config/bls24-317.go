@@ -69,15 +69,16 @@ var BLS24_317 = Curve{
 }
 
 var tBLS24_317 = TwistedEdwardsCurve{
-	Name:     BLS24_317.Name,
-	Package:  "twistededwards",
-	EnumID:   BLS24_317.EnumID,
-	A:        "-1",
-	D:        "20748505950524021841644589704740731932416084248011369709738936344973878925081",
-	Cofactor: "8",
-	Order:    "3858698654557105525567273719690987823069521430163883173133245580997415449969",
-	BaseX:    "4348505656527095883506785370890963704100065639426869666063106978260788240233",
-	BaseY:    "1929349327278552762783636859845493911537170411830425720219700276810167091201",
+	Name:        BLS24_317.Name,
+	Package:     "twistededwards",
+	EnumID:      BLS24_317.EnumID,
+	A:           "-1",
+	D:           "20748505950524021841644589704740731932416084248011369709738936344973878925081",
+	Cofactor:    "8",
+	Order:       "3858698654557105525567273719690987823069521430163883173133245580997415449969",
+	BaseX:       "4348505656527095883506785370890963704100065639426869666063106978260788240233",
+	BaseY:       "1929349327278552762783636859845493911537170411830425720219700276810167091201",
+	Elligator2Z: "7",
 }
 
 func init() {
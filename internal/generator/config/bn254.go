@@ -55,15 +55,16 @@ var BN254 = Curve{
 }
 
 var tBN254 = TwistedEdwardsCurve{
-	Name:     BN254.Name,
-	Package:  "twistededwards",
-	EnumID:   BN254.EnumID,
-	A:        "-1",
-	D:        "12181644023421730124874158521699555681764249180949974110617291017600649128846",
-	Cofactor: "8",
-	Order:    "2736030358979909402780800718157159386076813972158567259200215660948447373041",
-	BaseX:    "9671717474070082183213120605117400219616337014328744928644933853176787189663",
-	BaseY:    "16950150798460657717958625567821834550301663161624707787222815936182638968203",
+	Name:        BN254.Name,
+	Package:     "twistededwards",
+	EnumID:      BN254.EnumID,
+	A:           "-1",
+	D:           "12181644023421730124874158521699555681764249180949974110617291017600649128846",
+	Cofactor:    "8",
+	Order:       "2736030358979909402780800718157159386076813972158567259200215660948447373041",
+	BaseX:       "9671717474070082183213120605117400219616337014328744928644933853176787189663",
+	BaseY:       "16950150798460657717958625567821834550301663161624707787222815936182638968203",
+	Elligator2Z: "5",
 }
 
 func init() {
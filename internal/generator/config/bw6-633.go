@@ -110,15 +110,16 @@ var BW6_633 = Curve{
 }
 
 var tBW6_633 = TwistedEdwardsCurve{
-	Name:     BW6_633.Name,
-	Package:  "twistededwards",
-	EnumID:   BW6_633.EnumID,
-	A:        "-1",
-	D:        "37248940285811842784899494310834635440994424264352085037441815381151934266434102922992043546621",
-	Cofactor: "8",
-	Order:    "4963142838689179791878211236301121218116687802119716497817028544854034649070444389864454748079",
-	BaseX:    "37635937024655419978837220647164498012335808680404874556501960268316961933409049243153117555100",
-	BaseY:    "23823085625708063001015413934245381846960101450148849601038571303382730455875805408244170280142",
+	Name:        BW6_633.Name,
+	Package:     "twistededwards",
+	EnumID:      BW6_633.EnumID,
+	A:           "-1",
+	D:           "37248940285811842784899494310834635440994424264352085037441815381151934266434102922992043546621",
+	Cofactor:    "8",
+	Order:       "4963142838689179791878211236301121218116687802119716497817028544854034649070444389864454748079",
+	BaseX:       "37635937024655419978837220647164498012335808680404874556501960268316961933409049243153117555100",
+	BaseY:       "23823085625708063001015413934245381846960101450148849601038571303382730455875805408244170280142",
+	Elligator2Z: "13",
 }
 
 func init() {
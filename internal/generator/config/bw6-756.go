@@ -160,15 +160,16 @@ var BW6_756 = Curve{
 }
 
 var tBW6_756 = TwistedEdwardsCurve{
-	Name:     BW6_756.Name,
-	Package:  "twistededwards",
-	EnumID:   BW6_756.EnumID,
-	A:        "35895",
-	D:        "35894",
-	Cofactor: "8",
-	Order:    "75656025759413271466656060197725120092480961471365614219134998880569790930794516726065877484428941069706901665493",
-	BaseX:    "357240753431396842603421262238241571158569743053156052278371293545344505472364896271378029423975465332156840775830",
-	BaseY:    "279345325880910540799960837653138904956852780817349960193932651092957355032339063742900216468694143617372745972501",
+	Name:        BW6_756.Name,
+	Package:     "twistededwards",
+	EnumID:      BW6_756.EnumID,
+	A:           "35895",
+	D:           "35894",
+	Cofactor:    "8",
+	Order:       "75656025759413271466656060197725120092480961471365614219134998880569790930794516726065877484428941069706901665493",
+	BaseX:       "357240753431396842603421262238241571158569743053156052278371293545344505472364896271378029423975465332156840775830",
+	BaseY:       "279345325880910540799960837653138904956852780817349960193932651092957355032339063742900216468694143617372745972501",
+	Elligator2Z: "5",
 }
 
 func init() {
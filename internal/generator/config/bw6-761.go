@@ -262,15 +262,16 @@ var BW6_761 = Curve{
 }
 
 var tBW6_761 = TwistedEdwardsCurve{
-	Name:     BW6_761.Name,
-	Package:  "twistededwards",
-	EnumID:   BW6_761.EnumID,
-	A:        "-1",
-	D:        "79743",
-	Cofactor: "8",
-	Order:    "32333053251621136751331591711861691692049189094364332567435817881934511297123972799646723302813083835942624121493",
-	BaseX:    "109887223397525145051017418760180386187632078445902299543670312117371514695798874370143656894667315818446285582389",
-	BaseY:    "31146823455109675839494591101665406662142618451815824757336761504421066243585705807124836638254810186490790034654",
+	Name:        BW6_761.Name,
+	Package:     "twistededwards",
+	EnumID:      BW6_761.EnumID,
+	A:           "-1",
+	D:           "79743",
+	Cofactor:    "8",
+	Order:       "32333053251621136751331591711861691692049189094364332567435817881934511297123972799646723302813083835942624121493",
+	BaseX:       "109887223397525145051017418760180386187632078445902299543670312117371514695798874370143656894667315818446285582389",
+	BaseY:       "31146823455109675839494591101665406662142618451815824757336761504421066243585705807124836638254810186490790034654",
+	Elligator2Z: "5",
 }
 
 func init() {
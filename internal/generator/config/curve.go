@@ -32,14 +32,35 @@ type TwistedEdwardsCurve struct {
 	Package string
 	EnumID  string
 
+	// set if the curve is not the companion curve of a pairing friendly curve:
+	// its coordinates are in its own base field fp (instead of the scalar field
+	// fr of the inner curve) and it lives in ecc/<Name>
+	Standalone bool
+
+	// set if the field of the order of the prime subgroup is generated in
+	// <Package>/fr (standalone curves already have it in ecc/<Name>/fr)
+	ScalarField bool
+
 	A, D, Cofactor, Order, BaseX, BaseY string
 
+	// non square of smallest absolute value used by the Elligator 2 map
+	// (RFC 9380, Appendix H.5), only for companion curves
+	Elligator2Z string
+
 	// set if endomorphism
 	HasEndomorphism bool
 	Endo0, Endo1    string
 	Lambda          string
 }
 
+// Field returns the package of the field the coordinates of the curve are in
+func (c TwistedEdwardsCurve) Field() string {
+	if c.Standalone {
+		return "fp"
+	}
+	return "fr"
+}
+
 type Field struct {
 	Bits    int
 	Bytes   int
@@ -59,6 +80,7 @@ type Point struct {
 	CofactorCleaning bool     // flag telling if the Cofactor cleaning is available
 	CRange           []int    // multiexp bucket method: generate inner methods (with const arrays) for each c
 	Projective       bool     // generate projective coordinates
+	AMinus3          bool     // the curve is Y²=X³-3X+b (a=0 otherwise)
 	A                []string //A linear coefficient in Weierstrass form
 	B                []string //B constant term in Weierstrass form
 }
@@ -99,4 +121,8 @@ type FieldDependency struct {
 	FieldPackagePath string
 	ElementType      string
 	FieldPackageName string
+	// ElementName is set when the field package defines several element types, as the
+	// extensions packages do; its package-level helpers are then suffixed by it
+	// (e.g. extensions.BatchInvertE4)
+	ElementName string
 }
@@ -0,0 +1,30 @@
+package config
+
+// ED25519 is the twisted Edwards curve -x² + y² = 1 + d⋅x²y² over 𝔽p, p = 2²⁵⁵-19, birationally
+// equivalent to Curve25519 (RFC 7748); its prime subgroup has order ℓ = 2²⁵² + 27742317777372353535851937790883648493.
+// Only its fields are generated from this entry: its point arithmetic is generated from tED25519.
+var ED25519 = Curve{
+	Name:         "ed25519",
+	CurvePackage: "ed25519",
+	EnumID:       "ED25519",
+	FrModulus:    "7237005577332262213973186563042994240857116359379907606001950938285454250989",
+	FpModulus:    "57896044618658097711785492504343953926634992332820282019728792003956564819949",
+}
+
+var tED25519 = TwistedEdwardsCurve{
+	Name:       ED25519.Name,
+	Package:    ED25519.CurvePackage,
+	EnumID:     ED25519.EnumID,
+	Standalone: true,
+	A:          "-1",
+	D:          "37095705934669439343138083508754565189542113879843219016388785533085940283555",
+	Cofactor:   "8",
+	Order:      "7237005577332262213973186563042994240857116359379907606001950938285454250989",
+	BaseX:      "15112221349535400772501151409588531511454012693041857206046113283949847762202",
+	BaseY:      "46316835694926478169428394003475163141307993866256225615783033603165251855960",
+}
+
+func init() {
+	addCurve(&ED25519)
+	addTwistedEdwardCurve(&tED25519)
+}
@@ -0,0 +1,55 @@
+package config
+
+// PALLAS is the curve y² = x³ + 5 over the base field of VESTA, whose scalar field
+// it uses as base field: together they form the Pasta cycle of curves.
+var PALLAS = Curve{
+	Name:         "pallas",
+	CurvePackage: "pallas",
+	EnumID:       "PALLAS",
+	FrModulus:    "28948022309329048855892746252171976963363056481941647379679742748393362948097",
+	FpModulus:    "28948022309329048855892746252171976963363056481941560715954676764349967630337",
+	G1: Point{
+		CoordType:        "fp.Element",
+		CoordExtDegree:   1,
+		PointName:        "g1",
+		GLV:              true,
+		CofactorCleaning: false,
+		CRange:           defaultCRange(),
+	},
+	HashE1: &HashSuiteSswu{
+		A: []string{"0x18354a2eb0ea8c9c49be2d7258370742b74134581a27a59f92bb4b0b657a014b"},
+		B: []string{"0x4f1"},
+		Z: []int{-13},
+		Isogeny: &Isogeny{
+			XMap: RationalPolynomial{
+				Num: [][]string{
+					{"0x1c71c71c71c71c71c71c71c71c71c71c8102eea8e7b06eb6eebec06955555580"},
+					{"0x17329b9ec525375398c7d7ac3d98fd13380af066cfeb6d690eb64faef37ea4f7"},
+					{"0x3509afd51872d88e267c7ffa51cf412a0f93b82ee4b994958cf863b02814fb76"},
+					{"0xe38e38e38e38e38e38e38e38e38e38e4081775473d8375b775f6034aaaaaaab"},
+				},
+				Den: [][]string{
+					{"0x325669becaecd5d11d13bf2a7f22b105b4abf9fb9a1fc81c2aa3af1eae5b6604"},
+					{"0x1d572e7ddc099cff5a607fcce0494a799c434ac1c96b6980c47f2ab668bcd71f"},
+				},
+			},
+			YMap: RationalPolynomial{
+				Num: [][]string{
+					{"0x25ed097b425ed097b425ed097b425ed0ac03e8e134eb3e493e53ab371c71c4f"},
+					{"0x3fb98ff0d2ddcadd303216cce1db9ff11765e924f745937802e2be87d225b234"},
+					{"0x1a84d7ea8c396c47133e3ffd28e7a09507c9dc17725cca4ac67c31d8140a7dbb"},
+					{"0x1a12f684bda12f684bda12f684bda12f7642b01ad461bad25ad985b5e38e38e4"},
+				},
+				Den: [][]string{
+					{"0x40000000000000000000000000000000224698fc094cf91b992d30ecfffffde5"},
+					{"0x17033d3c60c68173573b3d7f7d681310d976bbfabbc5661d4d90ab820b12320a"},
+					{"0xc02c5bcca0e6b7f0790bfb3506defb65941a3a4a97aa1b35a28279b1d1b42ae"},
+				},
+			},
+		},
+	},
+}
+
+func init() {
+	addCurve(&PALLAS)
+}
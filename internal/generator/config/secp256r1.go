@@ -0,0 +1,28 @@
+package config
+
+var SECP256R1 = Curve{
+	Name:         "secp256r1",
+	CurvePackage: "secp256r1",
+	EnumID:       "SECP256R1",
+	FrModulus:    "115792089210356248762697446949407573529996955224135760342422259061068512044369",
+	FpModulus:    "115792089210356248762697446949407573530086143415290314195533631308867097853951",
+	G1: Point{
+		CoordType:        "fp.Element",
+		CoordExtDegree:   1,
+		PointName:        "g1",
+		GLV:              false,
+		AMinus3:          true,
+		CofactorCleaning: false,
+		CRange:           []int{4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+	},
+	// P256_XMD:SHA-256_SSWU_RO_ (RFC 9380, section 8.2): SSWU directly on the curve
+	HashE1: &HashSuiteSswu{
+		A: []string{"-3"},
+		B: []string{"41058363725152142129326129780047268409114441015993725554835256314039467401291"},
+		Z: []int{-10},
+	},
+}
+
+func init() {
+	addCurve(&SECP256R1)
+}
@@ -0,0 +1,55 @@
+package config
+
+// VESTA is the curve y² = x³ + 5 over the scalar field of PALLAS, whose base field
+// it uses as scalar field: together they form the Pasta cycle of curves.
+var VESTA = Curve{
+	Name:         "vesta",
+	CurvePackage: "vesta",
+	EnumID:       "VESTA",
+	FrModulus:    "28948022309329048855892746252171976963363056481941560715954676764349967630337",
+	FpModulus:    "28948022309329048855892746252171976963363056481941647379679742748393362948097",
+	G1: Point{
+		CoordType:        "fp.Element",
+		CoordExtDegree:   1,
+		PointName:        "g1",
+		GLV:              true,
+		CofactorCleaning: false,
+		CRange:           defaultCRange(),
+	},
+	HashE1: &HashSuiteSswu{
+		A: []string{"0x267f9b2ee592271a81639c4d96f787739673928c7d01b212c515ad7242eaa6b1"},
+		B: []string{"0x4f1"},
+		Z: []int{-13},
+		Isogeny: &Isogeny{
+			XMap: RationalPolynomial{
+				Num: [][]string{
+					{"0x31c71c71c71c71c71c71c71c71c71c71e1c521a795ac8356fb539a6f0000002b"},
+					{"0x18760c7f7a9ad20ded7ee4a9cdf78f8fd59d03d23b39cb11aeac67bbeb586a3d"},
+					{"0x1d935247b4473d17acecf10f5f7c09a2216b8861ec72bd5d8b95c6aaf703bcc5"},
+					{"0x38e38e38e38e38e38e38e38e38e38e390205dd51cfa0961a43cd42c800000001"},
+				},
+				Den: [][]string{
+					{"0x14735171ee5427780c621de8b91c242a30cd6d53df49d235f169c187d2533465"},
+					{"0xa2de485568125d51454798a5b5c56b2a3ad678129b604d3b7284f7eaf21a2e9"},
+				},
+			},
+			YMap: RationalPolynomial{
+				Num: [][]string{
+					{"0x1ed097b425ed097b425ed097b425ed098bc32d36fb21a6a38f64842c55555533"},
+					{"0x19b0d87e16e2578866d1466e9de10e6497a3ca5c24e9ea634986913ab4443034"},
+					{"0x2ec9a923da239e8bd6767887afbe04d121d910aefb03b31d8bee58e5fb81de63"},
+					{"0x12f684bda12f684bda12f684bda12f685601f4709a8adcb36bef1642aaaaaaab"},
+				},
+				Den: [][]string{
+					{"0x40000000000000000000000000000000224698fc0994a8dd8c46eb20fffffde5"},
+					{"0x3d59f455cafc7668252659ba2b546c7e926847fb9ddd76a1d43d449776f99d2f"},
+					{"0x2f44d6c801c1b8bf9e7eb64f890a820c06a767bfc35b5bac58dfecce86b2745e"},
+				},
+			},
+		},
+	},
+}
+
+func init() {
+	addCurve(&VESTA)
+}
@@ -2,6 +2,7 @@ package ecc
 
 import (
 	"fmt"
+	"math/big"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -33,6 +34,9 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 
 		funcs := make(template.FuncMap)
 		funcs["asElement"] = hashConf.Field.Base.WriteElement
+		funcs["abs"] = func(z big.Int) big.Int {
+			return *new(big.Int).Abs(&z)
+		}
 		bavardOpts := []func(*bavard.Bavard) error{bavard.Funcs(funcs)}
 
 		return bgen.GenerateWithOptions(hashConf, packageName, "./ecc/template", bavardOpts, entries...)
@@ -173,8 +177,8 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 		return err
 	}
 
-	// No G2 for secp256k1
-	if conf.Equal(config.SECP256K1) {
+	// No G2 for secp256k1 and secp256r1, their marshal is hand written
+	if conf.Equal(config.SECP256K1) || conf.Equal(config.SECP256R1) {
 		return nil
 	}
 
@@ -189,6 +193,11 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 		return err
 	}
 
+	// No G2 for grumpkin, pallas and vesta
+	if conf.G2.PointName == "" {
+		return nil
+	}
+
 	// G2
 	entries = []bavard.Entry{
 		{File: filepath.Join(baseDir, "g2.go"), Templates: []string{"point.go.tmpl"}},
@@ -15,9 +15,13 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 	entries := []bavard.Entry{
 		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
 		{File: filepath.Join(baseDir, "ecdsa.go"), Templates: []string{"ecdsa.go.tmpl"}},
+		{File: filepath.Join(baseDir, "ecdh.go"), Templates: []string{"ecdh.go.tmpl"}},
+		{File: filepath.Join(baseDir, "ecdh_test.go"), Templates: []string{"ecdh.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "ecies.go"), Templates: []string{"ecies.go.tmpl"}},
 		{File: filepath.Join(baseDir, "ecdsa_test.go"), Templates: []string{"ecdsa.test.go.tmpl"}},
 		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
 		{File: filepath.Join(baseDir, "marshal_test.go"), Templates: []string{"marshal.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "rfc6979.go"), Templates: []string{"rfc6979.go.tmpl"}},
 	}
 	return bgen.Generate(conf, conf.Package, "./ecdsa/template", entries...)
 
@@ -0,0 +1,36 @@
+package ecvrf
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+type templateData struct {
+	config.Curve
+	Suite       string // name of the ciphersuite
+	SuiteString string // identifier of the ciphersuite, prefix of all the hashes
+}
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+
+	// ECVRF with try-and-increment encoding to the curve (RFC 9381)
+	conf.Package = "ecvrf"
+	data := templateData{Curve: conf}
+	switch {
+	case conf.Equal(config.SECP256R1):
+		data.Suite, data.SuiteString = "ECVRF-P256-SHA256-TAI", "0x01"
+	default:
+		panic("no ECVRF ciphersuite for " + conf.Name)
+	}
+
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "ecvrf.go"), Templates: []string{"ecvrf.go.tmpl"}},
+		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
+		{File: filepath.Join(baseDir, "ecvrf_test.go"), Templates: []string{"ecvrf.test.go.tmpl"}},
+	}
+	return bgen.Generate(data, conf.Package, "./ecvrf/template/", entries...)
+
+}
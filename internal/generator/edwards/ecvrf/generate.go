@@ -0,0 +1,29 @@
+package ecvrf
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+type templateData struct {
+	config.TwistedEdwardsCurve
+	CurvePackage string // package of the curve, where the ecvrf package is
+}
+
+func Generate(conf config.TwistedEdwardsCurve, baseDir string, bgen *bavard.BatchGenerator) error {
+	// ECVRF with try-and-increment encoding to the curve, with any hash
+	data := templateData{TwistedEdwardsCurve: conf, CurvePackage: conf.Package}
+	data.Package = "ecvrf"
+	baseDir = filepath.Join(baseDir, data.Package)
+
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "ecvrf.go"), Templates: []string{"ecvrf.go.tmpl"}},
+		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
+		{File: filepath.Join(baseDir, "ecvrf_test.go"), Templates: []string{"ecvrf.test.go.tmpl"}},
+	}
+	return bgen.Generate(data, data.Package, "./edwards/ecvrf/template", entries...)
+
+}
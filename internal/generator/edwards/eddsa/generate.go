@@ -17,6 +17,10 @@ func Generate(conf config.TwistedEdwardsCurve, baseDir string, bgen *bavard.Batc
 		{File: filepath.Join(baseDir, "eddsa.go"), Templates: []string{"eddsa.go.tmpl"}},
 		{File: filepath.Join(baseDir, "eddsa_test.go"), Templates: []string{"eddsa.test.go.tmpl"}},
 		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
+		{File: filepath.Join(baseDir, "batch.go"), Templates: []string{"batch.go.tmpl"}},
+		{File: filepath.Join(baseDir, "ecdh.go"), Templates: []string{"ecdh.go.tmpl"}},
+		{File: filepath.Join(baseDir, "ecdh_test.go"), Templates: []string{"ecdh.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "ecies.go"), Templates: []string{"ecies.go.tmpl"}},
 	}
 	return bgen.Generate(conf, conf.Package, "./edwards/eddsa/template", entries...)
 
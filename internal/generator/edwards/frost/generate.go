@@ -0,0 +1,30 @@
+package frost
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+type templateData struct {
+	config.TwistedEdwardsCurve
+	CurvePackage string // package of the curve, where the eddsa package is
+}
+
+func Generate(conf config.TwistedEdwardsCurve, baseDir string, bgen *bavard.BatchGenerator) error {
+	// frost threshold signatures, producing eddsa signatures
+	data := templateData{TwistedEdwardsCurve: conf, CurvePackage: conf.Package}
+	data.Package = "frost"
+	baseDir = filepath.Join(baseDir, data.Package)
+
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "frost.go"), Templates: []string{"frost.go.tmpl"}},
+		{File: filepath.Join(baseDir, "dkg.go"), Templates: []string{"dkg.go.tmpl"}},
+		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
+		{File: filepath.Join(baseDir, "frost_test.go"), Templates: []string{"frost.test.go.tmpl"}},
+	}
+	return bgen.Generate(data, data.Package, "./edwards/frost/template", entries...)
+
+}
@@ -11,9 +11,20 @@ func Generate(conf config.TwistedEdwardsCurve, baseDir string, bgen *bavard.Batc
 	entries := []bavard.Entry{
 		{File: filepath.Join(baseDir, "point.go"), Templates: []string{"point.go.tmpl"}},
 		{File: filepath.Join(baseDir, "point_test.go"), Templates: []string{"tests/point.go.tmpl"}},
-		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "multiexp.go"), Templates: []string{"multiexp.go.tmpl"}},
+		{File: filepath.Join(baseDir, "multiexp_test.go"), Templates: []string{"tests/multiexp.go.tmpl"}},
 		{File: filepath.Join(baseDir, "curve.go"), Templates: []string{"curve.go.tmpl"}},
 	}
+	if !conf.Standalone {
+		// the package documentation of a standalone curve is hand written
+		entries = append(entries, bavard.Entry{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}})
+
+		// Elligator 2 hash-to-curve on the companion curves
+		entries = append(entries,
+			bavard.Entry{File: filepath.Join(baseDir, "hash_to_curve.go"), Templates: []string{"hash_to_curve.go.tmpl"}},
+			bavard.Entry{File: filepath.Join(baseDir, "hash_to_curve_test.go"), Templates: []string{"tests/hash_to_curve.go.tmpl"}},
+		)
+	}
 
 	return bgen.Generate(conf, conf.Package, "./edwards/template", entries...)
 }
@@ -8,7 +8,22 @@ import (
 	"github.com/consensys/gnark-crypto/internal/generator/config"
 )
 
+type fftConfig struct {
+	config.Curve
+	IsField bool // set when generating for a standalone field (see field/) rather than a curve scalar field
+}
+
 func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+	return generate(fftConfig{Curve: conf}, baseDir, bgen)
+}
+
+// GenerateField generates the fft package of a standalone field, such as field/babybear.
+// The field package is the parent directory of baseDir.
+func GenerateField(name, baseDir string, bgen *bavard.BatchGenerator) error {
+	return generate(fftConfig{Curve: config.Curve{Name: name}, IsField: true}, baseDir, bgen)
+}
+
+func generate(conf fftConfig, baseDir string, bgen *bavard.BatchGenerator) error {
 
 	conf.Package = "fft"
 
@@ -59,7 +74,7 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 	entries = []bavard.Entry{
 		{File: filepath.Join(frDir, "generator.go"), Templates: []string{"fr.generator.go.tmpl"}},
 	}
-	return bgen.GenerateWithOptions(conf, "fr", "./fft/template/", bavardOpts, entries...)
+	return bgen.GenerateWithOptions(conf, filepath.Base(frDir), "./fft/template/", bavardOpts, entries...)
 }
 
 func anyToUint64(x any) uint64 {
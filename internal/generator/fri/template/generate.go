@@ -7,7 +7,23 @@ import (
 	"github.com/consensys/gnark-crypto/internal/generator/config"
 )
 
+type friConfig struct {
+	config.Curve
+	Extension string // set when the challenges are sampled from field/<Name>/extensions.<Extension> (small fields)
+}
+
 func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+	return generate(friConfig{Curve: conf}, baseDir, bgen)
+}
+
+// GenerateField generates the fri package of a standalone field, such as field/babybear.
+// The field is too small to sample the folding challenges from, so they are sampled from
+// the extension field/<name>/extensions.<extension> instead.
+func GenerateField(name, extension, baseDir string, bgen *bavard.BatchGenerator) error {
+	return generate(friConfig{Curve: config.Curve{Name: name}, Extension: extension}, baseDir, bgen)
+}
+
+func generate(conf friConfig, baseDir string, bgen *bavard.BatchGenerator) error {
 
 	// fri commitment scheme
 	conf.Package = "fri"
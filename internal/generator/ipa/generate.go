@@ -0,0 +1,58 @@
+package ipa
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+// Group describes the prime order group the inner product argument is instantiated on
+type Group struct {
+	Package      string
+	GroupPath    string // import path of the group package, relative to gnark-crypto/ecc
+	GroupPackage string // name of the group package
+	FrPath       string // import path of the scalar field, relative to gnark-crypto/ecc
+	Point        string // type of the group elements
+	PointSize    string // size of their encoding
+	Encode       string // method returning their encoding, as an array of PointSize bytes
+	Banderwagon  bool
+}
+
+// FromCurve returns the Group of the G1 points of a short Weierstrass curve
+func FromCurve(conf config.Curve) Group {
+	return Group{
+		GroupPath:    conf.Name,
+		GroupPackage: conf.CurvePackage,
+		FrPath:       conf.Name + "/fr",
+		Point:        "G1Affine",
+		PointSize:    "SizeOfG1AffineUncompressed",
+		Encode:       "RawBytes",
+	}
+}
+
+// Banderwagon is the prime order quotient group of bandersnatch used by Verkle trees
+var Banderwagon = Group{
+	GroupPath:    "bls12-381/bandersnatch/banderwagon",
+	GroupPackage: "banderwagon",
+	FrPath:       "bls12-381/bandersnatch/fr",
+	Point:        "Element",
+	PointSize:    "EncodedSize",
+	Encode:       "Bytes",
+	Banderwagon:  true,
+}
+
+func Generate(g Group, baseDir string, bgen *bavard.BatchGenerator) error {
+
+	// inner product argument polynomial commitment scheme
+	g.Package = "ipa"
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "ipa.go"), Templates: []string{"ipa.go.tmpl"}},
+		{File: filepath.Join(baseDir, "ipa_test.go"), Templates: []string{"ipa.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "multiproof.go"), Templates: []string{"multiproof.go.tmpl"}},
+		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
+	}
+	return bgen.Generate(g, g.Package, "./ipa/template/", entries...)
+
+}
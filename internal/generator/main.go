@@ -10,23 +10,29 @@ import (
 	"github.com/consensys/bavard"
 	"github.com/consensys/gnark-crypto/field/generator"
 	field "github.com/consensys/gnark-crypto/field/generator/config"
+	"github.com/consensys/gnark-crypto/internal/generator/bulletproofs"
 	"github.com/consensys/gnark-crypto/internal/generator/config"
 	"github.com/consensys/gnark-crypto/internal/generator/crypto/hash/mimc"
 	"github.com/consensys/gnark-crypto/internal/generator/ecc"
 	"github.com/consensys/gnark-crypto/internal/generator/ecdsa"
+	"github.com/consensys/gnark-crypto/internal/generator/ecvrf"
 	"github.com/consensys/gnark-crypto/internal/generator/edwards"
+	edwardsecvrf "github.com/consensys/gnark-crypto/internal/generator/edwards/ecvrf"
 	"github.com/consensys/gnark-crypto/internal/generator/edwards/eddsa"
+	"github.com/consensys/gnark-crypto/internal/generator/edwards/frost"
 	"github.com/consensys/gnark-crypto/internal/generator/fft"
 	fri "github.com/consensys/gnark-crypto/internal/generator/fri/template"
 	"github.com/consensys/gnark-crypto/internal/generator/gkr"
 	"github.com/consensys/gnark-crypto/internal/generator/hash_to_field"
 	"github.com/consensys/gnark-crypto/internal/generator/iop"
+	"github.com/consensys/gnark-crypto/internal/generator/ipa"
 	"github.com/consensys/gnark-crypto/internal/generator/kzg"
 	"github.com/consensys/gnark-crypto/internal/generator/pairing"
 	"github.com/consensys/gnark-crypto/internal/generator/pedersen"
 	"github.com/consensys/gnark-crypto/internal/generator/permutation"
 	"github.com/consensys/gnark-crypto/internal/generator/plookup"
 	"github.com/consensys/gnark-crypto/internal/generator/polynomial"
+	"github.com/consensys/gnark-crypto/internal/generator/secretsharing"
 	"github.com/consensys/gnark-crypto/internal/generator/sis"
 	"github.com/consensys/gnark-crypto/internal/generator/sumcheck"
 	"github.com/consensys/gnark-crypto/internal/generator/test_vector_utils"
@@ -63,11 +69,20 @@ func main() {
 
 			conf.FpUnusedBits = 64 - (conf.Fp.NbBits % 64)
 
-			assertNoError(generator.GenerateFF(conf.Fr, filepath.Join(curveDir, "fr")))
-			assertNoError(generator.GenerateFF(conf.Fp, filepath.Join(curveDir, "fp")))
+			// grumpkin reuses the bn254 fields (with fp and fr swapped)
+			if !conf.Equal(config.GRUMPKIN) {
+				assertNoError(generator.GenerateFF(conf.Fr, filepath.Join(curveDir, "fr")))
+				assertNoError(generator.GenerateFF(conf.Fp, filepath.Join(curveDir, "fp")))
+			}
+
+			if conf.Equal(config.ED25519) {
+				return // twisted Edwards curve, generated with the companion curves below
+			}
 
 			// generate ecdsa
-			assertNoError(ecdsa.Generate(conf, curveDir, bgen))
+			if !conf.Equal(config.PALLAS) && !conf.Equal(config.VESTA) {
+				assertNoError(ecdsa.Generate(conf, curveDir, bgen))
+			}
 
 			if conf.Equal(config.STARK_CURVE) {
 				return // TODO @yelhousni
@@ -76,7 +91,37 @@ func main() {
 			// generate G1, G2, multiExp, ...
 			assertNoError(ecc.Generate(conf, curveDir, bgen))
 
-			if conf.Equal(config.SECP256K1) {
+			if conf.Equal(config.PALLAS) || conf.Equal(config.VESTA) || conf.Equal(config.SECP256K1) {
+				// generate the transparent ipa commitment scheme on G1
+				assertNoError(ipa.Generate(ipa.FromCurve(conf), filepath.Join(curveDir, "ipa"), bgen))
+			}
+
+			if conf.Equal(config.SECP256K1) || conf.Equal(config.BN254) || conf.Equal(config.BLS12_381) {
+				// generate bulletproofs range proofs on G1
+				assertNoError(bulletproofs.Generate(conf, filepath.Join(curveDir, "bulletproofs"), bgen))
+			}
+
+			if conf.Equal(config.SECP256R1) {
+				// generate ECVRF with try-and-increment (RFC 9381)
+				assertNoError(ecvrf.Generate(conf, filepath.Join(curveDir, "ecvrf"), bgen))
+			}
+
+			if conf.Equal(config.SECP256K1) || conf.Equal(config.SECP256R1) || conf.Equal(config.GRUMPKIN) {
+				return
+			}
+
+			frInfo := config.FieldDependency{
+				FieldPackagePath: "github.com/consensys/gnark-crypto/ecc/" + conf.Name + "/fr",
+				FieldPackageName: "fr",
+				ElementType:      "fr.Element",
+			}
+
+			if conf.Equal(config.PALLAS) || conf.Equal(config.VESTA) {
+				// no pairing on the pasta cycle, only the fr tooling
+				assertNoError(fft.Generate(conf, filepath.Join(curveDir, "fr", "fft"), bgen))
+				assertNoError(polynomial.Generate(frInfo, filepath.Join(curveDir, "fr", "polynomial"), true, bgen))
+				assertNoError(mimc.Generate(conf, filepath.Join(curveDir, "fr", "mimc"), bgen))
+				assertNoError(secretsharing.Generate(conf, filepath.Join(curveDir, "secretsharing"), bgen))
 				return
 			}
 
@@ -111,15 +156,12 @@ func main() {
 			// generate mimc on fr
 			assertNoError(mimc.Generate(conf, filepath.Join(curveDir, "fr", "mimc"), bgen))
 
-			frInfo := config.FieldDependency{
-				FieldPackagePath: "github.com/consensys/gnark-crypto/ecc/" + conf.Name + "/fr",
-				FieldPackageName: "fr",
-				ElementType:      "fr.Element",
-			}
-
 			// generate polynomial on fr
 			assertNoError(polynomial.Generate(frInfo, filepath.Join(curveDir, "fr", "polynomial"), true, bgen))
 
+			// generate shamir secret sharing and vss
+			assertNoError(secretsharing.Generate(conf, filepath.Join(curveDir, "secretsharing"), bgen))
+
 			// generate eddsa on companion curves
 			assertNoError(fri.Generate(conf, filepath.Join(curveDir, "fr", "fri"), bgen))
 
@@ -162,16 +204,88 @@ func main() {
 		go func(conf config.TwistedEdwardsCurve) {
 			defer wg.Done()
 
+			if conf.Standalone {
+				// eddsa, x25519 and ristretto255 on ed25519 are hand written
+				assertNoError(edwards.Generate(conf, filepath.Join(baseDir, "ecc", conf.Name), bgen))
+				return
+			}
+
 			curveDir := filepath.Join(baseDir, "ecc", conf.Name, conf.Package)
 			// generate twisted edwards companion curves
 			assertNoError(edwards.Generate(conf, curveDir, bgen))
 
+			if conf.ScalarField {
+				// scalar field of the prime subgroup (used by banderwagon and ipa)
+				fr, err := field.NewFieldConfig("fr", "Element", conf.Order, true)
+				assertNoError(err)
+				assertNoError(generator.GenerateFF(fr, filepath.Join(curveDir, "fr")))
+
+				// generate the ipa commitment scheme on the hand written banderwagon
+				assertNoError(ipa.Generate(ipa.Banderwagon, filepath.Join(curveDir, "banderwagon", "ipa"), bgen))
+			}
+
 			// generate eddsa on companion curves
 			assertNoError(eddsa.Generate(conf, curveDir, bgen))
+
+			// generate frost threshold signatures, producing eddsa signatures
+			assertNoError(frost.Generate(conf, curveDir, bgen))
+
+			// generate ECVRF
+			assertNoError(edwardsecvrf.Generate(conf, curveDir, bgen))
 		}(conf)
 
 	}
 
+	// the small fields sample their challenges from an extension (generated by
+	// field/<name>/internal); sumcheck is instantiated over it
+	for _, f := range []struct{ name, challenge string }{
+		{"goldilocks", "E2"},
+		{"babybear", "E4"},
+		{"koalabear", "E4"},
+		{"mersenne31", "E4"},
+	} {
+		wg.Add(1)
+		go func(name, challenge string) {
+			defer wg.Done()
+			extDir := filepath.Join(baseDir, "field", name, "extensions")
+			extInfo := config.FieldDependency{
+				FieldPackagePath: "github.com/consensys/gnark-crypto/field/" + name + "/extensions",
+				FieldPackageName: "extensions",
+				ElementType:      "extensions." + challenge,
+				ElementName:      challenge,
+			}
+			assertNoError(polynomial.Generate(extInfo, filepath.Join(extDir, "polynomial"), true, bgen))
+			assertNoError(sumcheck.Generate(extInfo, filepath.Join(extDir, "sumcheck"), bgen))
+			assertNoError(test_vector_utils.Generate(test_vector_utils.Config{
+				FieldDependency:             extInfo,
+				RandomizeMissingHashEntries: false,
+			}, filepath.Join(extDir, "test_vector_utils"), bgen))
+		}(f.name, f.challenge)
+	}
+
+	// the 31-bit fields are generated by field/<name>/internal; here we only generate their fft and fri
+	for _, name := range []string{"babybear", "koalabear"} {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			assertNoError(fft.GenerateField(name, filepath.Join(baseDir, "field", name, "fft"), bgen))
+			assertNoError(fri.GenerateField(name, "E4", filepath.Join(baseDir, "field", name, "fri"), bgen))
+		}(name)
+	}
+
+	// goldilocks is generated by field/goldilocks/internal; here we generate its fft and polynomial
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		goldilocksDir := filepath.Join(baseDir, "field", "goldilocks")
+		assertNoError(fft.GenerateField("goldilocks", filepath.Join(goldilocksDir, "fft"), bgen))
+		assertNoError(polynomial.Generate(config.FieldDependency{
+			FieldPackagePath: "github.com/consensys/gnark-crypto/field/goldilocks",
+			FieldPackageName: "goldilocks",
+			ElementType:      "goldilocks.Element",
+		}, filepath.Join(goldilocksDir, "polynomial"), true, bgen))
+	}()
+
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
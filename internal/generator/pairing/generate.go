@@ -11,8 +11,13 @@ import (
 func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
 
 	packageName := strings.ReplaceAll(conf.Name, "-", "")
-	return bgen.Generate(conf, packageName, "./pairing/template", bavard.Entry{
-		File: filepath.Join(baseDir, "pairing_test.go"), Templates: []string{"tests/pairing.go.tmpl"},
-	})
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "pairing_test.go"), Templates: []string{"tests/pairing.go.tmpl"}},
+		{File: filepath.Join(baseDir, "pairing_batch.go"), Templates: []string{"pairing_batch.go.tmpl"}},
+		{File: filepath.Join(baseDir, "pairing_batch_test.go"), Templates: []string{"tests/pairing_batch.go.tmpl"}},
+		{File: filepath.Join(baseDir, "gt.go"), Templates: []string{"gt.go.tmpl"}},
+		{File: filepath.Join(baseDir, "gt_test.go"), Templates: []string{"tests/gt.go.tmpl"}},
+	}
+	return bgen.Generate(conf, packageName, "./pairing/template", entries...)
 
 }
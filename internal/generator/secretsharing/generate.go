@@ -0,0 +1,23 @@
+package secretsharing
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+
+	// Shamir secret sharing and verifiable secret sharing
+	conf.Package = "secretsharing"
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "shamir.go"), Templates: []string{"shamir.go.tmpl"}},
+		{File: filepath.Join(baseDir, "vss.go"), Templates: []string{"vss.go.tmpl"}},
+		{File: filepath.Join(baseDir, "reshare.go"), Templates: []string{"reshare.go.tmpl"}},
+		{File: filepath.Join(baseDir, "secretsharing_test.go"), Templates: []string{"secretsharing.test.go.tmpl"}},
+	}
+	return bgen.Generate(conf, conf.Package, "./secretsharing/template/", entries...)
+
+}
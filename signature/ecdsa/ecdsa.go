@@ -14,6 +14,8 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
+// Package ecdsa registers the ECDSA signature schemes of gnark-crypto under
+// the identifiers "ecdsa/<curve>" of the signature registry.
 package ecdsa
 
 import (
@@ -29,37 +31,38 @@ import (
 	ecdsa_bw6633 "github.com/consensys/gnark-crypto/ecc/bw6-633/ecdsa"
 	ecdsa_bw6756 "github.com/consensys/gnark-crypto/ecc/bw6-756/ecdsa"
 	ecdsa_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/ecdsa"
+	ecdsa_grumpkin "github.com/consensys/gnark-crypto/ecc/grumpkin/ecdsa"
 	ecdsa_secp256k1 "github.com/consensys/gnark-crypto/ecc/secp256k1/ecdsa"
+	ecdsa_secp256r1 "github.com/consensys/gnark-crypto/ecc/secp256r1/ecdsa"
 	ecdsa_starkcurve "github.com/consensys/gnark-crypto/ecc/stark-curve/ecdsa"
 	"github.com/consensys/gnark-crypto/signature"
+	"github.com/consensys/gnark-crypto/signature/internal/registry"
 )
 
-// New takes a source of randomness and returns a new key pair
+func init() {
+	signature.Register(ID(ecc.BN254), registry.Implementation[ecdsa_bn254.PublicKey, ecdsa_bn254.PrivateKey, ecdsa_bn254.Signature](ecdsa_bn254.GenerateKey))
+	signature.Register(ID(ecc.BLS12_381), registry.Implementation[ecdsa_bls12381.PublicKey, ecdsa_bls12381.PrivateKey, ecdsa_bls12381.Signature](ecdsa_bls12381.GenerateKey))
+	signature.Register(ID(ecc.BLS12_377), registry.Implementation[ecdsa_bls12377.PublicKey, ecdsa_bls12377.PrivateKey, ecdsa_bls12377.Signature](ecdsa_bls12377.GenerateKey))
+	signature.Register(ID(ecc.BLS12_378), registry.Implementation[ecdsa_bls12378.PublicKey, ecdsa_bls12378.PrivateKey, ecdsa_bls12378.Signature](ecdsa_bls12378.GenerateKey))
+	signature.Register(ID(ecc.BW6_761), registry.Implementation[ecdsa_bw6761.PublicKey, ecdsa_bw6761.PrivateKey, ecdsa_bw6761.Signature](ecdsa_bw6761.GenerateKey))
+	signature.Register(ID(ecc.BW6_756), registry.Implementation[ecdsa_bw6756.PublicKey, ecdsa_bw6756.PrivateKey, ecdsa_bw6756.Signature](ecdsa_bw6756.GenerateKey))
+	signature.Register(ID(ecc.BLS24_315), registry.Implementation[ecdsa_bls24315.PublicKey, ecdsa_bls24315.PrivateKey, ecdsa_bls24315.Signature](ecdsa_bls24315.GenerateKey))
+	signature.Register(ID(ecc.BLS24_317), registry.Implementation[ecdsa_bls24317.PublicKey, ecdsa_bls24317.PrivateKey, ecdsa_bls24317.Signature](ecdsa_bls24317.GenerateKey))
+	signature.Register(ID(ecc.BW6_633), registry.Implementation[ecdsa_bw6633.PublicKey, ecdsa_bw6633.PrivateKey, ecdsa_bw6633.Signature](ecdsa_bw6633.GenerateKey))
+	signature.Register(ID(ecc.SECP256K1), registry.Implementation[ecdsa_secp256k1.PublicKey, ecdsa_secp256k1.PrivateKey, ecdsa_secp256k1.Signature](ecdsa_secp256k1.GenerateKey))
+	signature.Register(ID(ecc.SECP256R1), registry.Implementation[ecdsa_secp256r1.PublicKey, ecdsa_secp256r1.PrivateKey, ecdsa_secp256r1.Signature](ecdsa_secp256r1.GenerateKey))
+	signature.Register(ID(ecc.STARK_CURVE), registry.Implementation[ecdsa_starkcurve.PublicKey, ecdsa_starkcurve.PrivateKey, ecdsa_starkcurve.Signature](ecdsa_starkcurve.GenerateKey))
+	signature.Register(ID(ecc.GRUMPKIN), registry.Implementation[ecdsa_grumpkin.PublicKey, ecdsa_grumpkin.PrivateKey, ecdsa_grumpkin.Signature](ecdsa_grumpkin.GenerateKey))
+}
+
+// ID returns the identifier of ECDSA on the curve id in the signature registry.
+func ID(id ecc.ID) signature.ID {
+	return signature.ID{Scheme: signature.ECDSA, Curve: id.String()}
+}
+
+// New takes a source of randomness and returns a new key pair.
+// It returns an error wrapping signature.ErrUnknownScheme if ECDSA is not
+// implemented on the curve ss.
 func New(ss ecc.ID, r io.Reader) (signature.Signer, error) {
-	switch ss {
-	case ecc.BN254:
-		return ecdsa_bn254.GenerateKey(r)
-	case ecc.BLS12_381:
-		return ecdsa_bls12381.GenerateKey(r)
-	case ecc.BLS12_377:
-		return ecdsa_bls12377.GenerateKey(r)
-	case ecc.BLS12_378:
-		return ecdsa_bls12378.GenerateKey(r)
-	case ecc.BW6_761:
-		return ecdsa_bw6761.GenerateKey(r)
-	case ecc.BW6_756:
-		return ecdsa_bw6756.GenerateKey(r)
-	case ecc.BLS24_315:
-		return ecdsa_bls24315.GenerateKey(r)
-	case ecc.BLS24_317:
-		return ecdsa_bls24317.GenerateKey(r)
-	case ecc.BW6_633:
-		return ecdsa_bw6633.GenerateKey(r)
-	case ecc.SECP256K1:
-		return ecdsa_secp256k1.GenerateKey(r)
-	case ecc.STARK_CURVE:
-		return ecdsa_starkcurve.GenerateKey(r)
-	default:
-		panic("not implemented")
-	}
+	return signature.GenerateKey(ID(ss), r)
 }
@@ -14,11 +14,16 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
+// Package eddsa registers the EdDSA signature schemes of gnark-crypto under
+// the identifiers "eddsa/<curve>" of the signature registry: the twisted
+// Edwards companion curves, named after their twistededwards.ID, and ed25519.
 package eddsa
 
 import (
+	"hash"
 	"io"
 
+	"github.com/consensys/gnark-crypto/ecc"
 	eddsa_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/twistededwards/eddsa"
 	eddsa_bls12378 "github.com/consensys/gnark-crypto/ecc/bls12-378/twistededwards/eddsa"
 	eddsa_bls12381_bandersnatch "github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/eddsa"
@@ -29,34 +34,81 @@ import (
 	eddsa_bw6633 "github.com/consensys/gnark-crypto/ecc/bw6-633/twistededwards/eddsa"
 	eddsa_bw6756 "github.com/consensys/gnark-crypto/ecc/bw6-756/twistededwards/eddsa"
 	eddsa_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/twistededwards/eddsa"
+	eddsa_ed25519 "github.com/consensys/gnark-crypto/ecc/ed25519/eddsa"
 	"github.com/consensys/gnark-crypto/ecc/twistededwards"
 	"github.com/consensys/gnark-crypto/signature"
+	"github.com/consensys/gnark-crypto/signature/internal/registry"
 )
 
-// New takes a source of randomness and returns a new key pair
-func New(ss twistededwards.ID, r io.Reader) (signature.Signer, error) {
-	switch ss {
-	case twistededwards.BN254:
-		return eddsa_bn254.GenerateKey(r)
-	case twistededwards.BLS12_381:
-		return eddsa_bls12381.GenerateKey(r)
-	case twistededwards.BLS12_381_BANDERSNATCH:
-		return eddsa_bls12381_bandersnatch.GenerateKey(r)
-	case twistededwards.BLS12_377:
-		return eddsa_bls12377.GenerateKey(r)
-	case twistededwards.BLS12_378:
-		return eddsa_bls12378.GenerateKey(r)
-	case twistededwards.BW6_761:
-		return eddsa_bw6761.GenerateKey(r)
-	case twistededwards.BW6_756:
-		return eddsa_bw6756.GenerateKey(r)
-	case twistededwards.BLS24_315:
-		return eddsa_bls24315.GenerateKey(r)
-	case twistededwards.BLS24_317:
-		return eddsa_bls24317.GenerateKey(r)
-	case twistededwards.BW6_633:
-		return eddsa_bw6633.GenerateKey(r)
-	default:
-		panic("not implemented")
+func init() {
+	impl := registry.Implementation[eddsa_bn254.PublicKey, eddsa_bn254.PrivateKey, eddsa_bn254.Signature](eddsa_bn254.GenerateKey)
+	impl.BatchVerify = batchVerifyAdapter(eddsa_bn254.BatchVerify)
+	signature.Register(ID(twistededwards.BN254), impl)
+
+	impl = registry.Implementation[eddsa_bls12381.PublicKey, eddsa_bls12381.PrivateKey, eddsa_bls12381.Signature](eddsa_bls12381.GenerateKey)
+	impl.BatchVerify = batchVerifyAdapter(eddsa_bls12381.BatchVerify)
+	signature.Register(ID(twistededwards.BLS12_381), impl)
+
+	impl = registry.Implementation[eddsa_bls12381_bandersnatch.PublicKey, eddsa_bls12381_bandersnatch.PrivateKey, eddsa_bls12381_bandersnatch.Signature](eddsa_bls12381_bandersnatch.GenerateKey)
+	impl.BatchVerify = batchVerifyAdapter(eddsa_bls12381_bandersnatch.BatchVerify)
+	signature.Register(ID(twistededwards.BLS12_381_BANDERSNATCH), impl)
+
+	impl = registry.Implementation[eddsa_bls12377.PublicKey, eddsa_bls12377.PrivateKey, eddsa_bls12377.Signature](eddsa_bls12377.GenerateKey)
+	impl.BatchVerify = batchVerifyAdapter(eddsa_bls12377.BatchVerify)
+	signature.Register(ID(twistededwards.BLS12_377), impl)
+
+	impl = registry.Implementation[eddsa_bls12378.PublicKey, eddsa_bls12378.PrivateKey, eddsa_bls12378.Signature](eddsa_bls12378.GenerateKey)
+	impl.BatchVerify = batchVerifyAdapter(eddsa_bls12378.BatchVerify)
+	signature.Register(ID(twistededwards.BLS12_378), impl)
+
+	impl = registry.Implementation[eddsa_bw6761.PublicKey, eddsa_bw6761.PrivateKey, eddsa_bw6761.Signature](eddsa_bw6761.GenerateKey)
+	impl.BatchVerify = batchVerifyAdapter(eddsa_bw6761.BatchVerify)
+	signature.Register(ID(twistededwards.BW6_761), impl)
+
+	impl = registry.Implementation[eddsa_bw6756.PublicKey, eddsa_bw6756.PrivateKey, eddsa_bw6756.Signature](eddsa_bw6756.GenerateKey)
+	impl.BatchVerify = batchVerifyAdapter(eddsa_bw6756.BatchVerify)
+	signature.Register(ID(twistededwards.BW6_756), impl)
+
+	impl = registry.Implementation[eddsa_bls24315.PublicKey, eddsa_bls24315.PrivateKey, eddsa_bls24315.Signature](eddsa_bls24315.GenerateKey)
+	impl.BatchVerify = batchVerifyAdapter(eddsa_bls24315.BatchVerify)
+	signature.Register(ID(twistededwards.BLS24_315), impl)
+
+	impl = registry.Implementation[eddsa_bls24317.PublicKey, eddsa_bls24317.PrivateKey, eddsa_bls24317.Signature](eddsa_bls24317.GenerateKey)
+	impl.BatchVerify = batchVerifyAdapter(eddsa_bls24317.BatchVerify)
+	signature.Register(ID(twistededwards.BLS24_317), impl)
+
+	impl = registry.Implementation[eddsa_bw6633.PublicKey, eddsa_bw6633.PrivateKey, eddsa_bw6633.Signature](eddsa_bw6633.GenerateKey)
+	impl.BatchVerify = batchVerifyAdapter(eddsa_bw6633.BatchVerify)
+	signature.Register(ID(twistededwards.BW6_633), impl)
+
+	impl = registry.Implementation[eddsa_ed25519.PublicKey, eddsa_ed25519.PrivateKey, eddsa_ed25519.Signature](eddsa_ed25519.GenerateKey)
+	impl.BatchVerify = batchVerifyAdapter(eddsa_ed25519.BatchVerify)
+	signature.Register(signature.ID{Scheme: signature.EdDSA, Curve: ecc.ED25519.String()}, impl)
+}
+
+// batchVerifyAdapter adapts the batch verification of an EdDSA package, which also
+// returns the indices of the invalid signatures, to signature.Implementation.BatchVerify.
+func batchVerifyAdapter[PK any, PPK interface {
+	*PK
+	signature.PublicKey
+}](batchVerify func([]PK, [][]byte, [][]byte, hash.Hash) (bool, []int, error)) func([]signature.PublicKey, [][]byte, [][]byte, hash.Hash) (bool, error) {
+	return func(publicKeys []signature.PublicKey, messages, signatures [][]byte, hFunc hash.Hash) (bool, error) {
+		pks, err := registry.PublicKeys[PK, PPK](publicKeys)
+		if err != nil {
+			return false, err
+		}
+		ok, _, err := batchVerify(pks, messages, signatures, hFunc)
+		return ok, err
 	}
 }
+
+// ID returns the identifier of EdDSA on the twisted Edwards curve id in the signature registry.
+func ID(id twistededwards.ID) signature.ID {
+	return signature.ID{Scheme: signature.EdDSA, Curve: id.String()}
+}
+
+// New takes a source of randomness and returns a new key pair.
+// It returns an error wrapping signature.ErrUnknownScheme if ss is not implemented.
+func New(ss twistededwards.ID, r io.Reader) (signature.Signer, error) {
+	return signature.GenerateKey(ID(ss), r)
+}
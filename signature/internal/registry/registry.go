@@ -0,0 +1,69 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package registry provides the helpers used by the packages registering
+// their signature schemes in the signature registry.
+package registry
+
+import (
+	"io"
+
+	"github.com/consensys/gnark-crypto/signature"
+)
+
+// Implementation returns the signature.Implementation of a scheme whose
+// public keys, private keys and signatures are of type *PK, *SK and *S.
+func Implementation[PK, SK, S any, PPK interface {
+	*PK
+	signature.PublicKey
+}, PSK interface {
+	*SK
+	signature.Signer
+}, PS interface {
+	*S
+	signature.Signature
+}](generateKey func(io.Reader) (PSK, error)) signature.Implementation {
+	return signature.Implementation{
+		GenerateKey: func(rand io.Reader) (signature.Signer, error) {
+			sk, err := generateKey(rand)
+			if err != nil {
+				return nil, err
+			}
+			return sk, nil
+		},
+		NewPublicKey:  func() signature.PublicKey { return PPK(new(PK)) },
+		NewPrivateKey: func() signature.Signer { return PSK(new(SK)) },
+		NewSignature:  func() signature.Signature { return PS(new(S)) },
+	}
+}
+
+// PublicKeys converts publicKeys to the concrete type PK, as expected by the
+// batch verification of the schemes. It returns signature.ErrWrongPublicKeyType
+// if a public key is not a *PK.
+func PublicKeys[PK any, PPK interface {
+	*PK
+	signature.PublicKey
+}](publicKeys []signature.PublicKey) ([]PK, error) {
+	res := make([]PK, len(publicKeys))
+	for i := range publicKeys {
+		pk, ok := publicKeys[i].(PPK)
+		if !ok || pk == nil {
+			return nil, signature.ErrWrongPublicKeyType
+		}
+		res[i] = *pk
+	}
+	return res, nil
+}
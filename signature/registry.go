@@ -0,0 +1,230 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signature
+
+import (
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Names of the signature schemes, as used in ID.Scheme.
+const (
+	ECDSA    = "ecdsa"
+	EdDSA    = "eddsa"
+	Schnorr  = "schnorr"  // BIP-340 Schnorr signatures
+	Starknet = "starknet" // ECDSA variant of the Starknet protocol
+)
+
+var (
+	ErrUnknownScheme      = errors.New("unknown signature scheme")
+	ErrInvalidEncoding    = errors.New("invalid encoding")
+	ErrWrongPublicKeyType = errors.New("public key of the wrong scheme")
+	ErrMismatchedLengths  = errors.New("public keys, messages and signatures must have the same length")
+)
+
+// ID identifies a signature scheme on a given curve, written "scheme/curve",
+// for instance "eddsa/bn254" or "ecdsa/secp256k1".
+//
+// Curve names follow ecc.ID.String(), the twisted Edwards companion curves are
+// named after the curve they are defined on.
+type ID struct {
+	Scheme string
+	Curve  string
+}
+
+// ParseID parses an identifier of the form "scheme/curve".
+// It does not check that the identifier is registered.
+func ParseID(s string) (ID, error) {
+	scheme, curve, ok := strings.Cut(strings.ToLower(s), "/")
+	if !ok || scheme == "" || curve == "" || strings.Contains(curve, "/") {
+		return ID{}, fmt.Errorf("signature: malformed identifier %q, expected scheme/curve", s)
+	}
+	return ID{Scheme: scheme, Curve: curve}, nil
+}
+
+// String returns the identifier as "scheme/curve".
+func (id ID) String() string {
+	return id.Scheme + "/" + id.Curve
+}
+
+// Signature is the binary representation of a signature of a given scheme,
+// it is used to check the encoding of signatures.
+type Signature interface {
+	Bytes() []byte
+	SetBytes(buf []byte) (int, error)
+}
+
+// Implementation holds the constructors of a signature scheme on a curve.
+type Implementation struct {
+	// GenerateKey returns a new key pair from a source of randomness.
+	GenerateKey func(rand io.Reader) (Signer, error)
+
+	// NewPublicKey, NewPrivateKey and NewSignature return zero values to be
+	// set from their binary representation with SetBytes.
+	NewPublicKey  func() PublicKey
+	NewPrivateKey func() Signer
+	NewSignature  func() Signature
+
+	// BatchVerify is optional, it verifies the signatures at once and must
+	// return ErrWrongPublicKeyType if a public key is not of the scheme.
+	// If nil, the signatures are verified one by one.
+	BatchVerify func(publicKeys []PublicKey, messages, signatures [][]byte, hFunc hash.Hash) (bool, error)
+}
+
+var registry = struct {
+	sync.RWMutex
+	m map[ID]Implementation
+}{m: make(map[ID]Implementation)}
+
+// Register makes a signature scheme available under id. It is meant to be
+// called from the init function of the packages implementing the scheme,
+// and panics if id is registered twice or if a constructor is missing.
+func Register(id ID, impl Implementation) {
+	if impl.GenerateKey == nil || impl.NewPublicKey == nil || impl.NewPrivateKey == nil || impl.NewSignature == nil {
+		panic("signature: missing constructor for " + id.String())
+	}
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := registry.m[id]; ok {
+		panic("signature: Register called twice for " + id.String())
+	}
+	registry.m[id] = impl
+}
+
+// Lookup returns the implementation registered under id.
+func Lookup(id ID) (Implementation, error) {
+	registry.RLock()
+	impl, ok := registry.m[id]
+	registry.RUnlock()
+	if !ok {
+		return Implementation{}, fmt.Errorf("%w: %s", ErrUnknownScheme, id)
+	}
+	return impl, nil
+}
+
+// Registered returns the sorted list of the registered identifiers.
+func Registered() []ID {
+	registry.RLock()
+	res := make([]ID, 0, len(registry.m))
+	for id := range registry.m {
+		res = append(res, id)
+	}
+	registry.RUnlock()
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].String() < res[j].String()
+	})
+	return res
+}
+
+// GenerateKey returns a new key pair of the scheme id.
+func GenerateKey(id ID, rand io.Reader) (Signer, error) {
+	impl, err := Lookup(id)
+	if err != nil {
+		return nil, err
+	}
+	return impl.GenerateKey(rand)
+}
+
+// ParsePublicKey returns the public key of the scheme id encoded in buf.
+// buf must contain exactly one public key.
+func ParsePublicKey(id ID, buf []byte) (PublicKey, error) {
+	impl, err := Lookup(id)
+	if err != nil {
+		return nil, err
+	}
+	pk := impl.NewPublicKey()
+	if err = setBytes(pk, buf); err != nil {
+		return nil, err
+	}
+	return pk, nil
+}
+
+// ParsePrivateKey returns the private key of the scheme id encoded in buf.
+// buf must contain exactly one private key.
+func ParsePrivateKey(id ID, buf []byte) (Signer, error) {
+	impl, err := Lookup(id)
+	if err != nil {
+		return nil, err
+	}
+	sk := impl.NewPrivateKey()
+	if err = setBytes(sk, buf); err != nil {
+		return nil, err
+	}
+	return sk, nil
+}
+
+// ParseSignature returns the signature of the scheme id encoded in buf.
+// buf must contain exactly one signature.
+func ParseSignature(id ID, buf []byte) (Signature, error) {
+	impl, err := Lookup(id)
+	if err != nil {
+		return nil, err
+	}
+	sig := impl.NewSignature()
+	if err = setBytes(sig, buf); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+// Verify verifies the signature sig of message under the public key encoded
+// in publicKey, see PublicKey.Verify for the meaning of hFunc.
+func Verify(id ID, publicKey, sig, message []byte, hFunc hash.Hash) (bool, error) {
+	pk, err := ParsePublicKey(id, publicKey)
+	if err != nil {
+		return false, err
+	}
+	return pk.Verify(sig, message, hFunc)
+}
+
+// BatchVerify verifies the signatures signatures[i] of messages[i] under
+// publicKeys[i]. It returns true only if all the signatures are valid.
+func BatchVerify(id ID, publicKeys []PublicKey, messages, signatures [][]byte, hFunc hash.Hash) (bool, error) {
+	impl, err := Lookup(id)
+	if err != nil {
+		return false, err
+	}
+	if len(messages) != len(publicKeys) || len(signatures) != len(publicKeys) {
+		return false, ErrMismatchedLengths
+	}
+	if impl.BatchVerify != nil {
+		return impl.BatchVerify(publicKeys, messages, signatures, hFunc)
+	}
+	for i := range publicKeys {
+		ok, err := publicKeys[i].Verify(signatures[i], messages[i], hFunc)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func setBytes(v interface{ SetBytes([]byte) (int, error) }, buf []byte) error {
+	n, err := v.SetBytes(buf)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEncoding, err)
+	}
+	if n != len(buf) {
+		return fmt.Errorf("%w: %d trailing bytes", ErrInvalidEncoding, len(buf)-n)
+	}
+	return nil
+}
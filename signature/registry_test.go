@@ -0,0 +1,153 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signature_test
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"hash"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/twistededwards"
+	gcHash "github.com/consensys/gnark-crypto/hash"
+	"github.com/consensys/gnark-crypto/signature"
+	_ "github.com/consensys/gnark-crypto/signature/ecdsa"
+	"github.com/consensys/gnark-crypto/signature/eddsa"
+	_ "github.com/consensys/gnark-crypto/signature/schnorr"
+	_ "github.com/consensys/gnark-crypto/signature/starknet"
+)
+
+// the EdDSA schemes on the companion curves hash with the MiMC of their base field
+var mimc = map[twistededwards.ID]gcHash.Hash{
+	twistededwards.BN254:                  gcHash.MIMC_BN254,
+	twistededwards.BLS12_377:              gcHash.MIMC_BLS12_377,
+	twistededwards.BLS12_378:              gcHash.MIMC_BLS12_378,
+	twistededwards.BLS12_381:              gcHash.MIMC_BLS12_381,
+	twistededwards.BLS12_381_BANDERSNATCH: gcHash.MIMC_BLS12_381,
+	twistededwards.BLS24_315:              gcHash.MIMC_BLS24_315,
+	twistededwards.BLS24_317:              gcHash.MIMC_BLS24_317,
+	twistededwards.BW6_761:                gcHash.MIMC_BW6_761,
+	twistededwards.BW6_756:                gcHash.MIMC_BW6_756,
+	twistededwards.BW6_633:                gcHash.MIMC_BW6_633,
+}
+
+func newHash(id signature.ID) hash.Hash {
+	for tid, h := range mimc {
+		if eddsa.ID(tid) == id {
+			return h.New()
+		}
+	}
+	if id.Curve == ecc.ED25519.String() {
+		// pure Ed25519, Ed25519ph would pre-hash with SHA-512
+		return nil
+	}
+	return sha256.New()
+}
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+
+	ids := signature.Registered()
+	if len(ids) != 13+11+2 {
+		t.Fatalf("expected 26 registered schemes, got %d", len(ids))
+	}
+
+	msgs := [][]byte{[]byte("testing the registry"), []byte("a second message")}
+	for _, id := range ids {
+		parsed, err := signature.ParseID(id.String())
+		if err != nil || parsed != id {
+			t.Fatalf("%s: ParseID(String()) mismatch", id)
+		}
+
+		pks := make([]signature.PublicKey, len(msgs))
+		sigs := make([][]byte, len(msgs))
+		for i := range msgs {
+			sk, err := signature.GenerateKey(id, rand.Reader)
+			if err != nil {
+				t.Fatalf("%s: %v", id, err)
+			}
+			if sigs[i], err = sk.Sign(msgs[i], newHash(id)); err != nil {
+				t.Fatalf("%s: %v", id, err)
+			}
+
+			// round trip through the byte representations
+			skBis, err := signature.ParsePrivateKey(id, sk.Bytes())
+			if err != nil || !skBis.Public().Equal(sk.Public()) {
+				t.Fatalf("%s: private key round trip failed: %v", id, err)
+			}
+			if pks[i], err = signature.ParsePublicKey(id, sk.Public().Bytes()); err != nil {
+				t.Fatalf("%s: %v", id, err)
+			}
+			sig, err := signature.ParseSignature(id, sigs[i])
+			if err != nil || string(sig.Bytes()) != string(sigs[i]) {
+				t.Fatalf("%s: signature round trip failed: %v", id, err)
+			}
+			if _, err = signature.ParseSignature(id, append(sigs[i], 0)); !errors.Is(err, signature.ErrInvalidEncoding) {
+				t.Fatalf("%s: trailing bytes should be rejected", id)
+			}
+
+			ok, err := signature.Verify(id, sk.Public().Bytes(), sigs[i], msgs[i], newHash(id))
+			if err != nil || !ok {
+				t.Fatalf("%s: valid signature rejected: %v", id, err)
+			}
+		}
+
+		ok, err := signature.BatchVerify(id, pks, msgs, sigs, newHash(id))
+		if err != nil || !ok {
+			t.Fatalf("%s: valid batch rejected: %v", id, err)
+		}
+		ok, _ = signature.BatchVerify(id, pks, msgs, [][]byte{sigs[1], sigs[0]}, newHash(id))
+		if ok {
+			t.Fatalf("%s: invalid batch accepted", id)
+		}
+		if _, err = signature.BatchVerify(id, pks, msgs[:1], sigs, newHash(id)); err != signature.ErrMismatchedLengths {
+			t.Fatalf("%s: mismatched lengths accepted", id)
+		}
+	}
+}
+
+func TestRegistryErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []string{"", "eddsa", "eddsa/", "/bn254", "eddsa/bn254/x"} {
+		if _, err := signature.ParseID(s); err == nil {
+			t.Fatalf("ParseID(%q) should fail", s)
+		}
+	}
+
+	id, err := signature.ParseID("EdDSA/BN254")
+	if err != nil || id != eddsa.ID(twistededwards.BN254) {
+		t.Fatal("ParseID should be case insensitive")
+	}
+
+	unknown := signature.ID{Scheme: signature.EdDSA, Curve: "secp256k1"}
+	if _, err = signature.GenerateKey(unknown, rand.Reader); !errors.Is(err, signature.ErrUnknownScheme) {
+		t.Fatal("expected ErrUnknownScheme")
+	}
+	if _, err = eddsa.New(twistededwards.UNKNOWN, rand.Reader); !errors.Is(err, signature.ErrUnknownScheme) {
+		t.Fatal("expected ErrUnknownScheme")
+	}
+
+	// public keys of another scheme are rejected by batch verification
+	sk, _ := signature.GenerateKey(eddsa.ID(twistededwards.BN254), rand.Reader)
+	_, err = signature.BatchVerify(eddsa.ID(twistededwards.BLS12_381), []signature.PublicKey{sk.Public()}, [][]byte{nil}, [][]byte{nil}, nil)
+	if err != signature.ErrWrongPublicKeyType {
+		t.Fatal("expected ErrWrongPublicKeyType")
+	}
+}
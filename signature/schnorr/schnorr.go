@@ -0,0 +1,43 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package schnorr registers the BIP-340 Schnorr signatures on secp256k1 under
+// the identifier "schnorr/secp256k1" of the signature registry.
+package schnorr
+
+import (
+	"hash"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/schnorr"
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/consensys/gnark-crypto/signature/internal/registry"
+)
+
+// ID is the identifier of BIP-340 Schnorr signatures in the signature registry.
+var ID = signature.ID{Scheme: signature.Schnorr, Curve: ecc.SECP256K1.String()}
+
+func init() {
+	impl := registry.Implementation[schnorr.PublicKey, schnorr.PrivateKey, schnorr.Signature](schnorr.GenerateKey)
+	impl.BatchVerify = func(publicKeys []signature.PublicKey, messages, signatures [][]byte, hFunc hash.Hash) (bool, error) {
+		pks, err := registry.PublicKeys[schnorr.PublicKey](publicKeys)
+		if err != nil {
+			return false, err
+		}
+		return schnorr.BatchVerify(pks, messages, signatures, hFunc)
+	}
+	signature.Register(ID, impl)
+}
@@ -0,0 +1,33 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package starknet registers the Starknet signatures on the STARK curve under
+// the identifier "starknet/stark_curve" of the signature registry.
+package starknet
+
+import (
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/starknet"
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/consensys/gnark-crypto/signature/internal/registry"
+)
+
+// ID is the identifier of Starknet signatures in the signature registry.
+var ID = signature.ID{Scheme: signature.Starknet, Curve: ecc.STARK_CURVE.String()}
+
+func init() {
+	signature.Register(ID, registry.Implementation[starknet.PublicKey, starknet.PrivateKey, starknet.Signature](starknet.GenerateKey))
+}
@@ -0,0 +1,398 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mmap provides a raw on-disk layout for slices of field elements and
+// curve points, which can be memory-mapped and used in place.
+//
+// A dump is a fixed size header followed by the in-memory representation of
+// the slice (little-endian limbs, Montgomery form for field elements). It is
+// not portable across architectures and must only be used to cache data
+// produced by the same version of gnark-crypto; use the Encoder / Decoder of
+// each curve package for interoperable serialization.
+package mmap
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"math"
+	"math/bits"
+	"os"
+	"unsafe"
+
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+// Version of the dump layout; bumped on any incompatible change.
+const Version uint32 = 1
+
+// HeaderSize is the size in bytes of the header preceding the raw data.
+const HeaderSize = 32
+
+var magic = [4]byte{'g', 'n', 'r', 'k'}
+
+var (
+	ErrInvalidMagic    = errors.New("mmap: invalid magic bytes")
+	ErrInvalidVersion  = errors.New("mmap: unsupported dump version")
+	ErrCurveMismatch   = errors.New("mmap: curve ID mismatch")
+	ErrElementSize     = errors.New("mmap: element size mismatch")
+	ErrInvalidChecksum = errors.New("mmap: invalid checksum")
+	ErrBigEndian       = errors.New("mmap: raw dumps are only supported on little-endian architectures")
+	ErrDataSize        = errors.New("mmap: data size overflows")
+)
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// Header precedes the raw data of a dump.
+//
+// Layout (little-endian):
+//
+//	[0:4]   magic "gnrk"
+//	[4:8]   version
+//	[8:10]  curve ID
+//	[10:12] reserved
+//	[12:16] element size in bytes
+//	[16:24] number of elements
+//	[24:28] CRC-32C (Castagnoli) of the raw data
+//	[28:32] reserved
+type Header struct {
+	Version     uint32
+	CurveID     ecc.ID
+	ElementSize uint32
+	NbElements  uint64
+	Checksum    uint32
+}
+
+// DataSize returns the size in bytes of the raw data following the header,
+// or -1 if it does not fit in an int64.
+func (h *Header) DataSize() int64 {
+	hi, lo := bits.Mul64(uint64(h.ElementSize), h.NbElements)
+	if hi != 0 || lo > math.MaxInt64-HeaderSize {
+		return -1
+	}
+	return int64(lo)
+}
+
+func (h *Header) marshal() []byte {
+	var buf [HeaderSize]byte
+	copy(buf[0:4], magic[:])
+	binary.LittleEndian.PutUint32(buf[4:8], h.Version)
+	binary.LittleEndian.PutUint16(buf[8:10], uint16(h.CurveID))
+	binary.LittleEndian.PutUint32(buf[12:16], h.ElementSize)
+	binary.LittleEndian.PutUint64(buf[16:24], h.NbElements)
+	binary.LittleEndian.PutUint32(buf[24:28], h.Checksum)
+	return buf[:]
+}
+
+func (h *Header) unmarshal(buf []byte) error {
+	if len(buf) < HeaderSize {
+		return io.ErrUnexpectedEOF
+	}
+	if [4]byte{buf[0], buf[1], buf[2], buf[3]} != magic {
+		return ErrInvalidMagic
+	}
+	h.Version = binary.LittleEndian.Uint32(buf[4:8])
+	h.CurveID = ecc.ID(binary.LittleEndian.Uint16(buf[8:10]))
+	h.ElementSize = binary.LittleEndian.Uint32(buf[12:16])
+	h.NbElements = binary.LittleEndian.Uint64(buf[16:24])
+	h.Checksum = binary.LittleEndian.Uint32(buf[24:28])
+	if h.Version != Version {
+		return ErrInvalidVersion
+	}
+	return nil
+}
+
+// check verifies that the header describes a slice of E for the given curve.
+func (h *Header) check(id ecc.ID, elementSize uintptr) error {
+	if h.CurveID != id {
+		return ErrCurveMismatch
+	}
+	if uintptr(h.ElementSize) != elementSize {
+		return ErrElementSize
+	}
+	if h.DataSize() < 0 {
+		return ErrDataSize
+	}
+	return nil
+}
+
+// Option can be given to Map and ReadSlice.
+type Option func(*config)
+
+type config struct {
+	skipChecksum bool
+}
+
+// NoChecksum skips the verification of the checksum of the raw data. When
+// mapping a file, this avoids touching every page before the data is used.
+func NoChecksum() Option {
+	return func(c *config) {
+		c.skipChecksum = true
+	}
+}
+
+func newConfig(opts []Option) config {
+	var c config
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// WriteSlice writes the header and the raw in-memory representation of s to w.
+func WriteSlice[E any](w io.Writer, id ecc.ID, s []E) (int64, error) {
+	if !isLittleEndian() {
+		return 0, ErrBigEndian
+	}
+	data := asBytes(s)
+	h := Header{
+		Version:     Version,
+		CurveID:     id,
+		ElementSize: uint32(sizeOf[E]()),
+		NbElements:  uint64(len(s)),
+		Checksum:    crc32.Checksum(data, castagnoli),
+	}
+	n, err := w.Write(h.marshal())
+	if err != nil {
+		return int64(n), err
+	}
+	m, err := w.Write(data)
+	return int64(n + m), err
+}
+
+// ReadSlice reads a dump written by WriteSlice from r. The returned slice is
+// filled in place, without intermediate buffers.
+//
+// The number of elements in the header is not trusted: if r exposes its
+// remaining size (Len, as bytes.Reader, or a regular *os.File), a header
+// claiming more data is rejected before any allocation. Otherwise the slice
+// grows in bounded chunks as the data is read, so that a corrupt header can't
+// force an allocation much larger than the actual stream.
+func ReadSlice[E any](r io.Reader, id ecc.ID, opts ...Option) ([]E, int64, error) {
+	if !isLittleEndian() {
+		return nil, 0, ErrBigEndian
+	}
+	cfg := newConfig(opts)
+
+	var buf [HeaderSize]byte
+	n, err := io.ReadFull(r, buf[:])
+	read := int64(n)
+	if err != nil {
+		return nil, read, err
+	}
+	var h Header
+	if err := h.unmarshal(buf[:]); err != nil {
+		return nil, read, err
+	}
+	if err := h.check(id, sizeOf[E]()); err != nil {
+		return nil, read, err
+	}
+
+	dataSize := h.DataSize()
+	if size, ok := remaining(r); ok && dataSize > size {
+		return nil, read, io.ErrUnexpectedEOF
+	}
+
+	s, m, err := readElements[E](r, h.NbElements, dataSize)
+	read += m
+	if err != nil {
+		return nil, read, err
+	}
+	if !cfg.skipChecksum && crc32.Checksum(asBytes(s), castagnoli) != h.Checksum {
+		return nil, read, ErrInvalidChecksum
+	}
+	return s, read, nil
+}
+
+// maxChunkSize bounds the memory allocated ahead of the data actually read
+// when the size of the input is unknown.
+var maxChunkSize int64 = 1 << 26
+
+// readElements reads nbElements elements of E (dataSize bytes) from r.
+func readElements[E any](r io.Reader, nbElements uint64, dataSize int64) ([]E, int64, error) {
+	elementSize := int64(sizeOf[E]())
+	if elementSize == 0 || dataSize <= maxChunkSize {
+		s := make([]E, nbElements)
+		n, err := io.ReadFull(r, asBytes(s))
+		return s, int64(n), err
+	}
+
+	chunk := maxChunkSize / elementSize
+	if chunk == 0 {
+		chunk = 1
+	}
+	var s []E
+	var read int64
+	for left := int64(nbElements); left > 0; {
+		k := chunk
+		if k > left {
+			k = left
+		}
+		// grow s by k elements, at most doubling its capacity
+		l := len(s)
+		if int64(cap(s)-l) < k {
+			c := int64(2 * cap(s))
+			if c < int64(l)+k {
+				c = int64(l) + k
+			}
+			if c > int64(nbElements) {
+				c = int64(nbElements)
+			}
+			grown := make([]E, l, c)
+			copy(grown, s)
+			s = grown
+		}
+		s = s[:l+int(k)]
+		n, err := io.ReadFull(r, asBytes(s[l:]))
+		read += int64(n)
+		if err != nil {
+			return nil, read, err
+		}
+		left -= k
+	}
+	return s, read, nil
+}
+
+// remaining returns the number of bytes left in r, if it can be known without
+// reading it.
+func remaining(r io.Reader) (int64, bool) {
+	switch r := r.(type) {
+	case interface{ Len() int }:
+		return int64(r.Len()), true
+	case *os.File:
+		info, err := r.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return 0, false
+		}
+		offset, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return 0, false
+		}
+		return info.Size() - offset, true
+	}
+	return 0, false
+}
+
+// View is a read-only slice backed by a memory-mapped dump.
+//
+// The slice returned by Slice must not be modified (on Linux, the pages are
+// mapped read-only and a write triggers a segmentation fault) and must not be
+// used after Close.
+type View[E any] struct {
+	header Header
+	slice  []E
+	region []byte // mapped memory region, nil if the data was copied
+}
+
+// Slice returns the mapped elements.
+func (v *View[E]) Slice() []E {
+	return v.slice
+}
+
+// Header returns the header of the mapped dump.
+func (v *View[E]) Header() Header {
+	return v.header
+}
+
+// Size returns the number of bytes of the dump (header included), that is,
+// the offset of the next section in the file relative to the mapped one.
+func (v *View[E]) Size() int64 {
+	return HeaderSize + v.header.DataSize()
+}
+
+// Close unmaps the underlying memory.
+func (v *View[E]) Close() error {
+	v.slice = nil
+	if v.region == nil {
+		return nil
+	}
+	region := v.region
+	v.region = nil
+	return munmap(region)
+}
+
+// Map maps the dump starting at offset in f and returns a read-only view of
+// its content, without copying. The file can be closed once the view is
+// created; the mapping remains valid until the view is closed.
+//
+// On platforms without mmap support, the data is read into memory instead.
+func Map[E any](f *os.File, offset int64, id ecc.ID, opts ...Option) (*View[E], error) {
+	if !isLittleEndian() {
+		return nil, ErrBigEndian
+	}
+	cfg := newConfig(opts)
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if offset < 0 || offset > info.Size()-HeaderSize {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	var buf [HeaderSize]byte
+	if _, err := f.ReadAt(buf[:], offset); err != nil {
+		return nil, err
+	}
+	v := new(View[E])
+	if err := v.header.unmarshal(buf[:]); err != nil {
+		return nil, err
+	}
+	if err := v.header.check(id, sizeOf[E]()); err != nil {
+		return nil, err
+	}
+
+	// mapping past the end of the file would fault on access (SIGBUS)
+	dataSize := v.header.DataSize()
+	if dataSize > info.Size()-offset-HeaderSize {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if dataSize == 0 {
+		v.slice = []E{}
+		return v, nil
+	}
+
+	region, data, err := mmap(f, offset+HeaderSize, dataSize)
+	if err != nil {
+		return nil, err
+	}
+	v.region = region
+
+	if !cfg.skipChecksum && crc32.Checksum(data, castagnoli) != v.header.Checksum {
+		_ = v.Close()
+		return nil, ErrInvalidChecksum
+	}
+
+	v.slice = unsafe.Slice((*E)(unsafe.Pointer(&data[0])), v.header.NbElements)
+	return v, nil
+}
+
+func sizeOf[E any]() uintptr {
+	var e E
+	return unsafe.Sizeof(e)
+}
+
+// asBytes returns the memory backing s as a byte slice.
+func asBytes[E any](s []E) []byte {
+	if len(s) == 0 {
+		return nil
+	}
+	return unsafe.Slice((*byte)(unsafe.Pointer(&s[0])), uintptr(len(s))*sizeOf[E]())
+}
+
+func isLittleEndian() bool {
+	var x uint16 = 1
+	return *(*byte)(unsafe.Pointer(&x)) == 1
+}
@@ -0,0 +1,233 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func randomPoints(n int) []bn254.G1Affine {
+	points := make([]bn254.G1Affine, n)
+	var s fr.Element
+	var b big.Int
+	for i := range points {
+		s.SetRandom()
+		points[i].ScalarMultiplicationBase(s.BigInt(&b))
+	}
+	return points
+}
+
+func writeDump(t *testing.T, dumps ...func(*bytes.Buffer)) *os.File {
+	var buf bytes.Buffer
+	for _, d := range dumps {
+		d(&buf)
+	}
+	path := filepath.Join(t.TempDir(), "dump")
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0600))
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { f.Close() })
+	return f
+}
+
+func TestReadWriteSlice(t *testing.T) {
+	assert := require.New(t)
+
+	points := randomPoints(17)
+	var buf bytes.Buffer
+	n, err := WriteSlice(&buf, ecc.BN254, points)
+	assert.NoError(err)
+	assert.Equal(int64(buf.Len()), n)
+
+	read, m, err := ReadSlice[bn254.G1Affine](bytes.NewReader(buf.Bytes()), ecc.BN254)
+	assert.NoError(err)
+	assert.Equal(n, m)
+	assert.Equal(points, read)
+
+	_, _, err = ReadSlice[bn254.G1Affine](bytes.NewReader(buf.Bytes()), ecc.BLS12_381)
+	assert.ErrorIs(err, ErrCurveMismatch)
+
+	_, _, err = ReadSlice[fr.Element](bytes.NewReader(buf.Bytes()), ecc.BN254)
+	assert.ErrorIs(err, ErrElementSize)
+
+	corrupted := append([]byte{}, buf.Bytes()...)
+	corrupted[len(corrupted)-1] ^= 1
+	_, _, err = ReadSlice[bn254.G1Affine](bytes.NewReader(corrupted), ecc.BN254)
+	assert.ErrorIs(err, ErrInvalidChecksum)
+
+	_, _, err = ReadSlice[bn254.G1Affine](bytes.NewReader(corrupted), ecc.BN254, NoChecksum())
+	assert.NoError(err)
+}
+
+func TestMap(t *testing.T) {
+	assert := require.New(t)
+
+	points := randomPoints(1000)
+	scalars := make(fr.Vector, 33)
+	for i := range scalars {
+		scalars[i].SetRandom()
+	}
+
+	var offset int64
+	f := writeDump(t,
+		func(b *bytes.Buffer) {
+			_, err := WriteSlice(b, ecc.BN254, points)
+			assert.NoError(err)
+			offset = int64(b.Len())
+		},
+		func(b *bytes.Buffer) {
+			_, err := WriteSlice(b, ecc.BN254, scalars)
+			assert.NoError(err)
+		},
+	)
+
+	vPoints, err := Map[bn254.G1Affine](f, 0, ecc.BN254)
+	assert.NoError(err)
+	assert.Equal(offset, vPoints.Size())
+	assert.Equal(points, vPoints.Slice())
+
+	// second section starts at an offset which is not page aligned
+	vScalars, err := Map[fr.Element](f, vPoints.Size(), ecc.BN254)
+	assert.NoError(err)
+	assert.Equal([]fr.Element(scalars), vScalars.Slice())
+
+	// the mapping outlives the file
+	assert.NoError(f.Close())
+	for i := range points {
+		assert.True(vPoints.Slice()[i].IsOnCurve())
+	}
+
+	assert.NoError(vPoints.Close())
+	assert.NoError(vScalars.Close())
+	assert.Nil(vPoints.Slice())
+}
+
+func TestMapEmpty(t *testing.T) {
+	assert := require.New(t)
+
+	f := writeDump(t, func(b *bytes.Buffer) {
+		_, err := WriteSlice[fr.Element](b, ecc.UNKNOWN, nil)
+		assert.NoError(err)
+	})
+
+	v, err := Map[fr.Element](f, 0, ecc.UNKNOWN)
+	assert.NoError(err)
+	assert.Equal(0, len(v.Slice()))
+	assert.NoError(v.Close())
+}
+
+// setNbElements overwrites the number of elements in the header of a dump.
+func setNbElements(dump []byte, nbElements uint64) []byte {
+	res := append([]byte{}, dump...)
+	binary.LittleEndian.PutUint64(res[16:24], nbElements)
+	return res
+}
+
+// onlyReader hides the Len method of the underlying reader.
+type onlyReader struct {
+	io.Reader
+}
+
+func TestReadSliceUntrustedHeader(t *testing.T) {
+	assert := require.New(t)
+
+	points := randomPoints(10)
+	var buf bytes.Buffer
+	_, err := WriteSlice(&buf, ecc.BN254, points)
+	assert.NoError(err)
+
+	// truncated data
+	truncated := buf.Bytes()[:buf.Len()-1]
+	_, _, err = ReadSlice[bn254.G1Affine](bytes.NewReader(truncated), ecc.BN254)
+	assert.ErrorIs(err, io.ErrUnexpectedEOF)
+	_, _, err = ReadSlice[bn254.G1Affine](onlyReader{bytes.NewReader(truncated)}, ecc.BN254)
+	assert.ErrorIs(err, io.ErrUnexpectedEOF)
+
+	// the header claims ~2⁴⁶ bytes: rejected before allocating when the size is
+	// known, read in bounded chunks otherwise
+	huge := setNbElements(buf.Bytes(), 1<<40)
+	_, _, err = ReadSlice[bn254.G1Affine](bytes.NewReader(huge), ecc.BN254)
+	assert.ErrorIs(err, io.ErrUnexpectedEOF)
+	_, _, err = ReadSlice[bn254.G1Affine](onlyReader{bytes.NewReader(huge)}, ecc.BN254)
+	assert.ErrorIs(err, io.ErrUnexpectedEOF)
+
+	f := writeDump(t, func(b *bytes.Buffer) { b.Write(huge) })
+	_, _, err = ReadSlice[bn254.G1Affine](f, ecc.BN254)
+	assert.ErrorIs(err, io.ErrUnexpectedEOF)
+
+	// the data size overflows
+	overflow := setNbElements(buf.Bytes(), math.MaxUint64)
+	_, _, err = ReadSlice[bn254.G1Affine](bytes.NewReader(overflow), ecc.BN254)
+	assert.ErrorIs(err, ErrDataSize)
+}
+
+func TestReadSliceChunks(t *testing.T) {
+	assert := require.New(t)
+
+	defer func(size int64) { maxChunkSize = size }(maxChunkSize)
+	maxChunkSize = 200 // 3 points per chunk
+
+	points := randomPoints(17)
+	var buf bytes.Buffer
+	_, err := WriteSlice(&buf, ecc.BN254, points)
+	assert.NoError(err)
+
+	read, n, err := ReadSlice[bn254.G1Affine](onlyReader{bytes.NewReader(buf.Bytes())}, ecc.BN254)
+	assert.NoError(err)
+	assert.Equal(int64(buf.Len()), n)
+	assert.Equal(points, read)
+}
+
+func TestMapUntrustedHeader(t *testing.T) {
+	assert := require.New(t)
+
+	points := randomPoints(10)
+	var buf bytes.Buffer
+	_, err := WriteSlice(&buf, ecc.BN254, points)
+	assert.NoError(err)
+
+	// truncated file
+	f := writeDump(t, func(b *bytes.Buffer) { b.Write(buf.Bytes()[:buf.Len()-1]) })
+	_, err = Map[bn254.G1Affine](f, 0, ecc.BN254)
+	assert.ErrorIs(err, io.ErrUnexpectedEOF)
+
+	// truncated header, offset past the end of the file
+	_, err = Map[bn254.G1Affine](f, int64(buf.Len()-HeaderSize/2), ecc.BN254)
+	assert.ErrorIs(err, io.ErrUnexpectedEOF)
+	_, err = Map[bn254.G1Affine](f, int64(2*buf.Len()), ecc.BN254)
+	assert.ErrorIs(err, io.ErrUnexpectedEOF)
+
+	// the header claims more elements than the file holds
+	f = writeDump(t, func(b *bytes.Buffer) { b.Write(setNbElements(buf.Bytes(), 1<<40)) })
+	_, err = Map[bn254.G1Affine](f, 0, ecc.BN254)
+	assert.ErrorIs(err, io.ErrUnexpectedEOF)
+
+	// the data size overflows
+	f = writeDump(t, func(b *bytes.Buffer) { b.Write(setNbElements(buf.Bytes(), math.MaxUint64)) })
+	_, err = Map[bn254.G1Affine](f, 0, ecc.BN254)
+	assert.ErrorIs(err, ErrDataSize)
+}
@@ -0,0 +1,42 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package mmap
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// mmap maps length bytes of f starting at offset, read-only.
+// It returns the mapped region (to be passed to munmap) and the requested
+// bytes within it; offset need not be page aligned.
+func mmap(f *os.File, offset, length int64) (region, data []byte, err error) {
+	pageSize := int64(os.Getpagesize())
+	aligned := offset - offset%pageSize
+	delta := offset - aligned
+
+	region, err = unix.Mmap(int(f.Fd()), aligned, int(length+delta), unix.PROT_READ, unix.MAP_SHARED)
+	if err != nil {
+		return nil, nil, err
+	}
+	return region, region[delta : delta+length], nil
+}
+
+func munmap(region []byte) error {
+	return unix.Munmap(region)
+}
@@ -0,0 +1,37 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux
+
+package mmap
+
+import (
+	"os"
+)
+
+// mmap falls back to reading the data into memory.
+// The returned region is nil, so that munmap is never called on it.
+func mmap(f *os.File, offset, length int64) (region, data []byte, err error) {
+	// allocate with 8-byte alignment, as expected by field elements
+	words := make([]uint64, (length+7)/8)
+	data = asBytes(words)[:length]
+	if _, err = f.ReadAt(data, offset); err != nil {
+		return nil, nil, err
+	}
+	return nil, data, nil
+}
+
+func munmap(region []byte) error {
+	return nil
+}